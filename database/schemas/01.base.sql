@@ -155,10 +155,34 @@ CREATE TABLE base_prestation_medicale (
   CONSTRAINT FK_base_prestation_medicale_type_prestation_id FOREIGN KEY (type_prestation_id) REFERENCES base_type_prestation(id),
   CONSTRAINT FK_base_prestation_medicale_module_id FOREIGN KEY (module_id) REFERENCES base_module(id)
   
-  -- Note: Contrainte de cohérence entre les 3 références sera gérée par un trigger
-  -- pour vérifier que type_prestation_module_id correspond bien à (type_prestation_id, module_id)
+  -- Note: Contrainte de cohérence entre les 3 références gérée par trigger_prestation_medicale_coherence
+  -- qui vérifie que type_prestation_module_id correspond bien à (type_prestation_id, module_id)
 );
 
+-- Cohérence du triple référencement (sous-requête impossible dans une contrainte CHECK)
+CREATE OR REPLACE FUNCTION check_prestation_medicale_coherence() RETURNS trigger AS $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM base_type_prestation_module tpm
+        WHERE tpm.id = NEW.type_prestation_module_id
+        AND tpm.etablissement_id = NEW.etablissement_id
+        AND tpm.type_prestation_id = NEW.type_prestation_id
+        AND tpm.module_id = NEW.module_id
+    ) THEN
+        RAISE EXCEPTION 'base_prestation_medicale : type_prestation_module_id % incohérent avec (type_prestation_id %, module_id %)',
+            NEW.type_prestation_module_id, NEW.type_prestation_id, NEW.module_id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_prestation_medicale_coherence
+    BEFORE INSERT OR UPDATE OF type_prestation_module_id, type_prestation_id, module_id, etablissement_id
+    ON base_prestation_medicale
+    FOR EACH ROW
+    EXECUTE FUNCTION check_prestation_medicale_coherence();
+
 -- =====================================
 -- TABLE : BASE_TARIF_PRESTATION
 -- =====================================
@@ -198,8 +222,42 @@ CREATE TABLE base_tarif_prestation (
   CONSTRAINT CK_base_tarif_prestation_positif CHECK (tarif_unitaire > 0),
   CONSTRAINT CK_base_tarif_prestation_garde_positif CHECK (tarif_unitaire_periode_garde IS NULL OR tarif_unitaire_periode_garde > 0),
   CONSTRAINT CK_base_tarif_prestation_ferie_positif CHECK (tarif_unitaire_jour_ferie IS NULL OR tarif_unitaire_jour_ferie > 0)
+  -- Note: Absence de chevauchement des périodes gérée par trigger_tarif_prestation_chevauchement
 );
 
+-- Un seul tarif sans date de fin par prestation (la version courante ou la dernière planifiée)
+CREATE UNIQUE INDEX UQ_base_tarif_prestation_ouvert
+ON base_tarif_prestation (prestation_medicale_id)
+WHERE date_fin_validite IS NULL;
+
+-- Historique et résolution : versions d'une prestation par date d'effet
+CREATE INDEX IDX_base_tarif_prestation_validite
+ON base_tarif_prestation (prestation_medicale_id, date_debut_validite DESC);
+
+-- Périodes de validité [début, fin[ disjointes pour une même prestation
+CREATE OR REPLACE FUNCTION check_tarif_prestation_chevauchement() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM base_tarif_prestation t
+        WHERE t.prestation_medicale_id = NEW.prestation_medicale_id
+        AND t.id <> NEW.id
+        AND tsrange(t.date_debut_validite, t.date_fin_validite, '[)')
+            && tsrange(NEW.date_debut_validite, NEW.date_fin_validite, '[)')
+    ) THEN
+        RAISE EXCEPTION 'base_tarif_prestation : la période du tarif chevauche une autre version de la prestation %',
+            NEW.prestation_medicale_id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_tarif_prestation_chevauchement
+    BEFORE INSERT OR UPDATE OF prestation_medicale_id, date_debut_validite, date_fin_validite
+    ON base_tarif_prestation
+    FOR EACH ROW
+    EXECUTE FUNCTION check_tarif_prestation_chevauchement();
+
 -- ======================================================
 -- CIRCUITS PATIENTS - Workflow Flexible 
 -- ======================================================
@@ -251,8 +309,8 @@ CREATE TABLE base_circuit_patient (
   CONSTRAINT CK_circuit_periode_coherence CHECK (
     date_fin_activite IS NULL OR date_fin_activite > date_debut_activite
   )
-  -- Note: La contrainte de validation pour peut_prendre_ticket sera gérée par un trigger
-  -- car PostgreSQL n'autorise pas les sous-requêtes dans les contraintes CHECK
+  -- Note: peut_prendre_ticket du module d'entrée et éligibilité des modules des chemins (actifs, licenciés)
+  -- sont validés par CircuitAuthoringService, PostgreSQL n'autorisant pas les sous-requêtes dans les CHECK
 );
 
 -- =====================================
@@ -389,6 +447,80 @@ CREATE TABLE base_assurance (
   CONSTRAINT UQ_base_assurance_etablissement_code UNIQUE (etablissement_id, code_organisme)
 );
 
+-- =====================================
+-- TABLE : BASE_CONVENTION_ASSURANCE
+-- =====================================
+-- Description : Conventions de prise en charge négociées avec un organisme payeur
+-- Une convention cible soit un type de prestation, soit une prestation (la prestation l'emporte)
+CREATE TABLE base_convention_assurance (
+  -- Clé primaire
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+  -- Multi-tenant : Héritage depuis assurance
+  etablissement_id UUID NOT NULL REFERENCES base_etablissement(id),
+  assurance_id UUID NOT NULL REFERENCES base_assurance(id),
+
+  -- Cible de la convention (exactement une)
+  type_prestation_id UUID REFERENCES base_type_prestation(id),
+  prestation_medicale_id UUID REFERENCES base_prestation_medicale(id),
+
+  -- Conditions de prise en charge
+  taux_couverture NUMERIC(5,2) NOT NULL,       -- Pourcentage pris en charge par l'organisme
+  plafond_par_acte INTEGER,                    -- Part organisme maximale par acte (FCFA), NULL = sans plafond
+  accord_prealable_requis BOOLEAN DEFAULT FALSE,
+
+  -- Validité (dates incluses)
+  date_debut_validite DATE NOT NULL,
+  date_fin_validite DATE,
+
+  -- Configuration
+  est_actif BOOLEAN DEFAULT TRUE,
+
+  -- Métadonnées standards
+  created_at TIMESTAMP DEFAULT NOW(),
+  updated_at TIMESTAMP DEFAULT NOW(),
+  created_by UUID REFERENCES user_utilisateur(id),
+  updated_by UUID REFERENCES user_utilisateur(id),
+
+  -- Contraintes
+  CONSTRAINT CK_base_convention_assurance_cible CHECK ((type_prestation_id IS NULL) <> (prestation_medicale_id IS NULL)),
+  CONSTRAINT CK_base_convention_assurance_taux CHECK (taux_couverture > 0 AND taux_couverture <= 100),
+  CONSTRAINT CK_base_convention_assurance_plafond_positif CHECK (plafond_par_acte IS NULL OR plafond_par_acte > 0),
+  CONSTRAINT CK_base_convention_assurance_validite CHECK (date_fin_validite IS NULL OR date_fin_validite >= date_debut_validite)
+  -- Note: Absence de chevauchement des périodes gérée par trigger_convention_assurance_chevauchement
+);
+
+-- Résolution de la prise en charge : conventions d'un organisme par cible
+CREATE INDEX IDX_base_convention_assurance_resolution
+ON base_convention_assurance (assurance_id, est_actif, date_debut_validite DESC);
+
+-- Périodes de validité [début, fin] disjointes pour un même organisme et une même cible (conventions actives)
+CREATE OR REPLACE FUNCTION check_convention_assurance_chevauchement() RETURNS trigger AS $$
+BEGIN
+    IF NEW.est_actif IS DISTINCT FROM FALSE AND EXISTS (
+        SELECT 1
+        FROM base_convention_assurance c
+        WHERE c.assurance_id = NEW.assurance_id
+        AND c.id <> NEW.id
+        AND c.est_actif IS DISTINCT FROM FALSE
+        AND c.type_prestation_id IS NOT DISTINCT FROM NEW.type_prestation_id
+        AND c.prestation_medicale_id IS NOT DISTINCT FROM NEW.prestation_medicale_id
+        AND daterange(c.date_debut_validite, c.date_fin_validite, '[]')
+            && daterange(NEW.date_debut_validite, NEW.date_fin_validite, '[]')
+    ) THEN
+        RAISE EXCEPTION 'base_convention_assurance : la période chevauche une autre convention de l''organisme % pour la même cible',
+            NEW.assurance_id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_convention_assurance_chevauchement
+    BEFORE INSERT OR UPDATE OF assurance_id, type_prestation_id, prestation_medicale_id, date_debut_validite, date_fin_validite, est_actif
+    ON base_convention_assurance
+    FOR EACH ROW
+    EXECUTE FUNCTION check_convention_assurance_chevauchement();
+
 -- =====================================
 -- TABLE : BASE_BATIMENT
 -- =====================================
@@ -414,7 +546,8 @@ CREATE TABLE base_batiment (
   updated_at TIMESTAMP DEFAULT NOW(),
 
   -- Contraintes
-  CONSTRAINT UQ_base_batiment_etablissement_code UNIQUE (etablissement_id, code_batiment)
+  CONSTRAINT UQ_base_batiment_etablissement_code UNIQUE (etablissement_id, code_batiment),
+  CONSTRAINT CK_base_batiment_nombre_etages CHECK (nombre_etages >= 1)
 );
 
 
@@ -485,8 +618,36 @@ CREATE TABLE base_chambre (
   
   -- Contraintes
   CONSTRAINT CK_base_chambre_type_espace CHECK (type_espace IN ('consultation', 'administration', 'technique', 'hospitalisation', 'bloc_operatoire'))
+  -- Note: Étage compris entre le rez-de-chaussée (0) et le dernier étage du bâtiment, vérifié par trigger_chambre_niveau_etage
 );
 
+-- Numéro de chambre unique dans un bâtiment (casse ignorée)
+CREATE UNIQUE INDEX UQ_base_chambre_batiment_numero
+ON base_chambre (batiment_id, UPPER(numero_chambre));
+
+-- Étage de la chambre parmi ceux de son bâtiment (sous-requête impossible dans une contrainte CHECK)
+CREATE OR REPLACE FUNCTION check_chambre_niveau_etage() RETURNS trigger AS $$
+BEGIN
+    IF NEW.niveau_etage IS NOT NULL AND NOT EXISTS (
+        SELECT 1
+        FROM base_batiment b
+        WHERE b.id = NEW.batiment_id
+        AND b.etablissement_id = NEW.etablissement_id
+        AND NEW.niveau_etage BETWEEN 0 AND COALESCE(b.nombre_etages, 1) - 1
+    ) THEN
+        RAISE EXCEPTION 'base_chambre : niveau_etage % hors des étages du bâtiment %',
+            NEW.niveau_etage, NEW.batiment_id;
+    END IF;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_chambre_niveau_etage
+    BEFORE INSERT OR UPDATE OF batiment_id, niveau_etage, etablissement_id
+    ON base_chambre
+    FOR EACH ROW
+    EXECUTE FUNCTION check_chambre_niveau_etage();
+
 -- =====================================
 -- TABLE : BASE_LIT
 -- =====================================
@@ -519,6 +680,10 @@ CREATE TABLE base_lit (
   CONSTRAINT CK_base_lit_type_lit CHECK (type_lit IN ('standard', 'medicalise', 'reanimation'))
 );
 
+-- Numéro de lit unique dans une chambre (casse ignorée)
+CREATE UNIQUE INDEX UQ_base_lit_chambre_numero
+ON base_lit (chambre_id, UPPER(numero_lit));
+
 -- ======================================================
 -- COMMENTAIRES POUR DOCUMENTATION
 -- ======================================================
@@ -588,6 +753,11 @@ CREATE TRIGGER trigger_assurance_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER trigger_convention_assurance_updated_at
+    BEFORE UPDATE ON base_convention_assurance
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
 CREATE TRIGGER trigger_batiment_updated_at
     BEFORE UPDATE ON base_batiment
     FOR EACH ROW
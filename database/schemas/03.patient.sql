@@ -9,6 +9,9 @@
 -- Extension trigram requise pour recherche textuelle optimisée
 CREATE EXTENSION IF NOT EXISTS pg_trgm;
 
+-- Extension unaccent requise pour la présélection des doublons (Kouamé/Kouame)
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
 -- =====================================
 -- TABLES DE RÉFÉRENCE (NOMENCLATURES)
 -- =====================================
@@ -89,10 +92,10 @@ CREATE TABLE ref_affiliation (
 CREATE TABLE patients_code_sequences (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     etablissement_code VARCHAR(20) NOT NULL,
-    annee INTEGER NOT NULL,
-    dernier_numero INTEGER DEFAULT 0,      -- 001 à 999
-    dernier_suffixe VARCHAR(3) DEFAULT 'AAA', -- AAA à ZZZ
-    nombre_generes BIGINT DEFAULT 0,       -- Statistique
+    annee INTEGER NOT NULL,                -- 0 = séquence continue (format sans année)
+    dernier_numero INTEGER DEFAULT 0,      -- 1 à 10^largeur - 1 (001 à 999 au format par défaut)
+    dernier_suffixe VARCHAR(4) DEFAULT 'AAA', -- Bloc alphabétique (AAA à ZZZ au format par défaut)
+    nombre_generes BIGINT DEFAULT 0,       -- Statistique (codes réservés en allocation par blocs)
 
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW(),
@@ -111,21 +114,124 @@ CREATE TRIGGER trigger_patients_code_sequences_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+-- =====================================
+-- TABLE : PATIENTS_CODE_CONFIGURATION
+-- =====================================
+-- Description : Paramétrage du format des codes patient par établissement
+
+CREATE TABLE patients_code_configuration (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    etablissement_code VARCHAR(20) NOT NULL,
+
+    -- Format des codes générés : {ETAB}, {YYYY} ou {YY}, {N:largeur} (1-9), {L:largeur} (1-4)
+    -- Sans jeton d'année, la séquence est continue (jamais remise à zéro)
+    format_code VARCHAR(60) DEFAULT '{ETAB}-{YYYY}-{N:3}-{L:3}' NOT NULL,
+
+    -- Clé de contrôle ISO 7064 MOD 37-2 ajoutée en fin de code (ex: CENTREA-2025-001-AAA-K)
+    cle_controle BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- Allocation par blocs : chaque instance API réserve taille_bloc codes puis les attribue localement
+    -- 0 = allocation code par code. Les codes d'un bloc non consommé (arrêt d'instance) sont perdus (trous)
+    taille_bloc INTEGER DEFAULT 0 NOT NULL,
+
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    updated_by UUID,
+
+    CONSTRAINT UQ_patients_code_configuration_etablissement
+        UNIQUE (etablissement_code),
+    CONSTRAINT CK_patients_code_configuration_taille_bloc
+        CHECK (taille_bloc BETWEEN 0 AND 10000),
+    CONSTRAINT FK_patients_code_configuration_updated_by FOREIGN KEY (updated_by)
+        REFERENCES user_utilisateur(id)
+);
+
+CREATE TRIGGER trigger_patients_code_configuration_updated_at
+    BEFORE UPDATE ON patients_code_configuration
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- =====================================
+-- TABLE : PATIENTS_CARTE_CONFIGURATION
+-- =====================================
+-- Description : Mise en page de la carte patient imprimée (format ID-1 85,6 x 54 mm) par établissement
+
+CREATE TABLE patients_carte_configuration (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    etablissement_id UUID NOT NULL,
+
+    titre VARCHAR(40) DEFAULT 'CARTE PATIENT' NOT NULL,
+    couleur_principale VARCHAR(7) DEFAULT '#0B6E4F' NOT NULL,  -- Bandeau d'en-tête
+    couleur_texte VARCHAR(7) DEFAULT '#1A1A1A' NOT NULL,
+
+    -- Éléments affichés
+    afficher_logo BOOLEAN DEFAULT TRUE NOT NULL,
+    afficher_qr_code BOOLEAN DEFAULT TRUE NOT NULL,
+    afficher_code_barre BOOLEAN DEFAULT TRUE NOT NULL,
+    afficher_date_naissance BOOLEAN DEFAULT TRUE NOT NULL,
+    afficher_sexe BOOLEAN DEFAULT TRUE NOT NULL,
+    afficher_telephone BOOLEAN DEFAULT FALSE NOT NULL,
+    mention_pied VARCHAR(120),
+
+    -- Logo des documents copié à l'enregistrement de la mise en page (aucun accès externe à l'impression)
+    logo_contenu BYTEA,
+    logo_source_url VARCHAR(500),
+
+    resolution_dpi INTEGER DEFAULT 300 NOT NULL,  -- Rendu PNG
+
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    updated_by UUID,
+
+    CONSTRAINT UQ_patients_carte_configuration_etablissement
+        UNIQUE (etablissement_id),
+    CONSTRAINT FK_patients_carte_configuration_etablissement FOREIGN KEY (etablissement_id)
+        REFERENCES base_etablissement(id),
+    CONSTRAINT FK_patients_carte_configuration_updated_by FOREIGN KEY (updated_by)
+        REFERENCES user_utilisateur(id),
+    CONSTRAINT CK_patients_carte_configuration_couleurs
+        CHECK (couleur_principale ~ '^#[0-9A-Fa-f]{6}$' AND couleur_texte ~ '^#[0-9A-Fa-f]{6}$'),
+    CONSTRAINT CK_patients_carte_configuration_resolution
+        CHECK (resolution_dpi BETWEEN 150 AND 600)
+);
+
+CREATE TRIGGER trigger_patients_carte_configuration_updated_at
+    BEFORE UPDATE ON patients_carte_configuration
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
 -- Fonction PostgreSQL pour calculer le suffixe suivant
-CREATE OR REPLACE FUNCTION next_alpha_suffix(current_suffix VARCHAR(3))
-RETURNS VARCHAR(3) AS $$
+-- suffix_length : largeur du bloc alphabétique du format (0 = format sans bloc, capacité épuisée)
+-- Le suffixe courant est d'abord ajusté à la largeur (changement de format : AB → AAB, AAB → AB)
+CREATE OR REPLACE FUNCTION next_alpha_suffix(current_suffix VARCHAR, suffix_length INTEGER DEFAULT 3)
+RETURNS VARCHAR AS $$
 DECLARE
-    chars CHAR(3)[];
+    chars TEXT[];
+    normalized TEXT;
     i INTEGER;
 BEGIN
-    IF current_suffix = 'ZZZ' THEN
+    IF suffix_length = 0 THEN
+        RAISE EXCEPTION 'Capacité maximale atteinte pour le format';
+    END IF;
+
+    normalized := upper(current_suffix);
+    IF length(normalized) < suffix_length THEN
+        normalized := lpad(normalized, suffix_length, 'A');
+    ELSIF length(normalized) > suffix_length THEN
+        IF ltrim(left(normalized, length(normalized) - suffix_length), 'A') <> '' THEN
+            RAISE EXCEPTION 'Capacité maximale atteinte pour le format';
+        END IF;
+        normalized := right(normalized, suffix_length);
+    END IF;
+
+    IF normalized = repeat('Z', suffix_length) THEN
         RAISE EXCEPTION 'Capacité maximale atteinte pour l''année';
     END IF;
 
-    chars := string_to_array(current_suffix, NULL);
+    chars := string_to_array(normalized, NULL);
 
     -- Incrémenter de droite à gauche
-    FOR i IN REVERSE 3..1 LOOP
+    FOR i IN REVERSE suffix_length..1 LOOP
         IF chars[i] < 'Z' THEN
             chars[i] := chr(ascii(chars[i]) + 1);
             EXIT;
@@ -148,7 +254,7 @@ CREATE TABLE patients_patient (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
 
     -- IDENTIFICATION UNIQUE
-    code_patient VARCHAR(20) NOT NULL,  -- Unique
+    code_patient VARCHAR(40) NOT NULL,  -- Unique (clé de contrôle optionnelle incluse)
     etablissement_createur_id UUID NOT NULL,  -- Traçabilité création
 
     -- ==========================================
@@ -217,6 +323,24 @@ CREATE TABLE patients_patient (
     -- Flags spéciaux
     est_decede BOOLEAN DEFAULT FALSE,
     date_deces DATE,
+    cause_deces TEXT,
+    medecin_declarant_deces_id UUID,
+    deces_declare_le TIMESTAMP,
+
+    -- Fusion de doublons : pointeur vers le dossier survivant (dossier archivé)
+    fusionne_vers_patient_id UUID,
+
+    -- Patient inconnu (urgences) : identité provisoire à réconcilier
+    -- (identité complétée ou fusion vers un dossier existant)
+    est_provisoire BOOLEAN DEFAULT FALSE NOT NULL,
+    signalement TEXT,  -- Description physique, circonstances d'arrivée, effets personnels
+    reconcilie_le TIMESTAMP,
+    reconcilie_par UUID,
+
+    -- Anonymisation (loi 2013-450) : identité pseudonymisée de façon irréversible
+    -- code_patient et rattachements cliniques conservés
+    est_anonymise BOOLEAN DEFAULT FALSE NOT NULL,
+    anonymise_le TIMESTAMP,
 
     -- Recherche & matching
     search_vector tsvector,  -- Pour recherche full-text PostgreSQL
@@ -249,6 +373,12 @@ CREATE TABLE patients_patient (
         REFERENCES user_utilisateur(id),
     CONSTRAINT FK_patients_patient_updated_by FOREIGN KEY (updated_by)
         REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_patient_fusionne_vers FOREIGN KEY (fusionne_vers_patient_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_patient_medecin_declarant_deces FOREIGN KEY (medecin_declarant_deces_id)
+        REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_patient_reconcilie_par FOREIGN KEY (reconcilie_par)
+        REFERENCES user_utilisateur(id),
 
     -- ==========================================
     -- CONTRAINTES CHECK
@@ -269,6 +399,24 @@ CREATE TABLE patients_patient (
     CONSTRAINT CK_patients_patient_coherence_deces CHECK (
         (est_decede = FALSE AND date_deces IS NULL) OR
         (est_decede = TRUE AND date_deces IS NOT NULL)
+    ),
+    CONSTRAINT CK_patients_patient_coherence_declaration_deces CHECK (
+        est_decede = TRUE OR
+        (cause_deces IS NULL AND medecin_declarant_deces_id IS NULL AND deces_declare_le IS NULL)
+    ),
+    CONSTRAINT CK_patients_patient_statut_decede CHECK (
+        statut <> 'decede' OR est_decede = TRUE
+    ),
+    CONSTRAINT CK_patients_patient_coherence_fusion CHECK (
+        fusionne_vers_patient_id IS NULL OR
+        (statut = 'archive' AND fusionne_vers_patient_id <> id)
+    ),
+    CONSTRAINT CK_patients_patient_coherence_reconciliation CHECK (
+        est_provisoire = FALSE OR (reconcilie_le IS NULL AND reconcilie_par IS NULL)
+    ),
+    CONSTRAINT CK_patients_patient_coherence_anonymisation CHECK (
+        (est_anonymise = FALSE AND anonymise_le IS NULL) OR
+        (est_anonymise = TRUE AND anonymise_le IS NOT NULL)
     )
 );
 
@@ -296,18 +444,14 @@ CREATE TABLE patients_patient_assurance (
     -- Statut
     est_actif BOOLEAN DEFAULT TRUE,
     motif_inactivation VARCHAR(255),
+    inactive_le TIMESTAMP,
+    inactive_par UUID,
 
     -- Métadonnées standards
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW(),
     created_by UUID,
 
-    -- ==========================================
-    -- CONTRAINTES UNIQUE
-    -- ==========================================
-    CONSTRAINT UQ_patients_patient_assurance_patient_assurance_actif
-        UNIQUE (patient_id, assurance_id, est_actif),
-
     -- ==========================================
     -- CONTRAINTES FOREIGN KEY
     -- ==========================================
@@ -317,6 +461,8 @@ CREATE TABLE patients_patient_assurance (
         REFERENCES base_assurance(id),
     CONSTRAINT FK_patients_patient_assurance_created_by FOREIGN KEY (created_by)
         REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_patient_assurance_inactive_par FOREIGN KEY (inactive_par)
+        REFERENCES user_utilisateur(id),
 
     -- ==========================================
     -- CONTRAINTES CHECK
@@ -329,9 +475,405 @@ CREATE TABLE patients_patient_assurance (
         CHECK (
             (type_beneficiaire = 'principal' AND numero_assure_principal IS NULL AND lien_avec_principal IS NULL) OR
             (type_beneficiaire = 'ayant_droit' AND numero_assure_principal IS NOT NULL AND lien_avec_principal IS NOT NULL)
-        )
+        ),
+    CONSTRAINT CK_patients_patient_assurance_coherence_inactivation
+        CHECK (est_actif OR motif_inactivation IS NOT NULL)
+);
+
+-- Une seule couverture active par patient et par assurance (l'historique des couvertures inactives est conservé)
+CREATE UNIQUE INDEX UQ_patients_patient_assurance_patient_assurance_actif
+    ON patients_patient_assurance (patient_id, assurance_id) WHERE est_actif = true;
+
+-- Recherche de l'assuré principal lors du rattachement d'un ayant droit
+CREATE INDEX IDX_patients_patient_assurance_numero_assure
+    ON patients_patient_assurance (assurance_id, numero_assure) WHERE est_actif = true;
+
+-- =====================================
+-- TABLE : PATIENTS_FUSION_HISTORIQUE
+-- =====================================
+-- Description : Journal des fusions de doublons (annulables par un superviseur)
+
+CREATE TABLE patients_fusion_historique (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Dossiers concernés
+    patient_survivant_id UUID NOT NULL,
+    patient_fusionne_id UUID NOT NULL,
+    code_patient_survivant VARCHAR(40) NOT NULL,
+    code_patient_fusionne VARCHAR(40) NOT NULL,
+    etablissement_id UUID NOT NULL,  -- Établissement ayant effectué la fusion
+
+    -- Justification
+    motif TEXT NOT NULL,
+
+    -- État avant fusion (nécessaire à l'annulation)
+    statut_fusionne_avant VARCHAR(20) NOT NULL,
+    est_assure_survivant_avant BOOLEAN NOT NULL,
+    personnes_a_contacter_survivant_avant JSONB DEFAULT '[]'::jsonb NOT NULL,
+    personnes_a_contacter_ajoutees JSONB DEFAULT '[]'::jsonb NOT NULL,  -- Contacts du fusionné ajoutés au survivant
+    assurances_transferees UUID[] DEFAULT '{}' NOT NULL,  -- IDs patients_patient_assurance déplacées
+
+    -- Statut de la fusion
+    statut VARCHAR(20) DEFAULT 'effectuee' NOT NULL,  -- effectuee, annulee
+
+    -- Traçabilité
+    fusionne_par UUID NOT NULL,
+    fusionne_le TIMESTAMP DEFAULT NOW() NOT NULL,
+    annule_par UUID,
+    annule_le TIMESTAMP,
+    motif_annulation TEXT,
+
+    -- Métadonnées standards
+    created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+
+    -- ==========================================
+    -- CONTRAINTES FOREIGN KEY
+    -- ==========================================
+    CONSTRAINT FK_patients_fusion_historique_survivant FOREIGN KEY (patient_survivant_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_fusion_historique_fusionne FOREIGN KEY (patient_fusionne_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_fusion_historique_etablissement FOREIGN KEY (etablissement_id)
+        REFERENCES base_etablissement(id),
+    CONSTRAINT FK_patients_fusion_historique_fusionne_par FOREIGN KEY (fusionne_par)
+        REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_fusion_historique_annule_par FOREIGN KEY (annule_par)
+        REFERENCES user_utilisateur(id),
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_fusion_historique_statut
+        CHECK (statut IN ('effectuee', 'annulee')),
+    CONSTRAINT CK_patients_fusion_historique_patients_distincts
+        CHECK (patient_survivant_id <> patient_fusionne_id),
+    CONSTRAINT CK_patients_fusion_historique_coherence_annulation CHECK (
+        (statut = 'effectuee' AND annule_par IS NULL AND annule_le IS NULL) OR
+        (statut = 'annulee' AND annule_par IS NOT NULL AND annule_le IS NOT NULL)
+    )
 );
 
+-- Un dossier ne peut être fusionné qu'une seule fois tant que la fusion est effective
+CREATE UNIQUE INDEX UQ_patients_fusion_historique_fusionne_effectuee
+    ON patients_fusion_historique (patient_fusionne_id) WHERE statut = 'effectuee';
+
+CREATE INDEX IDX_patients_fusion_historique_survivant
+    ON patients_fusion_historique (patient_survivant_id);
+
+CREATE INDEX IDX_patients_fusion_historique_fusionne_le
+    ON patients_fusion_historique (fusionne_le DESC);
+
+CREATE TRIGGER trigger_patients_fusion_historique_updated_at
+    BEFORE UPDATE ON patients_fusion_historique
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- =====================================
+-- TABLE : PATIENTS_PATIENT_HISTORIQUE
+-- =====================================
+-- Description : Historique append-only des modifications patient (diff champ par champ)
+
+CREATE TABLE patients_patient_historique (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    patient_id UUID NOT NULL,
+    code_patient VARCHAR(40) NOT NULL,
+
+    -- Nature de la modification
+    type_modification VARCHAR(30) NOT NULL,  -- modification, fusion, annulation_fusion, declaration_deces, desactivation, archivage, reactivation, couverture, reconciliation, anonymisation
+
+    -- Diff champ par champ
+    modifications JSONB NOT NULL,
+    /* Structure JSON:
+    {
+        "nom": { "avant": "KOUADIO", "apres": "KOUADIO-YAO" },
+        "telephone_principal": { "avant": "0701020304", "apres": "0501020304" }
+    }
+    */
+
+    -- Justification et traçabilité
+    motif TEXT NOT NULL,
+    modifie_par UUID NOT NULL,
+    modifie_le TIMESTAMP DEFAULT NOW() NOT NULL,
+
+    -- ==========================================
+    -- CONTRAINTES FOREIGN KEY
+    -- ==========================================
+    CONSTRAINT FK_patients_patient_historique_patient FOREIGN KEY (patient_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_patient_historique_modifie_par FOREIGN KEY (modifie_par)
+        REFERENCES user_utilisateur(id),
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_patient_historique_type_modification
+        CHECK (type_modification IN (
+            'modification', 'fusion', 'annulation_fusion',
+            'declaration_deces', 'desactivation', 'archivage', 'reactivation',
+            'couverture', 'reconciliation', 'anonymisation'
+        )),
+    CONSTRAINT CK_patients_patient_historique_modifications_objet
+        CHECK (jsonb_typeof(modifications) = 'object')
+);
+
+CREATE INDEX IDX_patients_patient_historique_patient_date
+    ON patients_patient_historique (patient_id, modifie_le DESC);
+
+-- Historique append-only : aucune modification ni suppression autorisée
+-- Seule exception : expurgation des valeurs d'identité lors d'une anonymisation approuvée
+-- (UPDATE uniquement, activée pour la transaction par set_config('soins_suite.anonymisation', 'on', true))
+CREATE OR REPLACE FUNCTION prevent_patient_historique_mutation() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND current_setting('soins_suite.anonymisation', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'patients_patient_historique est en ajout seul (% interdit)', TG_OP;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_patients_patient_historique_append_only
+    BEFORE UPDATE OR DELETE ON patients_patient_historique
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_patient_historique_mutation();
+
+-- =====================================
+-- TABLE : PATIENTS_ACCES_JOURNAL
+-- =====================================
+-- Description : Journal persistant des accès aux dossiers patient (secret médical)
+-- Alimenté par lots asynchrones depuis le service cache (aucun impact sur la lecture)
+
+CREATE TABLE patients_acces_journal (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Dossier consulté (pas de FK : écriture par lots, le journal survit aux fusions et archivages)
+    patient_id UUID,
+    code_patient VARCHAR(40) NOT NULL,
+
+    -- Auteur et contexte de l'accès (NULL pour les accès internes sans session)
+    utilisateur_id UUID,
+    etablissement_id UUID,
+    module_code VARCHAR(50),
+    rubrique_code VARCHAR(50),
+
+    -- Nature de l'accès
+    type_acces VARCHAR(30) NOT NULL,  -- consultation, carte, export_fhir
+    source VARCHAR(10) NOT NULL,      -- cache, database
+
+    accede_le TIMESTAMP NOT NULL,
+    enregistre_le TIMESTAMP DEFAULT NOW() NOT NULL,
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_acces_journal_type_acces
+        CHECK (type_acces IN ('consultation', 'carte', 'export_fhir')),
+    CONSTRAINT CK_patients_acces_journal_source
+        CHECK (source IN ('cache', 'database'))
+);
+
+-- Consultation par patient, par utilisateur et par période (rubrique AUDIT_SECURITE)
+CREATE INDEX IDX_patients_acces_journal_patient_date
+    ON patients_acces_journal (code_patient, accede_le DESC);
+
+-- Accès aux dossiers fusionnés, retrouvés par identifiant depuis le dossier survivant
+CREATE INDEX IDX_patients_acces_journal_patient_id_date
+    ON patients_acces_journal (patient_id, accede_le DESC);
+
+CREATE INDEX IDX_patients_acces_journal_utilisateur_date
+    ON patients_acces_journal (utilisateur_id, accede_le DESC);
+
+CREATE INDEX IDX_patients_acces_journal_etablissement_date
+    ON patients_acces_journal (etablissement_id, accede_le DESC);
+
+-- Journal en ajout seul : aucune modification ni suppression autorisée
+CREATE OR REPLACE FUNCTION prevent_patient_acces_journal_mutation() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'patients_acces_journal est en ajout seul (% interdit)', TG_OP;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_patients_acces_journal_append_only
+    BEFORE UPDATE OR DELETE ON patients_acces_journal
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_patient_acces_journal_mutation();
+
+-- =====================================
+-- TABLE : PATIENTS_ANONYMISATION_DEMANDE
+-- =====================================
+-- Description : Demandes d'effacement (loi 2013-450), exécutées après approbation d'un second administrateur
+
+CREATE TABLE patients_anonymisation_demande (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    patient_id UUID NOT NULL,
+    code_patient VARCHAR(40) NOT NULL,
+    motif TEXT NOT NULL,
+
+    statut VARCHAR(20) DEFAULT 'en_attente' NOT NULL,  -- en_attente, executee, rejetee
+
+    -- Traçabilité (approbateur distinct du demandeur)
+    demande_par UUID NOT NULL,
+    demande_le TIMESTAMP DEFAULT NOW() NOT NULL,
+    traite_par UUID,
+    traite_le TIMESTAMP,
+    motif_rejet TEXT,
+    dossiers_anonymises TEXT[] DEFAULT '{}' NOT NULL,  -- Dossier demandé et dossiers fusionnés vers lui
+
+    -- ==========================================
+    -- CONTRAINTES FOREIGN KEY
+    -- ==========================================
+    CONSTRAINT FK_patients_anonymisation_demande_patient FOREIGN KEY (patient_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_anonymisation_demande_demande_par FOREIGN KEY (demande_par)
+        REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_anonymisation_demande_traite_par FOREIGN KEY (traite_par)
+        REFERENCES user_utilisateur(id),
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_anonymisation_demande_statut
+        CHECK (statut IN ('en_attente', 'executee', 'rejetee')),
+    CONSTRAINT CK_patients_anonymisation_demande_coherence_traitement CHECK (
+        (statut = 'en_attente' AND traite_par IS NULL AND traite_le IS NULL) OR
+        (statut <> 'en_attente' AND traite_par IS NOT NULL AND traite_le IS NOT NULL)
+    ),
+    CONSTRAINT CK_patients_anonymisation_demande_quatre_yeux
+        CHECK (traite_par IS NULL OR traite_par <> demande_par),
+    CONSTRAINT CK_patients_anonymisation_demande_motif_rejet
+        CHECK (statut <> 'rejetee' OR motif_rejet IS NOT NULL)
+);
+
+-- Une seule demande en attente par patient
+CREATE UNIQUE INDEX UQ_patients_anonymisation_demande_patient_en_attente
+    ON patients_anonymisation_demande (patient_id) WHERE statut = 'en_attente';
+
+CREATE INDEX IDX_patients_anonymisation_demande_demande_le
+    ON patients_anonymisation_demande (demande_le DESC);
+
+-- =====================================
+-- TABLE : PATIENTS_ANONYMISATION_JOURNAL
+-- =====================================
+-- Description : Journal inviolable des opérations d'anonymisation (chaînage SHA-256)
+-- hash = SHA-256(numero|operation|demande_id|code_patient|details|effectue_par|effectue_le|hash_precedent)
+-- Toute altération ou suppression intermédiaire rompt la chaîne (vérification back-office)
+
+CREATE TABLE patients_anonymisation_journal (
+    numero BIGINT PRIMARY KEY,  -- Séquence continue à partir de 1
+
+    operation VARCHAR(20) NOT NULL,  -- demande, rejet, anonymisation, extraction
+    demande_id UUID,
+    code_patient VARCHAR(40),
+    details TEXT NOT NULL,  -- JSON canonique tel que haché (TEXT et non JSONB : octets conservés)
+
+    effectue_par UUID NOT NULL,
+    effectue_le TIMESTAMP NOT NULL,
+
+    hash_precedent CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL,
+
+    -- ==========================================
+    -- CONTRAINTES FOREIGN KEY
+    -- ==========================================
+    CONSTRAINT FK_patients_anonymisation_journal_demande FOREIGN KEY (demande_id)
+        REFERENCES patients_anonymisation_demande(id),
+    CONSTRAINT FK_patients_anonymisation_journal_effectue_par FOREIGN KEY (effectue_par)
+        REFERENCES user_utilisateur(id),
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_anonymisation_journal_operation
+        CHECK (operation IN ('demande', 'rejet', 'anonymisation', 'extraction')),
+    CONSTRAINT CK_patients_anonymisation_journal_numero CHECK (numero > 0),
+    CONSTRAINT UQ_patients_anonymisation_journal_hash UNIQUE (hash)
+);
+
+-- Journal en ajout seul : aucune modification ni suppression autorisée
+CREATE OR REPLACE FUNCTION prevent_patient_anonymisation_journal_mutation() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'patients_anonymisation_journal est en ajout seul (% interdit)', TG_OP;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_patients_anonymisation_journal_append_only
+    BEFORE UPDATE OR DELETE ON patients_anonymisation_journal
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_patient_anonymisation_journal_mutation();
+
+-- =====================================
+-- TABLE : PATIENTS_OCCUPATION_LIT
+-- =====================================
+-- Description : Historique d'occupation des lits (une ligne par patient et par lit occupé)
+-- Occupation en cours : fin_le IS NULL ; base_lit.est_occupee et base_chambre.est_occupee en sont dérivés
+-- Un transfert clôt l'occupation d'origine (motif transfert) et ouvre la suivante dans la même transaction
+
+CREATE TABLE patients_occupation_lit (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    etablissement_id UUID NOT NULL,
+    lit_id UUID NOT NULL,
+    patient_id UUID NOT NULL,
+    reference_sejour VARCHAR(50),  -- Numéro de séjour ou d'admission (module HOSPITALISATION)
+    occupation_precedente_id UUID,  -- Occupation d'origine en cas de transfert
+
+    -- Période d'occupation
+    debut_le TIMESTAMP DEFAULT NOW() NOT NULL,
+    fin_le TIMESTAMP,
+    motif_fin VARCHAR(20),  -- sortie, transfert, deces, annulation
+    commentaire TEXT,
+    commentaire_fin TEXT,
+
+    -- Traçabilité
+    attribue_par UUID NOT NULL,
+    libere_par UUID,
+
+    -- Métadonnées standards
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    -- ==========================================
+    -- CONTRAINTES FOREIGN KEY
+    -- ==========================================
+    CONSTRAINT FK_patients_occupation_lit_etablissement FOREIGN KEY (etablissement_id)
+        REFERENCES base_etablissement(id),
+    CONSTRAINT FK_patients_occupation_lit_lit FOREIGN KEY (lit_id)
+        REFERENCES base_lit(id),
+    CONSTRAINT FK_patients_occupation_lit_patient FOREIGN KEY (patient_id)
+        REFERENCES patients_patient(id),
+    CONSTRAINT FK_patients_occupation_lit_precedente FOREIGN KEY (occupation_precedente_id)
+        REFERENCES patients_occupation_lit(id),
+    CONSTRAINT FK_patients_occupation_lit_attribue_par FOREIGN KEY (attribue_par)
+        REFERENCES user_utilisateur(id),
+    CONSTRAINT FK_patients_occupation_lit_libere_par FOREIGN KEY (libere_par)
+        REFERENCES user_utilisateur(id),
+
+    -- ==========================================
+    -- CONTRAINTES CHECK
+    -- ==========================================
+    CONSTRAINT CK_patients_occupation_lit_motif_fin
+        CHECK (motif_fin IN ('sortie', 'transfert', 'deces', 'annulation')),
+    CONSTRAINT CK_patients_occupation_lit_coherence_fin CHECK (
+        (fin_le IS NULL AND motif_fin IS NULL AND libere_par IS NULL) OR
+        (fin_le IS NOT NULL AND motif_fin IS NOT NULL AND libere_par IS NOT NULL)
+    ),
+    CONSTRAINT CK_patients_occupation_lit_periode CHECK (fin_le IS NULL OR fin_le >= debut_le)
+);
+
+-- Un lit n'a qu'un occupant à la fois (dernier rempart contre une double attribution)
+CREATE UNIQUE INDEX UQ_patients_occupation_lit_lit_en_cours
+    ON patients_occupation_lit (lit_id) WHERE fin_le IS NULL;
+
+-- Un patient n'occupe qu'un lit à la fois
+CREATE UNIQUE INDEX UQ_patients_occupation_lit_patient_en_cours
+    ON patients_occupation_lit (patient_id) WHERE fin_le IS NULL;
+
+-- Historique d'un lit, du plus récent au plus ancien
+CREATE INDEX IDX_patients_occupation_lit_lit_debut
+    ON patients_occupation_lit (lit_id, debut_le DESC);
+
 -- =====================================
 -- INDEXES CRITIQUES (Maximum 5)
 -- =====================================
@@ -353,9 +895,33 @@ CREATE INDEX IDX_patients_patient_telephone
 CREATE INDEX IDX_patients_patient_statut_actif
     ON patients_patient (statut) WHERE statut = 'actif';
 
--- 5. RECHERCHE TEXTUELLE FALLBACK (Si search_vector échoue - FALLBACK)
-CREATE INDEX IDX_patients_patient_nom_prenoms_trigram
-    ON patients_patient USING gin (nom gin_trgm_ops, prenoms gin_trgm_ops);
+-- 5. RECHERCHE TEXTUELLE FALLBACK ET AUTOCOMPLÉTION (saisie partielle nom, prénoms, code, téléphone)
+CREATE INDEX IDX_patients_patient_autocompletion_trigram
+    ON patients_patient USING gin (
+        nom gin_trgm_ops,
+        prenoms gin_trgm_ops,
+        code_patient gin_trgm_ops,
+        telephone_principal gin_trgm_ops
+    );
+
+-- Hors quota : pagination par curseur de la recherche (created_at, id) sans OFFSET
+CREATE INDEX IDX_patients_patient_keyset
+    ON patients_patient (created_at DESC, id DESC);
+
+-- Hors quota : index partiel limité aux dossiers provisoires à réconcilier (quelques lignes)
+CREATE INDEX IDX_patients_patient_provisoire
+    ON patients_patient (etablissement_createur_id, created_at)
+    WHERE est_provisoire = TRUE AND statut = 'actif';
+
+-- Hors quota : index partiel limité aux dossiers fusionnés (pré-contrôle de la redirection des codes)
+CREATE INDEX IDX_patients_patient_fusionne_code
+    ON patients_patient (code_patient)
+    WHERE fusionne_vers_patient_id IS NOT NULL;
+
+-- Hors quota : dossiers fusionnés dans un dossier donné (journal des accès, remontée de la chaîne de fusion)
+CREATE INDEX IDX_patients_patient_fusionne_vers
+    ON patients_patient (fusionne_vers_patient_id)
+    WHERE fusionne_vers_patient_id IS NOT NULL;
 
 -- =====================================
 -- TRIGGERS
@@ -372,6 +938,11 @@ CREATE TRIGGER trigger_patients_patient_assurance_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER trigger_patients_occupation_lit_updated_at
+    BEFORE UPDATE ON patients_occupation_lit
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
 CREATE TRIGGER trigger_ref_nationalite_updated_at
     BEFORE UPDATE ON ref_nationalite
     FOR EACH ROW
@@ -430,6 +1001,21 @@ COMMENT ON COLUMN patients_patient.est_date_supposee IS 'TRUE si date de naissan
 COMMENT ON TABLE patients_patient_assurance IS 'Association patient-assurance avec support multi-assurances';
 COMMENT ON COLUMN patients_patient_assurance.type_beneficiaire IS 'principal ou ayant_droit (enfant, conjoint, etc.)';
 COMMENT ON COLUMN patients_patient_assurance.numero_assure_principal IS 'Numéro de l''assuré principal si ayant_droit';
+COMMENT ON COLUMN patients_patient_assurance.motif_inactivation IS 'Obligatoire à l''inactivation (fin de contrat, changement d''employeur...)';
+COMMENT ON COLUMN patients_patient.est_assure IS 'Synchronisé avec l''existence d''une couverture active (patients_patient_assurance)';
+
+COMMENT ON COLUMN patients_patient.fusionne_vers_patient_id IS 'Dossier survivant si ce dossier a été fusionné (statut archive)';
+COMMENT ON COLUMN patients_patient.medecin_declarant_deces_id IS 'Médecin ayant certifié le décès (utilisateur est_medecin)';
+COMMENT ON COLUMN patients_patient.deces_declare_le IS 'Horodatage de la déclaration de décès dans le système';
+
+COMMENT ON TABLE patients_fusion_historique IS 'Journal des fusions de doublons patients avec état nécessaire à l''annulation';
+COMMENT ON COLUMN patients_fusion_historique.assurances_transferees IS 'Couvertures déplacées vers le survivant (restituées en cas d''annulation)';
+
+COMMENT ON TABLE patients_patient_historique IS 'Historique append-only des modifications patient (diff avant/après par champ)';
+COMMENT ON COLUMN patients_patient_historique.modifications IS 'Diff JSON {champ: {avant, apres}} - permet de reconstituer le dossier à une date donnée';
+
+COMMENT ON TABLE patients_occupation_lit IS 'Historique d''occupation des lits - occupation en cours si fin_le est NULL';
+COMMENT ON COLUMN patients_occupation_lit.occupation_precedente_id IS 'Occupation d''origine clôturée par transfert';
 
 COMMENT ON TABLE ref_nationalite IS 'Référentiel des nationalités (codes ISO Alpha-3)';
 COMMENT ON TABLE ref_situation_matrimoniale IS 'Référentiel des situations matrimoniales';
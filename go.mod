@@ -3,8 +3,10 @@ module soins-suite-core
 go 1.24.2
 
 require (
+	github.com/boombuler/barcode v1.0.2
 	github.com/gin-contrib/cors v1.7.6
 	github.com/gin-gonic/gin v1.10.1
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-playground/validator/v10 v10.26.0
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.5
@@ -14,6 +16,7 @@ require (
 	go.mongodb.org/mongo-driver v1.17.4
 	go.uber.org/fx v1.24.0
 	golang.org/x/crypto v0.39.0
+	golang.org/x/image v0.28.0
 )
 
 require (
@@ -9,7 +9,11 @@ import (
 	"soins-suite-core/internal/modules/auth"
 	"soins-suite-core/internal/modules/system"
 	"soins-suite-core/internal/modules/back-office/users"
+	backofficeestablishment "soins-suite-core/internal/modules/back-office/establishment"
+	backofficepatients "soins-suite-core/internal/modules/back-office/patients"
 	coreservices "soins-suite-core/internal/modules/core-services"
+	"soins-suite-core/internal/modules/front-office/accueil"
+	"soins-suite-core/internal/modules/front-office/hospitalisation"
 	tirauth "soins-suite-core/internal/modules/tir/tir-auth"
 	tiretablissement "soins-suite-core/internal/modules/tir/tir-etablissement"
 
@@ -44,6 +48,10 @@ var AppModule = fx.Options(
 	auth.Module,
 	system.Module,
 	users.Module,
+	backofficepatients.Module,
+	backofficeestablishment.Module,
+	accueil.Module,
+	hospitalisation.Module,
 	tirauth.Module,
 	tiretablissement.Module,
 
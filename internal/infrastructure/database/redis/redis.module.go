@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 )
 
@@ -11,9 +12,15 @@ func NewRedisClient(config *RedisConfig, keyGenerator *RedisKeyGenerator) (*Clie
 	return NewClient(config, keyGenerator)
 }
 
+// NewGoRedisClient expose le client go-redis natif (utilisé par les core-services patient)
+func NewGoRedisClient(client *Client) *redis.Client {
+	return client.Client()
+}
+
 var Module = fx.Options(
 	fx.Provide(NewRedisKeyGenerator),
 	fx.Provide(NewRedisClient),
+	fx.Provide(NewGoRedisClient),
 	fx.Invoke(RegisterLifecycle),
 )
 
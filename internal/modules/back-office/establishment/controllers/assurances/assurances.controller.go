@@ -0,0 +1,408 @@
+package assurances
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/assurances"
+	coreCatalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+	coreInsuranceDTO "soins-suite-core/internal/modules/core-services/insurance/dto"
+)
+
+type AssurancesController struct {
+	service   *services.AssurancesService
+	validator *validator.Validate
+}
+
+func NewAssurancesController(service *services.AssurancesService) *AssurancesController {
+	return &AssurancesController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// ListInsurers GET /api/v1/back-office/establishment/assurances
+func (c *AssurancesController) ListInsurers(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreInsuranceDTO.InsurerFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "type_organisme: publique, privee, mutuelle ou internationale ; inclure_inactifs booléen",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListInsurers(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des organismes")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetInsurer GET /api/v1/back-office/establishment/assurances/:id
+func (c *AssurancesController) GetInsurer(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetInsurer(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de l'organisme")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateInsurer POST /api/v1/back-office/establishment/assurances
+func (c *AssurancesController) CreateInsurer(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInsuranceDTO.CreateInsurerRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"code_organisme":       "Code requis (2 à 50 caractères)",
+		"nom_officiel":         "Nom officiel requis (2 à 255 caractères)",
+		"type_organisme":       "publique, privee, mutuelle ou internationale",
+		"email":                "Adresse email valide",
+		"delai_paiement_jours": "Entre 0 et 365 jours",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateInsurer(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création de l'organisme impossible", "Erreur lors de la création de l'organisme")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateInsurer PUT /api/v1/back-office/establishment/assurances/:id
+func (c *AssurancesController) UpdateInsurer(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInsuranceDTO.UpdateInsurerRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"nom_officiel":         "2 à 255 caractères",
+		"type_organisme":       "publique, privee, mutuelle ou internationale",
+		"email":                "Adresse email valide",
+		"delai_paiement_jours": "Entre 0 et 365 jours",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateInsurer(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification de l'organisme impossible", "Erreur lors de la modification de l'organisme")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateInsurer POST /api/v1/back-office/establishment/assurances/:id/activer
+func (c *AssurancesController) ActivateInsurer(ctx *gin.Context) {
+	c.setInsurerActive(ctx, true)
+}
+
+// DeactivateInsurer POST /api/v1/back-office/establishment/assurances/:id/desactiver
+func (c *AssurancesController) DeactivateInsurer(ctx *gin.Context) {
+	c.setInsurerActive(ctx, false)
+}
+
+func (c *AssurancesController) setInsurerActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetInsurerActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut de l'organisme")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListConventions GET /api/v1/back-office/establishment/assurances/:id/conventions
+func (c *AssurancesController) ListConventions(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreInsuranceDTO.ConventionFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "en_vigueur_le attendu au format AAAA-MM-JJ ; inclure_inactives booléen",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListConventions(ctx.Request.Context(), establishmentID, ctx.Param("id"), &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des conventions")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateConvention POST /api/v1/back-office/establishment/assurances/:id/conventions
+func (c *AssurancesController) CreateConvention(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInsuranceDTO.CreateConventionRequest
+	if !c.bind(ctx, &req, conventionFields) {
+		return
+	}
+
+	result, err := c.service.CreateConvention(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création de la convention impossible", "Erreur lors de la création de la convention")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateConvention PUT /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId
+func (c *AssurancesController) UpdateConvention(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInsuranceDTO.ConventionTerms
+	if !c.bind(ctx, &req, conventionFields) {
+		return
+	}
+
+	result, err := c.service.UpdateConvention(ctx.Request.Context(), establishmentID,
+		ctx.Param("id"), ctx.Param("conventionId"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification de la convention impossible", "Erreur lors de la modification de la convention")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateConvention POST /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId/activer
+func (c *AssurancesController) ActivateConvention(ctx *gin.Context) {
+	c.setConventionActive(ctx, true)
+}
+
+// DeactivateConvention POST /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId/desactiver
+func (c *AssurancesController) DeactivateConvention(ctx *gin.Context) {
+	c.setConventionActive(ctx, false)
+}
+
+func (c *AssurancesController) setConventionActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetConventionActive(ctx.Request.Context(), establishmentID,
+		ctx.Param("id"), ctx.Param("conventionId"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut de la convention")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CalculateCoverage GET /api/v1/back-office/establishment/assurances/:id/prise-en-charge
+func (c *AssurancesController) CalculateCoverage(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInsuranceDTO.CoverageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil || c.validator.Struct(&req) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "prestation_id requis ; montant entier positif ; instant au format AAAA-MM-JJTHH:MM:SS",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.CalculateCoverage(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err, "Calcul de prise en charge impossible", "Erreur lors du calcul de prise en charge")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// conventionFields messages de validation des conditions d'une convention
+var conventionFields = map[string]string{
+	"taux_couverture":     "Pourcentage requis, supérieur à 0 et au plus 100",
+	"plafond_par_acte":    "Montant entier positif",
+	"date_debut_validite": "Date requise au format AAAA-MM-JJ",
+	"date_fin_validite":   "Date au format AAAA-MM-JJ, incluse",
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *AssurancesController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *AssurancesController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier (404 introuvable, 422 cible ou période invalide, 409 sinon)
+// Les refus du catalogue (tarif absent lors du calcul de prise en charge) sont aussi traduits
+func (c *AssurancesController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var insuranceErr *coreInsuranceDTO.InsuranceError
+	if errors.As(err, &insuranceErr) {
+		status := http.StatusConflict
+		switch insuranceErr.Code {
+		case coreInsuranceDTO.ErrAssuranceIntrouvable, coreInsuranceDTO.ErrConventionIntrouvable:
+			status = http.StatusNotFound
+		case coreInsuranceDTO.ErrConventionCibleInvalide,
+			coreInsuranceDTO.ErrConventionPeriodeInvalide,
+			coreInsuranceDTO.ErrPriseEnChargePrestationInvalide:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    insuranceErr.Code,
+				"message": insuranceErr.Message,
+			},
+		})
+		return
+	}
+
+	var catalogErr *coreCatalogDTO.CatalogError
+	if errors.As(err, &catalogErr) {
+		status := http.StatusConflict
+		if catalogErr.Code == coreCatalogDTO.ErrTarifAucunEnVigueur ||
+			catalogErr.Code == coreCatalogDTO.ErrCataloguePrestationIntrouvable {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    catalogErr.Code,
+				"message": catalogErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
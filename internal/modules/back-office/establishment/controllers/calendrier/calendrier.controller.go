@@ -0,0 +1,402 @@
+package calendrier
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/calendrier"
+	coreCalendarDTO "soins-suite-core/internal/modules/core-services/calendar/dto"
+)
+
+// Taille maximale du calendrier iCalendar accepté (1 Mo, largement au-delà d'un calendrier national)
+const maxICalFileSize = 1 << 20
+
+type CalendrierController struct {
+	service   *services.CalendrierService
+	validator *validator.Validate
+}
+
+func NewCalendrierController(service *services.CalendrierService) *CalendrierController {
+	return &CalendrierController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetWeeklySchedule GET /api/v1/back-office/establishment/horaires
+func (c *CalendrierController) GetWeeklySchedule(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetWeeklySchedule(ctx.Request.Context(), establishmentID)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des horaires")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReplaceWeeklySchedule PUT /api/v1/back-office/establishment/horaires
+func (c *CalendrierController) ReplaceWeeklySchedule(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCalendarDTO.ReplaceOpeningHoursRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"jour_semaine": "Jour de 1 (lundi) à 7 (dimanche)",
+		"heure_debut":  "Heure d'ouverture requise (HH:MM)",
+		"heure_fin":    "Heure de fermeture requise (HH:MM)",
+	}) {
+		return
+	}
+
+	result, err := c.service.ReplaceWeeklySchedule(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification des horaires impossible", "Erreur lors de l'enregistrement des horaires")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetOpeningStatus GET /api/v1/back-office/establishment/horaires/statut?instant=AAAA-MM-JJTHH:MM:SS
+func (c *CalendrierController) GetOpeningStatus(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := c.bindInstant(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetOpeningStatus(ctx.Request.Context(), establishmentID, req)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors du calcul de l'ouverture")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetGardeStatus GET /api/v1/back-office/establishment/horaires/garde?instant=AAAA-MM-JJTHH:MM:SS
+func (c *CalendrierController) GetGardeStatus(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	req, ok := c.bindInstant(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetGardeStatus(ctx.Request.Context(), establishmentID, req)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors du calcul de la garde")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListHolidays GET /api/v1/back-office/establishment/jours-feries?annee=2026&inclure_inactifs=true
+func (c *CalendrierController) ListHolidays(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreCalendarDTO.HolidayFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		c.respondInvalidParameter(ctx, "annee entière et inclure_inactifs booléen attendus")
+		return
+	}
+
+	result, err := c.service.ListHolidays(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des jours fériés")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateHoliday POST /api/v1/back-office/establishment/jours-feries
+func (c *CalendrierController) CreateHoliday(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCalendarDTO.CreateHolidayRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"date_ferie": "Date requise (AAAA-MM-JJ)",
+		"libelle":    "Libellé requis (2 à 255 caractères)",
+		"type_ferie": "national, religieux, local ou exceptionnel",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateHoliday(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Ajout du jour férié impossible", "Erreur lors de l'ajout du jour férié")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateHoliday PUT /api/v1/back-office/establishment/jours-feries/:id
+func (c *CalendrierController) UpdateHoliday(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCalendarDTO.UpdateHolidayRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"libelle":    "Libellé de 2 à 255 caractères",
+		"type_ferie": "national, religieux, local ou exceptionnel",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateHoliday(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification du jour férié impossible", "Erreur lors de la modification du jour férié")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// DeleteHoliday DELETE /api/v1/back-office/establishment/jours-feries/:id
+func (c *CalendrierController) DeleteHoliday(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteHoliday(ctx.Request.Context(), establishmentID, ctx.Param("id"), userID); err != nil {
+		c.respondError(ctx, err, "Suppression du jour férié impossible", "Erreur lors de la suppression du jour férié")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Jour férié supprimé",
+	})
+}
+
+// ImportHolidays POST /api/v1/back-office/establishment/jours-feries/import?simulation=true
+// Paramètres : type_ferie (défaut national), annee_debut, annee_fin, ecraser ; fichier .ics dans le champ 'fichier'
+func (c *CalendrierController) ImportHolidays(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var options coreCalendarDTO.HolidayImportOptions
+	for param, cible := range map[string]*bool{"simulation": &options.Simulation, "ecraser": &options.Ecraser} {
+		if value := ctx.Query(param); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				c.respondInvalidParameter(ctx, param+" doit valoir true ou false")
+				return
+			}
+			*cible = parsed
+		}
+	}
+	for param, cible := range map[string]*int{"annee_debut": &options.AnneeDebut, "annee_fin": &options.AnneeFin} {
+		if value := ctx.Query(param); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 1900 || parsed > 2200 {
+				c.respondInvalidParameter(ctx, param+" doit être une année valide")
+				return
+			}
+			*cible = parsed
+		}
+	}
+	if value := ctx.Query("type_ferie"); value != "" {
+		if err := c.validator.Var(value, "oneof=national religieux local exceptionnel"); err != nil {
+			c.respondInvalidParameter(ctx, "type_ferie: national, religieux, local ou exceptionnel")
+			return
+		}
+		options.TypeFerie = value
+	}
+
+	header, err := ctx.FormFile("fichier")
+	if err != nil {
+		c.respondInvalidParameter(ctx, "Fichier iCalendar requis (champ 'fichier')")
+		return
+	}
+	if header.Size > maxICalFileSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Fichier trop volumineux",
+			"details": map[string]interface{}{
+				"code":    "IMPORT_FICHIER_TROP_VOLUMINEUX",
+				"message": "Taille maximale: 1 Mo",
+			},
+		})
+		return
+	}
+
+	fichier, err := header.Open()
+	if err != nil {
+		c.respondInvalidParameter(ctx, "Fichier iCalendar illisible")
+		return
+	}
+	defer fichier.Close()
+
+	content, err := io.ReadAll(io.LimitReader(fichier, maxICalFileSize))
+	if err != nil {
+		c.respondInvalidParameter(ctx, "Fichier iCalendar illisible")
+		return
+	}
+
+	result, err := c.service.ImportHolidays(ctx.Request.Context(), establishmentID, content, options, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Import du calendrier impossible", "Erreur lors de l'import des jours fériés")
+		return
+	}
+
+	status := http.StatusCreated
+	if options.Simulation {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *CalendrierController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *CalendrierController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// bindInstant décode l'instant interrogé (réponse d'erreur envoyée sinon)
+func (c *CalendrierController) bindInstant(ctx *gin.Context) (*coreCalendarDTO.CalendarQueryRequest, bool) {
+	var req coreCalendarDTO.CalendarQueryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.respondInvalidParameter(ctx, "instant attendu au format AAAA-MM-JJTHH:MM:SS")
+		return nil, false
+	}
+	return &req, true
+}
+
+func (c *CalendrierController) respondInvalidParameter(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusBadRequest, gin.H{
+		"error": "Paramètres invalides",
+		"details": map[string]interface{}{
+			"code":    "VALIDATION_ERROR",
+			"message": message,
+		},
+	})
+}
+
+// respondError traduit les refus métier (404 introuvable, 422 horaires ou fichier invalides, 409 sinon)
+func (c *CalendrierController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var calendarErr *coreCalendarDTO.CalendarError
+	if errors.As(err, &calendarErr) {
+		status := http.StatusConflict
+		switch calendarErr.Code {
+		case coreCalendarDTO.ErrCalendrierFerieIntrouvable:
+			status = http.StatusNotFound
+		case coreCalendarDTO.ErrCalendrierHoraireInvalide, coreCalendarDTO.ErrCalendrierJourDuplique, coreCalendarDTO.ErrCalendrierICSInvalide:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    calendarErr.Code,
+				"message": calendarErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
@@ -0,0 +1,236 @@
+package circuits
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/circuits"
+	coreCircuitDTO "soins-suite-core/internal/modules/core-services/circuit/dto"
+)
+
+type CircuitsController struct {
+	service   *services.CircuitsService
+	validator *validator.Validate
+}
+
+func NewCircuitsController(service *services.CircuitsService) *CircuitsController {
+	return &CircuitsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// ListCircuits GET /api/v1/back-office/establishment/circuits
+func (c *CircuitsController) ListCircuits(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreCircuitDTO.CircuitFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "type_application: defaut, type_prestation ou type_module ; inclure_clos booléen",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListCircuits(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des circuits")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetCircuit GET /api/v1/back-office/establishment/circuits/:id
+func (c *CircuitsController) GetCircuit(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetCircuit(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération du circuit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateCircuit POST /api/v1/back-office/establishment/circuits
+func (c *CircuitsController) CreateCircuit(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCircuitDTO.CreateCircuitRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"nom_circuit":      "Nom requis (2 à 255 caractères)",
+		"type_application": "defaut, type_prestation ou type_module",
+		"module_entree_id": "Module d'entrée requis",
+		"parcours":         "Au moins un parcours, chacun nommé et avec un chemin non vide",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateCircuit(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création du circuit impossible", "Erreur lors de la création du circuit")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReplaceCircuit POST /api/v1/back-office/establishment/circuits/:id/remplacement
+func (c *CircuitsController) ReplaceCircuit(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCircuitDTO.CircuitDefinition
+	if !c.bind(ctx, &req, map[string]string{
+		"nom_circuit":      "Nom requis (2 à 255 caractères)",
+		"module_entree_id": "Module d'entrée requis",
+		"parcours":         "Au moins un parcours, chacun nommé et avec un chemin non vide",
+	}) {
+		return
+	}
+
+	result, err := c.service.ReplaceCircuit(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Remplacement du circuit impossible", "Erreur lors du remplacement du circuit")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CloseCircuit POST /api/v1/back-office/establishment/circuits/:id/cloture
+func (c *CircuitsController) CloseCircuit(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.CloseCircuit(ctx.Request.Context(), establishmentID, ctx.Param("id"), userID)
+	if err != nil {
+		c.respondError(ctx, err, "Clôture du circuit impossible", "Erreur lors de la clôture du circuit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *CircuitsController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *CircuitsController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier (404 introuvable, 422 structure, cible ou date invalide, 409 sinon)
+// Les anomalies de structure sont toutes renvoyées pour être corrigées en une fois
+func (c *CircuitsController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var circuitErr *coreCircuitDTO.CircuitError
+	if errors.As(err, &circuitErr) {
+		status := http.StatusConflict
+		switch circuitErr.Code {
+		case coreCircuitDTO.ErrCircuitIntrouvable:
+			status = http.StatusNotFound
+		case coreCircuitDTO.ErrCircuitStructureInvalide,
+			coreCircuitDTO.ErrCircuitCibleInvalide,
+			coreCircuitDTO.ErrCircuitDateRetroactive,
+			coreCircuitDTO.ErrCircuitCheminInvalide:
+			status = http.StatusUnprocessableEntity
+		}
+		details := map[string]interface{}{
+			"code":    circuitErr.Code,
+			"message": circuitErr.Message,
+		}
+		if len(circuitErr.Anomalies) > 0 {
+			details["anomalies"] = circuitErr.Anomalies
+		}
+		ctx.JSON(status, gin.H{
+			"error":   refus,
+			"details": details,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
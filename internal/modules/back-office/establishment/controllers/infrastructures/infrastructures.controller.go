@@ -0,0 +1,618 @@
+package infrastructures
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/infrastructures"
+	coreInfraDTO "soins-suite-core/internal/modules/core-services/infrastructure/dto"
+)
+
+type InfrastructuresController struct {
+	service   *services.InfrastructuresService
+	validator *validator.Validate
+}
+
+func NewInfrastructuresController(service *services.InfrastructuresService) *InfrastructuresController {
+	return &InfrastructuresController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetTree GET /api/v1/back-office/establishment/infrastructures/arborescence
+func (c *InfrastructuresController) GetTree(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreInfraDTO.TreeFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "batiment_id UUID ; inclure_inactifs booléen",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.GetTree(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de l'arborescence")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListBuildings GET /api/v1/back-office/establishment/batiments
+func (c *InfrastructuresController) ListBuildings(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	filter, ok := c.filter(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.ListBuildings(ctx.Request.Context(), establishmentID, filter.InclureInactifs)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des bâtiments")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetBuilding GET /api/v1/back-office/establishment/batiments/:id
+func (c *InfrastructuresController) GetBuilding(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetBuilding(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération du bâtiment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateBuilding POST /api/v1/back-office/establishment/batiments
+func (c *InfrastructuresController) CreateBuilding(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.CreateBuildingRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"code_batiment": "Code requis (1 à 50 caractères)",
+		"nom_batiment":  "Nom requis (2 à 255 caractères)",
+		"nombre_etages": "Entre 1 et 200 étages, rez-de-chaussée compris",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateBuilding(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création du bâtiment impossible", "Erreur lors de la création du bâtiment")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateBuilding PUT /api/v1/back-office/establishment/batiments/:id
+func (c *InfrastructuresController) UpdateBuilding(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.UpdateBuildingRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"nom_batiment":  "2 à 255 caractères",
+		"nombre_etages": "Entre 1 et 200 étages, rez-de-chaussée compris",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateBuilding(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification du bâtiment impossible", "Erreur lors de la modification du bâtiment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateBuilding POST /api/v1/back-office/establishment/batiments/:id/activer
+func (c *InfrastructuresController) ActivateBuilding(ctx *gin.Context) {
+	c.setBuildingActive(ctx, true)
+}
+
+// DeactivateBuilding POST /api/v1/back-office/establishment/batiments/:id/desactiver
+func (c *InfrastructuresController) DeactivateBuilding(ctx *gin.Context) {
+	c.setBuildingActive(ctx, false)
+}
+
+func (c *InfrastructuresController) setBuildingActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetBuildingActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut du bâtiment")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListCategories GET /api/v1/back-office/establishment/categories-chambres
+func (c *InfrastructuresController) ListCategories(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	filter, ok := c.filter(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.ListCategories(ctx.Request.Context(), establishmentID, filter.InclureInactifs)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des catégories de chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetCategory GET /api/v1/back-office/establishment/categories-chambres/:id
+func (c *InfrastructuresController) GetCategory(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetCategory(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de la catégorie de chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateCategory POST /api/v1/back-office/establishment/categories-chambres
+func (c *InfrastructuresController) CreateCategory(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.CreateRoomCategoryRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"code_categorie": "Code requis (1 à 50 caractères)",
+		"nom_categorie":  "Nom requis (2 à 255 caractères)",
+		"tarif":          "Montant entier positif requis",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateCategory(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création de la catégorie impossible", "Erreur lors de la création de la catégorie de chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateCategory PUT /api/v1/back-office/establishment/categories-chambres/:id
+func (c *InfrastructuresController) UpdateCategory(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.UpdateRoomCategoryRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"nom_categorie": "2 à 255 caractères",
+		"tarif":         "Montant entier positif",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateCategory(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification de la catégorie impossible", "Erreur lors de la modification de la catégorie de chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateCategory POST /api/v1/back-office/establishment/categories-chambres/:id/activer
+func (c *InfrastructuresController) ActivateCategory(ctx *gin.Context) {
+	c.setCategoryActive(ctx, true)
+}
+
+// DeactivateCategory POST /api/v1/back-office/establishment/categories-chambres/:id/desactiver
+func (c *InfrastructuresController) DeactivateCategory(ctx *gin.Context) {
+	c.setCategoryActive(ctx, false)
+}
+
+func (c *InfrastructuresController) setCategoryActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetCategoryActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut de la catégorie de chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListRooms GET /api/v1/back-office/establishment/chambres
+func (c *InfrastructuresController) ListRooms(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	filter, ok := c.filter(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.ListRooms(ctx.Request.Context(), establishmentID, filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des chambres")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetRoom GET /api/v1/back-office/establishment/chambres/:id
+func (c *InfrastructuresController) GetRoom(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetRoom(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de la chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateRoom POST /api/v1/back-office/establishment/chambres
+func (c *InfrastructuresController) CreateRoom(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.CreateRoomRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"batiment_id":          "Bâtiment requis",
+		"categorie_chambre_id": "Catégorie de chambre requise",
+		"numero_chambre":       "Numéro requis (1 à 50 caractères)",
+		"niveau_etage":         "Étage positif ou nul (0 = rez-de-chaussée)",
+		"type_espace":          "consultation, administration, technique, hospitalisation ou bloc_operatoire",
+		"tarif_special":        "Montant entier positif",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateRoom(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création de la chambre impossible", "Erreur lors de la création de la chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateRoom PUT /api/v1/back-office/establishment/chambres/:id
+func (c *InfrastructuresController) UpdateRoom(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.UpdateRoomRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"niveau_etage":  "Étage positif ou nul (0 = rez-de-chaussée)",
+		"type_espace":   "consultation, administration, technique, hospitalisation ou bloc_operatoire",
+		"tarif_special": "Montant entier positif",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateRoom(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification de la chambre impossible", "Erreur lors de la modification de la chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateRoom POST /api/v1/back-office/establishment/chambres/:id/activer
+func (c *InfrastructuresController) ActivateRoom(ctx *gin.Context) {
+	c.setRoomActive(ctx, true)
+}
+
+// DeactivateRoom POST /api/v1/back-office/establishment/chambres/:id/desactiver
+func (c *InfrastructuresController) DeactivateRoom(ctx *gin.Context) {
+	c.setRoomActive(ctx, false)
+}
+
+func (c *InfrastructuresController) setRoomActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetRoomActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut de la chambre")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateBed POST /api/v1/back-office/establishment/chambres/:id/lits
+func (c *InfrastructuresController) CreateBed(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.CreateBedRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"numero_lit": "Numéro requis (1 à 50 caractères)",
+		"code_lit":   "Code requis (1 à 50 caractères)",
+		"type_lit":   "standard, medicalise ou reanimation",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateBed(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création du lit impossible", "Erreur lors de la création du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateBed PUT /api/v1/back-office/establishment/lits/:id
+func (c *InfrastructuresController) UpdateBed(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.UpdateBedRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"numero_lit": "1 à 50 caractères",
+		"code_lit":   "1 à 50 caractères",
+		"type_lit":   "standard, medicalise ou reanimation",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateBed(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification du lit impossible", "Erreur lors de la modification du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateBed POST /api/v1/back-office/establishment/lits/:id/activer
+func (c *InfrastructuresController) ActivateBed(ctx *gin.Context) {
+	c.setBedActive(ctx, true)
+}
+
+// DeactivateBed POST /api/v1/back-office/establishment/lits/:id/desactiver
+func (c *InfrastructuresController) DeactivateBed(ctx *gin.Context) {
+	c.setBedActive(ctx, false)
+}
+
+func (c *InfrastructuresController) setBedActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetBedActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// filter décode les filtres de liste (réponse d'erreur envoyée sinon)
+func (c *InfrastructuresController) filter(ctx *gin.Context) (*coreInfraDTO.InfrastructureFilter, bool) {
+	var filter coreInfraDTO.InfrastructureFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "batiment_id et categorie_chambre_id UUID ; inclure_inactifs booléen",
+			},
+		})
+		return nil, false
+	}
+	return &filter, true
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *InfrastructuresController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *InfrastructuresController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier (404 introuvable, 422 étage invalide, 409 sinon)
+func (c *InfrastructuresController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var infraErr *coreInfraDTO.InfrastructureError
+	if errors.As(err, &infraErr) {
+		status := http.StatusConflict
+		switch infraErr.Code {
+		case coreInfraDTO.ErrInfraBatimentIntrouvable,
+			coreInfraDTO.ErrInfraCategorieIntrouvable,
+			coreInfraDTO.ErrInfraChambreIntrouvable,
+			coreInfraDTO.ErrInfraLitIntrouvable:
+			status = http.StatusNotFound
+		case coreInfraDTO.ErrInfraEtageInvalide:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    infraErr.Code,
+				"message": infraErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
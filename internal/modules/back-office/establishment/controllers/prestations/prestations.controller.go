@@ -0,0 +1,504 @@
+package prestations
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/prestations"
+	coreCatalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+	coreCircuitDTO "soins-suite-core/internal/modules/core-services/circuit/dto"
+)
+
+type PrestationsController struct {
+	service   *services.PrestationsService
+	validator *validator.Validate
+}
+
+func NewPrestationsController(service *services.PrestationsService) *PrestationsController {
+	return &PrestationsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// ListTypes GET /api/v1/back-office/establishment/types-prestations
+func (c *PrestationsController) ListTypes(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	includeInactive, _ := strconv.ParseBool(ctx.DefaultQuery("inclure_inactifs", "false"))
+
+	result, err := c.service.ListTypes(ctx.Request.Context(), establishmentID, includeInactive)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des types de prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetType GET /api/v1/back-office/establishment/types-prestations/:id
+func (c *PrestationsController) GetType(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetType(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération du type de prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateType POST /api/v1/back-office/establishment/types-prestations
+func (c *PrestationsController) CreateType(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.CreateTypePrestationRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"code_type": "Code requis (2 à 50 caractères)",
+		"libelle":   "Libellé requis (2 à 255 caractères)",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreateType(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création du type de prestation impossible", "Erreur lors de la création du type de prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateType PUT /api/v1/back-office/establishment/types-prestations/:id
+func (c *PrestationsController) UpdateType(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.UpdateTypePrestationRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"libelle": "Libellé de 2 à 255 caractères",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdateType(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification du type de prestation impossible", "Erreur lors de la modification du type de prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateType POST /api/v1/back-office/establishment/types-prestations/:id/activer
+func (c *PrestationsController) ActivateType(ctx *gin.Context) {
+	c.setTypeActive(ctx, true)
+}
+
+// DeactivateType POST /api/v1/back-office/establishment/types-prestations/:id/desactiver
+func (c *PrestationsController) DeactivateType(ctx *gin.Context) {
+	c.setTypeActive(ctx, false)
+}
+
+func (c *PrestationsController) setTypeActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetTypeActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut du type de prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// LinkModule POST /api/v1/back-office/establishment/types-prestations/:id/modules
+func (c *PrestationsController) LinkModule(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.LinkTypePrestationModuleRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"module_id": "Module requis",
+	}) {
+		return
+	}
+
+	result, err := c.service.LinkModule(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Rattachement au module impossible", "Erreur lors du rattachement au module")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivateTypeModule POST /api/v1/back-office/establishment/rattachements-modules/:id/activer
+func (c *PrestationsController) ActivateTypeModule(ctx *gin.Context) {
+	c.setTypeModuleActive(ctx, true)
+}
+
+// DeactivateTypeModule POST /api/v1/back-office/establishment/rattachements-modules/:id/desactiver
+func (c *PrestationsController) DeactivateTypeModule(ctx *gin.Context) {
+	c.setTypeModuleActive(ctx, false)
+}
+
+func (c *PrestationsController) setTypeModuleActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetTypeModuleActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut du rattachement")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListPrestations GET /api/v1/back-office/establishment/prestations
+func (c *PrestationsController) ListPrestations(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreCatalogDTO.PrestationFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListPrestations(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération du catalogue des prestations")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetPrestation GET /api/v1/back-office/establishment/prestations/:id
+func (c *PrestationsController) GetPrestation(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetPrestation(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de la prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreatePrestation POST /api/v1/back-office/establishment/prestations
+func (c *PrestationsController) CreatePrestation(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.CreatePrestationRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"type_prestation_module_id":   "Rattachement type › module requis",
+		"code_prestation":             "Code requis (2 à 50 caractères)",
+		"libelle":                     "Libellé requis (2 à 500 caractères)",
+		"code_nomenclature_nationale": "50 caractères maximum",
+		"version_nomenclature":        "20 caractères maximum",
+	}) {
+		return
+	}
+
+	result, err := c.service.CreatePrestation(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Création de la prestation impossible", "Erreur lors de la création de la prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdatePrestation PUT /api/v1/back-office/establishment/prestations/:id
+func (c *PrestationsController) UpdatePrestation(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.UpdatePrestationRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"libelle":                     "Libellé de 2 à 500 caractères",
+		"code_nomenclature_nationale": "50 caractères maximum",
+		"version_nomenclature":        "20 caractères maximum",
+	}) {
+		return
+	}
+
+	result, err := c.service.UpdatePrestation(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Modification de la prestation impossible", "Erreur lors de la modification de la prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ActivatePrestation POST /api/v1/back-office/establishment/prestations/:id/activer
+func (c *PrestationsController) ActivatePrestation(ctx *gin.Context) {
+	c.setPrestationActive(ctx, true)
+}
+
+// DeactivatePrestation POST /api/v1/back-office/establishment/prestations/:id/desactiver
+func (c *PrestationsController) DeactivatePrestation(ctx *gin.Context) {
+	c.setPrestationActive(ctx, false)
+}
+
+func (c *PrestationsController) setPrestationActive(ctx *gin.Context, actif bool) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.SetPrestationActive(ctx.Request.Context(), establishmentID, ctx.Param("id"), actif, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de statut impossible", "Erreur lors du changement de statut de la prestation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ResolveTariff GET /api/v1/back-office/establishment/prestations/:id/tarif
+func (c *PrestationsController) ResolveTariff(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.ResolveTariffRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "instant attendu au format AAAA-MM-JJTHH:MM:SS",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ResolveTariff(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err, "Tarif indisponible", "Erreur lors de la résolution du tarif")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ResolveCircuit GET /api/v1/back-office/establishment/prestations/:id/circuit
+func (c *PrestationsController) ResolveCircuit(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCircuitDTO.ResolveCircuitRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "instant attendu au format AAAA-MM-JJTHH:MM:SS",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ResolveCircuit(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req)
+	if err != nil {
+		c.respondError(ctx, err, "Circuit indisponible", "Erreur lors de la résolution du circuit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *PrestationsController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *PrestationsController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier du catalogue et des circuits (404 introuvable, 403 hors licence, 409 conflit ou état incompatible)
+func (c *PrestationsController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var catalogErr *coreCatalogDTO.CatalogError
+	if errors.As(err, &catalogErr) {
+		status := http.StatusConflict
+		switch catalogErr.Code {
+		case coreCatalogDTO.ErrCatalogueTypeIntrouvable,
+			coreCatalogDTO.ErrCatalogueAssociationIntrouvable,
+			coreCatalogDTO.ErrCataloguePrestationIntrouvable,
+			coreCatalogDTO.ErrCatalogueModuleIntrouvable,
+			coreCatalogDTO.ErrTarifAucunEnVigueur:
+			status = http.StatusNotFound
+		case coreCatalogDTO.ErrCatalogueModuleNonLicencie:
+			status = http.StatusForbidden
+		case coreCatalogDTO.ErrCatalogueIncoherence, coreCatalogDTO.ErrCatalogueModuleSansTicket:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    catalogErr.Code,
+				"message": catalogErr.Message,
+			},
+		})
+		return
+	}
+
+	var circuitErr *coreCircuitDTO.CircuitError
+	if errors.As(err, &circuitErr) {
+		status := http.StatusConflict
+		switch circuitErr.Code {
+		case coreCircuitDTO.ErrCircuitPrestationIntrouvable,
+			coreCircuitDTO.ErrCircuitAucunEnVigueur,
+			coreCircuitDTO.ErrCircuitParcoursIntrouvable:
+			status = http.StatusNotFound
+		case coreCircuitDTO.ErrCircuitPositionInconnue:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    circuitErr.Code,
+				"message": circuitErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
@@ -0,0 +1,205 @@
+package tarifs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/establishment/services/tarifs"
+	coreCatalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+)
+
+type TarifsController struct {
+	service   *services.TarifsService
+	validator *validator.Validate
+}
+
+func NewTarifsController(service *services.TarifsService) *TarifsController {
+	return &TarifsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetHistory GET /api/v1/back-office/establishment/prestations/:id/tarifs
+func (c *TarifsController) GetHistory(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	result, err := c.service.GetHistory(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de l'historique tarifaire")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ScheduleTariff POST /api/v1/back-office/establishment/prestations/:id/tarifs
+func (c *TarifsController) ScheduleTariff(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.ScheduleTariffRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"tarif_unitaire":               "Tarif requis, strictement positif",
+		"tarif_unitaire_periode_garde": "Strictement positif si renseigné",
+		"tarif_unitaire_jour_ferie":    "Strictement positif si renseigné",
+		"type_changement":              "modification ou correction",
+		"motif_changement":             "Motif requis (3 à 255 caractères)",
+	}) {
+		return
+	}
+
+	result, err := c.service.ScheduleTariff(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Changement de tarif impossible", "Erreur lors de l'enregistrement du tarif")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CancelScheduledTariff DELETE /api/v1/back-office/establishment/prestations/:id/tarifs/:tarifId
+func (c *TarifsController) CancelScheduledTariff(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.CancelScheduledTariff(ctx.Request.Context(), establishmentID, ctx.Param("id"), ctx.Param("tarifId"), userID)
+	if err != nil {
+		c.respondError(ctx, err, "Annulation du tarif impossible", "Erreur lors de l'annulation du tarif planifié")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReviseTariffs POST /api/v1/back-office/establishment/tarifs/revisions
+func (c *TarifsController) ReviseTariffs(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreCatalogDTO.BulkTariffRevisionRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"pourcentage":      "Pourcentage non nul, supérieur à -100 et au plus 1000",
+		"arrondi":          "Arrondi de 1 à 10000",
+		"motif_changement": "Motif requis (3 à 255 caractères)",
+	}) {
+		return
+	}
+
+	result, err := c.service.ReviseTariffs(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Révision des tarifs impossible", "Erreur lors de la révision des tarifs")
+		return
+	}
+
+	status := http.StatusCreated
+	if req.Simulation {
+		status = http.StatusOK
+	}
+	ctx.JSON(status, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *TarifsController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *TarifsController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier (404 introuvable, 422 date rétroactive ou périmètre vide, 409 sinon)
+func (c *TarifsController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var catalogErr *coreCatalogDTO.CatalogError
+	if errors.As(err, &catalogErr) {
+		status := http.StatusConflict
+		switch catalogErr.Code {
+		case coreCatalogDTO.ErrCataloguePrestationIntrouvable, coreCatalogDTO.ErrTarifIntrouvable:
+			status = http.StatusNotFound
+		case coreCatalogDTO.ErrTarifDateRetroactive, coreCatalogDTO.ErrTarifPerimetreVide:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    catalogErr.Code,
+				"message": catalogErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
@@ -0,0 +1,284 @@
+package establishment
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+
+	assurancesControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/assurances"
+	calendrierControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/calendrier"
+	circuitsControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/circuits"
+	infrastructuresControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/infrastructures"
+	prestationsControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/prestations"
+	tarifsControllers "soins-suite-core/internal/modules/back-office/establishment/controllers/tarifs"
+	assurancesServices "soins-suite-core/internal/modules/back-office/establishment/services/assurances"
+	calendrierServices "soins-suite-core/internal/modules/back-office/establishment/services/calendrier"
+	circuitsServices "soins-suite-core/internal/modules/back-office/establishment/services/circuits"
+	infrastructuresServices "soins-suite-core/internal/modules/back-office/establishment/services/infrastructures"
+	prestationsServices "soins-suite-core/internal/modules/back-office/establishment/services/prestations"
+	tarifsServices "soins-suite-core/internal/modules/back-office/establishment/services/tarifs"
+	authMiddleware "soins-suite-core/internal/shared/middleware/auth"
+)
+
+// Module regroupe le paramétrage de l'établissement (back-office GESTION_ETABLISSEMENT)
+var Module = fx.Options(
+	fx.Provide(prestationsServices.NewPrestationsService),
+	fx.Provide(prestationsControllers.NewPrestationsController),
+	fx.Provide(tarifsServices.NewTarifsService),
+	fx.Provide(tarifsControllers.NewTarifsController),
+	fx.Provide(calendrierServices.NewCalendrierService),
+	fx.Provide(calendrierControllers.NewCalendrierController),
+	fx.Provide(circuitsServices.NewCircuitsService),
+	fx.Provide(circuitsControllers.NewCircuitsController),
+	fx.Provide(assurancesServices.NewAssurancesService),
+	fx.Provide(assurancesControllers.NewAssurancesController),
+	fx.Provide(infrastructuresServices.NewInfrastructuresService),
+	fx.Provide(infrastructuresControllers.NewInfrastructuresController),
+	fx.Invoke(RegisterEstablishmentRoutes),
+)
+
+// RegisterEstablishmentRoutes configure les routes de paramétrage, protégées rubrique par rubrique
+func RegisterEstablishmentRoutes(
+	r *gin.Engine,
+	prestationsCtrl *prestationsControllers.PrestationsController,
+	tarifsCtrl *tarifsControllers.TarifsController,
+	calendrierCtrl *calendrierControllers.CalendrierController,
+	circuitsCtrl *circuitsControllers.CircuitsController,
+	assurancesCtrl *assurancesControllers.AssurancesController,
+	infrastructuresCtrl *infrastructuresControllers.InfrastructuresController,
+	authStack *authMiddleware.AuthMiddlewareStack,
+) {
+	// Catalogue des prestations : rubrique GESTION_ETABLISSEMENT › PRESTATIONS
+	prestations := r.Group("/api/v1/back-office/establishment")
+	prestations.Use(authMiddleware.RequireRubrique(authStack, "GESTION_ETABLISSEMENT", "PRESTATIONS")...)
+	{
+		// GET /api/v1/back-office/establishment/types-prestations - Types de prestation (?inclure_inactifs=true)
+		prestations.GET("/types-prestations", prestationsCtrl.ListTypes)
+
+		// POST /api/v1/back-office/establishment/types-prestations - Création d'un type
+		prestations.POST("/types-prestations", prestationsCtrl.CreateType)
+
+		// GET /api/v1/back-office/establishment/types-prestations/:id - Type et modules rattachés
+		prestations.GET("/types-prestations/:id", prestationsCtrl.GetType)
+
+		// PUT /api/v1/back-office/establishment/types-prestations/:id - Libellé, description
+		prestations.PUT("/types-prestations/:id", prestationsCtrl.UpdateType)
+
+		// POST /api/v1/back-office/establishment/types-prestations/:id/activer - Réactivation
+		prestations.POST("/types-prestations/:id/activer", prestationsCtrl.ActivateType)
+
+		// POST /api/v1/back-office/establishment/types-prestations/:id/desactiver - Désactivation (sans prestation active)
+		prestations.POST("/types-prestations/:id/desactiver", prestationsCtrl.DeactivateType)
+
+		// POST /api/v1/back-office/establishment/types-prestations/:id/modules - Rattachement à un module licencié
+		prestations.POST("/types-prestations/:id/modules", prestationsCtrl.LinkModule)
+
+		// POST /api/v1/back-office/establishment/rattachements-modules/:id/activer - Réactivation d'un rattachement
+		prestations.POST("/rattachements-modules/:id/activer", prestationsCtrl.ActivateTypeModule)
+
+		// POST /api/v1/back-office/establishment/rattachements-modules/:id/desactiver - Désactivation d'un rattachement
+		prestations.POST("/rattachements-modules/:id/desactiver", prestationsCtrl.DeactivateTypeModule)
+
+		// GET /api/v1/back-office/establishment/prestations - Catalogue paginé et filtré
+		prestations.GET("/prestations", prestationsCtrl.ListPrestations)
+
+		// POST /api/v1/back-office/establishment/prestations - Ajout au catalogue
+		prestations.POST("/prestations", prestationsCtrl.CreatePrestation)
+
+		// GET /api/v1/back-office/establishment/prestations/:id - Détail d'une prestation
+		prestations.GET("/prestations/:id", prestationsCtrl.GetPrestation)
+
+		// PUT /api/v1/back-office/establishment/prestations/:id - Modification (reclassement revalidé)
+		prestations.PUT("/prestations/:id", prestationsCtrl.UpdatePrestation)
+
+		// POST /api/v1/back-office/establishment/prestations/:id/activer - Réactivation (parent actif et licencié)
+		prestations.POST("/prestations/:id/activer", prestationsCtrl.ActivatePrestation)
+
+		// POST /api/v1/back-office/establishment/prestations/:id/desactiver - Retrait du catalogue
+		prestations.POST("/prestations/:id/desactiver", prestationsCtrl.DeactivatePrestation)
+
+		// GET /api/v1/back-office/establishment/prestations/:id/tarif - Tarif applicable (?instant=AAAA-MM-JJTHH:MM:SS)
+		prestations.GET("/prestations/:id/tarif", prestationsCtrl.ResolveTariff)
+
+		// GET /api/v1/back-office/establishment/prestations/:id/circuit - Circuit effectif et étapes suivantes (?module_courant=&parcours_id=&sous_chemin_id=&instant=)
+		prestations.GET("/prestations/:id/circuit", prestationsCtrl.ResolveCircuit)
+
+		// GET /api/v1/back-office/establishment/prestations/:id/tarifs - Historique des prix (passés, en vigueur, planifiés)
+		prestations.GET("/prestations/:id/tarifs", tarifsCtrl.GetHistory)
+
+		// POST /api/v1/back-office/establishment/prestations/:id/tarifs - Nouveau tarif (immédiat ou date future)
+		prestations.POST("/prestations/:id/tarifs", tarifsCtrl.ScheduleTariff)
+
+		// DELETE /api/v1/back-office/establishment/prestations/:id/tarifs/:tarifId - Annulation d'un changement planifié
+		prestations.DELETE("/prestations/:id/tarifs/:tarifId", tarifsCtrl.CancelScheduledTariff)
+
+		// POST /api/v1/back-office/establishment/tarifs/revisions - Révision globale en % (simulation possible)
+		prestations.POST("/tarifs/revisions", tarifsCtrl.ReviseTariffs)
+	}
+
+	// Horaires d'ouverture et jours fériés : rubrique GESTION_ETABLISSEMENT › INFORMATIONS_GENERALES
+	calendrier := r.Group("/api/v1/back-office/establishment")
+	calendrier.Use(authMiddleware.RequireRubrique(authStack, "GESTION_ETABLISSEMENT", "INFORMATIONS_GENERALES")...)
+	{
+		// GET /api/v1/back-office/establishment/horaires - Semaine type et horaires de garde
+		calendrier.GET("/horaires", calendrierCtrl.GetWeeklySchedule)
+
+		// PUT /api/v1/back-office/establishment/horaires - Remplacement de la semaine type (jours absents fermés)
+		calendrier.PUT("/horaires", calendrierCtrl.ReplaceWeeklySchedule)
+
+		// GET /api/v1/back-office/establishment/horaires/statut - Ouvert maintenant ? prochaine ouverture (?instant=AAAA-MM-JJTHH:MM:SS)
+		calendrier.GET("/horaires/statut", calendrierCtrl.GetOpeningStatus)
+
+		// GET /api/v1/back-office/establishment/horaires/garde - Période de garde (?instant=AAAA-MM-JJTHH:MM:SS)
+		calendrier.GET("/horaires/garde", calendrierCtrl.GetGardeStatus)
+
+		// GET /api/v1/back-office/establishment/jours-feries - Jours fériés (?annee=AAAA&inclure_inactifs=true)
+		calendrier.GET("/jours-feries", calendrierCtrl.ListHolidays)
+
+		// POST /api/v1/back-office/establishment/jours-feries - Ajout d'un jour férié
+		calendrier.POST("/jours-feries", calendrierCtrl.CreateHoliday)
+
+		// POST /api/v1/back-office/establishment/jours-feries/import - Import d'un calendrier .ics (simulation possible)
+		calendrier.POST("/jours-feries/import", calendrierCtrl.ImportHolidays)
+
+		// PUT /api/v1/back-office/establishment/jours-feries/:id - Libellé, type, activation
+		calendrier.PUT("/jours-feries/:id", calendrierCtrl.UpdateHoliday)
+
+		// DELETE /api/v1/back-office/establishment/jours-feries/:id - Suppression d'un jour saisi par erreur
+		calendrier.DELETE("/jours-feries/:id", calendrierCtrl.DeleteHoliday)
+	}
+
+	// Circuits patients : rubrique GESTION_ETABLISSEMENT › MODULES
+	circuits := r.Group("/api/v1/back-office/establishment")
+	circuits.Use(authMiddleware.RequireRubrique(authStack, "GESTION_ETABLISSEMENT", "MODULES")...)
+	{
+		// GET /api/v1/back-office/establishment/circuits - Circuits (?type_application=&inclure_clos=true)
+		circuits.GET("/circuits", circuitsCtrl.ListCircuits)
+
+		// POST /api/v1/back-office/establishment/circuits - Création (cible sans circuit ouvert)
+		circuits.POST("/circuits", circuitsCtrl.CreateCircuit)
+
+		// GET /api/v1/back-office/establishment/circuits/:id - Circuit, parcours et sous-chemins
+		circuits.GET("/circuits/:id", circuitsCtrl.GetCircuit)
+
+		// POST /api/v1/back-office/establishment/circuits/:id/remplacement - Nouvelle version (clôture et ouverture atomiques)
+		circuits.POST("/circuits/:id/remplacement", circuitsCtrl.ReplaceCircuit)
+
+		// POST /api/v1/back-office/establishment/circuits/:id/cloture - Fin d'activité (annulation si planifié)
+		circuits.POST("/circuits/:id/cloture", circuitsCtrl.CloseCircuit)
+	}
+
+	// Organismes payeurs et conventions : rubrique GESTION_ETABLISSEMENT › ASSURANCES
+	assurances := r.Group("/api/v1/back-office/establishment")
+	assurances.Use(authMiddleware.RequireRubrique(authStack, "GESTION_ETABLISSEMENT", "ASSURANCES")...)
+	{
+		// GET /api/v1/back-office/establishment/assurances - Organismes (?type_organisme=&recherche=&inclure_inactifs=true)
+		assurances.GET("/assurances", assurancesCtrl.ListInsurers)
+
+		// POST /api/v1/back-office/establishment/assurances - Enregistrement (code unique dans l'établissement)
+		assurances.POST("/assurances", assurancesCtrl.CreateInsurer)
+
+		// GET /api/v1/back-office/establishment/assurances/:id - Organisme, conventions et couvertures actives
+		assurances.GET("/assurances/:id", assurancesCtrl.GetInsurer)
+
+		// PUT /api/v1/back-office/establishment/assurances/:id - Modification (code immuable)
+		assurances.PUT("/assurances/:id", assurancesCtrl.UpdateInsurer)
+
+		// POST /api/v1/back-office/establishment/assurances/:id/activer - Réactivation
+		assurances.POST("/assurances/:id/activer", assurancesCtrl.ActivateInsurer)
+
+		// POST /api/v1/back-office/establishment/assurances/:id/desactiver - Désactivation (couvertures existantes conservées)
+		assurances.POST("/assurances/:id/desactiver", assurancesCtrl.DeactivateInsurer)
+
+		// GET /api/v1/back-office/establishment/assurances/:id/conventions - Conventions (?en_vigueur_le=AAAA-MM-JJ&inclure_inactives=true)
+		assurances.GET("/assurances/:id/conventions", assurancesCtrl.ListConventions)
+
+		// POST /api/v1/back-office/establishment/assurances/:id/conventions - Convention sur un type de prestation ou une prestation
+		assurances.POST("/assurances/:id/conventions", assurancesCtrl.CreateConvention)
+
+		// PUT /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId - Taux, plafond, accord préalable, validité
+		assurances.PUT("/assurances/:id/conventions/:conventionId", assurancesCtrl.UpdateConvention)
+
+		// POST /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId/activer - Réactivation (sans chevauchement)
+		assurances.POST("/assurances/:id/conventions/:conventionId/activer", assurancesCtrl.ActivateConvention)
+
+		// POST /api/v1/back-office/establishment/assurances/:id/conventions/:conventionId/desactiver - Résiliation
+		assurances.POST("/assurances/:id/conventions/:conventionId/desactiver", assurancesCtrl.DeactivateConvention)
+
+		// GET /api/v1/back-office/establishment/assurances/:id/prise-en-charge - Part organisme et ticket modérateur (?prestation_id=&montant=&instant=)
+		assurances.GET("/assurances/:id/prise-en-charge", assurancesCtrl.CalculateCoverage)
+	}
+
+	// Bâtiments, étages, chambres et lits : rubrique GESTION_ETABLISSEMENT › INFRASTRUCTURES
+	infrastructures := r.Group("/api/v1/back-office/establishment")
+	infrastructures.Use(authMiddleware.RequireRubrique(authStack, "GESTION_ETABLISSEMENT", "INFRASTRUCTURES")...)
+	{
+		// GET /api/v1/back-office/establishment/infrastructures/arborescence - Bâtiment › étage › chambre › lit (?batiment_id=&inclure_inactifs=true)
+		infrastructures.GET("/infrastructures/arborescence", infrastructuresCtrl.GetTree)
+
+		// GET /api/v1/back-office/establishment/batiments - Bâtiments et capacité (?inclure_inactifs=true)
+		infrastructures.GET("/batiments", infrastructuresCtrl.ListBuildings)
+
+		// POST /api/v1/back-office/establishment/batiments - Création (code unique dans l'établissement)
+		infrastructures.POST("/batiments", infrastructuresCtrl.CreateBuilding)
+
+		// GET /api/v1/back-office/establishment/batiments/:id - Bâtiment et capacité
+		infrastructures.GET("/batiments/:id", infrastructuresCtrl.GetBuilding)
+
+		// PUT /api/v1/back-office/establishment/batiments/:id - Nom, description, nombre d'étages (code immuable)
+		infrastructures.PUT("/batiments/:id", infrastructuresCtrl.UpdateBuilding)
+
+		// POST /api/v1/back-office/establishment/batiments/:id/activer - Réactivation
+		infrastructures.POST("/batiments/:id/activer", infrastructuresCtrl.ActivateBuilding)
+
+		// POST /api/v1/back-office/establishment/batiments/:id/desactiver - Désactivation (aucun lit occupé)
+		infrastructures.POST("/batiments/:id/desactiver", infrastructuresCtrl.DeactivateBuilding)
+
+		// GET /api/v1/back-office/establishment/categories-chambres - Catégories et tarifs (?inclure_inactifs=true)
+		infrastructures.GET("/categories-chambres", infrastructuresCtrl.ListCategories)
+
+		// POST /api/v1/back-office/establishment/categories-chambres - Création (code unique dans l'établissement)
+		infrastructures.POST("/categories-chambres", infrastructuresCtrl.CreateCategory)
+
+		// GET /api/v1/back-office/establishment/categories-chambres/:id - Catégorie
+		infrastructures.GET("/categories-chambres/:id", infrastructuresCtrl.GetCategory)
+
+		// PUT /api/v1/back-office/establishment/categories-chambres/:id - Nom, description, tarif (code immuable)
+		infrastructures.PUT("/categories-chambres/:id", infrastructuresCtrl.UpdateCategory)
+
+		// POST /api/v1/back-office/establishment/categories-chambres/:id/activer - Réactivation
+		infrastructures.POST("/categories-chambres/:id/activer", infrastructuresCtrl.ActivateCategory)
+
+		// POST /api/v1/back-office/establishment/categories-chambres/:id/desactiver - Désactivation (aucun lit occupé)
+		infrastructures.POST("/categories-chambres/:id/desactiver", infrastructuresCtrl.DeactivateCategory)
+
+		// GET /api/v1/back-office/establishment/chambres - Chambres (?batiment_id=&categorie_chambre_id=&inclure_inactifs=true)
+		infrastructures.GET("/chambres", infrastructuresCtrl.ListRooms)
+
+		// POST /api/v1/back-office/establishment/chambres - Création sur un étage du bâtiment (numéro unique dans le bâtiment)
+		infrastructures.POST("/chambres", infrastructuresCtrl.CreateRoom)
+
+		// GET /api/v1/back-office/establishment/chambres/:id - Chambre et ses lits
+		infrastructures.GET("/chambres/:id", infrastructuresCtrl.GetRoom)
+
+		// PUT /api/v1/back-office/establishment/chambres/:id - Catégorie, étage, espace, tarif (bâtiment et numéro immuables)
+		infrastructures.PUT("/chambres/:id", infrastructuresCtrl.UpdateRoom)
+
+		// POST /api/v1/back-office/establishment/chambres/:id/activer - Réactivation (bâtiment et catégorie actifs)
+		infrastructures.POST("/chambres/:id/activer", infrastructuresCtrl.ActivateRoom)
+
+		// POST /api/v1/back-office/establishment/chambres/:id/desactiver - Désactivation (aucun lit occupé)
+		infrastructures.POST("/chambres/:id/desactiver", infrastructuresCtrl.DeactivateRoom)
+
+		// POST /api/v1/back-office/establishment/chambres/:id/lits - Ajout d'un lit (code unique dans l'établissement)
+		infrastructures.POST("/chambres/:id/lits", infrastructuresCtrl.CreateBed)
+
+		// PUT /api/v1/back-office/establishment/lits/:id - Numéro, code, type
+		infrastructures.PUT("/lits/:id", infrastructuresCtrl.UpdateBed)
+
+		// POST /api/v1/back-office/establishment/lits/:id/activer - Réactivation (chambre active)
+		infrastructures.POST("/lits/:id/activer", infrastructuresCtrl.ActivateBed)
+
+		// POST /api/v1/back-office/establishment/lits/:id/desactiver - Désactivation d'un lit libre
+		infrastructures.POST("/lits/:id/desactiver", infrastructuresCtrl.DeactivateBed)
+	}
+}
@@ -0,0 +1,275 @@
+package assurances
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreInsuranceDTO "soins-suite-core/internal/modules/core-services/insurance/dto"
+	coreInsuranceServices "soins-suite-core/internal/modules/core-services/insurance/services"
+)
+
+// AssurancesService expose les organismes payeurs, leurs conventions et le calcul de prise en charge (rubrique ASSURANCES)
+type AssurancesService struct {
+	insurerService    *coreInsuranceServices.InsurerService
+	conventionService *coreInsuranceServices.InsuranceConventionService
+	coverageService   *coreInsuranceServices.CoverageCalculatorService
+}
+
+// NewAssurancesService constructeur Fx compatible
+func NewAssurancesService(
+	insurerService *coreInsuranceServices.InsurerService,
+	conventionService *coreInsuranceServices.InsuranceConventionService,
+	coverageService *coreInsuranceServices.CoverageCalculatorService,
+) *AssurancesService {
+	return &AssurancesService{
+		insurerService:    insurerService,
+		conventionService: conventionService,
+		coverageService:   coverageService,
+	}
+}
+
+// ListInsurers retourne les organismes de l'établissement
+func (s *AssurancesService) ListInsurers(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreInsuranceDTO.InsurerFilter,
+) ([]coreInsuranceDTO.InsurerResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.insurerService.ListInsurers(ctx, etablissementUUID, filter)
+}
+
+// GetInsurer retourne un organisme
+func (s *AssurancesService) GetInsurer(ctx context.Context, establishmentID, assuranceID string) (*coreInsuranceDTO.InsurerResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.insurerService.GetInsurer(ctx, etablissementUUID, assuranceUUID)
+}
+
+// CreateInsurer enregistre un organisme
+func (s *AssurancesService) CreateInsurer(
+	ctx context.Context,
+	establishmentID string,
+	req *coreInsuranceDTO.CreateInsurerRequest,
+	userID string,
+) (*coreInsuranceDTO.InsurerResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.insurerService.CreateInsurer(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateInsurer modifie un organisme
+func (s *AssurancesService) UpdateInsurer(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	req *coreInsuranceDTO.UpdateInsurerRequest,
+	userID string,
+) (*coreInsuranceDTO.InsurerResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.insurerService.UpdateInsurer(ctx, etablissementUUID, assuranceUUID, req, userUUID)
+}
+
+// SetInsurerActive active ou désactive un organisme
+func (s *AssurancesService) SetInsurerActive(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	actif bool,
+	userID string,
+) (*coreInsuranceDTO.InsurerResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.insurerService.SetInsurerActive(ctx, etablissementUUID, assuranceUUID, actif, userUUID)
+}
+
+// ListConventions retourne les conventions d'un organisme
+func (s *AssurancesService) ListConventions(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	filter *coreInsuranceDTO.ConventionFilter,
+) ([]coreInsuranceDTO.ConventionResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.conventionService.ListConventions(ctx, etablissementUUID, assuranceUUID, filter)
+}
+
+// CreateConvention ajoute une convention à un organisme
+func (s *AssurancesService) CreateConvention(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	req *coreInsuranceDTO.CreateConventionRequest,
+	userID string,
+) (*coreInsuranceDTO.ConventionResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.conventionService.CreateConvention(ctx, etablissementUUID, assuranceUUID, req, userUUID)
+}
+
+// UpdateConvention remplace les conditions d'une convention
+func (s *AssurancesService) UpdateConvention(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	conventionID string,
+	req *coreInsuranceDTO.ConventionTerms,
+	userID string,
+) (*coreInsuranceDTO.ConventionResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assuranceUUID, conventionUUID, err := parseConventionIDs(assuranceID, conventionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.conventionService.UpdateConvention(ctx, etablissementUUID, assuranceUUID, conventionUUID, req, userUUID)
+}
+
+// SetConventionActive réactive ou résilie une convention
+func (s *AssurancesService) SetConventionActive(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	conventionID string,
+	actif bool,
+	userID string,
+) (*coreInsuranceDTO.ConventionResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assuranceUUID, conventionUUID, err := parseConventionIDs(assuranceID, conventionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.conventionService.SetConventionActive(ctx, etablissementUUID, assuranceUUID, conventionUUID, actif, userUUID)
+}
+
+// CalculateCoverage répartit un acte entre l'organisme et le patient (maintenant par défaut)
+func (s *AssurancesService) CalculateCoverage(
+	ctx context.Context,
+	establishmentID string,
+	assuranceID string,
+	req *coreInsuranceDTO.CoverageRequest,
+) (*coreInsuranceDTO.CoverageResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return nil, err
+	}
+
+	prestationUUID, err := uuid.Parse(req.PrestationID)
+	if err != nil {
+		return nil, coreInsuranceDTO.NewInsuranceError(coreInsuranceDTO.ErrPriseEnChargePrestationInvalide,
+			fmt.Sprintf("Identifiant de prestation invalide: %s", req.PrestationID))
+	}
+
+	instant := time.Now()
+	if req.Instant != nil {
+		instant = *req.Instant
+	}
+
+	return s.coverageService.CalculateCoverage(ctx, etablissementUUID, assuranceUUID, prestationUUID, instant, req.Montant)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseAssuranceID convertit l'identifiant d'un organisme (introuvable s'il est mal formé)
+func parseAssuranceID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreInsuranceDTO.NewInsuranceError(coreInsuranceDTO.ErrAssuranceIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
+
+// parseConventionIDs convertit les identifiants d'un organisme et de sa convention
+func parseConventionIDs(assuranceID, conventionID string) (uuid.UUID, uuid.UUID, error) {
+	assuranceUUID, err := parseAssuranceID(assuranceID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	conventionUUID, err := uuid.Parse(conventionID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, coreInsuranceDTO.NewInsuranceError(coreInsuranceDTO.ErrConventionIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", conventionID))
+	}
+
+	return assuranceUUID, conventionUUID, nil
+}
@@ -0,0 +1,191 @@
+package calendrier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreCalendarDTO "soins-suite-core/internal/modules/core-services/calendar/dto"
+	coreCalendarServices "soins-suite-core/internal/modules/core-services/calendar/services"
+)
+
+// CalendrierService expose horaires d'ouverture, jours fériés et état d'ouverture (rubrique INFORMATIONS_GENERALES)
+type CalendrierService struct {
+	calendarService *coreCalendarServices.CalendarService
+}
+
+// NewCalendrierService constructeur Fx compatible
+func NewCalendrierService(calendarService *coreCalendarServices.CalendarService) *CalendrierService {
+	return &CalendrierService{
+		calendarService: calendarService,
+	}
+}
+
+// GetWeeklySchedule retourne la semaine type et les horaires de garde
+func (s *CalendrierService) GetWeeklySchedule(ctx context.Context, establishmentID string) (*coreCalendarDTO.WeeklyScheduleResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.calendarService.GetWeeklySchedule(ctx, etablissementUUID)
+}
+
+// ReplaceWeeklySchedule remplace la semaine type
+func (s *CalendrierService) ReplaceWeeklySchedule(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCalendarDTO.ReplaceOpeningHoursRequest,
+	userID string,
+) (*coreCalendarDTO.WeeklyScheduleResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendarService.ReplaceWeeklySchedule(ctx, etablissementUUID, req, userUUID)
+}
+
+// ListHolidays retourne les jours fériés filtrés
+func (s *CalendrierService) ListHolidays(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreCalendarDTO.HolidayFilter,
+) ([]coreCalendarDTO.HolidayResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.calendarService.ListHolidays(ctx, etablissementUUID, filter)
+}
+
+// CreateHoliday ajoute un jour férié
+func (s *CalendrierService) CreateHoliday(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCalendarDTO.CreateHolidayRequest,
+	userID string,
+) (*coreCalendarDTO.HolidayResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendarService.CreateHoliday(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateHoliday modifie un jour férié
+func (s *CalendrierService) UpdateHoliday(
+	ctx context.Context,
+	establishmentID string,
+	ferieID string,
+	req *coreCalendarDTO.UpdateHolidayRequest,
+	userID string,
+) (*coreCalendarDTO.HolidayResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ferieUUID, err := parseFerieID(ferieID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendarService.UpdateHoliday(ctx, etablissementUUID, ferieUUID, req, userUUID)
+}
+
+// DeleteHoliday supprime un jour férié
+func (s *CalendrierService) DeleteHoliday(ctx context.Context, establishmentID, ferieID, userID string) error {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	ferieUUID, err := parseFerieID(ferieID)
+	if err != nil {
+		return err
+	}
+
+	return s.calendarService.DeleteHoliday(ctx, etablissementUUID, ferieUUID, userUUID)
+}
+
+// ImportHolidays importe (ou simule) un calendrier national au format iCalendar
+func (s *CalendrierService) ImportHolidays(
+	ctx context.Context,
+	establishmentID string,
+	content []byte,
+	options coreCalendarDTO.HolidayImportOptions,
+	userID string,
+) (*coreCalendarDTO.HolidayImportResult, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendarService.ImportHolidays(ctx, etablissementUUID, content, options, userUUID)
+}
+
+// GetOpeningStatus retourne l'état d'ouverture à un instant (maintenant par défaut)
+func (s *CalendrierService) GetOpeningStatus(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCalendarDTO.CalendarQueryRequest,
+) (*coreCalendarDTO.OpeningStatus, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.calendarService.IsOpenAt(ctx, etablissementUUID, instantOf(req))
+}
+
+// GetGardeStatus indique si un instant (maintenant par défaut) tombe en période de garde
+func (s *CalendrierService) GetGardeStatus(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCalendarDTO.CalendarQueryRequest,
+) (*coreCalendarDTO.GardeStatus, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.calendarService.GardeAt(ctx, etablissementUUID, instantOf(req))
+}
+
+// instantOf retourne l'instant interrogé ou l'heure courante
+func instantOf(req *coreCalendarDTO.CalendarQueryRequest) time.Time {
+	if req.Instant != nil {
+		return *req.Instant
+	}
+	return time.Now()
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseFerieID convertit l'identifiant d'un jour férié (introuvable s'il est mal formé)
+func parseFerieID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreCalendarDTO.NewCalendarError(coreCalendarDTO.ErrCalendrierFerieIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,133 @@
+package circuits
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	coreCircuitDTO "soins-suite-core/internal/modules/core-services/circuit/dto"
+	coreCircuitServices "soins-suite-core/internal/modules/core-services/circuit/services"
+)
+
+// CircuitsService expose la conception des circuits patients (rubrique MODULES)
+type CircuitsService struct {
+	authoringService *coreCircuitServices.CircuitAuthoringService
+}
+
+// NewCircuitsService constructeur Fx compatible
+func NewCircuitsService(authoringService *coreCircuitServices.CircuitAuthoringService) *CircuitsService {
+	return &CircuitsService{
+		authoringService: authoringService,
+	}
+}
+
+// ListCircuits retourne les circuits de l'établissement
+func (s *CircuitsService) ListCircuits(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreCircuitDTO.CircuitFilter,
+) ([]coreCircuitDTO.CircuitResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.authoringService.ListCircuits(ctx, etablissementUUID, filter)
+}
+
+// GetCircuit retourne un circuit et ses parcours
+func (s *CircuitsService) GetCircuit(ctx context.Context, establishmentID, circuitID string) (*coreCircuitDTO.CircuitResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	circuitUUID, err := parseCircuitID(circuitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authoringService.GetCircuit(ctx, etablissementUUID, circuitUUID)
+}
+
+// CreateCircuit crée un circuit
+func (s *CircuitsService) CreateCircuit(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCircuitDTO.CreateCircuitRequest,
+	userID string,
+) (*coreCircuitDTO.CircuitResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authoringService.CreateCircuit(ctx, etablissementUUID, req, userUUID)
+}
+
+// ReplaceCircuit remplace un circuit ouvert par une nouvelle version
+func (s *CircuitsService) ReplaceCircuit(
+	ctx context.Context,
+	establishmentID string,
+	circuitID string,
+	req *coreCircuitDTO.CircuitDefinition,
+	userID string,
+) (*coreCircuitDTO.CircuitResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	circuitUUID, err := parseCircuitID(circuitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authoringService.ReplaceCircuit(ctx, etablissementUUID, circuitUUID, req, userUUID)
+}
+
+// CloseCircuit clôture un circuit ouvert
+func (s *CircuitsService) CloseCircuit(
+	ctx context.Context,
+	establishmentID string,
+	circuitID string,
+	userID string,
+) (*coreCircuitDTO.CircuitResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	circuitUUID, err := parseCircuitID(circuitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authoringService.CloseCircuit(ctx, etablissementUUID, circuitUUID, userUUID)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseCircuitID convertit l'identifiant d'un circuit (introuvable s'il est mal formé)
+func parseCircuitID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreCircuitDTO.NewCircuitError(coreCircuitDTO.ErrCircuitIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,383 @@
+package infrastructures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	coreInfraDTO "soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	coreInfraServices "soins-suite-core/internal/modules/core-services/infrastructure/services"
+)
+
+// InfrastructuresService expose les bâtiments, catégories de chambre, chambres et lits (rubrique INFRASTRUCTURES)
+type InfrastructuresService struct {
+	layoutService *coreInfraServices.InfrastructureLayoutService
+}
+
+// NewInfrastructuresService constructeur Fx compatible
+func NewInfrastructuresService(layoutService *coreInfraServices.InfrastructureLayoutService) *InfrastructuresService {
+	return &InfrastructuresService{
+		layoutService: layoutService,
+	}
+}
+
+// GetTree retourne l'arborescence bâtiment › étage › chambre › lit
+func (s *InfrastructuresService) GetTree(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreInfraDTO.TreeFilter,
+) ([]coreInfraDTO.TreeBuilding, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.layoutService.GetTree(ctx, etablissementUUID, filter)
+}
+
+// ListBuildings retourne les bâtiments de l'établissement
+func (s *InfrastructuresService) ListBuildings(
+	ctx context.Context,
+	establishmentID string,
+	includeInactive bool,
+) ([]coreInfraDTO.BuildingResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.layoutService.ListBuildings(ctx, etablissementUUID, includeInactive)
+}
+
+// GetBuilding retourne un bâtiment
+func (s *InfrastructuresService) GetBuilding(ctx context.Context, establishmentID, batimentID string) (*coreInfraDTO.BuildingResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	batimentUUID, err := parseID(batimentID, coreInfraDTO.ErrInfraBatimentIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.GetBuilding(ctx, etablissementUUID, batimentUUID)
+}
+
+// CreateBuilding crée un bâtiment
+func (s *InfrastructuresService) CreateBuilding(
+	ctx context.Context,
+	establishmentID string,
+	req *coreInfraDTO.CreateBuildingRequest,
+	userID string,
+) (*coreInfraDTO.BuildingResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.CreateBuilding(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateBuilding modifie un bâtiment
+func (s *InfrastructuresService) UpdateBuilding(
+	ctx context.Context,
+	establishmentID string,
+	batimentID string,
+	req *coreInfraDTO.UpdateBuildingRequest,
+	userID string,
+) (*coreInfraDTO.BuildingResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	batimentUUID, err := parseID(batimentID, coreInfraDTO.ErrInfraBatimentIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.UpdateBuilding(ctx, etablissementUUID, batimentUUID, req, userUUID)
+}
+
+// SetBuildingActive active ou désactive un bâtiment
+func (s *InfrastructuresService) SetBuildingActive(
+	ctx context.Context,
+	establishmentID string,
+	batimentID string,
+	actif bool,
+	userID string,
+) (*coreInfraDTO.BuildingResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	batimentUUID, err := parseID(batimentID, coreInfraDTO.ErrInfraBatimentIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.SetBuildingActive(ctx, etablissementUUID, batimentUUID, actif, userUUID)
+}
+
+// ListCategories retourne les catégories de chambre
+func (s *InfrastructuresService) ListCategories(
+	ctx context.Context,
+	establishmentID string,
+	includeInactive bool,
+) ([]coreInfraDTO.RoomCategoryResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.layoutService.ListCategories(ctx, etablissementUUID, includeInactive)
+}
+
+// GetCategory retourne une catégorie de chambre
+func (s *InfrastructuresService) GetCategory(ctx context.Context, establishmentID, categorieID string) (*coreInfraDTO.RoomCategoryResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	categorieUUID, err := parseID(categorieID, coreInfraDTO.ErrInfraCategorieIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.GetCategory(ctx, etablissementUUID, categorieUUID)
+}
+
+// CreateCategory crée une catégorie de chambre
+func (s *InfrastructuresService) CreateCategory(
+	ctx context.Context,
+	establishmentID string,
+	req *coreInfraDTO.CreateRoomCategoryRequest,
+	userID string,
+) (*coreInfraDTO.RoomCategoryResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.CreateCategory(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateCategory modifie une catégorie de chambre
+func (s *InfrastructuresService) UpdateCategory(
+	ctx context.Context,
+	establishmentID string,
+	categorieID string,
+	req *coreInfraDTO.UpdateRoomCategoryRequest,
+	userID string,
+) (*coreInfraDTO.RoomCategoryResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categorieUUID, err := parseID(categorieID, coreInfraDTO.ErrInfraCategorieIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.UpdateCategory(ctx, etablissementUUID, categorieUUID, req, userUUID)
+}
+
+// SetCategoryActive active ou désactive une catégorie de chambre
+func (s *InfrastructuresService) SetCategoryActive(
+	ctx context.Context,
+	establishmentID string,
+	categorieID string,
+	actif bool,
+	userID string,
+) (*coreInfraDTO.RoomCategoryResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categorieUUID, err := parseID(categorieID, coreInfraDTO.ErrInfraCategorieIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.SetCategoryActive(ctx, etablissementUUID, categorieUUID, actif, userUUID)
+}
+
+// ListRooms retourne les chambres filtrées
+func (s *InfrastructuresService) ListRooms(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreInfraDTO.InfrastructureFilter,
+) ([]coreInfraDTO.RoomResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.layoutService.ListRooms(ctx, etablissementUUID, filter)
+}
+
+// GetRoom retourne une chambre et ses lits
+func (s *InfrastructuresService) GetRoom(ctx context.Context, establishmentID, chambreID string) (*coreInfraDTO.RoomResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	chambreUUID, err := parseID(chambreID, coreInfraDTO.ErrInfraChambreIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.GetRoom(ctx, etablissementUUID, chambreUUID)
+}
+
+// CreateRoom crée une chambre
+func (s *InfrastructuresService) CreateRoom(
+	ctx context.Context,
+	establishmentID string,
+	req *coreInfraDTO.CreateRoomRequest,
+	userID string,
+) (*coreInfraDTO.RoomResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.CreateRoom(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateRoom modifie une chambre
+func (s *InfrastructuresService) UpdateRoom(
+	ctx context.Context,
+	establishmentID string,
+	chambreID string,
+	req *coreInfraDTO.UpdateRoomRequest,
+	userID string,
+) (*coreInfraDTO.RoomResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	chambreUUID, err := parseID(chambreID, coreInfraDTO.ErrInfraChambreIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.UpdateRoom(ctx, etablissementUUID, chambreUUID, req, userUUID)
+}
+
+// SetRoomActive active ou désactive une chambre
+func (s *InfrastructuresService) SetRoomActive(
+	ctx context.Context,
+	establishmentID string,
+	chambreID string,
+	actif bool,
+	userID string,
+) (*coreInfraDTO.RoomResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	chambreUUID, err := parseID(chambreID, coreInfraDTO.ErrInfraChambreIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.SetRoomActive(ctx, etablissementUUID, chambreUUID, actif, userUUID)
+}
+
+// CreateBed ajoute un lit dans une chambre
+func (s *InfrastructuresService) CreateBed(
+	ctx context.Context,
+	establishmentID string,
+	chambreID string,
+	req *coreInfraDTO.CreateBedRequest,
+	userID string,
+) (*coreInfraDTO.BedResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	chambreUUID, err := parseID(chambreID, coreInfraDTO.ErrInfraChambreIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.CreateBed(ctx, etablissementUUID, chambreUUID, req, userUUID)
+}
+
+// UpdateBed modifie un lit
+func (s *InfrastructuresService) UpdateBed(
+	ctx context.Context,
+	establishmentID string,
+	litID string,
+	req *coreInfraDTO.UpdateBedRequest,
+	userID string,
+) (*coreInfraDTO.BedResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	litUUID, err := parseID(litID, coreInfraDTO.ErrInfraLitIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.UpdateBed(ctx, etablissementUUID, litUUID, req, userUUID)
+}
+
+// SetBedActive active ou désactive un lit
+func (s *InfrastructuresService) SetBedActive(
+	ctx context.Context,
+	establishmentID string,
+	litID string,
+	actif bool,
+	userID string,
+) (*coreInfraDTO.BedResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	litUUID, err := parseID(litID, coreInfraDTO.ErrInfraLitIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.layoutService.SetBedActive(ctx, etablissementUUID, litUUID, actif, userUUID)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseID convertit l'identifiant d'un élément d'infrastructure (introuvable s'il est mal formé)
+func parseID(id, codeIntrouvable string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreInfraDTO.NewInfrastructureError(codeIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
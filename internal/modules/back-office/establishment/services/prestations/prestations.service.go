@@ -0,0 +1,354 @@
+package prestations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	coreCatalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+	coreCatalogServices "soins-suite-core/internal/modules/core-services/catalog/services"
+	coreCircuitDTO "soins-suite-core/internal/modules/core-services/circuit/dto"
+	coreCircuitServices "soins-suite-core/internal/modules/core-services/circuit/services"
+)
+
+// PrestationsService expose la gestion du catalogue des prestations (rubrique PRESTATIONS)
+type PrestationsService struct {
+	catalogService  *coreCatalogServices.PrestationCatalogService
+	tariffResolver  *coreCatalogServices.TariffResolverService
+	circuitResolver *coreCircuitServices.CircuitResolverService
+}
+
+// NewPrestationsService constructeur Fx compatible
+func NewPrestationsService(
+	catalogService *coreCatalogServices.PrestationCatalogService,
+	tariffResolver *coreCatalogServices.TariffResolverService,
+	circuitResolver *coreCircuitServices.CircuitResolverService,
+) *PrestationsService {
+	return &PrestationsService{
+		catalogService:  catalogService,
+		tariffResolver:  tariffResolver,
+		circuitResolver: circuitResolver,
+	}
+}
+
+// ListTypes retourne les types de prestation de l'établissement
+func (s *PrestationsService) ListTypes(
+	ctx context.Context,
+	establishmentID string,
+	includeInactive bool,
+) ([]coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.catalogService.ListTypes(ctx, etablissementUUID, includeInactive)
+}
+
+// GetType retourne un type de prestation et ses modules
+func (s *PrestationsService) GetType(
+	ctx context.Context,
+	establishmentID string,
+	typeID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	typeUUID, err := parseCatalogID(typeID, coreCatalogDTO.ErrCatalogueTypeIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.GetType(ctx, etablissementUUID, typeUUID)
+}
+
+// CreateType crée un type de prestation
+func (s *PrestationsService) CreateType(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCatalogDTO.CreateTypePrestationRequest,
+	userID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.CreateType(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdateType modifie un type de prestation
+func (s *PrestationsService) UpdateType(
+	ctx context.Context,
+	establishmentID string,
+	typeID string,
+	req *coreCatalogDTO.UpdateTypePrestationRequest,
+	userID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	typeUUID, err := parseCatalogID(typeID, coreCatalogDTO.ErrCatalogueTypeIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.UpdateType(ctx, etablissementUUID, typeUUID, req, userUUID)
+}
+
+// SetTypeActive active ou désactive un type de prestation
+func (s *PrestationsService) SetTypeActive(
+	ctx context.Context,
+	establishmentID string,
+	typeID string,
+	actif bool,
+	userID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	typeUUID, err := parseCatalogID(typeID, coreCatalogDTO.ErrCatalogueTypeIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.SetTypeActive(ctx, etablissementUUID, typeUUID, actif, userUUID)
+}
+
+// LinkModule rattache un type de prestation à un module licencié
+func (s *PrestationsService) LinkModule(
+	ctx context.Context,
+	establishmentID string,
+	typeID string,
+	req *coreCatalogDTO.LinkTypePrestationModuleRequest,
+	userID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	typeUUID, err := parseCatalogID(typeID, coreCatalogDTO.ErrCatalogueTypeIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.LinkModule(ctx, etablissementUUID, typeUUID, req, userUUID)
+}
+
+// SetTypeModuleActive active ou désactive un rattachement type › module
+func (s *PrestationsService) SetTypeModuleActive(
+	ctx context.Context,
+	establishmentID string,
+	associationID string,
+	actif bool,
+	userID string,
+) (*coreCatalogDTO.TypePrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	associationUUID, err := parseCatalogID(associationID, coreCatalogDTO.ErrCatalogueAssociationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.SetTypeModuleActive(ctx, etablissementUUID, associationUUID, actif, userUUID)
+}
+
+// ListPrestations retourne le catalogue paginé
+func (s *PrestationsService) ListPrestations(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreCatalogDTO.PrestationFilter,
+) (*coreCatalogDTO.PrestationListResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.catalogService.ListPrestations(ctx, etablissementUUID, filter)
+}
+
+// GetPrestation retourne une prestation du catalogue
+func (s *PrestationsService) GetPrestation(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+) (*coreCatalogDTO.PrestationResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	prestationUUID, err := parseCatalogID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.GetPrestation(ctx, etablissementUUID, prestationUUID)
+}
+
+// CreatePrestation ajoute une prestation au catalogue
+func (s *PrestationsService) CreatePrestation(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCatalogDTO.CreatePrestationRequest,
+	userID string,
+) (*coreCatalogDTO.PrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.CreatePrestation(ctx, etablissementUUID, req, userUUID)
+}
+
+// UpdatePrestation modifie une prestation
+func (s *PrestationsService) UpdatePrestation(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	req *coreCatalogDTO.UpdatePrestationRequest,
+	userID string,
+) (*coreCatalogDTO.PrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prestationUUID, err := parseCatalogID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.UpdatePrestation(ctx, etablissementUUID, prestationUUID, req, userUUID)
+}
+
+// SetPrestationActive active ou désactive une prestation
+func (s *PrestationsService) SetPrestationActive(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	actif bool,
+	userID string,
+) (*coreCatalogDTO.PrestationResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prestationUUID, err := parseCatalogID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.catalogService.SetPrestationActive(ctx, etablissementUUID, prestationUUID, actif, userUUID)
+}
+
+// ResolveTariff simule le tarif appliqué à une prestation à un instant (maintenant par défaut)
+func (s *PrestationsService) ResolveTariff(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	req *coreCatalogDTO.ResolveTariffRequest,
+) (*coreCatalogDTO.ResolvedTariff, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	prestationUUID, err := parseCatalogID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	instant := time.Now()
+	if req.Instant != nil {
+		instant = *req.Instant
+	}
+
+	return s.tariffResolver.ResolveTariff(ctx, etablissementUUID, prestationUUID, instant)
+}
+
+// ResolveCircuit simule le circuit suivi par un patient venu pour une prestation
+// et les étapes suivantes depuis sa position (début du circuit par défaut)
+func (s *PrestationsService) ResolveCircuit(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	req *coreCircuitDTO.ResolveCircuitRequest,
+) (*coreCircuitDTO.ResolvedCircuit, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	prestationUUID, err := parseCatalogID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	var position coreCircuitDTO.CircuitPosition
+	if position.ModuleCourantID, err = parseOptionalCircuitID(req.ModuleCourant, coreCircuitDTO.ErrCircuitPositionInconnue); err != nil {
+		return nil, err
+	}
+	if position.ParcoursID, err = parseOptionalCircuitID(req.ParcoursID, coreCircuitDTO.ErrCircuitParcoursIntrouvable); err != nil {
+		return nil, err
+	}
+	if position.SousCheminID, err = parseOptionalCircuitID(req.SousCheminID, coreCircuitDTO.ErrCircuitParcoursIntrouvable); err != nil {
+		return nil, err
+	}
+
+	instant := time.Now()
+	if req.Instant != nil {
+		instant = *req.Instant
+	}
+
+	return s.circuitResolver.ResolveCircuit(ctx, etablissementUUID, prestationUUID, instant, position)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseCatalogID convertit un identifiant du chemin (introuvable s'il est mal formé)
+func parseCatalogID(id string, code string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreCatalogDTO.NewCatalogError(code, fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
+
+// parseOptionalCircuitID convertit un identifiant facultatif de position dans le circuit
+func parseOptionalCircuitID(id string, code string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, coreCircuitDTO.NewCircuitError(code, fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,128 @@
+package tarifs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	coreCatalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+	coreCatalogServices "soins-suite-core/internal/modules/core-services/catalog/services"
+)
+
+// TarifsService expose le versionnement des tarifs des prestations (rubrique PRESTATIONS)
+type TarifsService struct {
+	versioningService *coreCatalogServices.TariffVersioningService
+}
+
+// NewTarifsService constructeur Fx compatible
+func NewTarifsService(versioningService *coreCatalogServices.TariffVersioningService) *TarifsService {
+	return &TarifsService{
+		versioningService: versioningService,
+	}
+}
+
+// GetHistory retourne l'historique tarifaire d'une prestation
+func (s *TarifsService) GetHistory(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+) (*coreCatalogDTO.TariffHistoryResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	prestationUUID, err := parseID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.versioningService.GetHistory(ctx, etablissementUUID, prestationUUID)
+}
+
+// ScheduleTariff enregistre un nouveau tarif (immédiat ou planifié)
+func (s *TarifsService) ScheduleTariff(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	req *coreCatalogDTO.ScheduleTariffRequest,
+	userID string,
+) (*coreCatalogDTO.TariffHistoryResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prestationUUID, err := parseID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.versioningService.ScheduleTariff(ctx, etablissementUUID, prestationUUID, req, userUUID)
+}
+
+// CancelScheduledTariff annule un changement de tarif planifié
+func (s *TarifsService) CancelScheduledTariff(
+	ctx context.Context,
+	establishmentID string,
+	prestationID string,
+	tarifID string,
+	userID string,
+) (*coreCatalogDTO.TariffHistoryResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	prestationUUID, err := parseID(prestationID, coreCatalogDTO.ErrCataloguePrestationIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	tarifUUID, err := parseID(tarifID, coreCatalogDTO.ErrTarifIntrouvable)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.versioningService.CancelScheduledTariff(ctx, etablissementUUID, prestationUUID, tarifUUID, userUUID)
+}
+
+// ReviseTariffs applique (ou simule) une révision globale en pourcentage
+func (s *TarifsService) ReviseTariffs(
+	ctx context.Context,
+	establishmentID string,
+	req *coreCatalogDTO.BulkTariffRevisionRequest,
+	userID string,
+) (*coreCatalogDTO.BulkTariffRevisionResult, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.versioningService.ReviseTariffs(ctx, etablissementUUID, req, userUUID)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseID convertit un identifiant du chemin (introuvable s'il est mal formé)
+func parseID(id string, code string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreCatalogDTO.NewCatalogError(code, fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}
@@ -0,0 +1,73 @@
+package acces
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/acces"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+type AccesController struct {
+	service *services.AccesService
+}
+
+func NewAccesController(service *services.AccesService) *AccesController {
+	return &AccesController{
+		service: service,
+	}
+}
+
+// ListAccessLog GET /api/v1/back-office/patients/acces?code_patient=&utilisateur_id=&date_debut=&date_fin=
+func (c *AccesController) ListAccessLog(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	var filter corePatientDTO.AccessLogFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if filter.DateDebut != nil && filter.DateFin != nil && filter.DateFin.Before(*filter.DateDebut) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"date_fin": "Doit être postérieure ou égale à date_debut",
+				},
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListAccessLog(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la récupération du journal des accès",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
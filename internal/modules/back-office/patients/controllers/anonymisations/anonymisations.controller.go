@@ -0,0 +1,308 @@
+package anonymisations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/anonymisations"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+type AnonymisationsController struct {
+	service   *services.AnonymisationsService
+	validator *validator.Validate
+}
+
+func NewAnonymisationsController(service *services.AnonymisationsService) *AnonymisationsController {
+	return &AnonymisationsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// ListRequests GET /api/v1/back-office/patients/anonymisations
+func (c *AnonymisationsController) ListRequests(ctx *gin.Context) {
+	var filter corePatientDTO.AnonymizationFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListRequests(ctx.Request.Context(), &filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la récupération des demandes d'effacement",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CreateRequest POST /api/v1/back-office/patients/anonymisations
+func (c *AnonymisationsController) CreateRequest(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.CreateAnonymizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"code_patient": "Code patient requis",
+					"motif":        "Motif requis (5 à 1000 caractères)",
+				},
+			},
+		})
+		return
+	}
+
+	result, err := c.service.CreateRequest(ctx.Request.Context(), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Demande d'effacement impossible", "Erreur lors de l'enregistrement de la demande d'effacement")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ApproveRequest POST /api/v1/back-office/patients/anonymisations/:id/approuver
+func (c *AnonymisationsController) ApproveRequest(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	result, err := c.service.ApproveRequest(ctx.Request.Context(), ctx.Param("id"), userID)
+	if err != nil {
+		c.respondError(ctx, err, "Anonymisation impossible", "Erreur lors de l'anonymisation du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// RejectRequest POST /api/v1/back-office/patients/anonymisations/:id/rejeter
+func (c *AnonymisationsController) RejectRequest(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.RejectAnonymizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"motif": "Motif requis (5 à 1000 caractères)",
+				},
+			},
+		})
+		return
+	}
+
+	result, err := c.service.RejectRequest(ctx.Request.Context(), ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Rejet de la demande impossible", "Erreur lors du rejet de la demande d'effacement")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// VerifyJournal GET /api/v1/back-office/patients/anonymisations/journal/verification
+func (c *AnonymisationsController) VerifyJournal(ctx *gin.Context) {
+	result, err := c.service.VerifyJournal(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la vérification du journal des anonymisations",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ExportExtract POST /api/v1/back-office/patients/anonymisations/extractions
+func (c *AnonymisationsController) ExportExtract(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.AnonymizedExtractRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"motif": "Motif requis : projet de recherche (5 à 1000 caractères)",
+				},
+			},
+		})
+		return
+	}
+
+	if req.DateDebut != nil && req.DateFin != nil && req.DateFin.Before(*req.DateDebut) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "date_fin doit être postérieure à date_debut",
+			},
+		})
+		return
+	}
+
+	extract, err := c.service.ExportExtract(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la production de l'extrait anonymisé",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", extract.NomFichier))
+	ctx.Header("X-Extrait-Lignes", fmt.Sprintf("%d", extract.Lignes))
+	ctx.Header("X-Extrait-Empreinte", extract.Empreinte)
+	ctx.Header("X-Journal-Numero", fmt.Sprintf("%d", extract.JournalNumero))
+	ctx.Data(http.StatusOK, extract.ContentType, extract.Contenu)
+}
+
+// respondError traduit les refus métier (404 introuvable, 403 quatre yeux, 409 état incompatible)
+func (c *AnonymisationsController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var anonErr *corePatientDTO.PatientAnonymizationError
+	if errors.As(err, &anonErr) {
+		status := http.StatusConflict
+		switch anonErr.Code {
+		case corePatientDTO.ErrAnonymisationPatientIntrouvable, corePatientDTO.ErrAnonymisationDemandeIntrouvable:
+			status = http.StatusNotFound
+		case corePatientDTO.ErrAnonymisationQuatreYeux:
+			status = http.StatusForbidden
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    anonErr.Code,
+				"message": anonErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package cartes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/cartes"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+type CartesController struct {
+	service   *services.CartesService
+	validator *validator.Validate
+}
+
+func NewCartesController(service *services.CartesService) *CartesController {
+	return &CartesController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetConfiguration GET /api/v1/back-office/patients/cartes/configuration
+func (c *CartesController) GetConfiguration(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	result, err := c.service.GetConfiguration(ctx.Request.Context(), establishmentID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la récupération de la mise en page de la carte patient",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateConfiguration PUT /api/v1/back-office/patients/cartes/configuration
+func (c *CartesController) UpdateConfiguration(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.UpdatePatientCardLayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		champs := map[string]string{}
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, fieldErr := range validationErrors {
+				champs[getJSONFieldName(fieldErr.Field())] = getValidationMessage(fieldErr)
+			}
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return
+	}
+
+	result, err := c.service.UpdateConfiguration(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la modification de la mise en page de la carte patient",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func getJSONFieldName(fieldName string) string {
+	mapping := map[string]string{
+		"Titre":             "titre",
+		"CouleurPrincipale": "couleur_principale",
+		"CouleurTexte":      "couleur_texte",
+		"MentionPied":       "mention_pied",
+		"ResolutionDPI":     "resolution_dpi",
+	}
+	if jsonName, exists := mapping[fieldName]; exists {
+		return jsonName
+	}
+	return fieldName
+}
+
+func getValidationMessage(err validator.FieldError) string {
+	switch err.Field() + ":" + err.Tag() {
+	case "ResolutionDPI:min", "ResolutionDPI:max":
+		return "La résolution doit être comprise entre 150 et 600 DPI"
+	}
+	switch err.Tag() {
+	case "required":
+		return "Ce champ est requis"
+	case "hexcolor", "len":
+		return "Couleur invalide (format #RRGGBB attendu)"
+	case "max":
+		return "Doit contenir au maximum " + err.Param() + " caractères"
+	default:
+		return "Valeur invalide"
+	}
+}
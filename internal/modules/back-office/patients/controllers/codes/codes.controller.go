@@ -0,0 +1,138 @@
+package codes
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/codes"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+type CodesController struct {
+	service   *services.CodesService
+	validator *validator.Validate
+}
+
+func NewCodesController(service *services.CodesService) *CodesController {
+	return &CodesController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetConfiguration GET /api/v1/back-office/patients/codes/configuration
+func (c *CodesController) GetConfiguration(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	result, err := c.service.GetConfiguration(ctx.Request.Context(), establishmentCode)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la récupération du paramétrage des codes patient",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdateConfiguration PUT /api/v1/back-office/patients/codes/configuration
+// Format refusé (jeton inconnu, {ETAB} absent, séquence en cours non représentable) : 400 INVALID_FORMAT
+func (c *CodesController) UpdateConfiguration(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.UpdatePatientCodeConfigurationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		champs := map[string]string{}
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, fieldErr := range validationErrors {
+				switch fieldErr.Field() {
+				case "FormatCode":
+					champs["format_code"] = "Doit contenir au maximum 60 caractères"
+				case "TailleBloc":
+					champs["taille_bloc"] = "Doit être compris entre 0 (désactivé) et 10000"
+				default:
+					champs["cle_controle"] = "Ce champ est requis"
+				}
+			}
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return
+	}
+
+	result, err := c.service.UpdateConfiguration(ctx.Request.Context(), establishmentCode, &req, userID)
+	if err != nil {
+		var codeErr *corePatientDTO.CodeGenerationError
+		if errors.As(err, &codeErr) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"error": "Paramétrage des codes patient invalide",
+				"details": map[string]interface{}{
+					"code":    codeErr.Code,
+					"message": codeErr.Message,
+				},
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la modification du paramétrage des codes patient",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
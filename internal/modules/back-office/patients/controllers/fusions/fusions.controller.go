@@ -0,0 +1,136 @@
+package fusions
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/fusions"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+type FusionsController struct {
+	service   *services.FusionsService
+	validator *validator.Validate
+}
+
+func NewFusionsController(service *services.FusionsService) *FusionsController {
+	return &FusionsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// ListMergeHistory GET /api/v1/back-office/patients/fusions
+func (c *FusionsController) ListMergeHistory(ctx *gin.Context) {
+	var filter corePatientDTO.MergeHistoryFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(filter); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListMergeHistory(ctx.Request.Context(), &filter)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de la récupération de l'historique des fusions",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UndoMerge POST /api/v1/back-office/patients/fusions/:id/annuler
+func (c *FusionsController) UndoMerge(ctx *gin.Context) {
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.UndoMergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"motif": "Motif requis (5 à 1000 caractères)",
+				},
+			},
+		})
+		return
+	}
+
+	result, err := c.service.UndoMerge(ctx.Request.Context(), ctx.Param("id"), &req, userID)
+	if err != nil {
+		var mergeErr *corePatientDTO.PatientMergeError
+		if errors.As(err, &mergeErr) {
+			status := http.StatusConflict
+			if mergeErr.Code == corePatientDTO.ErrMergeNotFound {
+				status = http.StatusNotFound
+			}
+			ctx.JSON(status, gin.H{
+				"error": "Annulation de fusion impossible",
+				"details": map[string]interface{}{
+					"code":    mergeErr.Code,
+					"message": mergeErr.Message,
+				},
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de l'annulation de la fusion",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
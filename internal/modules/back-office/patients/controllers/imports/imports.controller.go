@@ -0,0 +1,133 @@
+package imports
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	services "soins-suite-core/internal/modules/back-office/patients/services/imports"
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+// Taille maximale du fichier CSV accepté (20 Mo ≈ 100 000 lignes)
+const maxImportFileSize = 20 << 20
+
+type ImportsController struct {
+	service *services.ImportsService
+}
+
+func NewImportsController(service *services.ImportsService) *ImportsController {
+	return &ImportsController{
+		service: service,
+	}
+}
+
+// ImportPatients POST /api/v1/back-office/patients/imports?dry_run=true
+// Le dry-run est le mode par défaut : l'import réel exige dry_run=false explicitement
+func (c *ImportsController) ImportPatients(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	options := corePatientDTO.BulkImportOptions{DryRun: true}
+	if value := ctx.Query("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			c.respondInvalidParameter(ctx, "dry_run doit valoir true ou false")
+			return
+		}
+		options.DryRun = dryRun
+	}
+	if value := ctx.Query("taille_lot"); value != "" {
+		tailleLot, err := strconv.Atoi(value)
+		if err != nil || tailleLot <= 0 {
+			c.respondInvalidParameter(ctx, "taille_lot doit être un entier positif")
+			return
+		}
+		options.TailleLot = tailleLot
+	}
+
+	header, err := ctx.FormFile("fichier")
+	if err != nil {
+		c.respondInvalidParameter(ctx, "Fichier CSV requis (champ 'fichier')")
+		return
+	}
+	if header.Size > maxImportFileSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Fichier trop volumineux",
+			"details": map[string]interface{}{
+				"code":    "IMPORT_FICHIER_TROP_VOLUMINEUX",
+				"message": "Taille maximale: 20 Mo",
+			},
+		})
+		return
+	}
+
+	fichier, err := header.Open()
+	if err != nil {
+		c.respondInvalidParameter(ctx, "Fichier CSV illisible")
+		return
+	}
+	defer fichier.Close()
+
+	result, err := c.service.ImportPatients(ctx.Request.Context(), establishmentCode, establishmentID, fichier, options, userID)
+	if err != nil {
+		var importErr *corePatientDTO.BulkImportError
+		if errors.As(err, &importErr) {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": "Fichier d'import invalide",
+				"details": map[string]interface{}{
+					"code":    importErr.Code,
+					"message": importErr.Message,
+				},
+			})
+			return
+		}
+
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Erreur lors de l'import des patients",
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+func (c *ImportsController) respondInvalidParameter(ctx *gin.Context, message string) {
+	ctx.JSON(http.StatusBadRequest, gin.H{
+		"error": "Paramètres invalides",
+		"details": map[string]interface{}{
+			"code":    "VALIDATION_ERROR",
+			"message": message,
+		},
+	})
+}
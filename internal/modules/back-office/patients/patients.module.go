@@ -0,0 +1,100 @@
+package patients
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+
+	accesControllers "soins-suite-core/internal/modules/back-office/patients/controllers/acces"
+	anonymisationsControllers "soins-suite-core/internal/modules/back-office/patients/controllers/anonymisations"
+	cartesControllers "soins-suite-core/internal/modules/back-office/patients/controllers/cartes"
+	codesControllers "soins-suite-core/internal/modules/back-office/patients/controllers/codes"
+	fusionsControllers "soins-suite-core/internal/modules/back-office/patients/controllers/fusions"
+	importsControllers "soins-suite-core/internal/modules/back-office/patients/controllers/imports"
+	accesServices "soins-suite-core/internal/modules/back-office/patients/services/acces"
+	anonymisationsServices "soins-suite-core/internal/modules/back-office/patients/services/anonymisations"
+	cartesServices "soins-suite-core/internal/modules/back-office/patients/services/cartes"
+	codesServices "soins-suite-core/internal/modules/back-office/patients/services/codes"
+	fusionsServices "soins-suite-core/internal/modules/back-office/patients/services/fusions"
+	importsServices "soins-suite-core/internal/modules/back-office/patients/services/imports"
+	authMiddleware "soins-suite-core/internal/shared/middleware/auth"
+)
+
+// Module regroupe les opérations de supervision du référentiel patient (back-office)
+var Module = fx.Options(
+	fx.Provide(fusionsServices.NewFusionsService),
+	fx.Provide(fusionsControllers.NewFusionsController),
+	fx.Provide(importsServices.NewImportsService),
+	fx.Provide(importsControllers.NewImportsController),
+	fx.Provide(codesServices.NewCodesService),
+	fx.Provide(codesControllers.NewCodesController),
+	fx.Provide(cartesServices.NewCartesService),
+	fx.Provide(cartesControllers.NewCartesController),
+	fx.Provide(accesServices.NewAccesService),
+	fx.Provide(accesControllers.NewAccesController),
+	fx.Provide(anonymisationsServices.NewAnonymisationsService),
+	fx.Provide(anonymisationsControllers.NewAnonymisationsController),
+	fx.Invoke(RegisterPatientsRoutes),
+)
+
+// RegisterPatientsRoutes configure les routes de supervision patient (administrateurs uniquement)
+func RegisterPatientsRoutes(
+	r *gin.Engine,
+	fusionsCtrl *fusionsControllers.FusionsController,
+	importsCtrl *importsControllers.ImportsController,
+	codesCtrl *codesControllers.CodesController,
+	cartesCtrl *cartesControllers.CartesController,
+	accesCtrl *accesControllers.AccesController,
+	anonymisationsCtrl *anonymisationsControllers.AnonymisationsController,
+	authStack *authMiddleware.AuthMiddlewareStack,
+) {
+	api := r.Group("/api/v1/back-office/patients")
+	api.Use(authMiddleware.RequireAdmin(authStack)...)
+	{
+		// GET /api/v1/back-office/patients/fusions - Historique des fusions
+		api.GET("/fusions", fusionsCtrl.ListMergeHistory)
+
+		// POST /api/v1/back-office/patients/fusions/:id/annuler - Annulation d'une fusion
+		api.POST("/fusions/:id/annuler", fusionsCtrl.UndoMerge)
+
+		// POST /api/v1/back-office/patients/imports - Import CSV (dry-run par défaut)
+		api.POST("/imports", importsCtrl.ImportPatients)
+
+		// GET /api/v1/back-office/patients/codes/configuration - Paramétrage des codes patient
+		api.GET("/codes/configuration", codesCtrl.GetConfiguration)
+
+		// PUT /api/v1/back-office/patients/codes/configuration - Format des codes et clé de contrôle
+		api.PUT("/codes/configuration", codesCtrl.UpdateConfiguration)
+
+		// GET /api/v1/back-office/patients/cartes/configuration - Mise en page de la carte patient
+		api.GET("/cartes/configuration", cartesCtrl.GetConfiguration)
+
+		// PUT /api/v1/back-office/patients/cartes/configuration - Modification de la mise en page
+		api.PUT("/cartes/configuration", cartesCtrl.UpdateConfiguration)
+
+		// GET /api/v1/back-office/patients/anonymisations - Demandes d'effacement (loi 2013-450)
+		api.GET("/anonymisations", anonymisationsCtrl.ListRequests)
+
+		// POST /api/v1/back-office/patients/anonymisations - Demande d'effacement d'un patient
+		api.POST("/anonymisations", anonymisationsCtrl.CreateRequest)
+
+		// POST /api/v1/back-office/patients/anonymisations/:id/approuver - Approbation par un second administrateur (irréversible)
+		api.POST("/anonymisations/:id/approuver", anonymisationsCtrl.ApproveRequest)
+
+		// POST /api/v1/back-office/patients/anonymisations/:id/rejeter - Rejet motivé
+		api.POST("/anonymisations/:id/rejeter", anonymisationsCtrl.RejectRequest)
+
+		// GET /api/v1/back-office/patients/anonymisations/journal/verification - Intégrité du journal chaîné
+		api.GET("/anonymisations/journal/verification", anonymisationsCtrl.VerifyJournal)
+
+		// POST /api/v1/back-office/patients/anonymisations/extractions - Extrait anonymisé (CSV) pour la recherche
+		api.POST("/anonymisations/extractions", anonymisationsCtrl.ExportExtract)
+	}
+
+	// Journal des accès : rubrique GESTION_UTILISATEURS › AUDIT_SECURITE (secret médical)
+	audit := r.Group("/api/v1/back-office/patients/acces")
+	audit.Use(authMiddleware.RequireRubrique(authStack, "GESTION_UTILISATEURS", "AUDIT_SECURITE")...)
+	{
+		// GET /api/v1/back-office/patients/acces - Accès filtrés par patient, utilisateur et période
+		audit.GET("", accesCtrl.ListAccessLog)
+	}
+}
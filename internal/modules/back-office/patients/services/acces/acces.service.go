@@ -0,0 +1,38 @@
+package acces
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// AccesService expose le journal des accès aux dossiers patient à la rubrique AUDIT_SECURITE
+type AccesService struct {
+	accessLogService *corePatientServices.PatientAccessLogService
+}
+
+// NewAccesService constructeur Fx compatible
+func NewAccesService(accessLogService *corePatientServices.PatientAccessLogService) *AccesService {
+	return &AccesService{
+		accessLogService: accessLogService,
+	}
+}
+
+// ListAccessLog retourne le journal paginé des accès, limité à l'établissement de la session
+func (s *AccesService) ListAccessLog(
+	ctx context.Context,
+	establishmentID string,
+	filter *corePatientDTO.AccessLogFilter,
+) (*corePatientDTO.AccessLogResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	filter.EtablissementID = &etablissementUUID
+	return s.accessLogService.ListAccessLog(ctx, filter)
+}
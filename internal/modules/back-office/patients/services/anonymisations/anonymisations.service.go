@@ -0,0 +1,119 @@
+package anonymisations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// AnonymisationsService expose l'effacement des données patient (loi 2013-450) aux administrateurs
+type AnonymisationsService struct {
+	anonymizationService *corePatientServices.PatientAnonymizationService
+}
+
+// NewAnonymisationsService constructeur Fx compatible
+func NewAnonymisationsService(anonymizationService *corePatientServices.PatientAnonymizationService) *AnonymisationsService {
+	return &AnonymisationsService{
+		anonymizationService: anonymizationService,
+	}
+}
+
+// ListRequests retourne les demandes d'effacement paginées
+func (s *AnonymisationsService) ListRequests(
+	ctx context.Context,
+	filter *corePatientDTO.AnonymizationFilter,
+) (*corePatientDTO.AnonymizationListResponse, error) {
+	return s.anonymizationService.ListRequests(ctx, filter)
+}
+
+// CreateRequest enregistre une demande d'effacement en attente d'approbation
+func (s *AnonymisationsService) CreateRequest(
+	ctx context.Context,
+	req *corePatientDTO.CreateAnonymizationRequest,
+	userID string,
+) (*corePatientDTO.AnonymizationEntry, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.anonymizationService.CreateRequest(ctx, req, userUUID)
+}
+
+// ApproveRequest approuve et exécute une demande (second administrateur, irréversible)
+func (s *AnonymisationsService) ApproveRequest(
+	ctx context.Context,
+	demandeID string,
+	userID string,
+) (*corePatientDTO.AnonymizationResult, error) {
+	demandeUUID, err := parseDemandeID(demandeID)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.anonymizationService.ApproveRequest(ctx, demandeUUID, userUUID)
+}
+
+// RejectRequest clôture une demande sans anonymisation
+func (s *AnonymisationsService) RejectRequest(
+	ctx context.Context,
+	demandeID string,
+	req *corePatientDTO.RejectAnonymizationRequest,
+	userID string,
+) (*corePatientDTO.AnonymizationEntry, error) {
+	demandeUUID, err := parseDemandeID(demandeID)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.anonymizationService.RejectRequest(ctx, demandeUUID, req, userUUID)
+}
+
+// VerifyJournal contrôle l'intégrité du journal chaîné des anonymisations
+func (s *AnonymisationsService) VerifyJournal(ctx context.Context) (*corePatientDTO.AnonymizationJournalVerification, error) {
+	return s.anonymizationService.VerifyJournal(ctx)
+}
+
+// ExportExtract produit l'extrait anonymisé des patients créés par l'établissement de la session
+func (s *AnonymisationsService) ExportExtract(
+	ctx context.Context,
+	establishmentID string,
+	req *corePatientDTO.AnonymizedExtractRequest,
+	userID string,
+) (*corePatientDTO.AnonymizedExtract, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.anonymizationService.ExportAnonymizedExtract(ctx, &etablissementUUID, req, userUUID)
+}
+
+// parseDemandeID convertit l'identifiant de demande du chemin
+func parseDemandeID(demandeID string) (uuid.UUID, error) {
+	demandeUUID, err := uuid.Parse(demandeID)
+	if err != nil {
+		return uuid.Nil, corePatientDTO.NewPatientAnonymizationError(corePatientDTO.ErrAnonymisationDemandeIntrouvable,
+			fmt.Sprintf("Identifiant de demande invalide: %s", demandeID))
+	}
+	return demandeUUID, nil
+}
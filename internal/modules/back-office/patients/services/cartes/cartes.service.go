@@ -0,0 +1,51 @@
+package cartes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// CartesService expose la mise en page de la carte patient de l'établissement aux administrateurs
+type CartesService struct {
+	cardService *corePatientServices.PatientCardService
+}
+
+// NewCartesService constructeur Fx compatible
+func NewCartesService(cardService *corePatientServices.PatientCardService) *CartesService {
+	return &CartesService{
+		cardService: cardService,
+	}
+}
+
+// GetConfiguration retourne la mise en page de la carte patient (défaut si non paramétrée)
+func (s *CartesService) GetConfiguration(
+	ctx context.Context,
+	establishmentID string,
+) (*corePatientDTO.PatientCardLayout, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.cardService.GetLayout(ctx, etablissementUUID)
+}
+
+// UpdateConfiguration remplace la mise en page de la carte patient (cartes imprimées ensuite uniquement)
+func (s *CartesService) UpdateConfiguration(
+	ctx context.Context,
+	establishmentID string,
+	req *corePatientDTO.UpdatePatientCardLayoutRequest,
+	userID string,
+) (*corePatientDTO.PatientCardLayout, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.cardService.UpdateLayout(ctx, etablissementUUID, req, userID)
+}
@@ -0,0 +1,46 @@
+package codes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// CodesService expose le paramétrage des codes patient de l'établissement aux administrateurs
+type CodesService struct {
+	codeGeneratorService *corePatientServices.PatientCodeGeneratorService
+}
+
+// NewCodesService constructeur Fx compatible
+func NewCodesService(codeGeneratorService *corePatientServices.PatientCodeGeneratorService) *CodesService {
+	return &CodesService{
+		codeGeneratorService: codeGeneratorService,
+	}
+}
+
+// GetConfiguration retourne le paramétrage des codes patient de l'établissement
+func (s *CodesService) GetConfiguration(
+	ctx context.Context,
+	establishmentCode string,
+) (*corePatientDTO.PatientCodeConfiguration, error) {
+	return s.codeGeneratorService.GetCodeConfiguration(ctx, establishmentCode)
+}
+
+// UpdateConfiguration modifie le paramétrage des codes patient (codes générés ensuite uniquement)
+func (s *CodesService) UpdateConfiguration(
+	ctx context.Context,
+	establishmentCode string,
+	req *corePatientDTO.UpdatePatientCodeConfigurationRequest,
+	userID string,
+) (*corePatientDTO.PatientCodeConfiguration, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.codeGeneratorService.UpdateCodeConfiguration(ctx, establishmentCode, req, userUUID)
+}
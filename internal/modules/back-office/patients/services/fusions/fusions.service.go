@@ -0,0 +1,52 @@
+package fusions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// FusionsService expose l'historique et l'annulation des fusions patient aux superviseurs
+type FusionsService struct {
+	mergeService *corePatientServices.PatientMergeService
+}
+
+// NewFusionsService constructeur Fx compatible
+func NewFusionsService(mergeService *corePatientServices.PatientMergeService) *FusionsService {
+	return &FusionsService{
+		mergeService: mergeService,
+	}
+}
+
+// ListMergeHistory retourne l'historique paginé des fusions
+func (s *FusionsService) ListMergeHistory(
+	ctx context.Context,
+	filter *corePatientDTO.MergeHistoryFilter,
+) (*corePatientDTO.MergeHistoryResponse, error) {
+	return s.mergeService.ListMergeHistory(ctx, filter)
+}
+
+// UndoMerge annule une fusion et restaure les deux dossiers
+func (s *FusionsService) UndoMerge(
+	ctx context.Context,
+	fusionID string,
+	req *corePatientDTO.UndoMergeRequest,
+	userID string,
+) (*corePatientDTO.MergeHistoryEntry, error) {
+	fusionUUID, err := uuid.Parse(fusionID)
+	if err != nil {
+		return nil, corePatientDTO.NewPatientMergeError(corePatientDTO.ErrMergeNotFound,
+			fmt.Sprintf("Identifiant de fusion invalide: %s", fusionID))
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.mergeService.UndoMerge(ctx, fusionUUID, req, userUUID)
+}
@@ -0,0 +1,46 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// ImportsService expose l'import CSV de patients aux administrateurs (migration d'établissement)
+type ImportsService struct {
+	bulkImportService *corePatientServices.PatientBulkImportService
+}
+
+// NewImportsService constructeur Fx compatible
+func NewImportsService(bulkImportService *corePatientServices.PatientBulkImportService) *ImportsService {
+	return &ImportsService{
+		bulkImportService: bulkImportService,
+	}
+}
+
+// ImportPatients importe (ou simule en dry-run) un fichier CSV de patients
+func (s *ImportsService) ImportPatients(
+	ctx context.Context,
+	establishmentCode string,
+	establishmentID string,
+	fichier io.Reader,
+	options corePatientDTO.BulkImportOptions,
+	userID string,
+) (*corePatientDTO.BulkImportReport, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.bulkImportService.ImportCSV(ctx, fichier, establishmentCode, etablissementUUID, options, userUUID)
+}
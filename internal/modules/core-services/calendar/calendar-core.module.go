@@ -0,0 +1,13 @@
+package calendar
+
+import (
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/modules/core-services/calendar/services"
+)
+
+// Module regroupe les services métier du calendrier de l'établissement (SANS endpoints)
+// Core Service : horaires d'ouverture, jours fériés et garde réutilisables par les tickets, rendez-vous et la tarification
+var Module = fx.Options(
+	fx.Provide(services.NewCalendarService), // CS-CAL-001: Horaires, jours fériés, ouverture et garde à un instant
+)
@@ -0,0 +1,180 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Motifs de l'état d'ouverture
+const (
+	MotifOuvert       = "ouvert"
+	MotifJourFerie    = "jour_ferie"
+	MotifHorsHoraires = "hors_horaires"
+	MotifJourNonOuvre = "jour_non_ouvre"
+)
+
+// Types de jour férié (CK_base_jour_ferie_type_ferie)
+const (
+	TypeFerieNational     = "national"
+	TypeFerieReligieux    = "religieux"
+	TypeFerieLocal        = "local"
+	TypeFerieExceptionnel = "exceptionnel"
+)
+
+// HorizonProchaineOuverture nombre de jours explorés pour trouver la prochaine ouverture
+const HorizonProchaineOuverture = 366
+
+// OpeningHourEntry représente les horaires d'un jour de la semaine (1 = lundi ... 7 = dimanche, ISO 8601)
+type OpeningHourEntry struct {
+	JourSemaine int    `json:"jour_semaine" validate:"required,min=1,max=7"`
+	HeureDebut  string `json:"heure_debut" validate:"required"` // HH:MM
+	HeureFin    string `json:"heure_fin" validate:"required"`   // HH:MM, après heure_debut
+	EstActif    *bool  `json:"est_actif"`
+}
+
+// ReplaceOpeningHoursRequest représente la semaine type complète (les jours absents sont fermés)
+type ReplaceOpeningHoursRequest struct {
+	Horaires []OpeningHourEntry `json:"horaires" validate:"dive"`
+}
+
+// OpeningHourResponse représente les horaires d'un jour
+type OpeningHourResponse struct {
+	ID          uuid.UUID `json:"id"`
+	JourSemaine int       `json:"jour_semaine"`
+	JourLibelle string    `json:"jour_libelle"`
+	HeureDebut  string    `json:"heure_debut"`
+	HeureFin    string    `json:"heure_fin"`
+	EstActif    bool      `json:"est_actif"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WeeklyScheduleResponse représente la semaine type et les horaires de garde
+type WeeklyScheduleResponse struct {
+	Horaires        []OpeningHourResponse `json:"horaires"`
+	GardeHeureDebut *string               `json:"garde_heure_debut,omitempty"`
+	GardeHeureFin   *string               `json:"garde_heure_fin,omitempty"`
+}
+
+// HolidayFilter représente les filtres de la liste des jours fériés
+type HolidayFilter struct {
+	Annee           int  `form:"annee"` // 0 = toutes les années
+	InclureInactifs bool `form:"inclure_inactifs"`
+}
+
+// CreateHolidayRequest représente l'ajout d'un jour férié
+type CreateHolidayRequest struct {
+	DateFerie string  `json:"date_ferie" validate:"required,datetime=2006-01-02"`
+	Libelle   string  `json:"libelle" validate:"required,min=2,max=255"`
+	TypeFerie *string `json:"type_ferie" validate:"omitempty,oneof=national religieux local exceptionnel"`
+}
+
+// UpdateHolidayRequest représente la modification d'un jour férié (la date est immuable)
+type UpdateHolidayRequest struct {
+	Libelle   *string `json:"libelle" validate:"omitempty,min=2,max=255"`
+	TypeFerie *string `json:"type_ferie" validate:"omitempty,oneof=national religieux local exceptionnel"`
+	EstActif  *bool   `json:"est_actif"`
+}
+
+// HolidayResponse représente un jour férié
+type HolidayResponse struct {
+	ID        uuid.UUID `json:"id"`
+	DateFerie string    `json:"date_ferie"`
+	Libelle   string    `json:"libelle"`
+	TypeFerie *string   `json:"type_ferie,omitempty"`
+	EstActif  bool      `json:"est_actif"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// HolidayImportOptions représente les options d'import d'un calendrier iCalendar (.ics)
+type HolidayImportOptions struct {
+	TypeFerie  string // Type par défaut si l'événement n'a pas de CATEGORIES reconnue
+	AnneeDebut int    // Fenêtre d'import (année courante par défaut)
+	AnneeFin   int    // Année de début + 1 par défaut
+	Ecraser    bool   // Remplace libellé et type des jours déjà saisis
+	Simulation bool   // Aperçu sans écriture
+}
+
+// HolidayImportLine représente un jour issu du calendrier importé
+type HolidayImportLine struct {
+	DateFerie string `json:"date_ferie"`
+	Libelle   string `json:"libelle"`
+	TypeFerie string `json:"type_ferie"`
+	Action    string `json:"action"` // cree, mis_a_jour, ignore
+}
+
+// HolidayImportResult représente le bilan d'un import iCalendar
+type HolidayImportResult struct {
+	Simulation bool                `json:"simulation"`
+	AnneeDebut int                 `json:"annee_debut"`
+	AnneeFin   int                 `json:"annee_fin"`
+	Evenements int                 `json:"evenements"`
+	Crees      int                 `json:"crees"`
+	MisAJour   int                 `json:"mis_a_jour"`
+	Ignores    int                 `json:"ignores"`
+	Jours      []HolidayImportLine `json:"jours"`
+	Anomalies  []string            `json:"anomalies"`
+}
+
+// HolidayInfo représente le jour férié couvrant une date
+type HolidayInfo struct {
+	Date      string  `json:"date"`
+	Libelle   string  `json:"libelle"`
+	TypeFerie *string `json:"type_ferie,omitempty"`
+}
+
+// GardeStatus représente la situation de garde à un instant
+// Une garde dont la fin précède le début traverse minuit (ex. 18:00 - 07:00)
+type GardeStatus struct {
+	Instant         time.Time `json:"instant"`
+	Configuree      bool      `json:"configuree"`
+	PeriodeGarde    bool      `json:"periode_garde"`
+	GardeHeureDebut *string   `json:"garde_heure_debut,omitempty"`
+	GardeHeureFin   *string   `json:"garde_heure_fin,omitempty"`
+}
+
+// OpeningStatus représente l'état d'ouverture de l'établissement à un instant
+type OpeningStatus struct {
+	Instant            time.Time    `json:"instant"`
+	EstOuvert          bool         `json:"est_ouvert"`
+	Motif              string       `json:"motif"` // ouvert, jour_ferie, hors_horaires, jour_non_ouvre
+	JourFerie          *HolidayInfo `json:"jour_ferie,omitempty"`
+	HeureOuverture     *string      `json:"heure_ouverture,omitempty"` // Horaires du jour
+	HeureFermeture     *string      `json:"heure_fermeture,omitempty"`
+	PeriodeGarde       bool         `json:"periode_garde"`
+	ProchaineOuverture *time.Time   `json:"prochaine_ouverture,omitempty"` // Si fermé
+}
+
+// CalendarQueryRequest représente un instant interrogé (maintenant par défaut)
+type CalendarQueryRequest struct {
+	Instant *time.Time `form:"instant" time_format:"2006-01-02T15:04:05"`
+}
+
+// CalendarError représente un refus métier sur le calendrier de l'établissement
+type CalendarError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs du calendrier
+const (
+	ErrCalendrierHoraireInvalide  = "CALENDRIER_HORAIRE_INVALIDE"
+	ErrCalendrierJourDuplique     = "CALENDRIER_JOUR_DUPLIQUE"
+	ErrCalendrierFerieIntrouvable = "CALENDRIER_FERIE_INTROUVABLE"
+	ErrCalendrierFerieExistant    = "CALENDRIER_FERIE_EXISTANT"
+	ErrCalendrierICSInvalide      = "CALENDRIER_ICS_INVALIDE"
+)
+
+// NewCalendarError crée une nouvelle erreur du calendrier
+func NewCalendarError(code, message string) *CalendarError {
+	return &CalendarError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *CalendarError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,168 @@
+package queries
+
+// CalendarQueries contient les requêtes SQL du calendrier de l'établissement
+// Heures manipulées en texte HH:MM côté API et en secondes depuis minuit pour les calculs
+var CalendarQueries = struct {
+	GetGardeWindow            string
+	ListOpeningHours          string
+	ListActiveOpeningHours    string
+	DeleteOpeningHoursExcept  string
+	UpsertOpeningHour         string
+	ListHolidays              string
+	ListActiveHolidaysBetween string
+	GetHoliday                string
+	InsertHoliday             string
+	UpdateHoliday             string
+	DeleteHoliday             string
+	ListHolidaysByDates       string
+	UpsertImportedHoliday     string
+}{
+	// GetGardeWindow - Horaires de garde (texte et secondes depuis minuit, NULL si non configurés)
+	GetGardeWindow: `
+		SELECT
+			to_char(garde_heure_debut, 'HH24:MI'),
+			to_char(garde_heure_fin, 'HH24:MI'),
+			EXTRACT(EPOCH FROM garde_heure_debut)::int,
+			EXTRACT(EPOCH FROM garde_heure_fin)::int
+		FROM base_etablissement
+		WHERE id = $1;
+	`,
+
+	// ListOpeningHours - Semaine type de l'établissement
+	ListOpeningHours: `
+		SELECT
+			id,
+			jour_semaine,
+			to_char(heure_debut, 'HH24:MI'),
+			to_char(heure_fin, 'HH24:MI'),
+			COALESCE(est_actif, TRUE),
+			updated_at
+		FROM base_heure_ouverture
+		WHERE etablissement_id = $1
+		ORDER BY jour_semaine;
+	`,
+
+	// ListActiveOpeningHours - Plages actives en secondes depuis minuit
+	ListActiveOpeningHours: `
+		SELECT
+			jour_semaine,
+			EXTRACT(EPOCH FROM heure_debut)::int,
+			EXTRACT(EPOCH FROM heure_fin)::int
+		FROM base_heure_ouverture
+		WHERE etablissement_id = $1
+		AND COALESCE(est_actif, TRUE) = TRUE;
+	`,
+
+	// DeleteOpeningHoursExcept - Retrait des jours absents de la nouvelle semaine type
+	DeleteOpeningHoursExcept: `
+		DELETE FROM base_heure_ouverture
+		WHERE etablissement_id = $1
+		AND jour_semaine <> ALL($2::int[]);
+	`,
+
+	// UpsertOpeningHour - Horaires d'un jour (UQ_base_heure_ouverture_etablissement_jour)
+	UpsertOpeningHour: `
+		INSERT INTO base_heure_ouverture (
+			etablissement_id, jour_semaine, heure_debut, heure_fin, est_actif
+		) VALUES (
+			$1, $2, $3::time, $4::time, $5
+		)
+		ON CONFLICT (etablissement_id, jour_semaine)
+		DO UPDATE SET
+			heure_debut = EXCLUDED.heure_debut,
+			heure_fin   = EXCLUDED.heure_fin,
+			est_actif   = EXCLUDED.est_actif;
+	`,
+
+	// ListHolidays - Jours fériés (année optionnelle, inactifs sur demande)
+	ListHolidays: `
+		SELECT
+			id,
+			to_char(date_ferie, 'YYYY-MM-DD'),
+			libelle,
+			type_ferie,
+			COALESCE(est_actif, TRUE),
+			created_at,
+			updated_at
+		FROM base_jour_ferie
+		WHERE etablissement_id = $1
+		AND ($2::int = 0 OR EXTRACT(YEAR FROM date_ferie)::int = $2)
+		AND ($3::boolean OR COALESCE(est_actif, TRUE) = TRUE)
+		ORDER BY date_ferie;
+	`,
+
+	// ListActiveHolidaysBetween - Jours fériés actifs d'une période [début, fin]
+	ListActiveHolidaysBetween: `
+		SELECT
+			to_char(date_ferie, 'YYYY-MM-DD'),
+			libelle,
+			type_ferie
+		FROM base_jour_ferie
+		WHERE etablissement_id = $1
+		AND date_ferie BETWEEN $2::date AND $3::date
+		AND COALESCE(est_actif, TRUE) = TRUE;
+	`,
+
+	// GetHoliday - Jour férié de l'établissement
+	GetHoliday: `
+		SELECT
+			id,
+			to_char(date_ferie, 'YYYY-MM-DD'),
+			libelle,
+			type_ferie,
+			COALESCE(est_actif, TRUE),
+			created_at,
+			updated_at
+		FROM base_jour_ferie
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// InsertHoliday - Ajout d'un jour férié
+	InsertHoliday: `
+		INSERT INTO base_jour_ferie (
+			etablissement_id, date_ferie, libelle, type_ferie
+		) VALUES (
+			$1, $2::date, $3, $4
+		) RETURNING id;
+	`,
+
+	// UpdateHoliday - Modification partielle d'un jour férié
+	UpdateHoliday: `
+		UPDATE base_jour_ferie SET
+			libelle    = COALESCE($3, libelle),
+			type_ferie = COALESCE($4, type_ferie),
+			est_actif  = COALESCE($5, est_actif)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// DeleteHoliday - Suppression d'un jour férié saisi par erreur
+	DeleteHoliday: `
+		DELETE FROM base_jour_ferie
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// ListHolidaysByDates - Jours déjà saisis parmi les dates d'un import
+	ListHolidaysByDates: `
+		SELECT to_char(date_ferie, 'YYYY-MM-DD')
+		FROM base_jour_ferie
+		WHERE etablissement_id = $1
+		AND date_ferie = ANY($2::text[]::date[]);
+	`,
+
+	// UpsertImportedHoliday - Jour importé (réactivé et renommé si déjà saisi)
+	UpsertImportedHoliday: `
+		INSERT INTO base_jour_ferie (
+			etablissement_id, date_ferie, libelle, type_ferie
+		) VALUES (
+			$1, $2::date, $3, $4
+		)
+		ON CONFLICT (etablissement_id, date_ferie)
+		DO UPDATE SET
+			libelle    = EXCLUDED.libelle,
+			type_ferie = EXCLUDED.type_ferie,
+			est_actif  = TRUE;
+	`,
+}
@@ -0,0 +1,620 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/calendar/dto"
+	"soins-suite-core/internal/modules/core-services/calendar/queries"
+)
+
+// joursSemaine libellés ISO 8601 (1 = lundi ... 7 = dimanche)
+var joursSemaine = [...]string{"", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi", "dimanche"}
+
+// CalendarService gère horaires d'ouverture, jours fériés et garde d'un établissement
+// et répond aux questions temporelles des autres domaines (tickets, rendez-vous, tarification)
+// Les instants sont interprétés en heure murale de l'établissement (colonnes TIMESTAMP sans fuseau)
+type CalendarService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewCalendarService crée une nouvelle instance du service
+func NewCalendarService(db *postgres.Client) *CalendarService {
+	return &CalendarService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// plage représente une plage horaire [debut, fin[ en secondes depuis minuit
+type plage struct {
+	debut int
+	fin   int
+}
+
+// calendrier représente les règles d'un établissement chargées pour une période
+type calendrier struct {
+	horaires   map[int]plage
+	feries     map[string]dto.HolidayInfo
+	garde      *plage
+	gardeDebut *string
+	gardeFin   *string
+}
+
+// IsOpenAt indique si l'établissement est ouvert à un instant (fermé les jours fériés)
+// et, s'il est fermé, la prochaine ouverture
+func (s *CalendarService) IsOpenAt(ctx context.Context, etablissementID uuid.UUID, instant time.Time) (*dto.OpeningStatus, error) {
+	cal, err := s.load(ctx, etablissementID, instant, dto.HorizonProchaineOuverture)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &dto.OpeningStatus{
+		Instant:      instant,
+		PeriodeGarde: cal.inGarde(instant),
+	}
+
+	date := instant.Format("2006-01-02")
+	horaire, ouvre := cal.horaires[isoWeekday(instant)]
+	if ouvre {
+		debut, fin := formatSecondsOfDay(horaire.debut), formatSecondsOfDay(horaire.fin)
+		status.HeureOuverture = &debut
+		status.HeureFermeture = &fin
+	}
+
+	switch ferie, estFerie := cal.feries[date]; {
+	case estFerie:
+		status.Motif = dto.MotifJourFerie
+		status.JourFerie = &ferie
+	case !ouvre:
+		status.Motif = dto.MotifJourNonOuvre
+	case secondsOfDay(instant) >= horaire.debut && secondsOfDay(instant) < horaire.fin:
+		status.Motif = dto.MotifOuvert
+		status.EstOuvert = true
+	default:
+		status.Motif = dto.MotifHorsHoraires
+	}
+
+	if !status.EstOuvert {
+		status.ProchaineOuverture = cal.nextOpening(instant)
+	}
+
+	return status, nil
+}
+
+// NextOpening retourne l'instant lui-même si l'établissement est ouvert, sinon la prochaine ouverture
+// (nil si aucune ouverture dans l'horizon, par exemple sans horaires saisis)
+func (s *CalendarService) NextOpening(ctx context.Context, etablissementID uuid.UUID, instant time.Time) (*time.Time, error) {
+	cal, err := s.load(ctx, etablissementID, instant, dto.HorizonProchaineOuverture)
+	if err != nil {
+		return nil, err
+	}
+	return cal.nextOpening(instant), nil
+}
+
+// GardeAt indique si un instant tombe dans la période de garde de l'établissement
+func (s *CalendarService) GardeAt(ctx context.Context, etablissementID uuid.UUID, instant time.Time) (*dto.GardeStatus, error) {
+	cal := &calendrier{}
+	if err := s.loadGarde(ctx, etablissementID, cal); err != nil {
+		return nil, err
+	}
+
+	return &dto.GardeStatus{
+		Instant:         instant,
+		Configuree:      cal.garde != nil,
+		PeriodeGarde:    cal.inGarde(instant),
+		GardeHeureDebut: cal.gardeDebut,
+		GardeHeureFin:   cal.gardeFin,
+	}, nil
+}
+
+// HolidayOn retourne le jour férié actif couvrant la date de l'instant (nil sinon)
+func (s *CalendarService) HolidayOn(ctx context.Context, etablissementID uuid.UUID, instant time.Time) (*dto.HolidayInfo, error) {
+	feries, err := s.loadHolidays(ctx, etablissementID, instant, instant)
+	if err != nil {
+		return nil, err
+	}
+	if ferie, ok := feries[instant.Format("2006-01-02")]; ok {
+		return &ferie, nil
+	}
+	return nil, nil
+}
+
+// GetWeeklySchedule retourne la semaine type et les horaires de garde
+func (s *CalendarService) GetWeeklySchedule(ctx context.Context, etablissementID uuid.UUID) (*dto.WeeklyScheduleResponse, error) {
+	rows, err := s.db.Query(ctx, queries.CalendarQueries.ListOpeningHours, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list opening hours: %w", err)
+	}
+	defer rows.Close()
+
+	schedule := &dto.WeeklyScheduleResponse{
+		Horaires: []dto.OpeningHourResponse{},
+	}
+	for rows.Next() {
+		var horaire dto.OpeningHourResponse
+		if err := rows.Scan(
+			&horaire.ID,
+			&horaire.JourSemaine,
+			&horaire.HeureDebut,
+			&horaire.HeureFin,
+			&horaire.EstActif,
+			&horaire.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan opening hour: %w", err)
+		}
+		horaire.JourLibelle = joursSemaine[horaire.JourSemaine]
+		schedule.Horaires = append(schedule.Horaires, horaire)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate opening hours: %w", err)
+	}
+
+	cal := &calendrier{}
+	if err := s.loadGarde(ctx, etablissementID, cal); err != nil {
+		return nil, err
+	}
+	schedule.GardeHeureDebut = cal.gardeDebut
+	schedule.GardeHeureFin = cal.gardeFin
+
+	return schedule, nil
+}
+
+// ReplaceWeeklySchedule remplace la semaine type en une transaction (les jours absents deviennent fermés)
+func (s *CalendarService) ReplaceWeeklySchedule(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.ReplaceOpeningHoursRequest,
+	userID uuid.UUID,
+) (*dto.WeeklyScheduleResponse, error) {
+	jours := make([]int, 0, len(req.Horaires))
+	vus := map[int]bool{}
+	for _, horaire := range req.Horaires {
+		if vus[horaire.JourSemaine] {
+			return nil, dto.NewCalendarError(dto.ErrCalendrierJourDuplique,
+				fmt.Sprintf("Le %s est saisi plusieurs fois", joursSemaine[horaire.JourSemaine]))
+		}
+		vus[horaire.JourSemaine] = true
+		jours = append(jours, horaire.JourSemaine)
+
+		debut, errDebut := parseClock(horaire.HeureDebut)
+		fin, errFin := parseClock(horaire.HeureFin)
+		if errDebut != nil || errFin != nil {
+			return nil, dto.NewCalendarError(dto.ErrCalendrierHoraireInvalide,
+				fmt.Sprintf("%s : heures attendues au format HH:MM", joursSemaine[horaire.JourSemaine]))
+		}
+		if fin <= debut {
+			return nil, dto.NewCalendarError(dto.ErrCalendrierHoraireInvalide,
+				fmt.Sprintf("%s : la fermeture doit suivre l'ouverture le même jour (la nuit relève de la garde)",
+					joursSemaine[horaire.JourSemaine]))
+		}
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if err := tx.Exec(ctx, queries.CalendarQueries.DeleteOpeningHoursExcept, etablissementID, jours); err != nil {
+			return fmt.Errorf("failed to delete opening hours: %w", err)
+		}
+		for _, horaire := range req.Horaires {
+			actif := horaire.EstActif == nil || *horaire.EstActif
+			if err := tx.Exec(ctx,
+				queries.CalendarQueries.UpsertOpeningHour,
+				etablissementID,
+				horaire.JourSemaine,
+				horaire.HeureDebut,
+				horaire.HeureFin,
+				actif,
+			); err != nil {
+				return fmt.Errorf("failed to upsert opening hour: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Weekly schedule replaced - Etablissement: %s, Jours: %v, User: %s\n", etablissementID, jours, userID)
+
+	return s.GetWeeklySchedule(ctx, etablissementID)
+}
+
+// ListHolidays retourne les jours fériés de l'établissement
+func (s *CalendarService) ListHolidays(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.HolidayFilter,
+) ([]dto.HolidayResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.CalendarQueries.ListHolidays,
+		etablissementID,
+		filter.Annee,
+		filter.InclureInactifs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holidays: %w", err)
+	}
+	defer rows.Close()
+
+	feries := []dto.HolidayResponse{}
+	for rows.Next() {
+		ferie, err := scanHoliday(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan holiday: %w", err)
+		}
+		feries = append(feries, *ferie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate holidays: %w", err)
+	}
+
+	return feries, nil
+}
+
+// GetHoliday retourne un jour férié
+func (s *CalendarService) GetHoliday(ctx context.Context, etablissementID, ferieID uuid.UUID) (*dto.HolidayResponse, error) {
+	ferie, err := scanHoliday(s.db.QueryRow(ctx, queries.CalendarQueries.GetHoliday, ferieID, etablissementID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCalendarError(dto.ErrCalendrierFerieIntrouvable,
+				fmt.Sprintf("Jour férié introuvable: %s", ferieID))
+		}
+		return nil, fmt.Errorf("failed to get holiday: %w", err)
+	}
+	return ferie, nil
+}
+
+// CreateHoliday ajoute un jour férié (une seule entrée par date)
+func (s *CalendarService) CreateHoliday(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateHolidayRequest,
+	userID uuid.UUID,
+) (*dto.HolidayResponse, error) {
+	var ferieID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.CalendarQueries.InsertHoliday,
+		etablissementID,
+		req.DateFerie,
+		strings.TrimSpace(req.Libelle),
+		req.TypeFerie,
+	).Scan(&ferieID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, dto.NewCalendarError(dto.ErrCalendrierFerieExistant,
+				fmt.Sprintf("Un jour férié est déjà saisi le %s", req.DateFerie))
+		}
+		return nil, fmt.Errorf("failed to insert holiday: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Holiday created - ID: %s, Date: %s, User: %s\n", ferieID, req.DateFerie, userID)
+
+	return s.GetHoliday(ctx, etablissementID, ferieID)
+}
+
+// UpdateHoliday modifie libellé, type ou activation d'un jour férié
+func (s *CalendarService) UpdateHoliday(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	ferieID uuid.UUID,
+	req *dto.UpdateHolidayRequest,
+	userID uuid.UUID,
+) (*dto.HolidayResponse, error) {
+	if _, err := s.GetHoliday(ctx, etablissementID, ferieID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.CalendarQueries.UpdateHoliday,
+		ferieID,
+		etablissementID,
+		req.Libelle,
+		req.TypeFerie,
+		req.EstActif,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update holiday: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Holiday updated - ID: %s, User: %s\n", ferieID, userID)
+
+	return s.GetHoliday(ctx, etablissementID, ferieID)
+}
+
+// DeleteHoliday supprime un jour férié saisi par erreur
+func (s *CalendarService) DeleteHoliday(ctx context.Context, etablissementID, ferieID, userID uuid.UUID) error {
+	ferie, err := s.GetHoliday(ctx, etablissementID, ferieID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Exec(ctx, queries.CalendarQueries.DeleteHoliday, ferieID, etablissementID); err != nil {
+		return fmt.Errorf("failed to delete holiday: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Holiday deleted - ID: %s, Date: %s, User: %s\n", ferieID, ferie.DateFerie, userID)
+	return nil
+}
+
+// ImportHolidays importe les jours fériés d'un calendrier iCalendar (.ics) en une transaction
+// Sans écrasement, les dates déjà saisies sont conservées telles quelles (ignorées)
+func (s *CalendarService) ImportHolidays(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	content []byte,
+	options dto.HolidayImportOptions,
+	userID uuid.UUID,
+) (*dto.HolidayImportResult, error) {
+	if options.AnneeDebut == 0 {
+		options.AnneeDebut = time.Now().Year()
+	}
+	if options.AnneeFin == 0 {
+		options.AnneeFin = options.AnneeDebut + 1
+	}
+	if options.AnneeFin < options.AnneeDebut || options.AnneeFin-options.AnneeDebut > 10 {
+		return nil, dto.NewCalendarError(dto.ErrCalendrierICSInvalide,
+			"Fenêtre d'import invalide : au plus 10 ans, annee_fin postérieure à annee_debut")
+	}
+	if options.TypeFerie == "" {
+		options.TypeFerie = dto.TypeFerieNational
+	}
+
+	jours, evenements, anomalies, err := parseICalHolidays(content, options.AnneeDebut, options.AnneeFin, options.TypeFerie)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.HolidayImportResult{
+		Simulation: options.Simulation,
+		AnneeDebut: options.AnneeDebut,
+		AnneeFin:   options.AnneeFin,
+		Evenements: evenements,
+		Jours:      []dto.HolidayImportLine{},
+		Anomalies:  anomalies,
+	}
+
+	dates := make([]string, len(jours))
+	for i, jour := range jours {
+		dates[i] = jour.date
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		rows, err := tx.Query(ctx, queries.CalendarQueries.ListHolidaysByDates, etablissementID, dates)
+		if err != nil {
+			return fmt.Errorf("failed to list existing holidays: %w", err)
+		}
+		existants := map[string]bool{}
+		for rows.Next() {
+			var date string
+			if err := rows.Scan(&date); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan existing holiday: %w", err)
+			}
+			existants[date] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate existing holidays: %w", err)
+		}
+
+		for _, jour := range jours {
+			line := dto.HolidayImportLine{DateFerie: jour.date, Libelle: jour.libelle, TypeFerie: jour.typeFerie}
+			switch {
+			case existants[jour.date] && !options.Ecraser:
+				line.Action = "ignore"
+				result.Ignores++
+			case existants[jour.date]:
+				line.Action = "mis_a_jour"
+				result.MisAJour++
+			default:
+				line.Action = "cree"
+				result.Crees++
+			}
+			result.Jours = append(result.Jours, line)
+
+			if options.Simulation || line.Action == "ignore" {
+				continue
+			}
+			libelle := jour.libelle
+			if len([]rune(libelle)) > 255 {
+				libelle = string([]rune(libelle)[:255])
+			}
+			if err := tx.Exec(ctx,
+				queries.CalendarQueries.UpsertImportedHoliday,
+				etablissementID,
+				jour.date,
+				libelle,
+				jour.typeFerie,
+			); err != nil {
+				return fmt.Errorf("failed to import holiday %s: %w", jour.date, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Simulation {
+		fmt.Printf("[AUDIT] Holidays imported - Etablissement: %s, Crees: %d, MisAJour: %d, Ignores: %d, User: %s\n",
+			etablissementID, result.Crees, result.MisAJour, result.Ignores, userID)
+	}
+
+	return result, nil
+}
+
+// load charge horaires, garde et jours fériés de [instant, instant + horizon jours]
+func (s *CalendarService) load(ctx context.Context, etablissementID uuid.UUID, instant time.Time, horizon int) (*calendrier, error) {
+	cal := &calendrier{horaires: map[int]plage{}}
+
+	rows, err := s.db.Query(ctx, queries.CalendarQueries.ListActiveOpeningHours, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list opening hours: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jour int
+		var p plage
+		if err := rows.Scan(&jour, &p.debut, &p.fin); err != nil {
+			return nil, fmt.Errorf("failed to scan opening hour: %w", err)
+		}
+		cal.horaires[jour] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate opening hours: %w", err)
+	}
+
+	if err := s.loadGarde(ctx, etablissementID, cal); err != nil {
+		return nil, err
+	}
+
+	cal.feries, err = s.loadHolidays(ctx, etablissementID, instant, instant.AddDate(0, 0, horizon))
+	if err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+}
+
+// loadGarde charge la fenêtre de garde de l'établissement
+func (s *CalendarService) loadGarde(ctx context.Context, etablissementID uuid.UUID, cal *calendrier) error {
+	var debut, fin *int
+	err := s.db.QueryRow(ctx,
+		queries.CalendarQueries.GetGardeWindow,
+		etablissementID,
+	).Scan(&cal.gardeDebut, &cal.gardeFin, &debut, &fin)
+	if err != nil && err != pgx.ErrNoRows {
+		return fmt.Errorf("failed to get garde window: %w", err)
+	}
+	if debut != nil && fin != nil {
+		cal.garde = &plage{debut: *debut, fin: *fin}
+	}
+	return nil
+}
+
+// loadHolidays charge les jours fériés actifs d'une période, indexés par date
+func (s *CalendarService) loadHolidays(ctx context.Context, etablissementID uuid.UUID, debut, fin time.Time) (map[string]dto.HolidayInfo, error) {
+	rows, err := s.db.Query(ctx,
+		queries.CalendarQueries.ListActiveHolidaysBetween,
+		etablissementID,
+		debut.Format("2006-01-02"),
+		fin.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holidays: %w", err)
+	}
+	defer rows.Close()
+
+	feries := map[string]dto.HolidayInfo{}
+	for rows.Next() {
+		var ferie dto.HolidayInfo
+		if err := rows.Scan(&ferie.Date, &ferie.Libelle, &ferie.TypeFerie); err != nil {
+			return nil, fmt.Errorf("failed to scan holiday: %w", err)
+		}
+		feries[ferie.Date] = ferie
+	}
+	return feries, rows.Err()
+}
+
+// inGarde indique si l'instant tombe dans la garde configurée
+func (c *calendrier) inGarde(instant time.Time) bool {
+	if c.garde == nil {
+		return false
+	}
+	return inGardeWindow(secondsOfDay(instant), c.garde.debut, c.garde.fin)
+}
+
+// nextOpening retourne l'instant s'il est dans une plage ouverte, sinon le début de la prochaine plage
+func (c *calendrier) nextOpening(instant time.Time) *time.Time {
+	minuit := time.Date(instant.Year(), instant.Month(), instant.Day(), 0, 0, 0, 0, instant.Location())
+	secondes := secondsOfDay(instant)
+
+	for i := 0; i <= dto.HorizonProchaineOuverture; i++ {
+		jour := minuit.AddDate(0, 0, i)
+		if _, ferie := c.feries[jour.Format("2006-01-02")]; ferie {
+			continue
+		}
+		horaire, ouvre := c.horaires[isoWeekday(jour)]
+		if !ouvre {
+			continue
+		}
+		if i == 0 {
+			if secondes >= horaire.fin {
+				continue
+			}
+			if secondes >= horaire.debut {
+				return &instant
+			}
+		}
+		ouverture := jour.Add(time.Duration(horaire.debut) * time.Second)
+		return &ouverture
+	}
+
+	return nil
+}
+
+// inGardeWindow indique si un instant (secondes depuis minuit) tombe dans la garde [debut, fin[
+// Une garde dont la fin précède le début traverse minuit (ex. 18:00 - 07:00) ; debut = fin : aucune garde
+func inGardeWindow(instant, debut, fin int) bool {
+	switch {
+	case debut == fin:
+		return false
+	case debut < fin:
+		return instant >= debut && instant < fin
+	default:
+		return instant >= debut || instant < fin
+	}
+}
+
+// isoWeekday retourne le jour ISO 8601 (1 = lundi ... 7 = dimanche)
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// secondsOfDay retourne le nombre de secondes écoulées depuis minuit (heure murale)
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
+
+// formatSecondsOfDay formate des secondes depuis minuit en HH:MM
+func formatSecondsOfDay(seconds int) string {
+	return fmt.Sprintf("%02d:%02d", seconds/3600, (seconds%3600)/60)
+}
+
+// parseClock lit une heure HH:MM (ou HH:MM:SS) en secondes depuis minuit
+func parseClock(value string) (int, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return secondsOfDay(t), nil
+		}
+	}
+	return 0, fmt.Errorf("heure invalide: %s", value)
+}
+
+// scanHoliday lit une ligne de jour férié
+func scanHoliday(row pgx.Row) (*dto.HolidayResponse, error) {
+	var ferie dto.HolidayResponse
+	if err := row.Scan(
+		&ferie.ID,
+		&ferie.DateFerie,
+		&ferie.Libelle,
+		&ferie.TypeFerie,
+		&ferie.EstActif,
+		&ferie.CreatedAt,
+		&ferie.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &ferie, nil
+}
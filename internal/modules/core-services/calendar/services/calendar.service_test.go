@@ -0,0 +1,42 @@
+package services
+
+import "testing"
+
+func TestInGardeWindow(t *testing.T) {
+	const (
+		h07 = 7 * 3600
+		h08 = 8 * 3600
+		h18 = 18 * 3600
+	)
+
+	tests := []struct {
+		name    string
+		instant int
+		debut   int
+		fin     int
+		want    bool
+	}{
+		{"journée : avant le début", h08 - 1, h08, h18, false},
+		{"journée : début inclus", h08, h08, h18, true},
+		{"journée : milieu", 12 * 3600, h08, h18, true},
+		{"journée : fin exclue", h18, h08, h18, false},
+		{"nuit : début inclus", h18, h18, h07, true},
+		{"nuit : avant minuit", 23*3600 + 59*60, h18, h07, true},
+		{"nuit : minuit", 0, h18, h07, true},
+		{"nuit : après minuit", 3 * 3600, h18, h07, true},
+		{"nuit : fin exclue", h07, h18, h07, false},
+		{"nuit : hors garde", 12 * 3600, h18, h07, false},
+		{"nuit : juste avant le début", h18 - 1, h18, h07, false},
+		{"début égal à la fin : aucune garde", h08, h08, h08, false},
+		{"jusqu'à minuit", 23 * 3600, h18, 0, true},
+		{"jusqu'à minuit : minuit exclu", 0, h18, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inGardeWindow(tt.instant, tt.debut, tt.fin); got != tt.want {
+				t.Errorf("inGardeWindow(%d, %d, %d) = %v, want %v", tt.instant, tt.debut, tt.fin, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"soins-suite-core/internal/modules/core-services/calendar/dto"
+)
+
+// Durée maximale d'un événement férié (au-delà, l'événement est rejeté comme anomalie)
+const maxJoursEvenementFerie = 31
+
+// icalHoliday représente un jour férié extrait d'un calendrier iCalendar
+type icalHoliday struct {
+	date      string
+	libelle   string
+	typeFerie string
+}
+
+// icalEvent représente un VEVENT en cours de lecture
+type icalEvent struct {
+	ligne     int
+	debut     string
+	debutDate bool
+	fin       string
+	finDate   bool
+	resume    string
+	rrule     string
+	categorie string
+	annule    bool
+}
+
+// icalTextUnescaper restaure les caractères échappés d'une valeur TEXT (RFC 5545 §3.3.11)
+var icalTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, " ", `\N`, " ")
+
+// parseICalHolidays extrait les jours fériés d'un fichier .ics pour les années [anneeDebut, anneeFin]
+// Prend en charge les événements sur un ou plusieurs jours et la récurrence annuelle (RRULE:FREQ=YEARLY)
+// Plusieurs événements le même jour sont fusionnés (libellés joints par " / ")
+func parseICalHolidays(content []byte, anneeDebut, anneeFin int, typeDefaut string) ([]icalHoliday, int, []string, error) {
+	lignes := unfoldICalLines(string(content))
+	if len(lignes) == 0 || !strings.EqualFold(strings.TrimSpace(lignes[0]), "BEGIN:VCALENDAR") {
+		return nil, 0, nil, dto.NewCalendarError(dto.ErrCalendrierICSInvalide,
+			"Fichier iCalendar invalide : BEGIN:VCALENDAR attendu en première ligne")
+	}
+
+	jours := map[string]*icalHoliday{}
+	anomalies := []string{}
+	evenements := 0
+
+	var courant *icalEvent
+	for i, ligne := range lignes {
+		nom, params, valeur, ok := splitICalProperty(ligne)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case nom == "BEGIN" && strings.EqualFold(valeur, "VEVENT"):
+			courant = &icalEvent{ligne: i + 1}
+		case nom == "END" && strings.EqualFold(valeur, "VEVENT"):
+			if courant != nil && !courant.annule {
+				evenements++
+				if err := expandICalEvent(courant, anneeDebut, anneeFin, typeDefaut, jours); err != nil {
+					anomalies = append(anomalies, fmt.Sprintf("Événement ligne %d (%s) : %s", courant.ligne, courant.resume, err.Error()))
+				}
+			}
+			courant = nil
+		case courant == nil:
+			continue
+		case nom == "DTSTART":
+			courant.debut, courant.debutDate = valeur, strings.Contains(strings.ToUpper(params), "VALUE=DATE") || len(valeur) == 8
+		case nom == "DTEND":
+			courant.fin, courant.finDate = valeur, strings.Contains(strings.ToUpper(params), "VALUE=DATE") || len(valeur) == 8
+		case nom == "SUMMARY":
+			courant.resume = strings.TrimSpace(icalTextUnescaper.Replace(valeur))
+		case nom == "RRULE":
+			courant.rrule = strings.ToUpper(valeur)
+		case nom == "CATEGORIES":
+			courant.categorie = strings.ToLower(strings.TrimSpace(icalTextUnescaper.Replace(valeur)))
+		case nom == "STATUS":
+			courant.annule = strings.EqualFold(valeur, "CANCELLED")
+		}
+	}
+
+	result := make([]icalHoliday, 0, len(jours))
+	for _, jour := range jours {
+		result = append(result, *jour)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].date < result[j].date })
+
+	return result, evenements, anomalies, nil
+}
+
+// expandICalEvent ajoute les jours d'un événement (et de ses récurrences annuelles) compris dans la fenêtre
+func expandICalEvent(event *icalEvent, anneeDebut, anneeFin int, typeDefaut string, jours map[string]*icalHoliday) error {
+	if event.resume == "" {
+		return fmt.Errorf("SUMMARY manquant")
+	}
+
+	debut, err := parseICalDate(event.debut)
+	if err != nil {
+		return fmt.Errorf("DTSTART invalide")
+	}
+
+	duree := 1
+	if event.fin != "" {
+		fin, err := parseICalDate(event.fin)
+		if err != nil {
+			return fmt.Errorf("DTEND invalide")
+		}
+		duree = int(fin.Sub(debut).Hours() / 24)
+		// DTEND d'un événement « journée entière » est exclusif ; une fin horodatée inclut son jour
+		if !event.finDate && len(event.fin) > 8 && !strings.HasPrefix(event.fin[8:], "T000000") {
+			duree++
+		}
+		if duree < 1 {
+			duree = 1
+		}
+	}
+	if duree > maxJoursEvenementFerie {
+		return fmt.Errorf("durée de %d jours supérieure à %d", duree, maxJoursEvenementFerie)
+	}
+
+	typeFerie := typeDefaut
+	for _, candidat := range []string{dto.TypeFerieNational, dto.TypeFerieReligieux, dto.TypeFerieLocal, dto.TypeFerieExceptionnel} {
+		if strings.Contains(event.categorie, candidat) {
+			typeFerie = candidat
+			break
+		}
+	}
+
+	occurrences, err := yearlyOccurrences(debut, event.rrule, anneeDebut, anneeFin)
+	if err != nil {
+		return err
+	}
+
+	for _, occurrence := range occurrences {
+		for d := 0; d < duree; d++ {
+			jour := occurrence.AddDate(0, 0, d)
+			if jour.Year() < anneeDebut || jour.Year() > anneeFin {
+				continue
+			}
+			date := jour.Format("2006-01-02")
+			if existant, ok := jours[date]; ok {
+				if !strings.Contains(existant.libelle, event.resume) {
+					existant.libelle = existant.libelle + " / " + event.resume
+				}
+				continue
+			}
+			jours[date] = &icalHoliday{date: date, libelle: event.resume, typeFerie: typeFerie}
+		}
+	}
+
+	return nil
+}
+
+// yearlyOccurrences retourne les débuts d'occurrence d'un événement dans la fenêtre d'années
+// Seule la récurrence annuelle est acceptée (fêtes à date fixe) ; les fêtes mobiles sont des événements distincts
+func yearlyOccurrences(debut time.Time, rrule string, anneeDebut, anneeFin int) ([]time.Time, error) {
+	if rrule == "" {
+		return []time.Time{debut}, nil
+	}
+
+	regles := map[string]string{}
+	for _, partie := range strings.Split(rrule, ";") {
+		if cle, valeur, ok := strings.Cut(partie, "="); ok {
+			regles[cle] = valeur
+		}
+	}
+	if regles["FREQ"] != "YEARLY" {
+		return nil, fmt.Errorf("RRULE %s non prise en charge (seule FREQ=YEARLY est acceptée)", rrule)
+	}
+	if regles["BYMONTH"] != "" || regles["BYDAY"] != "" || regles["BYMONTHDAY"] != "" {
+		return nil, fmt.Errorf("RRULE %s non prise en charge (règles BY* non supportées)", rrule)
+	}
+
+	intervalle := 1
+	if valeur, ok := regles["INTERVAL"]; ok {
+		n, err := strconv.Atoi(valeur)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("INTERVAL invalide")
+		}
+		intervalle = n
+	}
+
+	compte := -1
+	if valeur, ok := regles["COUNT"]; ok {
+		n, err := strconv.Atoi(valeur)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("COUNT invalide")
+		}
+		compte = n
+	}
+
+	var jusqua *time.Time
+	if valeur, ok := regles["UNTIL"]; ok {
+		limite, err := parseICalDate(valeur)
+		if err != nil {
+			return nil, fmt.Errorf("UNTIL invalide")
+		}
+		jusqua = &limite
+	}
+
+	occurrences := []time.Time{}
+	emises := 0
+	for n := 0; compte < 0 || emises < compte; n++ {
+		occurrence := debut.AddDate(n*intervalle, 0, 0)
+		if occurrence.Year() > anneeFin || (jusqua != nil && occurrence.After(*jusqua)) {
+			break
+		}
+		// 29 février : pas d'occurrence les années non bissextiles, ni décompte dans COUNT (RFC 5545 §3.3.10)
+		if occurrence.Day() != debut.Day() {
+			continue
+		}
+		emises++
+		if occurrence.Year() >= anneeDebut {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// parseICalDate lit la date d'une valeur DATE (AAAAMMJJ) ou DATE-TIME (AAAAMMJJTHHMMSS[Z])
+func parseICalDate(valeur string) (time.Time, error) {
+	if len(valeur) < 8 {
+		return time.Time{}, fmt.Errorf("date iCalendar invalide: %s", valeur)
+	}
+	return time.Parse("20060102", valeur[:8])
+}
+
+// unfoldICalLines découpe le contenu en lignes logiques (continuations RFC 5545 §3.1 recollées)
+func unfoldICalLines(content string) []string {
+	content = strings.TrimPrefix(content, "\ufeff")
+	brutes := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	lignes := make([]string, 0, len(brutes))
+	for _, brute := range brutes {
+		if (strings.HasPrefix(brute, " ") || strings.HasPrefix(brute, "\t")) && len(lignes) > 0 {
+			lignes[len(lignes)-1] += brute[1:]
+			continue
+		}
+		if strings.TrimSpace(brute) == "" {
+			continue
+		}
+		lignes = append(lignes, brute)
+	}
+	return lignes
+}
+
+// splitICalProperty sépare nom, paramètres et valeur d'une ligne « NOM;PARAM=X:valeur »
+func splitICalProperty(ligne string) (string, string, string, bool) {
+	entete, valeur, ok := strings.Cut(ligne, ":")
+	if !ok {
+		return "", "", "", false
+	}
+	nom, params, _ := strings.Cut(entete, ";")
+	return strings.ToUpper(strings.TrimSpace(nom)), params, strings.TrimSpace(valeur), true
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"soins-suite-core/internal/modules/core-services/calendar/dto"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) []string {
+	result := make([]string, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, d.Format("2006-01-02"))
+	}
+	return result
+}
+
+func TestYearlyOccurrences(t *testing.T) {
+	tests := []struct {
+		name       string
+		debut      time.Time
+		rrule      string
+		anneeDebut int
+		anneeFin   int
+		want       []string
+		wantErr    bool
+	}{
+		{"sans récurrence", date(2025, 1, 1), "", 2025, 2025, []string{"2025-01-01"}, false},
+		{"annuelle bornée à la fenêtre", date(2020, 8, 7), "FREQ=YEARLY", 2024, 2026,
+			[]string{"2024-08-07", "2025-08-07", "2026-08-07"}, false},
+		{"INTERVAL", date(2020, 5, 1), "FREQ=YEARLY;INTERVAL=2", 2020, 2025,
+			[]string{"2020-05-01", "2022-05-01", "2024-05-01"}, false},
+		{"COUNT", date(2024, 1, 1), "FREQ=YEARLY;COUNT=2", 2024, 2030,
+			[]string{"2024-01-01", "2025-01-01"}, false},
+		{"COUNT consommé avant la fenêtre", date(2020, 1, 1), "FREQ=YEARLY;COUNT=3", 2022, 2030,
+			[]string{"2022-01-01"}, false},
+		{"UNTIL inclusif", date(2024, 8, 7), "FREQ=YEARLY;UNTIL=20260807", 2024, 2030,
+			[]string{"2024-08-07", "2025-08-07", "2026-08-07"}, false},
+		{"UNTIL horodaté la veille", date(2024, 8, 7), "FREQ=YEARLY;UNTIL=20260806T235959Z", 2024, 2030,
+			[]string{"2024-08-07", "2025-08-07"}, false},
+		{"29 février années bissextiles seules", date(2024, 2, 29), "FREQ=YEARLY", 2024, 2032,
+			[]string{"2024-02-29", "2028-02-29", "2032-02-29"}, false},
+		{"29 février COUNT hors années sautées", date(2024, 2, 29), "FREQ=YEARLY;COUNT=3", 2024, 2040,
+			[]string{"2024-02-29", "2028-02-29", "2032-02-29"}, false},
+		{"29 février UNTIL", date(2024, 2, 29), "FREQ=YEARLY;UNTIL=20310101", 2024, 2040,
+			[]string{"2024-02-29", "2028-02-29"}, false},
+		{"FREQ non annuelle", date(2025, 1, 1), "FREQ=MONTHLY", 2025, 2025, nil, true},
+		{"règle BY*", date(2025, 1, 1), "FREQ=YEARLY;BYDAY=MO", 2025, 2025, nil, true},
+		{"COUNT nul", date(2025, 1, 1), "FREQ=YEARLY;COUNT=0", 2025, 2025, nil, true},
+		{"UNTIL invalide", date(2025, 1, 1), "FREQ=YEARLY;UNTIL=2026", 2025, 2030, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := yearlyOccurrences(tt.debut, tt.rrule, tt.anneeDebut, tt.anneeFin)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("yearlyOccurrences(%q) = %v, want error", tt.rrule, formatDates(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("yearlyOccurrences(%q) error: %v", tt.rrule, err)
+			}
+			if dates := formatDates(got); !reflect.DeepEqual(dates, tt.want) {
+				t.Errorf("yearlyOccurrences(%q) = %v, want %v", tt.rrule, dates, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandICalEventDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   icalEvent
+		want    []string
+		wantErr bool
+	}{
+		{"journée sans DTEND", icalEvent{debut: "20250101", debutDate: true},
+			[]string{"2025-01-01"}, false},
+		{"DTEND date exclusif", icalEvent{debut: "20250101", debutDate: true, fin: "20250103", finDate: true},
+			[]string{"2025-01-01", "2025-01-02"}, false},
+		{"DTEND date égal au lendemain", icalEvent{debut: "20250101", debutDate: true, fin: "20250102", finDate: true},
+			[]string{"2025-01-01"}, false},
+		{"horodaté le même jour", icalEvent{debut: "20250101T080000", fin: "20250101T170000"},
+			[]string{"2025-01-01"}, false},
+		{"horodaté jusqu'au lendemain midi", icalEvent{debut: "20250101T080000", fin: "20250102T120000"},
+			[]string{"2025-01-01", "2025-01-02"}, false},
+		{"horodaté jusqu'à minuit exclusif", icalEvent{debut: "20250101T080000", fin: "20250102T000000"},
+			[]string{"2025-01-01"}, false},
+		{"DTEND avant DTSTART", icalEvent{debut: "20250105", debutDate: true, fin: "20250101", finDate: true},
+			[]string{"2025-01-05"}, false},
+		{"à cheval sur la fenêtre", icalEvent{debut: "20241231", debutDate: true, fin: "20250102", finDate: true},
+			[]string{"2025-01-01"}, false},
+		{"durée excessive", icalEvent{debut: "20250101", debutDate: true, fin: "20250301", finDate: true}, nil, true},
+		{"DTSTART invalide", icalEvent{debut: "2025"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			event.resume = "Férié"
+			jours := map[string]*icalHoliday{}
+
+			err := expandICalEvent(&event, 2025, 2025, dto.TypeFerieNational, jours)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expandICalEvent(%s -> %s) = %d jours, want error", tt.event.debut, tt.event.fin, len(jours))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandICalEvent(%s -> %s) error: %v", tt.event.debut, tt.event.fin, err)
+			}
+
+			got := []string{}
+			for _, want := range tt.want {
+				if _, ok := jours[want]; ok {
+					got = append(got, want)
+				}
+			}
+			if len(jours) != len(tt.want) || len(got) != len(tt.want) {
+				t.Errorf("expandICalEvent(%s -> %s) = %d jours, want %v", tt.event.debut, tt.event.fin, len(jours), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseICalHolidays(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20240807",
+		"RRULE:FREQ=YEARLY",
+		"SUMMARY:Fête de l'indépendan",
+		" ce",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250807",
+		"SUMMARY:Journée locale",
+		"CATEGORIES:Local",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250815",
+		"SUMMARY:Annulé",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20250101",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	jours, evenements, anomalies, err := parseICalHolidays([]byte(ics), 2025, 2026, dto.TypeFerieNational)
+	if err != nil {
+		t.Fatalf("parseICalHolidays error: %v", err)
+	}
+	if evenements != 3 {
+		t.Errorf("evenements = %d, want 3 (événement annulé ignoré)", evenements)
+	}
+	if len(anomalies) != 1 {
+		t.Errorf("anomalies = %v, want 1 (SUMMARY manquant)", anomalies)
+	}
+
+	want := []icalHoliday{
+		{date: "2025-08-07", libelle: "Fête de l'indépendance / Journée locale", typeFerie: dto.TypeFerieNational},
+		{date: "2026-08-07", libelle: "Fête de l'indépendance", typeFerie: dto.TypeFerieNational},
+	}
+	if !reflect.DeepEqual(jours, want) {
+		t.Errorf("parseICalHolidays = %+v, want %+v", jours, want)
+	}
+
+	if _, _, _, err := parseICalHolidays([]byte("BEGIN:VEVENT"), 2025, 2025, dto.TypeFerieNational); err == nil {
+		t.Error("parseICalHolidays sans BEGIN:VCALENDAR : erreur attendue")
+	}
+}
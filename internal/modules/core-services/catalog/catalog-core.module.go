@@ -0,0 +1,15 @@
+package catalog
+
+import (
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/modules/core-services/catalog/services"
+)
+
+// Module regroupe les services métier du catalogue des prestations (SANS endpoints)
+// Core Service : catalogue et tarification réutilisables par l'accueil, la caisse et la facturation
+var Module = fx.Options(
+	fx.Provide(services.NewPrestationCatalogService), // CS-C-001: Types, rattachements aux modules, prestations
+	fx.Provide(services.NewTariffResolverService),    // CS-C-002: Tarif applicable (normal, garde, férié)
+	fx.Provide(services.NewTariffVersioningService),  // CS-C-003: Versions de tarif, révisions globales, historique
+)
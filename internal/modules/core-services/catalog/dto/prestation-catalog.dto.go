@@ -0,0 +1,176 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateTypePrestationRequest représente la création d'un type de prestation (consultation, analyse...)
+type CreateTypePrestationRequest struct {
+	CodeType    string  `json:"code_type" validate:"required,min=2,max=50"`
+	Libelle     string  `json:"libelle" validate:"required,min=2,max=255"`
+	Description *string `json:"description"`
+}
+
+// UpdateTypePrestationRequest représente la modification partielle d'un type (le code est immuable)
+type UpdateTypePrestationRequest struct {
+	Libelle     *string `json:"libelle" validate:"omitempty,min=2,max=255"`
+	Description *string `json:"description"`
+}
+
+// LinkTypePrestationModuleRequest représente le rattachement d'un type de prestation à un module
+type LinkTypePrestationModuleRequest struct {
+	ModuleID uuid.UUID `json:"module_id" validate:"required"`
+}
+
+// CreatePrestationRequest représente l'ajout d'une prestation au catalogue
+// Type et module sont déduits de l'association ; s'ils sont fournis, ils doivent lui correspondre
+type CreatePrestationRequest struct {
+	TypePrestationModuleID    uuid.UUID  `json:"type_prestation_module_id" validate:"required"`
+	TypePrestationID          *uuid.UUID `json:"type_prestation_id"`
+	ModuleID                  *uuid.UUID `json:"module_id"`
+	CodePrestation            string     `json:"code_prestation" validate:"required,min=2,max=50"`
+	Libelle                   string     `json:"libelle" validate:"required,min=2,max=500"`
+	Description               *string    `json:"description"`
+	CodeNomenclatureNationale *string    `json:"code_nomenclature_nationale" validate:"omitempty,max=50"`
+	VersionNomenclature       *string    `json:"version_nomenclature" validate:"omitempty,max=20"`
+}
+
+// UpdatePrestationRequest représente la modification partielle d'une prestation (le code est immuable)
+type UpdatePrestationRequest struct {
+	TypePrestationModuleID    *uuid.UUID `json:"type_prestation_module_id"`
+	TypePrestationID          *uuid.UUID `json:"type_prestation_id"`
+	ModuleID                  *uuid.UUID `json:"module_id"`
+	Libelle                   *string    `json:"libelle" validate:"omitempty,min=2,max=500"`
+	Description               *string    `json:"description"`
+	CodeNomenclatureNationale *string    `json:"code_nomenclature_nationale" validate:"omitempty,max=50"`
+	VersionNomenclature       *string    `json:"version_nomenclature" validate:"omitempty,max=20"`
+}
+
+// PrestationFilter représente les filtres du catalogue des prestations
+type PrestationFilter struct {
+	TypePrestationID *uuid.UUID `form:"type_prestation_id"`
+	ModuleID         *uuid.UUID `form:"module_id"`
+	Recherche        *string    `form:"recherche"` // Code, libellé ou code nomenclature
+	EstActif         *bool      `form:"est_actif"`
+	Page             int        `form:"page"`
+	Limit            int        `form:"limit"`
+}
+
+// ModuleInfo représente un module dans les réponses du catalogue
+type ModuleInfo struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Nom  string    `json:"nom"`
+}
+
+// TypePrestationInfo représente un type de prestation résumé
+type TypePrestationInfo struct {
+	ID       uuid.UUID `json:"id"`
+	CodeType string    `json:"code_type"`
+	Libelle  string    `json:"libelle"`
+}
+
+// TypePrestationModuleResponse représente une association type de prestation › module
+type TypePrestationModuleResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	TypePrestationID uuid.UUID  `json:"type_prestation_id"`
+	Module           ModuleInfo `json:"module"`
+	EstActif         bool       `json:"est_actif"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// TypePrestationResponse représente un type de prestation et ses modules
+type TypePrestationResponse struct {
+	ID                uuid.UUID                      `json:"id"`
+	CodeType          string                         `json:"code_type"`
+	Libelle           string                         `json:"libelle"`
+	Description       *string                        `json:"description,omitempty"`
+	EstActif          bool                           `json:"est_actif"`
+	NombrePrestations int                            `json:"nombre_prestations"` // Prestations actives
+	Modules           []TypePrestationModuleResponse `json:"modules"`
+	CreatedAt         time.Time                      `json:"created_at"`
+	UpdatedAt         time.Time                      `json:"updated_at"`
+}
+
+// PrestationResponse représente une prestation du catalogue
+type PrestationResponse struct {
+	ID                        uuid.UUID          `json:"id"`
+	TypePrestationModuleID    uuid.UUID          `json:"type_prestation_module_id"`
+	TypePrestation            TypePrestationInfo `json:"type_prestation"`
+	Module                    ModuleInfo         `json:"module"`
+	CodePrestation            string             `json:"code_prestation"`
+	Libelle                   string             `json:"libelle"`
+	Description               *string            `json:"description,omitempty"`
+	CodeNomenclatureNationale *string            `json:"code_nomenclature_nationale,omitempty"`
+	VersionNomenclature       *string            `json:"version_nomenclature,omitempty"`
+	EstActif                  bool               `json:"est_actif"`
+	CreatedAt                 time.Time          `json:"created_at"`
+	UpdatedAt                 time.Time          `json:"updated_at"`
+}
+
+// PrestationListResponse représente une page du catalogue
+type PrestationListResponse struct {
+	Prestations []PrestationResponse `json:"prestations"`
+	Pagination  PaginationInfo       `json:"pagination"`
+}
+
+// PaginationInfo contient les informations de pagination
+type PaginationInfo struct {
+	Page        int  `json:"page"`
+	Limit       int  `json:"limit"`
+	Total       int  `json:"total"`
+	TotalPages  int  `json:"total_pages"`
+	HasNext     bool `json:"has_next"`
+	HasPrevious bool `json:"has_previous"`
+}
+
+// NewPaginationInfo crée les informations de pagination
+func NewPaginationInfo(page, limit, total int) PaginationInfo {
+	totalPages := (total + limit - 1) / limit
+
+	return PaginationInfo{
+		Page:        page,
+		Limit:       limit,
+		Total:       total,
+		TotalPages:  totalPages,
+		HasNext:     page < totalPages,
+		HasPrevious: page > 1,
+	}
+}
+
+// CatalogError représente un refus métier sur le catalogue des prestations
+type CatalogError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs du catalogue
+const (
+	ErrCatalogueTypeIntrouvable        = "CATALOGUE_TYPE_INTROUVABLE"
+	ErrCatalogueAssociationIntrouvable = "CATALOGUE_ASSOCIATION_INTROUVABLE"
+	ErrCataloguePrestationIntrouvable  = "CATALOGUE_PRESTATION_INTROUVABLE"
+	ErrCatalogueModuleIntrouvable      = "CATALOGUE_MODULE_INTROUVABLE"
+	ErrCatalogueModuleNonLicencie      = "CATALOGUE_MODULE_NON_LICENCIE"
+	ErrCatalogueModuleSansTicket       = "CATALOGUE_MODULE_SANS_TICKET"
+	ErrCatalogueCodeExistant           = "CATALOGUE_CODE_EXISTANT"
+	ErrCatalogueLibelleExistant        = "CATALOGUE_LIBELLE_EXISTANT"
+	ErrCatalogueAssociationExistante   = "CATALOGUE_ASSOCIATION_EXISTANTE"
+	ErrCatalogueIncoherence            = "CATALOGUE_INCOHERENCE_TYPE_MODULE"
+	ErrCatalogueParentInactif          = "CATALOGUE_PARENT_INACTIF"
+	ErrCatalogueElementsActifs         = "CATALOGUE_ELEMENTS_ACTIFS_RATTACHES"
+)
+
+// NewCatalogError crée une nouvelle erreur du catalogue
+func NewCatalogError(code, message string) *CatalogError {
+	return &CatalogError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *CatalogError) Error() string {
+	return e.Message
+}
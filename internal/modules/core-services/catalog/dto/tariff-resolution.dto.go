@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Motifs de tarification retournés par le résolveur
+const (
+	MotifTarifNormal = "normal"
+	MotifTarifGarde  = "garde"
+	MotifTarifFerie  = "ferie"
+)
+
+// ResolveTariffRequest représente une demande de tarif à un instant donné (maintenant par défaut)
+type ResolveTariffRequest struct {
+	Instant *time.Time `form:"instant" time_format:"2006-01-02T15:04:05"`
+}
+
+// JourFerieInfo représente le jour férié couvrant l'instant demandé
+type JourFerieInfo struct {
+	Date      string  `json:"date"`
+	Libelle   string  `json:"libelle"`
+	TypeFerie *string `json:"type_ferie,omitempty"`
+}
+
+// TariffContext décrit le contexte temporel détecté pour l'instant demandé
+type TariffContext struct {
+	JourFerie       *JourFerieInfo `json:"jour_ferie,omitempty"`
+	PeriodeGarde    bool           `json:"periode_garde"`
+	GardeHeureDebut *string        `json:"garde_heure_debut,omitempty"`
+	GardeHeureFin   *string        `json:"garde_heure_fin,omitempty"`
+}
+
+// ResolvedTariff représente le prix applicable et sa justification
+// Priorité : férié > garde > normal ; un tarif spécial absent (NULL) se replie sur le niveau suivant
+type ResolvedTariff struct {
+	PrestationID   uuid.UUID `json:"prestation_id"`
+	CodePrestation string    `json:"code_prestation"`
+	Libelle        string    `json:"libelle"`
+	Instant        time.Time `json:"instant"`
+
+	Montant     int    `json:"montant"`
+	Unite       string `json:"unite"`
+	Motif       string `json:"motif"` // normal, garde, ferie
+	Repli       bool   `json:"repli"` // Contexte spécial sans tarif spécial défini
+	Explication string `json:"explication"`
+
+	Contexte TariffContext `json:"contexte"`
+
+	TarifID           uuid.UUID  `json:"tarif_id"`
+	TarifNormal       int        `json:"tarif_normal"`
+	TarifGarde        *int       `json:"tarif_garde,omitempty"`
+	TarifFerie        *int       `json:"tarif_ferie,omitempty"`
+	DateDebutValidite time.Time  `json:"date_debut_validite"`
+	DateFinValidite   *time.Time `json:"date_fin_validite,omitempty"`
+}
+
+// Erreurs de tarification
+const (
+	ErrCataloguePrestationInactive = "CATALOGUE_PRESTATION_INACTIVE"
+	ErrTarifAucunEnVigueur         = "TARIF_AUCUN_EN_VIGUEUR"
+)
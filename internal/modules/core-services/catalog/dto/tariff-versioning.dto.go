@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuts d'un tarif par rapport à l'instant de consultation
+const (
+	StatutTarifPasse     = "passe"
+	StatutTarifEnVigueur = "en_vigueur"
+	StatutTarifPlanifie  = "planifie"
+)
+
+// Types de changement (CK_base_tarif_prestation_type_changement)
+const (
+	TypeChangementCreation     = "creation"
+	TypeChangementModification = "modification"
+	TypeChangementCorrection   = "correction"
+)
+
+// ArrondiTarifDefaut arrondi des révisions globales (plus petite pièce en circulation : 5 FCFA)
+const ArrondiTarifDefaut = 5
+
+// ScheduleTariffRequest représente un nouveau tarif, immédiat ou planifié
+// Le tarif couvrant la date de début est clôturé à cette date ; un tarif planifié plus tard borne le nouveau
+type ScheduleTariffRequest struct {
+	TarifUnitaire             int        `json:"tarif_unitaire" validate:"required,gt=0"`
+	TarifUnitairePeriodeGarde *int       `json:"tarif_unitaire_periode_garde" validate:"omitempty,gt=0"`
+	TarifUnitaireJourFerie    *int       `json:"tarif_unitaire_jour_ferie" validate:"omitempty,gt=0"`
+	UniteTarification         *string    `json:"unite_tarification" validate:"omitempty,max=50"`
+	DateDebutValidite         *time.Time `json:"date_debut_validite"` // Maintenant par défaut
+	TypeChangement            string     `json:"type_changement" validate:"omitempty,oneof=modification correction"`
+	MotifChangement           string     `json:"motif_changement" validate:"required,min=3,max=255"`
+}
+
+// BulkTariffRevisionRequest représente une révision en pourcentage d'un ensemble de prestations
+// Au moins un périmètre (type, module ou liste de prestations) est requis
+type BulkTariffRevisionRequest struct {
+	TypePrestationID      *uuid.UUID  `json:"type_prestation_id"`
+	ModuleID              *uuid.UUID  `json:"module_id"`
+	PrestationIDs         []uuid.UUID `json:"prestation_ids"`
+	Pourcentage           float64     `json:"pourcentage" validate:"required,gt=-100,lte=1000"` // +5 = hausse de 5 %
+	Arrondi               int         `json:"arrondi" validate:"omitempty,min=1,max=10000"`     // 5 FCFA par défaut
+	DateEffet             *time.Time  `json:"date_effet"`                                       // Maintenant par défaut
+	InclureTarifsSpeciaux *bool       `json:"inclure_tarifs_speciaux"`                          // Garde et férié révisés (oui par défaut)
+	MotifChangement       string      `json:"motif_changement" validate:"required,min=3,max=255"`
+	Simulation            bool        `json:"simulation"` // Aperçu sans écriture
+}
+
+// TarifEntry représente une version de tarif dans l'historique
+type TarifEntry struct {
+	ID                        uuid.UUID  `json:"id"`
+	TarifUnitaire             int        `json:"tarif_unitaire"`
+	UniteTarification         string     `json:"unite_tarification"`
+	TarifUnitairePeriodeGarde *int       `json:"tarif_unitaire_periode_garde,omitempty"`
+	TarifUnitaireJourFerie    *int       `json:"tarif_unitaire_jour_ferie,omitempty"`
+	TypeChangement            *string    `json:"type_changement,omitempty"`
+	MotifChangement           *string    `json:"motif_changement,omitempty"`
+	DateDebutValidite         time.Time  `json:"date_debut_validite"`
+	DateFinValidite           *time.Time `json:"date_fin_validite,omitempty"`
+	Statut                    string     `json:"statut"`                          // passe, en_vigueur, planifie
+	VariationPourcentage      *float64   `json:"variation_pourcentage,omitempty"` // Par rapport à la version précédente
+	CreatedAt                 time.Time  `json:"created_at"`
+	CreatedBy                 uuid.UUID  `json:"created_by"`
+	CreatedByNom              *string    `json:"created_by_nom,omitempty"`
+}
+
+// TariffHistoryResponse représente l'historique tarifaire d'une prestation (plus récent d'abord)
+type TariffHistoryResponse struct {
+	PrestationID   uuid.UUID    `json:"prestation_id"`
+	CodePrestation string       `json:"code_prestation"`
+	Libelle        string       `json:"libelle"`
+	TarifEnVigueur *TarifEntry  `json:"tarif_en_vigueur,omitempty"`
+	Planifies      int          `json:"planifies"`
+	Historique     []TarifEntry `json:"historique"`
+}
+
+// BulkTariffRevisionLine représente la révision d'une prestation
+type BulkTariffRevisionLine struct {
+	PrestationID   uuid.UUID  `json:"prestation_id"`
+	CodePrestation string     `json:"code_prestation"`
+	Libelle        string     `json:"libelle"`
+	AncienTarif    int        `json:"ancien_tarif"`
+	NouveauTarif   int        `json:"nouveau_tarif"`
+	AncienGarde    *int       `json:"ancien_tarif_garde,omitempty"`
+	NouveauGarde   *int       `json:"nouveau_tarif_garde,omitempty"`
+	AncienFerie    *int       `json:"ancien_tarif_ferie,omitempty"`
+	NouveauFerie   *int       `json:"nouveau_tarif_ferie,omitempty"`
+	TarifID        *uuid.UUID `json:"tarif_id,omitempty"` // Version créée (hors simulation)
+}
+
+// BulkTariffRevisionSkip représente une prestation du périmètre non révisée
+type BulkTariffRevisionSkip struct {
+	PrestationID   uuid.UUID `json:"prestation_id"`
+	CodePrestation string    `json:"code_prestation"`
+	Libelle        string    `json:"libelle"`
+	Raison         string    `json:"raison"`
+}
+
+// BulkTariffRevisionResult représente le résultat (ou l'aperçu) d'une révision globale
+type BulkTariffRevisionResult struct {
+	Simulation  bool                     `json:"simulation"`
+	DateEffet   time.Time                `json:"date_effet"`
+	Pourcentage float64                  `json:"pourcentage"`
+	Arrondi     int                      `json:"arrondi"`
+	Revisees    []BulkTariffRevisionLine `json:"revisees"`
+	Ignorees    []BulkTariffRevisionSkip `json:"ignorees"`
+}
+
+// Erreurs du versionnement tarifaire
+const (
+	ErrTarifIntrouvable     = "TARIF_INTROUVABLE"
+	ErrTarifDateRetroactive = "TARIF_DATE_RETROACTIVE"
+	ErrTarifDejaPlanifie    = "TARIF_CHANGEMENT_DEJA_PLANIFIE"
+	ErrTarifNonAnnulable    = "TARIF_NON_ANNULABLE"
+	ErrTarifPerimetreVide   = "TARIF_PERIMETRE_VIDE"
+)
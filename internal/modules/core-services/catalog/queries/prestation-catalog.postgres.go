@@ -0,0 +1,294 @@
+package queries
+
+// PrestationCatalogQueries contient les requêtes SQL du catalogue des prestations médicales
+var PrestationCatalogQueries = struct {
+	ListTypesPrestation          string
+	GetTypePrestation            string
+	InsertTypePrestation         string
+	UpdateTypePrestation         string
+	SetTypePrestationActif       string
+	CountActivePrestationsOfType string
+	ListTypePrestationModules    string
+	GetModuleForLink             string
+	IsModuleLicensed             string
+	UpsertTypePrestationModule   string
+	GetTypePrestationModule      string
+	SetTypePrestationModuleActif string
+	CountActivePrestationsOfLink string
+	ListPrestations              string
+	CountPrestations             string
+	GetPrestation                string
+	InsertPrestation             string
+	UpdatePrestation             string
+	SetPrestationActif           string
+}{
+	// ListTypesPrestation - Types de l'établissement avec leur nombre de prestations actives
+	ListTypesPrestation: `
+		SELECT
+			tp.id,
+			tp.code_type,
+			tp.libelle,
+			tp.description,
+			tp.est_actif,
+			tp.created_at,
+			tp.updated_at,
+			(SELECT COUNT(*) FROM base_prestation_medicale p
+			 WHERE p.type_prestation_id = tp.id AND p.est_actif = TRUE)
+		FROM base_type_prestation tp
+		WHERE tp.etablissement_id = $1
+		AND ($2::boolean OR tp.est_actif = TRUE)
+		ORDER BY tp.libelle;
+	`,
+
+	// GetTypePrestation - Type de prestation de l'établissement
+	GetTypePrestation: `
+		SELECT
+			tp.id,
+			tp.code_type,
+			tp.libelle,
+			tp.description,
+			tp.est_actif,
+			tp.created_at,
+			tp.updated_at,
+			(SELECT COUNT(*) FROM base_prestation_medicale p
+			 WHERE p.type_prestation_id = tp.id AND p.est_actif = TRUE)
+		FROM base_type_prestation tp
+		WHERE tp.id = $1
+		AND tp.etablissement_id = $2;
+	`,
+
+	// InsertTypePrestation - Création d'un type de prestation
+	InsertTypePrestation: `
+		INSERT INTO base_type_prestation (
+			etablissement_id, code_type, libelle, description, created_by, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $5
+		) RETURNING id;
+	`,
+
+	// UpdateTypePrestation - Modification partielle (libellé, description)
+	UpdateTypePrestation: `
+		UPDATE base_type_prestation SET
+			libelle     = COALESCE($3, libelle),
+			description = COALESCE($4, description),
+			updated_by  = $5
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetTypePrestationActif - Activation ou désactivation d'un type
+	SetTypePrestationActif: `
+		UPDATE base_type_prestation SET
+			est_actif  = $3,
+			updated_by = $4
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// CountActivePrestationsOfType - Prestations actives bloquant la désactivation d'un type
+	CountActivePrestationsOfType: `
+		SELECT COUNT(*)
+		FROM base_prestation_medicale
+		WHERE type_prestation_id = $1
+		AND est_actif = TRUE;
+	`,
+
+	// ListTypePrestationModules - Modules rattachés à un ensemble de types
+	ListTypePrestationModules: `
+		SELECT
+			tpm.id,
+			tpm.type_prestation_id,
+			m.id,
+			m.code_module,
+			COALESCE(m.nom_personnalise, m.nom_standard),
+			tpm.est_actif,
+			tpm.created_at
+		FROM base_type_prestation_module tpm
+		JOIN base_module m ON tpm.module_id = m.id
+		WHERE tpm.etablissement_id = $1
+		AND tpm.type_prestation_id = ANY($2::uuid[])
+		ORDER BY m.code_module;
+	`,
+
+	// GetModuleForLink - Caractéristiques d'un module candidat au rattachement
+	GetModuleForLink: `
+		SELECT
+			id,
+			code_module,
+			COALESCE(nom_personnalise, nom_standard),
+			est_actif,
+			est_module_back_office,
+			peut_prendre_ticket
+		FROM base_module
+		WHERE id = $1;
+	`,
+
+	// IsModuleLicensed - Module présent dans modules_autorises de la licence active et non expirée
+	// Format: {"modules": [{"id": "uuid", "code": "CODE_MODULE"}, ...]}
+	IsModuleLicensed: `
+		SELECT EXISTS (
+			SELECT 1
+			FROM base_licence l
+			CROSS JOIN LATERAL jsonb_array_elements(COALESCE(l.modules_autorises->'modules', '[]'::jsonb)) m
+			WHERE l.etablissement_id = $1
+			AND l.statut = 'actif'
+			AND (l.date_expiration IS NULL OR l.date_expiration > NOW())
+			AND m->>'id' = $2::text
+		);
+	`,
+
+	// UpsertTypePrestationModule - Rattachement (ou réactivation d'un rattachement inactif)
+	// Aucune ligne retournée si le rattachement existe déjà et est actif
+	UpsertTypePrestationModule: `
+		INSERT INTO base_type_prestation_module (
+			etablissement_id, type_prestation_id, module_id, created_by
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT (etablissement_id, type_prestation_id, module_id)
+		DO UPDATE SET est_actif = TRUE
+		WHERE base_type_prestation_module.est_actif = FALSE
+		RETURNING id;
+	`,
+
+	// GetTypePrestationModule - Association avec l'état de son type
+	GetTypePrestationModule: `
+		SELECT
+			tpm.id,
+			tpm.type_prestation_id,
+			tpm.module_id,
+			tpm.est_actif,
+			tp.est_actif
+		FROM base_type_prestation_module tpm
+		JOIN base_type_prestation tp ON tpm.type_prestation_id = tp.id
+		WHERE tpm.id = $1
+		AND tpm.etablissement_id = $2;
+	`,
+
+	// SetTypePrestationModuleActif - Activation ou désactivation d'un rattachement
+	SetTypePrestationModuleActif: `
+		UPDATE base_type_prestation_module SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// CountActivePrestationsOfLink - Prestations actives bloquant la désactivation d'un rattachement
+	CountActivePrestationsOfLink: `
+		SELECT COUNT(*)
+		FROM base_prestation_medicale
+		WHERE type_prestation_module_id = $1
+		AND est_actif = TRUE;
+	`,
+
+	// ListPrestations - Catalogue paginé et filtré
+	// Paramètres: $1 = établissement, $2 = type, $3 = module, $4 = recherche, $5 = actif, $6 = limite, $7 = offset
+	ListPrestations: `
+		SELECT
+			p.id,
+			p.type_prestation_module_id,
+			tp.id,
+			tp.code_type,
+			tp.libelle,
+			m.id,
+			m.code_module,
+			COALESCE(m.nom_personnalise, m.nom_standard),
+			p.code_prestation,
+			p.libelle,
+			p.description,
+			p.code_nomenclature_nationale,
+			p.version_nomenclature,
+			p.est_actif,
+			p.created_at,
+			p.updated_at
+		FROM base_prestation_medicale p
+		JOIN base_type_prestation tp ON p.type_prestation_id = tp.id
+		JOIN base_module m ON p.module_id = m.id
+		WHERE p.etablissement_id = $1
+		AND ($2::uuid IS NULL OR p.type_prestation_id = $2)
+		AND ($3::uuid IS NULL OR p.module_id = $3)
+		AND ($4::text IS NULL
+			OR p.code_prestation ILIKE '%' || $4 || '%'
+			OR p.libelle ILIKE '%' || $4 || '%'
+			OR p.code_nomenclature_nationale ILIKE '%' || $4 || '%')
+		AND ($5::boolean IS NULL OR p.est_actif = $5)
+		ORDER BY tp.libelle, p.libelle
+		LIMIT $6 OFFSET $7;
+	`,
+
+	// CountPrestations - Total pour pagination
+	CountPrestations: `
+		SELECT COUNT(*)
+		FROM base_prestation_medicale p
+		WHERE p.etablissement_id = $1
+		AND ($2::uuid IS NULL OR p.type_prestation_id = $2)
+		AND ($3::uuid IS NULL OR p.module_id = $3)
+		AND ($4::text IS NULL
+			OR p.code_prestation ILIKE '%' || $4 || '%'
+			OR p.libelle ILIKE '%' || $4 || '%'
+			OR p.code_nomenclature_nationale ILIKE '%' || $4 || '%')
+		AND ($5::boolean IS NULL OR p.est_actif = $5);
+	`,
+
+	// GetPrestation - Prestation de l'établissement
+	GetPrestation: `
+		SELECT
+			p.id,
+			p.type_prestation_module_id,
+			tp.id,
+			tp.code_type,
+			tp.libelle,
+			m.id,
+			m.code_module,
+			COALESCE(m.nom_personnalise, m.nom_standard),
+			p.code_prestation,
+			p.libelle,
+			p.description,
+			p.code_nomenclature_nationale,
+			p.version_nomenclature,
+			p.est_actif,
+			p.created_at,
+			p.updated_at
+		FROM base_prestation_medicale p
+		JOIN base_type_prestation tp ON p.type_prestation_id = tp.id
+		JOIN base_module m ON p.module_id = m.id
+		WHERE p.id = $1
+		AND p.etablissement_id = $2;
+	`,
+
+	// InsertPrestation - Ajout au catalogue (type et module dénormalisés depuis l'association)
+	InsertPrestation: `
+		INSERT INTO base_prestation_medicale (
+			etablissement_id, type_prestation_module_id, type_prestation_id, module_id,
+			code_prestation, libelle, description,
+			code_nomenclature_nationale, version_nomenclature,
+			created_by, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10
+		) RETURNING id;
+	`,
+
+	// UpdatePrestation - Modification partielle (reclassement inclus)
+	UpdatePrestation: `
+		UPDATE base_prestation_medicale SET
+			type_prestation_module_id   = $3,
+			type_prestation_id          = $4,
+			module_id                   = $5,
+			libelle                     = COALESCE($6, libelle),
+			description                 = COALESCE($7, description),
+			code_nomenclature_nationale = COALESCE($8, code_nomenclature_nationale),
+			version_nomenclature        = COALESCE($9, version_nomenclature),
+			updated_by                  = $10
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetPrestationActif - Activation ou désactivation d'une prestation
+	SetPrestationActif: `
+		UPDATE base_prestation_medicale SET
+			est_actif  = $3,
+			updated_by = $4
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+}
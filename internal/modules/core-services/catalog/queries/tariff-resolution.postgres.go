@@ -0,0 +1,35 @@
+package queries
+
+// TariffResolutionQueries contient les requêtes SQL de résolution des tarifs
+var TariffResolutionQueries = struct {
+	GetPrestationForTariff string
+	GetTarifEnVigueur      string
+}{
+	// GetPrestationForTariff - Prestation à tarifer
+	GetPrestationForTariff: `
+		SELECT id, code_prestation, libelle, est_actif
+		FROM base_prestation_medicale
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// GetTarifEnVigueur - Tarif dont la période de validité couvre l'instant
+	// Le plus récent l'emporte si des périodes se chevauchent (historique antérieur au versionnement)
+	GetTarifEnVigueur: `
+		SELECT
+			id,
+			tarif_unitaire,
+			COALESCE(unite_tarification, 'FCFA'),
+			tarif_unitaire_periode_garde,
+			tarif_unitaire_jour_ferie,
+			date_debut_validite,
+			date_fin_validite
+		FROM base_tarif_prestation
+		WHERE prestation_medicale_id = $1
+		AND etablissement_id = $2
+		AND date_debut_validite <= $3
+		AND (date_fin_validite IS NULL OR date_fin_validite > $3)
+		ORDER BY date_debut_validite DESC, created_at DESC
+		LIMIT 1;
+	`,
+}
@@ -0,0 +1,130 @@
+package queries
+
+// TariffVersioningQueries contient les requêtes SQL du versionnement des tarifs
+// Toute écriture verrouille d'abord la prestation (FOR UPDATE) : une seule révision à la fois par prestation
+var TariffVersioningQueries = struct {
+	LockPrestation             string
+	LockPrestationsForRevision string
+	ExistsTarifStartingAt      string
+	GetNextTarifStart          string
+	CountTarifs                string
+	CloseTarif                 string
+	InsertTarif                string
+	ListTarifHistory           string
+	GetTarifForCancel          string
+	DeleteTarif                string
+	ReopenPredecessor          string
+}{
+	// LockPrestation - Verrou de la prestation pendant la révision de ses tarifs
+	LockPrestation: `
+		SELECT id, code_prestation, libelle, est_actif
+		FROM base_prestation_medicale
+		WHERE id = $1
+		AND etablissement_id = $2
+		FOR UPDATE;
+	`,
+
+	// LockPrestationsForRevision - Prestations actives du périmètre d'une révision globale (verrouillées)
+	// Paramètres: $1 = établissement, $2 = type, $3 = module, $4 = liste d'identifiants (vide = pas de filtre)
+	LockPrestationsForRevision: `
+		SELECT id, code_prestation, libelle
+		FROM base_prestation_medicale
+		WHERE etablissement_id = $1
+		AND est_actif = TRUE
+		AND ($2::uuid IS NULL OR type_prestation_id = $2)
+		AND ($3::uuid IS NULL OR module_id = $3)
+		AND (cardinality($4::uuid[]) = 0 OR id = ANY($4::uuid[]))
+		ORDER BY code_prestation
+		FOR UPDATE;
+	`,
+
+	// ExistsTarifStartingAt - Changement déjà planifié au même instant
+	ExistsTarifStartingAt: `
+		SELECT EXISTS (
+			SELECT 1 FROM base_tarif_prestation
+			WHERE prestation_medicale_id = $1
+			AND date_debut_validite = $2
+		);
+	`,
+
+	// GetNextTarifStart - Début du prochain tarif planifié après un instant (borne du nouveau tarif)
+	GetNextTarifStart: `
+		SELECT MIN(date_debut_validite)
+		FROM base_tarif_prestation
+		WHERE prestation_medicale_id = $1
+		AND date_debut_validite > $2;
+	`,
+
+	// CountTarifs - Nombre de versions (0 = première tarification)
+	CountTarifs: `
+		SELECT COUNT(*)
+		FROM base_tarif_prestation
+		WHERE prestation_medicale_id = $1;
+	`,
+
+	// CloseTarif - Clôture d'une version à la date d'effet de la suivante
+	CloseTarif: `
+		UPDATE base_tarif_prestation SET
+			date_fin_validite = $2
+		WHERE id = $1;
+	`,
+
+	// InsertTarif - Nouvelle version de tarif
+	InsertTarif: `
+		INSERT INTO base_tarif_prestation (
+			etablissement_id, prestation_medicale_id,
+			tarif_unitaire, unite_tarification,
+			tarif_unitaire_periode_garde, tarif_unitaire_jour_ferie,
+			motif_changement, type_changement,
+			date_debut_validite, date_fin_validite,
+			created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) RETURNING id;
+	`,
+
+	// ListTarifHistory - Versions de tarif d'une prestation (plus récente d'abord)
+	ListTarifHistory: `
+		SELECT
+			t.id,
+			t.tarif_unitaire,
+			COALESCE(t.unite_tarification, 'FCFA'),
+			t.tarif_unitaire_periode_garde,
+			t.tarif_unitaire_jour_ferie,
+			t.type_changement,
+			t.motif_changement,
+			t.date_debut_validite,
+			t.date_fin_validite,
+			t.created_at,
+			t.created_by,
+			CASE WHEN u.id IS NULL THEN NULL ELSE u.nom || ' ' || u.prenoms END
+		FROM base_tarif_prestation t
+		LEFT JOIN user_utilisateur u ON u.id = t.created_by
+		WHERE t.prestation_medicale_id = $1
+		AND t.etablissement_id = $2
+		ORDER BY t.date_debut_validite DESC, t.created_at DESC;
+	`,
+
+	// GetTarifForCancel - Version candidate à l'annulation
+	GetTarifForCancel: `
+		SELECT id, date_debut_validite, date_fin_validite
+		FROM base_tarif_prestation
+		WHERE id = $1
+		AND prestation_medicale_id = $2
+		AND etablissement_id = $3;
+	`,
+
+	// DeleteTarif - Suppression d'un changement planifié (jamais appliqué)
+	DeleteTarif: `
+		DELETE FROM base_tarif_prestation
+		WHERE id = $1;
+	`,
+
+	// ReopenPredecessor - La version clôturée par le changement annulé reprend sa fin d'origine
+	ReopenPredecessor: `
+		UPDATE base_tarif_prestation SET
+			date_fin_validite = $3
+		WHERE prestation_medicale_id = $1
+		AND date_fin_validite = $2;
+	`,
+}
@@ -0,0 +1,727 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/catalog/dto"
+	"soins-suite-core/internal/modules/core-services/catalog/queries"
+)
+
+// PrestationCatalogService gère le catalogue des prestations médicales d'un établissement :
+// types de prestation, rattachement des types aux modules licenciés, prestations et leur activation
+// Invariant : une prestation porte le type et le module de son association (triple référencement)
+type PrestationCatalogService struct {
+	db *postgres.Client
+}
+
+// NewPrestationCatalogService crée une nouvelle instance du service
+func NewPrestationCatalogService(db *postgres.Client) *PrestationCatalogService {
+	return &PrestationCatalogService{
+		db: db,
+	}
+}
+
+// ListTypes retourne les types de prestation et leurs modules (inactifs inclus sur demande)
+func (s *PrestationCatalogService) ListTypes(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	includeInactive bool,
+) ([]dto.TypePrestationResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.PrestationCatalogQueries.ListTypesPrestation,
+		etablissementID,
+		includeInactive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list types prestation: %w", err)
+	}
+	defer rows.Close()
+
+	types := []dto.TypePrestationResponse{}
+	for rows.Next() {
+		typePrestation, err := scanTypePrestation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan type prestation: %w", err)
+		}
+		types = append(types, *typePrestation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate types prestation: %w", err)
+	}
+
+	if err := s.attachModules(ctx, etablissementID, types); err != nil {
+		return nil, err
+	}
+
+	return types, nil
+}
+
+// GetType retourne un type de prestation et ses modules
+func (s *PrestationCatalogService) GetType(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	typeID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	typePrestation, err := scanTypePrestation(s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.GetTypePrestation,
+		typeID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueTypeIntrouvable,
+				fmt.Sprintf("Type de prestation introuvable: %s", typeID))
+		}
+		return nil, fmt.Errorf("failed to get type prestation: %w", err)
+	}
+
+	types := []dto.TypePrestationResponse{*typePrestation}
+	if err := s.attachModules(ctx, etablissementID, types); err != nil {
+		return nil, err
+	}
+
+	return &types[0], nil
+}
+
+// CreateType crée un type de prestation (code et libellé uniques dans l'établissement)
+func (s *PrestationCatalogService) CreateType(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateTypePrestationRequest,
+	userID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	var typeID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.InsertTypePrestation,
+		etablissementID,
+		strings.ToUpper(strings.TrimSpace(req.CodeType)),
+		strings.TrimSpace(req.Libelle),
+		req.Description,
+		userID,
+	).Scan(&typeID)
+	if err != nil {
+		if conflict := catalogConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to insert type prestation: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Type prestation created - ID: %s, Code: %s, User: %s\n", typeID, req.CodeType, userID)
+
+	return s.GetType(ctx, etablissementID, typeID)
+}
+
+// UpdateType modifie le libellé ou la description d'un type
+func (s *PrestationCatalogService) UpdateType(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	typeID uuid.UUID,
+	req *dto.UpdateTypePrestationRequest,
+	userID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	if _, err := s.GetType(ctx, etablissementID, typeID); err != nil {
+		return nil, err
+	}
+
+	var libelle *string
+	if req.Libelle != nil {
+		trimmed := strings.TrimSpace(*req.Libelle)
+		libelle = &trimmed
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.PrestationCatalogQueries.UpdateTypePrestation,
+		typeID,
+		etablissementID,
+		libelle,
+		req.Description,
+		userID,
+	); err != nil {
+		if conflict := catalogConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to update type prestation: %w", err)
+	}
+
+	return s.GetType(ctx, etablissementID, typeID)
+}
+
+// SetTypeActive active ou désactive un type de prestation
+// La désactivation est refusée tant que des prestations actives y sont rattachées
+func (s *PrestationCatalogService) SetTypeActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	typeID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	if _, err := s.GetType(ctx, etablissementID, typeID); err != nil {
+		return nil, err
+	}
+
+	if !actif {
+		var actives int
+		if err := s.db.QueryRow(ctx,
+			queries.PrestationCatalogQueries.CountActivePrestationsOfType,
+			typeID,
+		).Scan(&actives); err != nil {
+			return nil, fmt.Errorf("failed to count active prestations: %w", err)
+		}
+		if actives > 0 {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueElementsActifs,
+				fmt.Sprintf("%d prestation(s) active(s) rattachée(s) à ce type : à désactiver d'abord", actives))
+		}
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.PrestationCatalogQueries.SetTypePrestationActif,
+		typeID,
+		etablissementID,
+		actif,
+		userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update type prestation status: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Type prestation status changed - ID: %s, Actif: %t, User: %s\n", typeID, actif, userID)
+
+	return s.GetType(ctx, etablissementID, typeID)
+}
+
+// LinkModule rattache un type à un module (ou réactive le rattachement)
+// Le module doit être actif, front-office, habilité à émettre des tickets et licencié pour l'établissement
+func (s *PrestationCatalogService) LinkModule(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	typeID uuid.UUID,
+	req *dto.LinkTypePrestationModuleRequest,
+	userID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	typePrestation, err := s.GetType(ctx, etablissementID, typeID)
+	if err != nil {
+		return nil, err
+	}
+	if !typePrestation.EstActif {
+		return nil, dto.NewCatalogError(dto.ErrCatalogueParentInactif,
+			fmt.Sprintf("Le type de prestation '%s' est inactif", typePrestation.Libelle))
+	}
+
+	if err := s.checkModuleEligible(ctx, etablissementID, req.ModuleID); err != nil {
+		return nil, err
+	}
+
+	var associationID uuid.UUID
+	err = s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.UpsertTypePrestationModule,
+		etablissementID,
+		typeID,
+		req.ModuleID,
+		userID,
+	).Scan(&associationID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueAssociationExistante,
+				"Ce type de prestation est déjà rattaché à ce module")
+		}
+		return nil, fmt.Errorf("failed to link type prestation to module: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Type prestation linked to module - Association: %s, Type: %s, Module: %s, User: %s\n",
+		associationID, typeID, req.ModuleID, userID)
+
+	return s.GetType(ctx, etablissementID, typeID)
+}
+
+// SetTypeModuleActive active ou désactive un rattachement type › module
+// Réactivation : le module doit toujours être licencié ; désactivation : aucune prestation active rattachée
+func (s *PrestationCatalogService) SetTypeModuleActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	associationID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.TypePrestationResponse, error) {
+	var typeID, moduleID uuid.UUID
+	var associationActive, typeActif bool
+	err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.GetTypePrestationModule,
+		associationID,
+		etablissementID,
+	).Scan(&associationID, &typeID, &moduleID, &associationActive, &typeActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueAssociationIntrouvable,
+				fmt.Sprintf("Rattachement type › module introuvable: %s", associationID))
+		}
+		return nil, fmt.Errorf("failed to get type prestation module: %w", err)
+	}
+
+	if actif {
+		if !typeActif {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueParentInactif, "Le type de prestation est inactif")
+		}
+		if err := s.checkModuleEligible(ctx, etablissementID, moduleID); err != nil {
+			return nil, err
+		}
+	} else {
+		var actives int
+		if err := s.db.QueryRow(ctx,
+			queries.PrestationCatalogQueries.CountActivePrestationsOfLink,
+			associationID,
+		).Scan(&actives); err != nil {
+			return nil, fmt.Errorf("failed to count active prestations: %w", err)
+		}
+		if actives > 0 {
+			return nil, dto.NewCatalogError(dto.ErrCatalogueElementsActifs,
+				fmt.Sprintf("%d prestation(s) active(s) rattachée(s) à ce module : à désactiver d'abord", actives))
+		}
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.PrestationCatalogQueries.SetTypePrestationModuleActif,
+		associationID,
+		etablissementID,
+		actif,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update type prestation module status: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Type prestation module status changed - Association: %s, Actif: %t, User: %s\n",
+		associationID, actif, userID)
+
+	return s.GetType(ctx, etablissementID, typeID)
+}
+
+// ListPrestations retourne le catalogue paginé
+func (s *PrestationCatalogService) ListPrestations(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.PrestationFilter,
+) (*dto.PrestationListResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var recherche *string
+	if filter.Recherche != nil && strings.TrimSpace(*filter.Recherche) != "" {
+		trimmed := strings.TrimSpace(*filter.Recherche)
+		recherche = &trimmed
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.CountPrestations,
+		etablissementID,
+		filter.TypePrestationID,
+		filter.ModuleID,
+		recherche,
+		filter.EstActif,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count prestations: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PrestationCatalogQueries.ListPrestations,
+		etablissementID,
+		filter.TypePrestationID,
+		filter.ModuleID,
+		recherche,
+		filter.EstActif,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prestations: %w", err)
+	}
+	defer rows.Close()
+
+	prestations := []dto.PrestationResponse{}
+	for rows.Next() {
+		prestation, err := scanPrestation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prestation: %w", err)
+		}
+		prestations = append(prestations, *prestation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate prestations: %w", err)
+	}
+
+	return &dto.PrestationListResponse{
+		Prestations: prestations,
+		Pagination:  dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// GetPrestation retourne une prestation du catalogue
+func (s *PrestationCatalogService) GetPrestation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+) (*dto.PrestationResponse, error) {
+	prestation, err := scanPrestation(s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.GetPrestation,
+		prestationID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCataloguePrestationIntrouvable,
+				fmt.Sprintf("Prestation introuvable: %s", prestationID))
+		}
+		return nil, fmt.Errorf("failed to get prestation: %w", err)
+	}
+	return prestation, nil
+}
+
+// CreatePrestation ajoute une prestation rattachée à une association active d'un module licencié
+func (s *PrestationCatalogService) CreatePrestation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreatePrestationRequest,
+	userID uuid.UUID,
+) (*dto.PrestationResponse, error) {
+	typeID, moduleID, err := s.resolveAssociation(ctx, etablissementID,
+		req.TypePrestationModuleID, req.TypePrestationID, req.ModuleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var prestationID uuid.UUID
+	err = s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.InsertPrestation,
+		etablissementID,
+		req.TypePrestationModuleID,
+		typeID,
+		moduleID,
+		strings.ToUpper(strings.TrimSpace(req.CodePrestation)),
+		strings.TrimSpace(req.Libelle),
+		req.Description,
+		req.CodeNomenclatureNationale,
+		req.VersionNomenclature,
+		userID,
+	).Scan(&prestationID)
+	if err != nil {
+		if conflict := catalogConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to insert prestation: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Prestation created - ID: %s, Code: %s, User: %s\n", prestationID, req.CodePrestation, userID)
+
+	return s.GetPrestation(ctx, etablissementID, prestationID)
+}
+
+// UpdatePrestation modifie une prestation ; un reclassement est revalidé comme à la création
+func (s *PrestationCatalogService) UpdatePrestation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	req *dto.UpdatePrestationRequest,
+	userID uuid.UUID,
+) (*dto.PrestationResponse, error) {
+	current, err := s.GetPrestation(ctx, etablissementID, prestationID)
+	if err != nil {
+		return nil, err
+	}
+
+	associationID := current.TypePrestationModuleID
+	typeID := current.TypePrestation.ID
+	moduleID := current.Module.ID
+
+	if req.TypePrestationModuleID != nil || req.TypePrestationID != nil || req.ModuleID != nil {
+		if req.TypePrestationModuleID != nil {
+			associationID = *req.TypePrestationModuleID
+		}
+		typeID, moduleID, err = s.resolveAssociation(ctx, etablissementID, associationID, req.TypePrestationID, req.ModuleID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var libelle *string
+	if req.Libelle != nil {
+		trimmed := strings.TrimSpace(*req.Libelle)
+		libelle = &trimmed
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.PrestationCatalogQueries.UpdatePrestation,
+		prestationID,
+		etablissementID,
+		associationID,
+		typeID,
+		moduleID,
+		libelle,
+		req.Description,
+		req.CodeNomenclatureNationale,
+		req.VersionNomenclature,
+		userID,
+	); err != nil {
+		if conflict := catalogConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to update prestation: %w", err)
+	}
+
+	return s.GetPrestation(ctx, etablissementID, prestationID)
+}
+
+// SetPrestationActive active ou désactive une prestation
+// L'activation exige une association active, un type actif et un module toujours licencié
+func (s *PrestationCatalogService) SetPrestationActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.PrestationResponse, error) {
+	current, err := s.GetPrestation(ctx, etablissementID, prestationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if actif {
+		typeID := current.TypePrestation.ID
+		moduleID := current.Module.ID
+		if _, _, err := s.resolveAssociation(ctx, etablissementID,
+			current.TypePrestationModuleID, &typeID, &moduleID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.PrestationCatalogQueries.SetPrestationActif,
+		prestationID,
+		etablissementID,
+		actif,
+		userID,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update prestation status: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Prestation status changed - ID: %s, Actif: %t, User: %s\n", prestationID, actif, userID)
+
+	return s.GetPrestation(ctx, etablissementID, prestationID)
+}
+
+// resolveAssociation contrôle une association avant d'y rattacher une prestation
+// et retourne le type et le module à dénormaliser
+func (s *PrestationCatalogService) resolveAssociation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	associationID uuid.UUID,
+	typeID *uuid.UUID,
+	moduleID *uuid.UUID,
+) (uuid.UUID, uuid.UUID, error) {
+	var associationTypeID, associationModuleID uuid.UUID
+	var associationActive, typeActif bool
+	err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.GetTypePrestationModule,
+		associationID,
+		etablissementID,
+	).Scan(&associationID, &associationTypeID, &associationModuleID, &associationActive, &typeActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, uuid.Nil, dto.NewCatalogError(dto.ErrCatalogueAssociationIntrouvable,
+				fmt.Sprintf("Rattachement type › module introuvable: %s", associationID))
+		}
+		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to get type prestation module: %w", err)
+	}
+
+	if (typeID != nil && *typeID != associationTypeID) || (moduleID != nil && *moduleID != associationModuleID) {
+		return uuid.Nil, uuid.Nil, dto.NewCatalogError(dto.ErrCatalogueIncoherence,
+			"Le type de prestation et le module ne correspondent pas au rattachement type_prestation_module_id")
+	}
+
+	if !associationActive || !typeActif {
+		return uuid.Nil, uuid.Nil, dto.NewCatalogError(dto.ErrCatalogueParentInactif,
+			"Le type de prestation ou son rattachement au module est inactif")
+	}
+
+	licensed, err := s.isModuleLicensed(ctx, etablissementID, associationModuleID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !licensed {
+		return uuid.Nil, uuid.Nil, dto.NewCatalogError(dto.ErrCatalogueModuleNonLicencie,
+			"Le module de cette prestation n'est pas couvert par la licence de l'établissement")
+	}
+
+	return associationTypeID, associationModuleID, nil
+}
+
+// checkModuleEligible vérifie qu'un module peut porter des types de prestation pour l'établissement
+func (s *PrestationCatalogService) checkModuleEligible(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	moduleID uuid.UUID,
+) error {
+	var module dto.ModuleInfo
+	var estActif, estBackOffice, peutPrendreTicket bool
+	err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.GetModuleForLink,
+		moduleID,
+	).Scan(&module.ID, &module.Code, &module.Nom, &estActif, &estBackOffice, &peutPrendreTicket)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewCatalogError(dto.ErrCatalogueModuleIntrouvable,
+				fmt.Sprintf("Module introuvable: %s", moduleID))
+		}
+		return fmt.Errorf("failed to get module: %w", err)
+	}
+
+	if !estActif || estBackOffice || !peutPrendreTicket {
+		return dto.NewCatalogError(dto.ErrCatalogueModuleSansTicket,
+			fmt.Sprintf("Le module %s n'émet pas de tickets : aucun type de prestation ne peut y être rattaché", module.Code))
+	}
+
+	licensed, err := s.isModuleLicensed(ctx, etablissementID, moduleID)
+	if err != nil {
+		return err
+	}
+	if !licensed {
+		return dto.NewCatalogError(dto.ErrCatalogueModuleNonLicencie,
+			fmt.Sprintf("Le module %s n'est pas couvert par la licence de l'établissement", module.Code))
+	}
+
+	return nil
+}
+
+// isModuleLicensed vérifie la présence du module dans la licence active de l'établissement
+func (s *PrestationCatalogService) isModuleLicensed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	moduleID uuid.UUID,
+) (bool, error) {
+	var licensed bool
+	if err := s.db.QueryRow(ctx,
+		queries.PrestationCatalogQueries.IsModuleLicensed,
+		etablissementID,
+		moduleID.String(),
+	).Scan(&licensed); err != nil {
+		return false, fmt.Errorf("failed to check module license: %w", err)
+	}
+	return licensed, nil
+}
+
+// attachModules charge les modules rattachés aux types en une seule requête
+func (s *PrestationCatalogService) attachModules(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	types []dto.TypePrestationResponse,
+) error {
+	if len(types) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(types))
+	index := make(map[uuid.UUID]int, len(types))
+	for i := range types {
+		ids[i] = types[i].ID
+		index[types[i].ID] = i
+		types[i].Modules = []dto.TypePrestationModuleResponse{}
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PrestationCatalogQueries.ListTypePrestationModules,
+		etablissementID,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list type prestation modules: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var association dto.TypePrestationModuleResponse
+		if err := rows.Scan(
+			&association.ID,
+			&association.TypePrestationID,
+			&association.Module.ID,
+			&association.Module.Code,
+			&association.Module.Nom,
+			&association.EstActif,
+			&association.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan type prestation module: %w", err)
+		}
+		i := index[association.TypePrestationID]
+		types[i].Modules = append(types[i].Modules, association)
+	}
+
+	return rows.Err()
+}
+
+// scanTypePrestation lit une ligne de type de prestation
+func scanTypePrestation(row pgx.Row) (*dto.TypePrestationResponse, error) {
+	var typePrestation dto.TypePrestationResponse
+	if err := row.Scan(
+		&typePrestation.ID,
+		&typePrestation.CodeType,
+		&typePrestation.Libelle,
+		&typePrestation.Description,
+		&typePrestation.EstActif,
+		&typePrestation.CreatedAt,
+		&typePrestation.UpdatedAt,
+		&typePrestation.NombrePrestations,
+	); err != nil {
+		return nil, err
+	}
+	return &typePrestation, nil
+}
+
+// scanPrestation lit une ligne de prestation
+func scanPrestation(row pgx.Row) (*dto.PrestationResponse, error) {
+	var prestation dto.PrestationResponse
+	if err := row.Scan(
+		&prestation.ID,
+		&prestation.TypePrestationModuleID,
+		&prestation.TypePrestation.ID,
+		&prestation.TypePrestation.CodeType,
+		&prestation.TypePrestation.Libelle,
+		&prestation.Module.ID,
+		&prestation.Module.Code,
+		&prestation.Module.Nom,
+		&prestation.CodePrestation,
+		&prestation.Libelle,
+		&prestation.Description,
+		&prestation.CodeNomenclatureNationale,
+		&prestation.VersionNomenclature,
+		&prestation.EstActif,
+		&prestation.CreatedAt,
+		&prestation.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &prestation, nil
+}
+
+// catalogConflict traduit les violations d'unicité du catalogue (nil si autre erreur)
+func catalogConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+
+	switch strings.ToLower(pgErr.ConstraintName) {
+	case "uq_base_type_prestation_etablissement_code", "uq_base_prestation_medicale_etablissement_code":
+		return dto.NewCatalogError(dto.ErrCatalogueCodeExistant, "Ce code est déjà utilisé dans l'établissement")
+	case "uq_base_type_prestation_libelle_ci", "uq_base_prestation_medicale_libelle_ci":
+		return dto.NewCatalogError(dto.ErrCatalogueLibelleExistant, "Ce libellé est déjà utilisé (casse ignorée)")
+	default:
+		return dto.NewCatalogError(dto.ErrCatalogueCodeExistant, "Élément déjà présent dans le catalogue")
+	}
+}
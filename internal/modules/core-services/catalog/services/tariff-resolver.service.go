@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	calendarServices "soins-suite-core/internal/modules/core-services/calendar/services"
+	"soins-suite-core/internal/modules/core-services/catalog/dto"
+	"soins-suite-core/internal/modules/core-services/catalog/queries"
+)
+
+// TariffResolverService détermine le prix applicable à une prestation à un instant donné
+// Base commune de tous les flux de facturation (caisse, hospitalisation, assurances)
+// Les instants sont interprétés en heure murale de l'établissement (colonnes TIMESTAMP sans fuseau)
+type TariffResolverService struct {
+	db       *postgres.Client
+	calendar *calendarServices.CalendarService
+}
+
+// NewTariffResolverService crée une nouvelle instance du service
+func NewTariffResolverService(db *postgres.Client, calendar *calendarServices.CalendarService) *TariffResolverService {
+	return &TariffResolverService{
+		db:       db,
+		calendar: calendar,
+	}
+}
+
+// ResolveTariff retourne le tarif applicable et son motif (normal, garde, férié)
+// Priorité : jour férié, puis période de garde, puis tarif normal ; un tarif spécial NULL
+// se replie sur le niveau suivant et la réponse le signale (repli)
+func (s *TariffResolverService) ResolveTariff(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	instant time.Time,
+) (*dto.ResolvedTariff, error) {
+	result := &dto.ResolvedTariff{
+		PrestationID: prestationID,
+		Instant:      instant,
+	}
+
+	var estActif bool
+	err := s.db.QueryRow(ctx,
+		queries.TariffResolutionQueries.GetPrestationForTariff,
+		prestationID,
+		etablissementID,
+	).Scan(&result.PrestationID, &result.CodePrestation, &result.Libelle, &estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCataloguePrestationIntrouvable,
+				fmt.Sprintf("Prestation introuvable: %s", prestationID))
+		}
+		return nil, fmt.Errorf("failed to get prestation: %w", err)
+	}
+	if !estActif {
+		return nil, dto.NewCatalogError(dto.ErrCataloguePrestationInactive,
+			fmt.Sprintf("La prestation %s est retirée du catalogue", result.CodePrestation))
+	}
+
+	err = s.db.QueryRow(ctx,
+		queries.TariffResolutionQueries.GetTarifEnVigueur,
+		prestationID,
+		etablissementID,
+		instant,
+	).Scan(
+		&result.TarifID,
+		&result.TarifNormal,
+		&result.Unite,
+		&result.TarifGarde,
+		&result.TarifFerie,
+		&result.DateDebutValidite,
+		&result.DateFinValidite,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrTarifAucunEnVigueur,
+				fmt.Sprintf("Aucun tarif en vigueur pour la prestation %s au %s",
+					result.CodePrestation, instant.Format("02/01/2006 15:04")))
+		}
+		return nil, fmt.Errorf("failed to get tarif en vigueur: %w", err)
+	}
+
+	contexte, err := s.resolveContext(ctx, etablissementID, instant)
+	if err != nil {
+		return nil, err
+	}
+	result.Contexte = *contexte
+
+	applyTariffPriority(result)
+
+	return result, nil
+}
+
+// resolveContext détecte jour férié et période de garde via le calendrier de l'établissement
+func (s *TariffResolverService) resolveContext(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	instant time.Time,
+) (*dto.TariffContext, error) {
+	garde, err := s.calendar.GardeAt(ctx, etablissementID, instant)
+	if err != nil {
+		return nil, err
+	}
+
+	contexte := &dto.TariffContext{
+		PeriodeGarde:    garde.PeriodeGarde,
+		GardeHeureDebut: garde.GardeHeureDebut,
+		GardeHeureFin:   garde.GardeHeureFin,
+	}
+
+	ferie, err := s.calendar.HolidayOn(ctx, etablissementID, instant)
+	if err != nil {
+		return nil, err
+	}
+	if ferie != nil {
+		contexte.JourFerie = &dto.JourFerieInfo{
+			Date:      ferie.Date,
+			Libelle:   ferie.Libelle,
+			TypeFerie: ferie.TypeFerie,
+		}
+	}
+
+	return contexte, nil
+}
+
+// applyTariffPriority choisit le montant selon le contexte et rédige l'explication
+func applyTariffPriority(result *dto.ResolvedTariff) {
+	result.Montant = result.TarifNormal
+	result.Motif = dto.MotifTarifNormal
+	result.Explication = "Tarif normal"
+
+	ferie := result.Contexte.JourFerie
+	garde := result.Contexte.PeriodeGarde
+
+	switch {
+	case ferie != nil && result.TarifFerie != nil:
+		result.Montant = *result.TarifFerie
+		result.Motif = dto.MotifTarifFerie
+		result.Explication = fmt.Sprintf("Tarif jour férié (%s)", ferie.Libelle)
+
+	case garde && result.TarifGarde != nil:
+		result.Montant = *result.TarifGarde
+		result.Motif = dto.MotifTarifGarde
+		result.Explication = fmt.Sprintf("Tarif de garde (%s - %s)",
+			*result.Contexte.GardeHeureDebut, *result.Contexte.GardeHeureFin)
+		if ferie != nil {
+			result.Repli = true
+			result.Explication = fmt.Sprintf("Jour férié (%s) sans tarif férié : tarif de garde (%s - %s)",
+				ferie.Libelle, *result.Contexte.GardeHeureDebut, *result.Contexte.GardeHeureFin)
+		}
+
+	case ferie != nil:
+		result.Repli = true
+		result.Explication = fmt.Sprintf("Jour férié (%s) sans tarif férié : tarif normal", ferie.Libelle)
+
+	case garde:
+		result.Repli = true
+		result.Explication = "Période de garde sans tarif de garde : tarif normal"
+	}
+}
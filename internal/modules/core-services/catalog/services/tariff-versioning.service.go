@@ -0,0 +1,526 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/catalog/dto"
+	"soins-suite-core/internal/modules/core-services/catalog/queries"
+)
+
+// toleranceRetroactivite marge acceptée entre la saisie et l'enregistrement d'un tarif immédiat
+const toleranceRetroactivite = time.Minute
+
+// TariffVersioningService gère les versions de tarif des prestations
+// Chaque changement clôture la version couvrant sa date d'effet, dans la même transaction :
+// les périodes [début, fin[ d'une prestation restent contiguës et disjointes
+type TariffVersioningService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewTariffVersioningService crée une nouvelle instance du service
+func NewTariffVersioningService(db *postgres.Client) *TariffVersioningService {
+	return &TariffVersioningService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// tariffVersion représente les montants d'une nouvelle version
+type tariffVersion struct {
+	tarif  int
+	unite  string
+	garde  *int
+	ferie  *int
+	motif  string
+	change string
+}
+
+// ScheduleTariff enregistre un nouveau tarif, immédiat ou planifié, et clôture la version qu'il remplace
+func (s *TariffVersioningService) ScheduleTariff(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	req *dto.ScheduleTariffRequest,
+	userID uuid.UUID,
+) (*dto.TariffHistoryResponse, error) {
+	dateEffet, err := resolveEffectiveDate(req.DateDebutValidite)
+	if err != nil {
+		return nil, err
+	}
+
+	version := tariffVersion{
+		tarif:  req.TarifUnitaire,
+		unite:  "FCFA",
+		garde:  req.TarifUnitairePeriodeGarde,
+		ferie:  req.TarifUnitaireJourFerie,
+		motif:  req.MotifChangement,
+		change: req.TypeChangement,
+	}
+	if req.UniteTarification != nil && *req.UniteTarification != "" {
+		version.unite = *req.UniteTarification
+	}
+	if version.change == "" {
+		version.change = dto.TypeChangementModification
+	}
+
+	var tarifID uuid.UUID
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		var code, libelle string
+		var estActif bool
+		if err := tx.QueryRow(ctx,
+			queries.TariffVersioningQueries.LockPrestation,
+			prestationID,
+			etablissementID,
+		).Scan(&prestationID, &code, &libelle, &estActif); err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewCatalogError(dto.ErrCataloguePrestationIntrouvable,
+					fmt.Sprintf("Prestation introuvable: %s", prestationID))
+			}
+			return fmt.Errorf("failed to lock prestation: %w", err)
+		}
+		if !estActif {
+			return dto.NewCatalogError(dto.ErrCataloguePrestationInactive,
+				fmt.Sprintf("La prestation %s est retirée du catalogue", code))
+		}
+
+		id, err := s.supersede(ctx, tx, etablissementID, prestationID, dateEffet, version, userID)
+		tarifID = id
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Tarif scheduled - Prestation: %s, Tarif: %s, Montant: %d, Effet: %s, User: %s\n",
+		prestationID, tarifID, req.TarifUnitaire, dateEffet.Format(time.RFC3339), userID)
+
+	return s.GetHistory(ctx, etablissementID, prestationID)
+}
+
+// CancelScheduledTariff annule un changement planifié non encore entré en vigueur
+// La version qu'il devait remplacer retrouve sa date de fin d'origine
+func (s *TariffVersioningService) CancelScheduledTariff(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	tarifID uuid.UUID,
+	userID uuid.UUID,
+) (*dto.TariffHistoryResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		var code, libelle string
+		var estActif bool
+		if err := tx.QueryRow(ctx,
+			queries.TariffVersioningQueries.LockPrestation,
+			prestationID,
+			etablissementID,
+		).Scan(&prestationID, &code, &libelle, &estActif); err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewCatalogError(dto.ErrCataloguePrestationIntrouvable,
+					fmt.Sprintf("Prestation introuvable: %s", prestationID))
+			}
+			return fmt.Errorf("failed to lock prestation: %w", err)
+		}
+
+		var debut time.Time
+		var fin *time.Time
+		if err := tx.QueryRow(ctx,
+			queries.TariffVersioningQueries.GetTarifForCancel,
+			tarifID,
+			prestationID,
+			etablissementID,
+		).Scan(&tarifID, &debut, &fin); err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewCatalogError(dto.ErrTarifIntrouvable,
+					fmt.Sprintf("Tarif introuvable: %s", tarifID))
+			}
+			return fmt.Errorf("failed to get tarif: %w", err)
+		}
+		if !debut.After(time.Now()) {
+			return dto.NewCatalogError(dto.ErrTarifNonAnnulable,
+				"Ce tarif est déjà entré en vigueur : planifier une correction plutôt que l'annuler")
+		}
+
+		if err := tx.Exec(ctx, queries.TariffVersioningQueries.DeleteTarif, tarifID); err != nil {
+			return fmt.Errorf("failed to delete tarif: %w", err)
+		}
+		if err := tx.Exec(ctx,
+			queries.TariffVersioningQueries.ReopenPredecessor,
+			prestationID,
+			debut,
+			fin,
+		); err != nil {
+			return fmt.Errorf("failed to reopen previous tarif: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Scheduled tarif cancelled - Prestation: %s, Tarif: %s, User: %s\n", prestationID, tarifID, userID)
+
+	return s.GetHistory(ctx, etablissementID, prestationID)
+}
+
+// ReviseTariffs applique une révision en pourcentage à toutes les prestations du périmètre
+// Tout ou rien : une seule transaction ; en simulation, aucune écriture
+func (s *TariffVersioningService) ReviseTariffs(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.BulkTariffRevisionRequest,
+	userID uuid.UUID,
+) (*dto.BulkTariffRevisionResult, error) {
+	if req.TypePrestationID == nil && req.ModuleID == nil && len(req.PrestationIDs) == 0 {
+		return nil, dto.NewCatalogError(dto.ErrTarifPerimetreVide,
+			"Préciser un type de prestation, un module ou une liste de prestations à réviser")
+	}
+
+	dateEffet, err := resolveEffectiveDate(req.DateEffet)
+	if err != nil {
+		return nil, err
+	}
+
+	arrondi := req.Arrondi
+	if arrondi <= 0 {
+		arrondi = dto.ArrondiTarifDefaut
+	}
+	inclureSpeciaux := req.InclureTarifsSpeciaux == nil || *req.InclureTarifsSpeciaux
+
+	result := &dto.BulkTariffRevisionResult{
+		Simulation:  req.Simulation,
+		DateEffet:   dateEffet,
+		Pourcentage: req.Pourcentage,
+		Arrondi:     arrondi,
+		Revisees:    []dto.BulkTariffRevisionLine{},
+		Ignorees:    []dto.BulkTariffRevisionSkip{},
+	}
+
+	prestationIDs := req.PrestationIDs
+	if prestationIDs == nil {
+		prestationIDs = []uuid.UUID{}
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		rows, err := tx.Query(ctx,
+			queries.TariffVersioningQueries.LockPrestationsForRevision,
+			etablissementID,
+			req.TypePrestationID,
+			req.ModuleID,
+			prestationIDs,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to lock prestations: %w", err)
+		}
+
+		var perimetre []dto.BulkTariffRevisionSkip
+		for rows.Next() {
+			var p dto.BulkTariffRevisionSkip
+			if err := rows.Scan(&p.PrestationID, &p.CodePrestation, &p.Libelle); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan prestation: %w", err)
+			}
+			perimetre = append(perimetre, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to iterate prestations: %w", err)
+		}
+
+		for _, p := range perimetre {
+			var courantID uuid.UUID
+			var courant tariffVersion
+			var debut time.Time
+			var fin *time.Time
+			err := tx.QueryRow(ctx,
+				queries.TariffResolutionQueries.GetTarifEnVigueur,
+				p.PrestationID,
+				etablissementID,
+				dateEffet,
+			).Scan(&courantID, &courant.tarif, &courant.unite, &courant.garde, &courant.ferie, &debut, &fin)
+			if err == pgx.ErrNoRows {
+				p.Raison = "Aucun tarif en vigueur à la date d'effet"
+				result.Ignorees = append(result.Ignorees, p)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get tarif en vigueur: %w", err)
+			}
+			if debut.Equal(dateEffet) {
+				p.Raison = "Un changement est déjà planifié à cette date"
+				result.Ignorees = append(result.Ignorees, p)
+				continue
+			}
+
+			line := dto.BulkTariffRevisionLine{
+				PrestationID:   p.PrestationID,
+				CodePrestation: p.CodePrestation,
+				Libelle:        p.Libelle,
+				AncienTarif:    courant.tarif,
+				NouveauTarif:   reviseAmount(courant.tarif, req.Pourcentage, arrondi),
+				AncienGarde:    courant.garde,
+				NouveauGarde:   courant.garde,
+				AncienFerie:    courant.ferie,
+				NouveauFerie:   courant.ferie,
+			}
+			if inclureSpeciaux {
+				line.NouveauGarde = reviseOptionalAmount(courant.garde, req.Pourcentage, arrondi)
+				line.NouveauFerie = reviseOptionalAmount(courant.ferie, req.Pourcentage, arrondi)
+			}
+
+			if line.NouveauTarif == line.AncienTarif &&
+				equalOptionalAmount(line.NouveauGarde, line.AncienGarde) &&
+				equalOptionalAmount(line.NouveauFerie, line.AncienFerie) {
+				p.Raison = "Montants inchangés après arrondi"
+				result.Ignorees = append(result.Ignorees, p)
+				continue
+			}
+
+			if !req.Simulation {
+				tarifID, err := s.supersede(ctx, tx, etablissementID, p.PrestationID, dateEffet, tariffVersion{
+					tarif:  line.NouveauTarif,
+					unite:  courant.unite,
+					garde:  line.NouveauGarde,
+					ferie:  line.NouveauFerie,
+					motif:  req.MotifChangement,
+					change: dto.TypeChangementModification,
+				}, userID)
+				if err != nil {
+					return err
+				}
+				line.TarifID = &tarifID
+			}
+
+			result.Revisees = append(result.Revisees, line)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Simulation {
+		fmt.Printf("[AUDIT] Bulk tarif revision - Pourcentage: %.2f, Arrondi: %d, Revisees: %d, Ignorees: %d, Effet: %s, User: %s\n",
+			req.Pourcentage, arrondi, len(result.Revisees), len(result.Ignorees), dateEffet.Format(time.RFC3339), userID)
+	}
+
+	return result, nil
+}
+
+// GetHistory retourne l'historique tarifaire d'une prestation avec statut et variation de chaque version
+func (s *TariffVersioningService) GetHistory(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+) (*dto.TariffHistoryResponse, error) {
+	history := &dto.TariffHistoryResponse{
+		Historique: []dto.TarifEntry{},
+	}
+
+	var estActif bool
+	err := s.db.QueryRow(ctx,
+		queries.TariffResolutionQueries.GetPrestationForTariff,
+		prestationID,
+		etablissementID,
+	).Scan(&history.PrestationID, &history.CodePrestation, &history.Libelle, &estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCatalogError(dto.ErrCataloguePrestationIntrouvable,
+				fmt.Sprintf("Prestation introuvable: %s", prestationID))
+		}
+		return nil, fmt.Errorf("failed to get prestation: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.TariffVersioningQueries.ListTarifHistory,
+		prestationID,
+		etablissementID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tarif history: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	for rows.Next() {
+		var entry dto.TarifEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.TarifUnitaire,
+			&entry.UniteTarification,
+			&entry.TarifUnitairePeriodeGarde,
+			&entry.TarifUnitaireJourFerie,
+			&entry.TypeChangement,
+			&entry.MotifChangement,
+			&entry.DateDebutValidite,
+			&entry.DateFinValidite,
+			&entry.CreatedAt,
+			&entry.CreatedBy,
+			&entry.CreatedByNom,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan tarif: %w", err)
+		}
+
+		switch {
+		case entry.DateDebutValidite.After(now):
+			entry.Statut = dto.StatutTarifPlanifie
+			history.Planifies++
+		case entry.DateFinValidite != nil && !entry.DateFinValidite.After(now):
+			entry.Statut = dto.StatutTarifPasse
+		default:
+			entry.Statut = dto.StatutTarifEnVigueur
+		}
+
+		history.Historique = append(history.Historique, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tarif history: %w", err)
+	}
+
+	// Variation par rapport à la version précédente (liste du plus récent au plus ancien)
+	for i := 0; i+1 < len(history.Historique); i++ {
+		precedent := history.Historique[i+1].TarifUnitaire
+		variation := math.Round(float64(history.Historique[i].TarifUnitaire-precedent)*10000/float64(precedent)) / 100
+		history.Historique[i].VariationPourcentage = &variation
+	}
+	for i := range history.Historique {
+		if history.Historique[i].Statut == dto.StatutTarifEnVigueur {
+			history.TarifEnVigueur = &history.Historique[i]
+			break
+		}
+	}
+
+	return history, nil
+}
+
+// supersede insère une version à dateEffet : la version couvrant cette date est clôturée,
+// et la nouvelle s'arrête au prochain changement déjà planifié (prestation verrouillée par l'appelant)
+func (s *TariffVersioningService) supersede(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	dateEffet time.Time,
+	version tariffVersion,
+	userID uuid.UUID,
+) (uuid.UUID, error) {
+	var dejaPlanifie bool
+	if err := tx.QueryRow(ctx,
+		queries.TariffVersioningQueries.ExistsTarifStartingAt,
+		prestationID,
+		dateEffet,
+	).Scan(&dejaPlanifie); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check scheduled tarifs: %w", err)
+	}
+	if dejaPlanifie {
+		return uuid.Nil, dto.NewCatalogError(dto.ErrTarifDejaPlanifie,
+			fmt.Sprintf("Un changement de tarif est déjà planifié au %s : l'annuler d'abord", dateEffet.Format("02/01/2006 15:04")))
+	}
+
+	var versions int
+	if err := tx.QueryRow(ctx,
+		queries.TariffVersioningQueries.CountTarifs,
+		prestationID,
+	).Scan(&versions); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to count tarifs: %w", err)
+	}
+	if versions == 0 {
+		version.change = dto.TypeChangementCreation
+	}
+
+	var courantID uuid.UUID
+	var courant tariffVersion
+	var debut time.Time
+	var fin *time.Time
+	err := tx.QueryRow(ctx,
+		queries.TariffResolutionQueries.GetTarifEnVigueur,
+		prestationID,
+		etablissementID,
+		dateEffet,
+	).Scan(&courantID, &courant.tarif, &courant.unite, &courant.garde, &courant.ferie, &debut, &fin)
+	if err != nil && err != pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("failed to get tarif en vigueur: %w", err)
+	}
+	if err == nil {
+		if err := tx.Exec(ctx, queries.TariffVersioningQueries.CloseTarif, courantID, dateEffet); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to close current tarif: %w", err)
+		}
+	}
+
+	var prochainDebut *time.Time
+	if err := tx.QueryRow(ctx,
+		queries.TariffVersioningQueries.GetNextTarifStart,
+		prestationID,
+		dateEffet,
+	).Scan(&prochainDebut); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get next scheduled tarif: %w", err)
+	}
+
+	var tarifID uuid.UUID
+	if err := tx.QueryRow(ctx,
+		queries.TariffVersioningQueries.InsertTarif,
+		etablissementID,
+		prestationID,
+		version.tarif,
+		version.unite,
+		version.garde,
+		version.ferie,
+		version.motif,
+		version.change,
+		dateEffet,
+		prochainDebut,
+		userID,
+	).Scan(&tarifID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to insert tarif: %w", err)
+	}
+
+	return tarifID, nil
+}
+
+// resolveEffectiveDate retourne la date d'effet demandée (maintenant par défaut) et refuse la rétroactivité
+func resolveEffectiveDate(requested *time.Time) (time.Time, error) {
+	now := time.Now()
+	if requested == nil {
+		return now.Truncate(time.Second), nil
+	}
+	if requested.Before(now.Add(-toleranceRetroactivite)) {
+		return time.Time{}, dto.NewCatalogError(dto.ErrTarifDateRetroactive,
+			"La date d'effet ne peut pas être antérieure à maintenant : les montants déjà facturés sont figés")
+	}
+	return requested.Truncate(time.Second), nil
+}
+
+// reviseAmount applique un pourcentage puis arrondit au multiple le plus proche (jamais sous l'arrondi)
+func reviseAmount(montant int, pourcentage float64, arrondi int) int {
+	revise := float64(montant) * (100 + pourcentage) / 100
+	arrondiMontant := int(math.Round(revise/float64(arrondi))) * arrondi
+	if arrondiMontant < arrondi {
+		return arrondi
+	}
+	return arrondiMontant
+}
+
+// reviseOptionalAmount révise un tarif spécial s'il est défini
+func reviseOptionalAmount(montant *int, pourcentage float64, arrondi int) *int {
+	if montant == nil {
+		return nil
+	}
+	revise := reviseAmount(*montant, pourcentage, arrondi)
+	return &revise
+}
+
+// equalOptionalAmount compare deux tarifs spéciaux éventuellement absents
+func equalOptionalAmount(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
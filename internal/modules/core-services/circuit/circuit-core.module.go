@@ -0,0 +1,14 @@
+package circuit
+
+import (
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/modules/core-services/circuit/services"
+)
+
+// Module regroupe les services métier des circuits patients (SANS endpoints)
+// Core Service : orientation du patient entre modules réutilisable par l'accueil, les tickets et les services cliniques
+var Module = fx.Options(
+	fx.Provide(services.NewCircuitResolverService),  // CS-CIR-001: Circuit effectif d'une prestation et étapes suivantes
+	fx.Provide(services.NewCircuitAuthoringService), // CS-CIR-002: Conception validée, remplacement et clôture des circuits
+)
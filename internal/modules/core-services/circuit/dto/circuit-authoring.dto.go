@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuts d'un circuit par rapport à l'instant de consultation
+const (
+	StatutCircuitPlanifie = "planifie"
+	StatutCircuitActif    = "actif"
+	StatutCircuitClos     = "clos"
+)
+
+// CircuitSousCheminInput représente une suite possible après la fin d'un parcours
+// Les modules sont saisis en texte pour signaler précisément chaque identifiant invalide
+type CircuitSousCheminInput struct {
+	Nom            string   `json:"nom" validate:"required,min=2,max=255"`
+	Description    *string  `json:"description"`
+	OrdreAffichage int      `json:"ordre_affichage" validate:"min=0"`
+	Suite          []string `json:"suite" validate:"required,min=1,dive,required"`
+}
+
+// CircuitParcoursInput représente un parcours ordonné de modules et ses sous-chemins
+type CircuitParcoursInput struct {
+	Nom            string                   `json:"nom" validate:"required,min=2,max=255"`
+	Description    *string                  `json:"description"`
+	OrdreAffichage int                      `json:"ordre_affichage" validate:"min=0"`
+	Chemin         []string                 `json:"chemin" validate:"required,min=1,dive,required"`
+	SousChemins    []CircuitSousCheminInput `json:"sous_chemins" validate:"dive"`
+}
+
+// CircuitDefinition représente le contenu d'un circuit (création ou remplacement)
+type CircuitDefinition struct {
+	NomCircuit        string                 `json:"nom_circuit" validate:"required,min=2,max=255"`
+	Description       *string                `json:"description"`
+	ModuleEntreeID    uuid.UUID              `json:"module_entree_id" validate:"required"`
+	DateDebutActivite *time.Time             `json:"date_debut_activite"` // Maintenant par défaut
+	Parcours          []CircuitParcoursInput `json:"parcours" validate:"required,min=1,dive"`
+}
+
+// CreateCircuitRequest représente un nouveau circuit et sa cible dans la hiérarchie
+// defaut : aucune cible ; type_prestation : type_prestation_id ; type_module : type_prestation_module_id
+type CreateCircuitRequest struct {
+	CircuitDefinition
+	TypeApplication        string     `json:"type_application" validate:"required,oneof=defaut type_prestation type_module"`
+	TypePrestationID       *uuid.UUID `json:"type_prestation_id"`
+	TypePrestationModuleID *uuid.UUID `json:"type_prestation_module_id"`
+}
+
+// CircuitFilter représente les filtres de la liste des circuits
+type CircuitFilter struct {
+	TypeApplication string `form:"type_application" validate:"omitempty,oneof=defaut type_prestation type_module"`
+	InclureClos     bool   `form:"inclure_clos"`
+}
+
+// CircuitResponse représente un circuit (parcours détaillés en consultation unitaire)
+type CircuitResponse struct {
+	ID                     uuid.UUID         `json:"id"`
+	NomCircuit             string            `json:"nom_circuit"`
+	Description            *string           `json:"description,omitempty"`
+	TypeApplication        string            `json:"type_application"`
+	TypePrestationID       *uuid.UUID        `json:"type_prestation_id,omitempty"`
+	TypePrestationModuleID *uuid.UUID        `json:"type_prestation_module_id,omitempty"`
+	Cible                  *string           `json:"cible,omitempty"` // Libellé du type ou « type › module »
+	ModuleEntree           CircuitModule     `json:"module_entree"`
+	EstActif               bool              `json:"est_actif"`
+	Statut                 string            `json:"statut"` // planifie, actif, clos
+	DateDebutActivite      time.Time         `json:"date_debut_activite"`
+	DateFinActivite        *time.Time        `json:"date_fin_activite,omitempty"`
+	CreatedAt              time.Time         `json:"created_at"`
+	Parcours               []CircuitParcours `json:"parcours,omitempty"`
+}
+
+// Erreurs de conception des circuits
+const (
+	ErrCircuitIntrouvable       = "CIRCUIT_INTROUVABLE"
+	ErrCircuitStructureInvalide = "CIRCUIT_STRUCTURE_INVALIDE"
+	ErrCircuitCibleInvalide     = "CIRCUIT_CIBLE_INVALIDE"
+	ErrCircuitActifExistant     = "CIRCUIT_ACTIF_EXISTANT"
+	ErrCircuitClos              = "CIRCUIT_CLOS"
+	ErrCircuitDateRetroactive   = "CIRCUIT_DATE_RETROACTIVE"
+)
@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Niveaux d'application d'un circuit, du plus spécifique (1) au plus général (3)
+const (
+	TypeApplicationModule     = "type_module"
+	TypeApplicationPrestation = "type_prestation"
+	TypeApplicationDefaut     = "defaut"
+)
+
+// ResolveCircuitRequest représente la position du patient dans son circuit (aucune : début du circuit)
+// Les identifiants sont lus en texte et convertis par le service appelant
+type ResolveCircuitRequest struct {
+	Instant       *time.Time `form:"instant" time_format:"2006-01-02T15:04:05"`
+	ModuleCourant string     `form:"module_courant"` // Module où se trouve le patient
+	ParcoursID    string     `form:"parcours_id"`    // Parcours suivi, si déjà engagé
+	SousCheminID  string     `form:"sous_chemin_id"` // Sous-chemin suivi, si déjà engagé
+}
+
+// CircuitPosition représente la position du patient utilisée pour calculer les étapes suivantes
+type CircuitPosition struct {
+	ModuleCourantID *uuid.UUID `json:"module_courant_id,omitempty"`
+	ParcoursID      *uuid.UUID `json:"parcours_id,omitempty"`
+	SousCheminID    *uuid.UUID `json:"sous_chemin_id,omitempty"`
+}
+
+// CircuitModule représente un module référencé par un circuit
+// Un module supprimé ou désactivé depuis la conception du circuit est signalé (est_actif = false)
+type CircuitModule struct {
+	ID                uuid.UUID `json:"id"`
+	CodeModule        string    `json:"code_module"`
+	Nom               string    `json:"nom"`
+	PeutPrendreTicket bool      `json:"peut_prendre_ticket"`
+	EstActif          bool      `json:"est_actif"`
+}
+
+// CircuitSousChemin représente la suite possible à partir de la fin d'un parcours
+type CircuitSousChemin struct {
+	ID             uuid.UUID       `json:"id"`
+	Nom            string          `json:"nom"`
+	Description    *string         `json:"description,omitempty"`
+	OrdreAffichage int             `json:"ordre_affichage"`
+	Suite          []CircuitModule `json:"suite"`
+}
+
+// CircuitParcours représente un parcours ordonné du circuit et ses sous-chemins
+type CircuitParcours struct {
+	ID             uuid.UUID           `json:"id"`
+	Nom            string              `json:"nom"`
+	Description    *string             `json:"description,omitempty"`
+	OrdreAffichage int                 `json:"ordre_affichage"`
+	Chemin         []CircuitModule     `json:"chemin"`
+	SousChemins    []CircuitSousChemin `json:"sous_chemins"`
+}
+
+// CircuitNextStep représente un module où le patient peut être orienté ensuite
+type CircuitNextStep struct {
+	Module        CircuitModule `json:"module"`
+	ParcoursID    *uuid.UUID    `json:"parcours_id,omitempty"` // Absent pour le module d'entrée
+	NomParcours   *string       `json:"nom_parcours,omitempty"`
+	SousCheminID  *uuid.UUID    `json:"sous_chemin_id,omitempty"`
+	NomSousChemin *string       `json:"nom_sous_chemin,omitempty"`
+	Rang          int           `json:"rang"` // Position dans le chemin ou la suite (0 = première étape)
+}
+
+// ResolvedCircuit représente le circuit effectif d'une prestation et les étapes suivantes
+// Niveau : 1 = type_module, 2 = type_prestation, 3 = defaut ; le plus spécifique actif l'emporte
+type ResolvedCircuit struct {
+	PrestationID   uuid.UUID `json:"prestation_id"`
+	CodePrestation string    `json:"code_prestation"`
+	Libelle        string    `json:"libelle"`
+	Instant        time.Time `json:"instant"`
+
+	CircuitID         uuid.UUID  `json:"circuit_id"`
+	NomCircuit        string     `json:"nom_circuit"`
+	Description       *string    `json:"description,omitempty"`
+	TypeApplication   string     `json:"type_application"`
+	Niveau            int        `json:"niveau"`
+	DateDebutActivite time.Time  `json:"date_debut_activite"`
+	DateFinActivite   *time.Time `json:"date_fin_activite,omitempty"`
+
+	ModuleEntree CircuitModule     `json:"module_entree"`
+	Parcours     []CircuitParcours `json:"parcours"`
+
+	Position         CircuitPosition   `json:"position"`
+	ProchainesEtapes []CircuitNextStep `json:"prochaines_etapes"`
+	FinDeCircuit     bool              `json:"fin_de_circuit"`
+}
+
+// CircuitError représente un refus métier sur les circuits patients
+// Anomalies détaille chaque défaut d'une structure refusée
+type CircuitError struct {
+	Code      string   `json:"code"`
+	Message   string   `json:"message"`
+	Anomalies []string `json:"anomalies,omitempty"`
+}
+
+// Constantes pour les erreurs des circuits
+const (
+	ErrCircuitPrestationIntrouvable = "CIRCUIT_PRESTATION_INTROUVABLE"
+	ErrCircuitPrestationInactive    = "CIRCUIT_PRESTATION_INACTIVE"
+	ErrCircuitAucunEnVigueur        = "CIRCUIT_AUCUN_EN_VIGUEUR"
+	ErrCircuitParcoursIntrouvable   = "CIRCUIT_PARCOURS_INTROUVABLE"
+	ErrCircuitPositionInconnue      = "CIRCUIT_POSITION_INCONNUE"
+	ErrCircuitCheminInvalide        = "CIRCUIT_CHEMIN_INVALIDE"
+)
+
+// NewCircuitError crée une nouvelle erreur de circuit
+func NewCircuitError(code, message string) *CircuitError {
+	return &CircuitError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// NewCircuitStructureError crée le refus d'une structure de circuit et la liste de ses anomalies
+func NewCircuitStructureError(anomalies []string) *CircuitError {
+	return &CircuitError{
+		Code:      ErrCircuitStructureInvalide,
+		Message:   fmt.Sprintf("Circuit invalide : %d anomalie(s) à corriger", len(anomalies)),
+		Anomalies: anomalies,
+	}
+}
+
+// Error implémente l'interface error
+func (e *CircuitError) Error() string {
+	return e.Message
+}
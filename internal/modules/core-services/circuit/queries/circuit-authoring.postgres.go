@@ -0,0 +1,185 @@
+package queries
+
+// CircuitAuthoringQueries contient les requêtes SQL de conception des circuits patients
+var CircuitAuthoringQueries = struct {
+	ListCircuits                  string
+	GetCircuit                    string
+	LockCircuit                   string
+	GetTypePrestationTarget       string
+	GetTypePrestationModuleTarget string
+	ListModulesForValidation      string
+	InsertCircuit                 string
+	InsertParcours                string
+	InsertSousChemin              string
+	CloseCircuit                  string
+	CancelCircuit                 string
+}{
+	// ListCircuits - Circuits de l'établissement (type optionnel, clos sur demande)
+	ListCircuits: `
+		SELECT
+			c.id,
+			c.nom_circuit,
+			c.description,
+			c.type_application,
+			c.type_prestation_id,
+			c.type_prestation_module_id,
+			COALESCE(tp.libelle, tpm_tp.libelle || ' › ' || COALESCE(m_tpm.nom_personnalise, m_tpm.nom_standard)),
+			me.id,
+			me.code_module,
+			COALESCE(me.nom_personnalise, me.nom_standard),
+			COALESCE(me.peut_prendre_ticket, FALSE),
+			COALESCE(me.est_actif, TRUE),
+			COALESCE(c.est_actif, TRUE),
+			c.date_debut_activite,
+			c.date_fin_activite,
+			c.created_at
+		FROM base_circuit_patient c
+		JOIN base_module me ON me.id = c.module_entree_id
+		LEFT JOIN base_type_prestation tp ON tp.id = c.type_prestation_id
+		LEFT JOIN base_type_prestation_module tpm ON tpm.id = c.type_prestation_module_id
+		LEFT JOIN base_type_prestation tpm_tp ON tpm_tp.id = tpm.type_prestation_id
+		LEFT JOIN base_module m_tpm ON m_tpm.id = tpm.module_id
+		WHERE c.etablissement_id = $1
+		AND ($2::text = '' OR c.type_application = $2)
+		AND ($3::boolean OR (COALESCE(c.est_actif, TRUE) = TRUE AND (c.date_fin_activite IS NULL OR c.date_fin_activite > NOW())))
+		ORDER BY
+			CASE c.type_application
+				WHEN 'defaut' THEN 1
+				WHEN 'type_prestation' THEN 2
+				ELSE 3
+			END,
+			c.nom_circuit,
+			c.date_debut_activite DESC;
+	`,
+
+	// GetCircuit - Circuit de l'établissement
+	GetCircuit: `
+		SELECT
+			c.id,
+			c.nom_circuit,
+			c.description,
+			c.type_application,
+			c.type_prestation_id,
+			c.type_prestation_module_id,
+			COALESCE(tp.libelle, tpm_tp.libelle || ' › ' || COALESCE(m_tpm.nom_personnalise, m_tpm.nom_standard)),
+			me.id,
+			me.code_module,
+			COALESCE(me.nom_personnalise, me.nom_standard),
+			COALESCE(me.peut_prendre_ticket, FALSE),
+			COALESCE(me.est_actif, TRUE),
+			COALESCE(c.est_actif, TRUE),
+			c.date_debut_activite,
+			c.date_fin_activite,
+			c.created_at
+		FROM base_circuit_patient c
+		JOIN base_module me ON me.id = c.module_entree_id
+		LEFT JOIN base_type_prestation tp ON tp.id = c.type_prestation_id
+		LEFT JOIN base_type_prestation_module tpm ON tpm.id = c.type_prestation_module_id
+		LEFT JOIN base_type_prestation tpm_tp ON tpm_tp.id = tpm.type_prestation_id
+		LEFT JOIN base_module m_tpm ON m_tpm.id = tpm.module_id
+		WHERE c.id = $1
+		AND c.etablissement_id = $2;
+	`,
+
+	// LockCircuit - Circuit à remplacer ou clôturer (verrou jusqu'à la fin de la transaction)
+	LockCircuit: `
+		SELECT
+			type_application,
+			type_prestation_id,
+			type_prestation_module_id,
+			COALESCE(est_actif, TRUE),
+			date_debut_activite,
+			date_fin_activite
+		FROM base_circuit_patient
+		WHERE id = $1
+		AND etablissement_id = $2
+		FOR UPDATE;
+	`,
+
+	// GetTypePrestationTarget - Type de prestation ciblé par un circuit
+	GetTypePrestationTarget: `
+		SELECT libelle, COALESCE(est_actif, TRUE)
+		FROM base_type_prestation
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// GetTypePrestationModuleTarget - Rattachement type › module ciblé (actif si le type l'est aussi)
+	GetTypePrestationModuleTarget: `
+		SELECT
+			tp.libelle || ' › ' || COALESCE(m.nom_personnalise, m.nom_standard),
+			COALESCE(tpm.est_actif, TRUE) AND COALESCE(tp.est_actif, TRUE)
+		FROM base_type_prestation_module tpm
+		JOIN base_type_prestation tp ON tp.id = tpm.type_prestation_id
+		JOIN base_module m ON m.id = tpm.module_id
+		WHERE tpm.id = $1
+		AND tpm.etablissement_id = $2;
+	`,
+
+	// ListModulesForValidation - Modules référencés et leur présence dans la licence active
+	// Format modules_autorises: {"modules": [{"id": "uuid", "code": "CODE_MODULE"}, ...]}
+	ListModulesForValidation: `
+		SELECT
+			m.id,
+			m.code_module,
+			COALESCE(m.est_actif, TRUE),
+			COALESCE(m.est_module_back_office, FALSE),
+			COALESCE(m.peut_prendre_ticket, FALSE),
+			EXISTS (
+				SELECT 1
+				FROM base_licence l
+				CROSS JOIN LATERAL jsonb_array_elements(COALESCE(l.modules_autorises->'modules', '[]'::jsonb)) am
+				WHERE l.etablissement_id = $2
+				AND l.statut = 'actif'
+				AND (l.date_expiration IS NULL OR l.date_expiration > NOW())
+				AND am->>'id' = m.id::text
+			)
+		FROM base_module m
+		WHERE m.id = ANY($1::uuid[]);
+	`,
+
+	// InsertCircuit - Nouveau circuit (UQ_circuit_*_actif : un seul circuit ouvert par cible)
+	InsertCircuit: `
+		INSERT INTO base_circuit_patient (
+			etablissement_id, nom_circuit, description, type_application,
+			type_prestation_id, type_prestation_module_id, module_entree_id,
+			date_debut_activite, created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		) RETURNING id;
+	`,
+
+	// InsertParcours - Parcours d'un circuit (chemin JSONB ["uuid1", "uuid2"])
+	InsertParcours: `
+		INSERT INTO base_circuit_patient_parcours (
+			etablissement_id, circuit_id, nom_parcours, description, chemin_principal, ordre_affichage
+		) VALUES (
+			$1, $2, $3, $4, $5::jsonb, $6
+		) RETURNING id;
+	`,
+
+	// InsertSousChemin - Sous-chemin d'un parcours (suite JSONB ["uuid4", "uuid5"])
+	InsertSousChemin: `
+		INSERT INTO base_circuit_patient_sous_chemin (
+			etablissement_id, parcours_id, nom_sous_chemin, description, suite_chemin, ordre_affichage
+		) VALUES (
+			$1, $2, $3, $4, $5::jsonb, $6
+		);
+	`,
+
+	// CloseCircuit - Fin d'activité d'un circuit (le circuit reste consultable et résolu avant cette date)
+	CloseCircuit: `
+		UPDATE base_circuit_patient SET
+			date_fin_activite = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// CancelCircuit - Annulation d'un circuit planifié (jamais entré en activité)
+	CancelCircuit: `
+		UPDATE base_circuit_patient SET
+			est_actif = FALSE
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+}
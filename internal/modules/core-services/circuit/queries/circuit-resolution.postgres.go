@@ -0,0 +1,98 @@
+package queries
+
+// CircuitResolutionQueries contient les requêtes SQL de résolution des circuits patients
+var CircuitResolutionQueries = struct {
+	GetPrestationForCircuit string
+	GetEffectiveCircuit     string
+	ListActiveParcours      string
+	ListActiveSousChemins   string
+	ListModulesByIDs        string
+}{
+	// GetPrestationForCircuit - Prestation et rattachements servant à choisir le circuit
+	GetPrestationForCircuit: `
+		SELECT
+			id,
+			code_prestation,
+			libelle,
+			est_actif,
+			type_prestation_id,
+			type_prestation_module_id
+		FROM base_prestation_medicale
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// GetEffectiveCircuit - Circuit actif à l'instant, le niveau le plus spécifique l'emporte
+	// type_module (1) < type_prestation (2) < defaut (3) ; à niveau égal, la période la plus récente
+	GetEffectiveCircuit: `
+		SELECT
+			id,
+			nom_circuit,
+			description,
+			type_application,
+			module_entree_id,
+			date_debut_activite,
+			date_fin_activite
+		FROM base_circuit_patient
+		WHERE etablissement_id = $1
+		AND est_actif = TRUE
+		AND date_debut_activite <= $4
+		AND (date_fin_activite IS NULL OR date_fin_activite > $4)
+		AND (
+			type_application = 'defaut'
+			OR (type_application = 'type_prestation' AND type_prestation_id = $2)
+			OR (type_application = 'type_module' AND type_prestation_module_id = $3)
+		)
+		ORDER BY
+			CASE type_application
+				WHEN 'type_module' THEN 1
+				WHEN 'type_prestation' THEN 2
+				ELSE 3
+			END,
+			date_debut_activite DESC
+		LIMIT 1;
+	`,
+
+	// ListActiveParcours - Parcours actifs du circuit (chemin JSONB brut)
+	ListActiveParcours: `
+		SELECT
+			id,
+			nom_parcours,
+			description,
+			chemin_principal,
+			COALESCE(ordre_affichage, 0)
+		FROM base_circuit_patient_parcours
+		WHERE circuit_id = $1
+		AND etablissement_id = $2
+		AND COALESCE(est_actif, TRUE) = TRUE
+		ORDER BY ordre_affichage, nom_parcours;
+	`,
+
+	// ListActiveSousChemins - Sous-chemins actifs d'un ensemble de parcours
+	ListActiveSousChemins: `
+		SELECT
+			id,
+			parcours_id,
+			nom_sous_chemin,
+			description,
+			suite_chemin,
+			COALESCE(ordre_affichage, 0)
+		FROM base_circuit_patient_sous_chemin
+		WHERE parcours_id = ANY($1::uuid[])
+		AND etablissement_id = $2
+		AND COALESCE(est_actif, TRUE) = TRUE
+		ORDER BY ordre_affichage, nom_sous_chemin;
+	`,
+
+	// ListModulesByIDs - Modules référencés par le circuit
+	ListModulesByIDs: `
+		SELECT
+			id,
+			code_module,
+			COALESCE(nom_personnalise, nom_standard),
+			COALESCE(peut_prendre_ticket, FALSE),
+			COALESCE(est_actif, TRUE)
+		FROM base_module
+		WHERE id = ANY($1::uuid[]);
+	`,
+}
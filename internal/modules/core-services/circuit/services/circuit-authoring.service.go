@@ -0,0 +1,594 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/circuit/dto"
+	"soins-suite-core/internal/modules/core-services/circuit/queries"
+)
+
+// toleranceRetroactivite marge acceptée entre la saisie et l'enregistrement d'un circuit immédiat
+const toleranceRetroactivite = time.Minute
+
+// CircuitAuthoringService gère la conception des circuits patients d'un établissement
+// Le schéma ne garantit que des chemins non vides : chaque module référencé est validé ici
+// (existant, actif, front-office, licencié) et le module d'entrée doit émettre des tickets
+// Un circuit n'est jamais modifié en place : il est remplacé (clôture et ouverture atomiques)
+type CircuitAuthoringService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewCircuitAuthoringService crée une nouvelle instance du service
+func NewCircuitAuthoringService(db *postgres.Client) *CircuitAuthoringService {
+	return &CircuitAuthoringService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// circuitTarget représente la cible d'un circuit dans la hiérarchie d'application
+type circuitTarget struct {
+	typeApplication        string
+	typePrestationID       *uuid.UUID
+	typePrestationModuleID *uuid.UUID
+}
+
+// validatedParcours représente un parcours saisi dont les identifiants ont été convertis
+type validatedParcours struct {
+	input       dto.CircuitParcoursInput
+	chemin      []uuid.UUID
+	sousChemins []validatedSousChemin
+}
+
+// validatedSousChemin représente un sous-chemin saisi dont les identifiants ont été convertis
+type validatedSousChemin struct {
+	input dto.CircuitSousCheminInput
+	suite []uuid.UUID
+}
+
+// moduleValidation représente un module référencé et son éligibilité pour l'établissement
+type moduleValidation struct {
+	code              string
+	estActif          bool
+	estBackOffice     bool
+	peutPrendreTicket bool
+	licencie          bool
+}
+
+// ListCircuits retourne les circuits de l'établissement (sans le détail des parcours)
+func (s *CircuitAuthoringService) ListCircuits(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.CircuitFilter,
+) ([]dto.CircuitResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.CircuitAuthoringQueries.ListCircuits,
+		etablissementID,
+		filter.TypeApplication,
+		filter.InclureClos,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list circuits: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	circuits := []dto.CircuitResponse{}
+	for rows.Next() {
+		circuit, err := scanCircuit(rows, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan circuit: %w", err)
+		}
+		circuits = append(circuits, *circuit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate circuits: %w", err)
+	}
+
+	return circuits, nil
+}
+
+// GetCircuit retourne un circuit et ses parcours actifs
+func (s *CircuitAuthoringService) GetCircuit(ctx context.Context, etablissementID, circuitID uuid.UUID) (*dto.CircuitResponse, error) {
+	circuit, err := scanCircuit(s.db.QueryRow(ctx, queries.CircuitAuthoringQueries.GetCircuit, circuitID, etablissementID), time.Now())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCircuitError(dto.ErrCircuitIntrouvable,
+				fmt.Sprintf("Circuit introuvable: %s", circuitID))
+		}
+		return nil, fmt.Errorf("failed to get circuit: %w", err)
+	}
+
+	parcours, err := loadCircuitParcours(ctx, s.db, etablissementID, circuitID)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := loadCircuitModules(ctx, s.db, circuit.ModuleEntree.ID, parcours)
+	if err != nil {
+		return nil, err
+	}
+	circuit.Parcours = resolveParcours(parcours, modules)
+
+	return circuit, nil
+}
+
+// CreateCircuit crée un circuit pour une cible sans circuit ouvert
+// Une cible déjà pourvue se remplace via ReplaceCircuit
+func (s *CircuitAuthoringService) CreateCircuit(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateCircuitRequest,
+	userID uuid.UUID,
+) (*dto.CircuitResponse, error) {
+	target := circuitTarget{
+		typeApplication:        req.TypeApplication,
+		typePrestationID:       req.TypePrestationID,
+		typePrestationModuleID: req.TypePrestationModuleID,
+	}
+	if err := s.checkTarget(ctx, etablissementID, target); err != nil {
+		return nil, err
+	}
+
+	debut, err := resolveStartDate(req.DateDebutActivite)
+	if err != nil {
+		return nil, err
+	}
+
+	parcours, err := s.validateDefinition(ctx, etablissementID, &req.CircuitDefinition)
+	if err != nil {
+		return nil, err
+	}
+
+	var circuitID uuid.UUID
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		circuitID, err = insertCircuit(ctx, tx, etablissementID, target, &req.CircuitDefinition, debut, parcours, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Circuit created - ID: %s, Type: %s, User: %s\n", circuitID, target.typeApplication, userID)
+
+	return s.GetCircuit(ctx, etablissementID, circuitID)
+}
+
+// ReplaceCircuit clôture un circuit ouvert à la date de début du nouveau et crée celui-ci pour la même cible
+// Les deux écritures sont atomiques : la cible n'est jamais sans circuit ni pourvue de deux circuits ouverts
+func (s *CircuitAuthoringService) ReplaceCircuit(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	circuitID uuid.UUID,
+	req *dto.CircuitDefinition,
+	userID uuid.UUID,
+) (*dto.CircuitResponse, error) {
+	debut, err := resolveStartDate(req.DateDebutActivite)
+	if err != nil {
+		return nil, err
+	}
+
+	parcours, err := s.validateDefinition(ctx, etablissementID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var nouveauID uuid.UUID
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		target, ancienDebut, err := lockOpenCircuit(ctx, tx, etablissementID, circuitID)
+		if err != nil {
+			return err
+		}
+		if !debut.After(ancienDebut) {
+			return dto.NewCircuitError(dto.ErrCircuitDateRetroactive,
+				fmt.Sprintf("Le nouveau circuit doit débuter après le circuit remplacé (%s)", ancienDebut.Format("02/01/2006 15:04")))
+		}
+
+		if err := tx.Exec(ctx, queries.CircuitAuthoringQueries.CloseCircuit, circuitID, etablissementID, debut); err != nil {
+			return fmt.Errorf("failed to close circuit: %w", err)
+		}
+
+		nouveauID, err = insertCircuit(ctx, tx, etablissementID, target, req, debut, parcours, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Circuit replaced - Ancien: %s, Nouveau: %s, Effet: %s, User: %s\n",
+		circuitID, nouveauID, debut.Format(time.RFC3339), userID)
+
+	return s.GetCircuit(ctx, etablissementID, nouveauID)
+}
+
+// CloseCircuit met fin à un circuit ouvert (annulation s'il n'est pas encore entré en activité)
+// La cible retombe sur le niveau hiérarchique supérieur (type de prestation, puis défaut)
+func (s *CircuitAuthoringService) CloseCircuit(ctx context.Context, etablissementID, circuitID, userID uuid.UUID) (*dto.CircuitResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		_, debut, err := lockOpenCircuit(ctx, tx, etablissementID, circuitID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().Truncate(time.Second)
+		if debut.After(now) {
+			if err := tx.Exec(ctx, queries.CircuitAuthoringQueries.CancelCircuit, circuitID, etablissementID); err != nil {
+				return fmt.Errorf("failed to cancel circuit: %w", err)
+			}
+			return nil
+		}
+
+		if err := tx.Exec(ctx, queries.CircuitAuthoringQueries.CloseCircuit, circuitID, etablissementID, now); err != nil {
+			return fmt.Errorf("failed to close circuit: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Circuit closed - ID: %s, User: %s\n", circuitID, userID)
+
+	return s.GetCircuit(ctx, etablissementID, circuitID)
+}
+
+// checkTarget vérifie la cohérence du type d'application et l'existence de sa cible active
+func (s *CircuitAuthoringService) checkTarget(ctx context.Context, etablissementID uuid.UUID, target circuitTarget) error {
+	var query string
+	var cibleID *uuid.UUID
+	switch target.typeApplication {
+	case dto.TypeApplicationDefaut:
+		if target.typePrestationID != nil || target.typePrestationModuleID != nil {
+			return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+				"Un circuit par défaut ne cible ni type de prestation ni rattachement type › module")
+		}
+		return nil
+	case dto.TypeApplicationPrestation:
+		if target.typePrestationID == nil || target.typePrestationModuleID != nil {
+			return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+				"Un circuit type_prestation cible uniquement un type_prestation_id")
+		}
+		query, cibleID = queries.CircuitAuthoringQueries.GetTypePrestationTarget, target.typePrestationID
+	case dto.TypeApplicationModule:
+		if target.typePrestationModuleID == nil || target.typePrestationID != nil {
+			return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+				"Un circuit type_module cible uniquement un type_prestation_module_id")
+		}
+		query, cibleID = queries.CircuitAuthoringQueries.GetTypePrestationModuleTarget, target.typePrestationModuleID
+	default:
+		return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+			fmt.Sprintf("Type d'application inconnu: %s", target.typeApplication))
+	}
+
+	var libelle string
+	var estActif bool
+	if err := s.db.QueryRow(ctx, query, *cibleID, etablissementID).Scan(&libelle, &estActif); err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+				fmt.Sprintf("Cible du circuit introuvable: %s", *cibleID))
+		}
+		return fmt.Errorf("failed to get circuit target: %w", err)
+	}
+	if !estActif {
+		return dto.NewCircuitError(dto.ErrCircuitCibleInvalide,
+			fmt.Sprintf("La cible '%s' est inactive", libelle))
+	}
+
+	return nil
+}
+
+// validateDefinition contrôle la structure d'un circuit et retourne ses parcours convertis
+// Toutes les anomalies sont collectées pour être corrigées en une fois
+func (s *CircuitAuthoringService) validateDefinition(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	def *dto.CircuitDefinition,
+) ([]validatedParcours, error) {
+	anomalies := []string{}
+	references := []uuid.UUID{def.ModuleEntreeID}
+
+	parcours := make([]validatedParcours, 0, len(def.Parcours))
+	nomsParcours := map[string]bool{}
+	for _, p := range def.Parcours {
+		libelle := fmt.Sprintf("Parcours « %s »", p.Nom)
+		if nomsParcours[strings.ToLower(strings.TrimSpace(p.Nom))] {
+			anomalies = append(anomalies, fmt.Sprintf("%s : nom déjà utilisé dans ce circuit", libelle))
+		}
+		nomsParcours[strings.ToLower(strings.TrimSpace(p.Nom))] = true
+
+		valide := validatedParcours{input: p}
+		valide.chemin = parseModuleInputs(p.Chemin, libelle+", chemin principal", &anomalies)
+		references = append(references, valide.chemin...)
+
+		dansChemin := map[uuid.UUID]bool{}
+		for _, id := range valide.chemin {
+			dansChemin[id] = true
+		}
+
+		nomsSousChemins := map[string]bool{}
+		for _, sc := range p.SousChemins {
+			libelleSC := fmt.Sprintf("%s, sous-chemin « %s »", libelle, sc.Nom)
+			if nomsSousChemins[strings.ToLower(strings.TrimSpace(sc.Nom))] {
+				anomalies = append(anomalies, fmt.Sprintf("%s : nom déjà utilisé dans ce parcours", libelleSC))
+			}
+			nomsSousChemins[strings.ToLower(strings.TrimSpace(sc.Nom))] = true
+
+			suite := parseModuleInputs(sc.Suite, libelleSC, &anomalies)
+			// Un sous-chemin prolonge le parcours après son dernier module : il n'y revient pas
+			for rang, id := range suite {
+				if dansChemin[id] {
+					anomalies = append(anomalies, fmt.Sprintf(
+						"%s, étape %d : le module %s figure déjà dans le chemin principal (un sous-chemin continue après la fin du parcours)",
+						libelleSC, rang+1, id))
+				}
+			}
+			references = append(references, suite...)
+			valide.sousChemins = append(valide.sousChemins, validatedSousChemin{input: sc, suite: suite})
+		}
+
+		parcours = append(parcours, valide)
+	}
+
+	modules, err := s.loadModulesForValidation(ctx, etablissementID, references)
+	if err != nil {
+		return nil, err
+	}
+
+	if module, ok := modules[def.ModuleEntreeID]; ok && !module.peutPrendreTicket {
+		anomalies = append(anomalies, fmt.Sprintf("Module d'entrée %s : n'émet pas de tickets (peut_prendre_ticket)", module.code))
+	}
+
+	controles := map[uuid.UUID]bool{}
+	for _, id := range references {
+		if controles[id] {
+			continue
+		}
+		controles[id] = true
+
+		module, ok := modules[id]
+		switch {
+		case !ok:
+			anomalies = append(anomalies, fmt.Sprintf("Module %s : introuvable", id))
+		case !module.estActif:
+			anomalies = append(anomalies, fmt.Sprintf("Module %s : inactif", module.code))
+		case module.estBackOffice:
+			anomalies = append(anomalies, fmt.Sprintf("Module %s : module back-office, hors parcours patient", module.code))
+		case !module.licencie:
+			anomalies = append(anomalies, fmt.Sprintf("Module %s : non couvert par la licence de l'établissement", module.code))
+		}
+	}
+
+	if len(anomalies) > 0 {
+		return nil, dto.NewCircuitStructureError(anomalies)
+	}
+
+	return parcours, nil
+}
+
+// loadModulesForValidation charge les modules référencés et leur présence dans la licence active
+func (s *CircuitAuthoringService) loadModulesForValidation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	ids []uuid.UUID,
+) (map[uuid.UUID]moduleValidation, error) {
+	rows, err := s.db.Query(ctx, queries.CircuitAuthoringQueries.ListModulesForValidation, ids, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list modules for validation: %w", err)
+	}
+	defer rows.Close()
+
+	modules := map[uuid.UUID]moduleValidation{}
+	for rows.Next() {
+		var id uuid.UUID
+		var module moduleValidation
+		if err := rows.Scan(
+			&id,
+			&module.code,
+			&module.estActif,
+			&module.estBackOffice,
+			&module.peutPrendreTicket,
+			&module.licencie,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan module: %w", err)
+		}
+		modules[id] = module
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate modules: %w", err)
+	}
+
+	return modules, nil
+}
+
+// lockOpenCircuit verrouille un circuit ouvert et retourne sa cible et sa date de début
+func lockOpenCircuit(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	circuitID uuid.UUID,
+) (circuitTarget, time.Time, error) {
+	var target circuitTarget
+	var estActif bool
+	var debut time.Time
+	var fin *time.Time
+	err := tx.QueryRow(ctx,
+		queries.CircuitAuthoringQueries.LockCircuit,
+		circuitID,
+		etablissementID,
+	).Scan(
+		&target.typeApplication,
+		&target.typePrestationID,
+		&target.typePrestationModuleID,
+		&estActif,
+		&debut,
+		&fin,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return target, debut, dto.NewCircuitError(dto.ErrCircuitIntrouvable,
+				fmt.Sprintf("Circuit introuvable: %s", circuitID))
+		}
+		return target, debut, fmt.Errorf("failed to lock circuit: %w", err)
+	}
+	if !estActif || fin != nil {
+		return target, debut, dto.NewCircuitError(dto.ErrCircuitClos,
+			"Ce circuit est déjà clos ou remplacé : seul le circuit ouvert de la cible peut évoluer")
+	}
+
+	return target, debut, nil
+}
+
+// insertCircuit écrit un circuit, ses parcours et leurs sous-chemins dans la transaction
+func insertCircuit(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	target circuitTarget,
+	def *dto.CircuitDefinition,
+	debut time.Time,
+	parcours []validatedParcours,
+	userID uuid.UUID,
+) (uuid.UUID, error) {
+	var circuitID uuid.UUID
+	err := tx.QueryRow(ctx,
+		queries.CircuitAuthoringQueries.InsertCircuit,
+		etablissementID,
+		strings.TrimSpace(def.NomCircuit),
+		def.Description,
+		target.typeApplication,
+		target.typePrestationID,
+		target.typePrestationModuleID,
+		def.ModuleEntreeID,
+		debut,
+		userID,
+	).Scan(&circuitID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return uuid.Nil, dto.NewCircuitError(dto.ErrCircuitActifExistant,
+				"Un circuit est déjà ouvert pour cette cible : le remplacer plutôt que d'en créer un second")
+		}
+		return uuid.Nil, fmt.Errorf("failed to insert circuit: %w", err)
+	}
+
+	for _, p := range parcours {
+		chemin, err := json.Marshal(p.chemin)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to encode chemin: %w", err)
+		}
+
+		var parcoursID uuid.UUID
+		if err := tx.QueryRow(ctx,
+			queries.CircuitAuthoringQueries.InsertParcours,
+			etablissementID,
+			circuitID,
+			strings.TrimSpace(p.input.Nom),
+			p.input.Description,
+			string(chemin),
+			p.input.OrdreAffichage,
+		).Scan(&parcoursID); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to insert parcours: %w", err)
+		}
+
+		for _, sc := range p.sousChemins {
+			suite, err := json.Marshal(sc.suite)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("failed to encode suite: %w", err)
+			}
+			if err := tx.Exec(ctx,
+				queries.CircuitAuthoringQueries.InsertSousChemin,
+				etablissementID,
+				parcoursID,
+				strings.TrimSpace(sc.input.Nom),
+				sc.input.Description,
+				string(suite),
+				sc.input.OrdreAffichage,
+			); err != nil {
+				return uuid.Nil, fmt.Errorf("failed to insert sous-chemin: %w", err)
+			}
+		}
+	}
+
+	return circuitID, nil
+}
+
+// parseModuleInputs convertit les identifiants saisis d'un chemin, en signalant invalides et doublons
+// Un module figure au plus une fois par chemin : la position du patient reste ainsi sans ambiguïté
+func parseModuleInputs(values []string, libelle string, anomalies *[]string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	vus := map[uuid.UUID]bool{}
+	for rang, value := range values {
+		id, err := uuid.Parse(strings.TrimSpace(value))
+		if err != nil {
+			*anomalies = append(*anomalies, fmt.Sprintf("%s, étape %d : identifiant de module invalide '%s'", libelle, rang+1, value))
+			continue
+		}
+		if vus[id] {
+			*anomalies = append(*anomalies, fmt.Sprintf("%s, étape %d : module %s déjà présent dans ce chemin", libelle, rang+1, id))
+			continue
+		}
+		vus[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// resolveStartDate retourne la date de début demandée (maintenant par défaut) et refuse la rétroactivité
+func resolveStartDate(requested *time.Time) (time.Time, error) {
+	now := time.Now()
+	if requested == nil {
+		return now.Truncate(time.Second), nil
+	}
+	if requested.Before(now.Add(-toleranceRetroactivite)) {
+		return time.Time{}, dto.NewCircuitError(dto.ErrCircuitDateRetroactive,
+			"La date de début ne peut pas être antérieure à maintenant : les patients déjà orientés gardent leur circuit")
+	}
+	return requested.Truncate(time.Second), nil
+}
+
+// scanCircuit lit une ligne de circuit et calcule son statut à l'instant donné
+func scanCircuit(row pgx.Row, now time.Time) (*dto.CircuitResponse, error) {
+	var circuit dto.CircuitResponse
+	if err := row.Scan(
+		&circuit.ID,
+		&circuit.NomCircuit,
+		&circuit.Description,
+		&circuit.TypeApplication,
+		&circuit.TypePrestationID,
+		&circuit.TypePrestationModuleID,
+		&circuit.Cible,
+		&circuit.ModuleEntree.ID,
+		&circuit.ModuleEntree.CodeModule,
+		&circuit.ModuleEntree.Nom,
+		&circuit.ModuleEntree.PeutPrendreTicket,
+		&circuit.ModuleEntree.EstActif,
+		&circuit.EstActif,
+		&circuit.DateDebutActivite,
+		&circuit.DateFinActivite,
+		&circuit.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !circuit.EstActif || (circuit.DateFinActivite != nil && !circuit.DateFinActivite.After(now)):
+		circuit.Statut = dto.StatutCircuitClos
+	case circuit.DateDebutActivite.After(now):
+		circuit.Statut = dto.StatutCircuitPlanifie
+	default:
+		circuit.Statut = dto.StatutCircuitActif
+	}
+
+	return &circuit, nil
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/circuit/dto"
+	"soins-suite-core/internal/modules/core-services/circuit/queries"
+)
+
+// niveauxApplication rang hiérarchique de chaque type d'application (1 = le plus spécifique)
+var niveauxApplication = map[string]int{
+	dto.TypeApplicationModule:     1,
+	dto.TypeApplicationPrestation: 2,
+	dto.TypeApplicationDefaut:     3,
+}
+
+// CircuitResolverService détermine le circuit patient applicable à une prestation
+// et les modules où orienter le patient depuis sa position courante
+// Base commune de l'accueil, des tickets et de l'orientation entre services
+type CircuitResolverService struct {
+	db *postgres.Client
+}
+
+// NewCircuitResolverService crée une nouvelle instance du service
+func NewCircuitResolverService(db *postgres.Client) *CircuitResolverService {
+	return &CircuitResolverService{
+		db: db,
+	}
+}
+
+// parcoursBrut représente un parcours lu en base avant résolution des modules
+type parcoursBrut struct {
+	dto.CircuitParcours
+	chemin      []uuid.UUID
+	sousChemins []sousCheminBrut
+}
+
+// sousCheminBrut représente un sous-chemin lu en base avant résolution des modules
+type sousCheminBrut struct {
+	dto.CircuitSousChemin
+	suite []uuid.UUID
+}
+
+// ResolveCircuit retourne le circuit effectif d'une prestation à un instant, ses parcours ordonnés
+// et les étapes possibles depuis la position du patient (module d'entrée si aucune position)
+func (s *CircuitResolverService) ResolveCircuit(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	prestationID uuid.UUID,
+	instant time.Time,
+	position dto.CircuitPosition,
+) (*dto.ResolvedCircuit, error) {
+	result := &dto.ResolvedCircuit{
+		PrestationID: prestationID,
+		Instant:      instant,
+		Position:     position,
+	}
+
+	var estActif *bool
+	var typePrestationID, typePrestationModuleID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.CircuitResolutionQueries.GetPrestationForCircuit,
+		prestationID,
+		etablissementID,
+	).Scan(
+		&result.PrestationID,
+		&result.CodePrestation,
+		&result.Libelle,
+		&estActif,
+		&typePrestationID,
+		&typePrestationModuleID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCircuitError(dto.ErrCircuitPrestationIntrouvable,
+				fmt.Sprintf("Prestation introuvable: %s", prestationID))
+		}
+		return nil, fmt.Errorf("failed to get prestation: %w", err)
+	}
+	if estActif != nil && !*estActif {
+		return nil, dto.NewCircuitError(dto.ErrCircuitPrestationInactive,
+			fmt.Sprintf("La prestation %s est retirée du catalogue", result.CodePrestation))
+	}
+
+	var moduleEntreeID uuid.UUID
+	err = s.db.QueryRow(ctx,
+		queries.CircuitResolutionQueries.GetEffectiveCircuit,
+		etablissementID,
+		typePrestationID,
+		typePrestationModuleID,
+		instant,
+	).Scan(
+		&result.CircuitID,
+		&result.NomCircuit,
+		&result.Description,
+		&result.TypeApplication,
+		&moduleEntreeID,
+		&result.DateDebutActivite,
+		&result.DateFinActivite,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewCircuitError(dto.ErrCircuitAucunEnVigueur,
+				fmt.Sprintf("Aucun circuit actif pour la prestation %s au %s (ni circuit par défaut)",
+					result.CodePrestation, instant.Format("02/01/2006 15:04")))
+		}
+		return nil, fmt.Errorf("failed to get effective circuit: %w", err)
+	}
+	result.Niveau = niveauxApplication[result.TypeApplication]
+
+	parcours, err := loadCircuitParcours(ctx, s.db, etablissementID, result.CircuitID)
+	if err != nil {
+		return nil, err
+	}
+
+	modules, err := loadCircuitModules(ctx, s.db, moduleEntreeID, parcours)
+	if err != nil {
+		return nil, err
+	}
+
+	result.ModuleEntree = modules(moduleEntreeID)
+	result.Parcours = resolveParcours(parcours, modules)
+
+	steps, err := nextSteps(result, position)
+	if err != nil {
+		return nil, err
+	}
+	result.ProchainesEtapes = steps
+	result.FinDeCircuit = len(steps) == 0
+
+	return result, nil
+}
+
+// loadCircuitParcours charge les parcours actifs d'un circuit et leurs sous-chemins actifs
+func loadCircuitParcours(ctx context.Context, db *postgres.Client, etablissementID, circuitID uuid.UUID) ([]*parcoursBrut, error) {
+	rows, err := db.Query(ctx, queries.CircuitResolutionQueries.ListActiveParcours, circuitID, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list parcours: %w", err)
+	}
+	defer rows.Close()
+
+	parcours := []*parcoursBrut{}
+	index := map[uuid.UUID]*parcoursBrut{}
+	for rows.Next() {
+		var p parcoursBrut
+		var chemin []byte
+		if err := rows.Scan(&p.ID, &p.Nom, &p.Description, &chemin, &p.OrdreAffichage); err != nil {
+			return nil, fmt.Errorf("failed to scan parcours: %w", err)
+		}
+		if p.chemin, err = parseModuleArray(chemin); err != nil {
+			return nil, dto.NewCircuitError(dto.ErrCircuitCheminInvalide,
+				fmt.Sprintf("Parcours %s : chemin principal invalide (%s)", p.Nom, err.Error()))
+		}
+		parcours = append(parcours, &p)
+		index[p.ID] = &p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate parcours: %w", err)
+	}
+	if len(parcours) == 0 {
+		return parcours, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(parcours))
+	for _, p := range parcours {
+		ids = append(ids, p.ID)
+	}
+
+	scRows, err := db.Query(ctx, queries.CircuitResolutionQueries.ListActiveSousChemins, ids, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sous-chemins: %w", err)
+	}
+	defer scRows.Close()
+
+	for scRows.Next() {
+		var sc sousCheminBrut
+		var parcoursID uuid.UUID
+		var suite []byte
+		if err := scRows.Scan(&sc.ID, &parcoursID, &sc.Nom, &sc.Description, &suite, &sc.OrdreAffichage); err != nil {
+			return nil, fmt.Errorf("failed to scan sous-chemin: %w", err)
+		}
+		if sc.suite, err = parseModuleArray(suite); err != nil {
+			return nil, dto.NewCircuitError(dto.ErrCircuitCheminInvalide,
+				fmt.Sprintf("Sous-chemin %s : suite invalide (%s)", sc.Nom, err.Error()))
+		}
+		if p, ok := index[parcoursID]; ok {
+			p.sousChemins = append(p.sousChemins, sc)
+		}
+	}
+	if err := scRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sous-chemins: %w", err)
+	}
+
+	return parcours, nil
+}
+
+// loadCircuitModules charge en une requête tous les modules référencés par un circuit
+// Le résolveur retourné signale un module absent de base_module comme inactif
+func loadCircuitModules(
+	ctx context.Context,
+	db *postgres.Client,
+	moduleEntreeID uuid.UUID,
+	parcours []*parcoursBrut,
+) (func(uuid.UUID) dto.CircuitModule, error) {
+	ids := []uuid.UUID{moduleEntreeID}
+	for _, p := range parcours {
+		ids = append(ids, p.chemin...)
+		for _, sc := range p.sousChemins {
+			ids = append(ids, sc.suite...)
+		}
+	}
+
+	rows, err := db.Query(ctx, queries.CircuitResolutionQueries.ListModulesByIDs, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list circuit modules: %w", err)
+	}
+	defer rows.Close()
+
+	modules := map[uuid.UUID]dto.CircuitModule{}
+	for rows.Next() {
+		var module dto.CircuitModule
+		if err := rows.Scan(&module.ID, &module.CodeModule, &module.Nom, &module.PeutPrendreTicket, &module.EstActif); err != nil {
+			return nil, fmt.Errorf("failed to scan circuit module: %w", err)
+		}
+		modules[module.ID] = module
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate circuit modules: %w", err)
+	}
+
+	return func(id uuid.UUID) dto.CircuitModule {
+		if module, ok := modules[id]; ok {
+			return module
+		}
+		return dto.CircuitModule{ID: id}
+	}, nil
+}
+
+// resolveParcours remplace les identifiants des chemins et suites par les modules correspondants
+func resolveParcours(parcours []*parcoursBrut, modules func(uuid.UUID) dto.CircuitModule) []dto.CircuitParcours {
+	result := make([]dto.CircuitParcours, 0, len(parcours))
+	for _, p := range parcours {
+		resolu := p.CircuitParcours
+		resolu.Chemin = mapModules(p.chemin, modules)
+		resolu.SousChemins = make([]dto.CircuitSousChemin, 0, len(p.sousChemins))
+		for _, sc := range p.sousChemins {
+			sousChemin := sc.CircuitSousChemin
+			sousChemin.Suite = mapModules(sc.suite, modules)
+			resolu.SousChemins = append(resolu.SousChemins, sousChemin)
+		}
+		result = append(result, resolu)
+	}
+	return result
+}
+
+// nextSteps calcule les modules suivants depuis la position du patient
+// Dans un chemin principal, l'étape suivante est le module qui suit ; en fin de chemin, le premier
+// module de chaque sous-chemin ; le module d'entrée, s'il ne figure pas dans le chemin, mène à son début
+func nextSteps(circuit *dto.ResolvedCircuit, position dto.CircuitPosition) ([]dto.CircuitNextStep, error) {
+	steps := []dto.CircuitNextStep{}
+	if position.ModuleCourantID == nil {
+		return append(steps, dto.CircuitNextStep{Module: circuit.ModuleEntree}), nil
+	}
+	courant := *position.ModuleCourantID
+
+	parcoursTrouve, sousCheminTrouve, positionTrouvee := false, false, false
+	for i := range circuit.Parcours {
+		p := &circuit.Parcours[i]
+		if position.ParcoursID != nil && *position.ParcoursID != p.ID {
+			continue
+		}
+		parcoursTrouve = true
+
+		if position.SousCheminID == nil {
+			rang := indexOfModule(p.Chemin, courant)
+			switch {
+			case rang < 0 && courant == circuit.ModuleEntree.ID:
+				positionTrouvee = true
+				steps = append(steps, parcoursStep(p, nil, 0))
+			case rang >= 0 && rang < len(p.Chemin)-1:
+				positionTrouvee = true
+				steps = append(steps, parcoursStep(p, nil, rang+1))
+			case rang >= 0:
+				positionTrouvee = true
+				for j := range p.SousChemins {
+					steps = append(steps, parcoursStep(p, &p.SousChemins[j], 0))
+				}
+			}
+		}
+
+		for j := range p.SousChemins {
+			sc := &p.SousChemins[j]
+			if position.SousCheminID != nil && *position.SousCheminID != sc.ID {
+				continue
+			}
+			sousCheminTrouve = true
+			if rang := indexOfModule(sc.Suite, courant); rang >= 0 {
+				positionTrouvee = true
+				if rang < len(sc.Suite)-1 {
+					steps = append(steps, parcoursStep(p, sc, rang+1))
+				}
+			}
+		}
+	}
+
+	if (position.ParcoursID != nil && !parcoursTrouve) || (position.SousCheminID != nil && !sousCheminTrouve) {
+		return nil, dto.NewCircuitError(dto.ErrCircuitParcoursIntrouvable,
+			fmt.Sprintf("Parcours ou sous-chemin absent du circuit %s", circuit.NomCircuit))
+	}
+	if !positionTrouvee {
+		return nil, dto.NewCircuitError(dto.ErrCircuitPositionInconnue,
+			fmt.Sprintf("Le module %s ne figure pas dans le circuit %s", courant, circuit.NomCircuit))
+	}
+
+	return steps, nil
+}
+
+// parcoursStep construit l'étape de rang donné d'un chemin principal ou d'un sous-chemin
+func parcoursStep(p *dto.CircuitParcours, sc *dto.CircuitSousChemin, rang int) dto.CircuitNextStep {
+	step := dto.CircuitNextStep{
+		ParcoursID:  &p.ID,
+		NomParcours: &p.Nom,
+		Rang:        rang,
+	}
+	if sc == nil {
+		step.Module = p.Chemin[rang]
+		return step
+	}
+	step.Module = sc.Suite[rang]
+	step.SousCheminID = &sc.ID
+	step.NomSousChemin = &sc.Nom
+	return step
+}
+
+// indexOfModule retourne le rang du module dans un chemin (-1 s'il n'y figure pas)
+func indexOfModule(chemin []dto.CircuitModule, moduleID uuid.UUID) int {
+	for i, module := range chemin {
+		if module.ID == moduleID {
+			return i
+		}
+	}
+	return -1
+}
+
+// mapModules résout une liste d'identifiants en modules
+func mapModules(ids []uuid.UUID, modules func(uuid.UUID) dto.CircuitModule) []dto.CircuitModule {
+	result := make([]dto.CircuitModule, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, modules(id))
+	}
+	return result
+}
+
+// parseModuleArray lit un tableau JSONB d'UUID de modules ["uuid1", "uuid2"]
+func parseModuleArray(raw []byte) ([]uuid.UUID, error) {
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, fmt.Errorf("tableau d'identifiants attendu")
+	}
+
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("identifiant de module invalide: %s", value)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
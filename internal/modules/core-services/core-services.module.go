@@ -3,7 +3,12 @@ package core_services
 import (
 	"go.uber.org/fx"
 
+	"soins-suite-core/internal/modules/core-services/calendar"
+	"soins-suite-core/internal/modules/core-services/catalog"
+	"soins-suite-core/internal/modules/core-services/circuit"
 	"soins-suite-core/internal/modules/core-services/establishment"
+	"soins-suite-core/internal/modules/core-services/infrastructure"
+	"soins-suite-core/internal/modules/core-services/insurance"
 	"soins-suite-core/internal/modules/core-services/patient"
 )
 
@@ -16,6 +21,21 @@ var Module = fx.Options(
 	// Establishment Core Services (Création, validation, etc.)
 	establishment.Module,
 
+	// Calendar Core Services (Horaires d'ouverture, jours fériés, garde)
+	calendar.Module,
+
+	// Catalog Core Services (Prestations, tarification)
+	catalog.Module,
+
+	// Circuit Core Services (Circuits patients, orientation entre modules)
+	circuit.Module,
+
+	// Insurance Core Services (Organismes payeurs, conventions, prise en charge)
+	insurance.Module,
+
+	// Infrastructure Core Services (Bâtiments, étages, chambres, lits, occupation)
+	infrastructure.Module,
+
 	// TODO: Autres domaines Core Services à ajouter selon besoins
 	// user.Module,          // Services utilisateur centralisés
 )
\ No newline at end of file
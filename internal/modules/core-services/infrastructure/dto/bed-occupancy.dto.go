@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Motifs de fin d'occupation d'un lit (CK_patients_occupation_lit_motif_fin)
+const (
+	MotifFinSortie     = "sortie"
+	MotifFinTransfert  = "transfert" // Réservé au transfert de lit
+	MotifFinDeces      = "deces"
+	MotifFinAnnulation = "annulation"
+)
+
+// TypeLitNonPrecise regroupe au tableau d'occupation les lits sans type renseigné
+const TypeLitNonPrecise = "non_precise"
+
+// AssignBedRequest représente l'attribution d'un lit libre à un patient
+type AssignBedRequest struct {
+	CodePatient     string  `json:"code_patient" validate:"required,min=1,max=40"`
+	ReferenceSejour *string `json:"reference_sejour" validate:"omitempty,max=50"` // Numéro de séjour ou d'admission
+	Commentaire     *string `json:"commentaire" validate:"omitempty,max=1000"`
+}
+
+// ReleaseBedRequest représente la libération d'un lit (le transfert a sa propre opération)
+type ReleaseBedRequest struct {
+	Motif       string  `json:"motif" validate:"required,oneof=sortie deces annulation"`
+	Commentaire *string `json:"commentaire" validate:"omitempty,max=1000"`
+}
+
+// TransferBedRequest représente le déplacement de l'occupant d'un lit vers un autre lit libre
+type TransferBedRequest struct {
+	LitDestinationID uuid.UUID `json:"lit_destination_id" validate:"required"`
+	Commentaire      *string   `json:"commentaire" validate:"omitempty,max=1000"`
+}
+
+// AvailableBedFilter représente les filtres de recherche de lits libres
+type AvailableBedFilter struct {
+	BatimentID         string `form:"batiment_id" validate:"omitempty,uuid"`
+	CategorieChambreID string `form:"categorie_chambre_id" validate:"omitempty,uuid"`
+	TypeLit            string `form:"type_lit" validate:"omitempty,oneof=standard medicalise reanimation"`
+}
+
+// OccupancyBoardFilter représente le périmètre du tableau d'occupation
+type OccupancyBoardFilter struct {
+	BatimentID string `form:"batiment_id" validate:"omitempty,uuid"`
+}
+
+// BedLocation représente un lit et sa localisation complète
+type BedLocation struct {
+	LitID         uuid.UUID         `json:"lit_id"`
+	NumeroLit     string            `json:"numero_lit"`
+	CodeLit       *string           `json:"code_lit,omitempty"`
+	TypeLit       *string           `json:"type_lit,omitempty"`
+	ChambreID     uuid.UUID         `json:"chambre_id"`
+	NumeroChambre string            `json:"numero_chambre"`
+	NiveauEtage   *int              `json:"niveau_etage,omitempty"`
+	Batiment      InfrastructureRef `json:"batiment"`
+	Categorie     InfrastructureRef `json:"categorie"`
+}
+
+// AvailableBed représente un lit libre et le tarif journalier de sa chambre
+type AvailableBed struct {
+	BedLocation
+	Tarif int `json:"tarif"`
+}
+
+// OccupantRef représente le patient occupant un lit
+type OccupantRef struct {
+	ID          uuid.UUID `json:"id"`
+	CodePatient string    `json:"code_patient"`
+	Nom         string    `json:"nom"`
+	Prenoms     string    `json:"prenoms"`
+}
+
+// BedOccupancyResponse représente une occupation de lit (en cours si fin_le est absent)
+type BedOccupancyResponse struct {
+	ID                     uuid.UUID   `json:"id"`
+	Lit                    BedLocation `json:"lit"`
+	Patient                OccupantRef `json:"patient"`
+	ReferenceSejour        *string     `json:"reference_sejour,omitempty"`
+	OccupationPrecedenteID *uuid.UUID  `json:"occupation_precedente_id,omitempty"`
+	DebutLe                time.Time   `json:"debut_le"`
+	FinLe                  *time.Time  `json:"fin_le,omitempty"`
+	MotifFin               *string     `json:"motif_fin,omitempty"`
+	Commentaire            *string     `json:"commentaire,omitempty"`
+	CommentaireFin         *string     `json:"commentaire_fin,omitempty"`
+	AttribuePar            uuid.UUID   `json:"attribue_par"`
+	LiberePar              *uuid.UUID  `json:"libere_par,omitempty"`
+}
+
+// OccupancyCounts représente la capacité et l'occupation d'un périmètre
+type OccupancyCounts struct {
+	Capacite       int     `json:"capacite"` // Lits actifs des chambres, bâtiments et catégories actifs
+	Occupes        int     `json:"occupes"`
+	Libres         int     `json:"libres"`
+	TauxOccupation float64 `json:"taux_occupation"` // Pourcentage arrondi au dixième
+}
+
+// OccupancyBoardLine représente l'occupation d'une catégorie, d'un bâtiment ou d'un type de lit
+type OccupancyBoardLine struct {
+	ID      *uuid.UUID `json:"id,omitempty"`
+	Code    *string    `json:"code,omitempty"`
+	Libelle string     `json:"libelle"`
+	OccupancyCounts
+}
+
+// OccupancyBoard représente le tableau d'occupation des lits à l'instant de la consultation
+type OccupancyBoard struct {
+	GenereLe     time.Time            `json:"genere_le"`
+	Total        OccupancyCounts      `json:"total"`
+	ParCategorie []OccupancyBoardLine `json:"par_categorie"`
+	ParBatiment  []OccupancyBoardLine `json:"par_batiment"`
+	ParTypeLit   []OccupancyBoardLine `json:"par_type_lit"`
+}
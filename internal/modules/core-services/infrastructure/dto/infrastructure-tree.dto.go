@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// TreeFilter représente les filtres de l'arborescence bâtiment › étage › chambre › lit
+type TreeFilter struct {
+	BatimentID      string `form:"batiment_id" validate:"omitempty,uuid"`
+	InclureInactifs bool   `form:"inclure_inactifs"`
+}
+
+// TreeBed représente un lit dans l'arborescence
+type TreeBed struct {
+	ID         uuid.UUID `json:"id"`
+	NumeroLit  string    `json:"numero_lit"`
+	CodeLit    *string   `json:"code_lit,omitempty"`
+	TypeLit    *string   `json:"type_lit,omitempty"`
+	EstOccupee bool      `json:"est_occupee"`
+	EstActif   bool      `json:"est_actif"`
+}
+
+// TreeRoom représente une chambre dans l'arborescence
+type TreeRoom struct {
+	ID            uuid.UUID         `json:"id"`
+	NumeroChambre string            `json:"numero_chambre"`
+	NomChambre    *string           `json:"nom_chambre,omitempty"`
+	Categorie     InfrastructureRef `json:"categorie"`
+	TypeEspace    *string           `json:"type_espace,omitempty"`
+	EstOccupee    bool              `json:"est_occupee"`
+	EstActif      bool              `json:"est_actif"`
+	Capacite      int               `json:"capacite"` // Lits actifs
+	LitsOccupes   int               `json:"lits_occupes"`
+	Lits          []TreeBed         `json:"lits"`
+}
+
+// TreeFloor représente un étage (niveau absent : chambres sans étage renseigné)
+type TreeFloor struct {
+	NiveauEtage *int       `json:"niveau_etage"`
+	Libelle     string     `json:"libelle"`
+	Capacite    int        `json:"capacite"`
+	LitsOccupes int        `json:"lits_occupes"`
+	Chambres    []TreeRoom `json:"chambres"`
+}
+
+// TreeBuilding représente un bâtiment et tous ses étages, même vides
+type TreeBuilding struct {
+	ID           uuid.UUID   `json:"id"`
+	CodeBatiment *string     `json:"code_batiment,omitempty"`
+	NomBatiment  string      `json:"nom_batiment"`
+	NombreEtages int         `json:"nombre_etages"`
+	EstActif     bool        `json:"est_actif"`
+	Capacite     int         `json:"capacite"`
+	LitsOccupes  int         `json:"lits_occupes"`
+	Etages       []TreeFloor `json:"etages"`
+}
@@ -0,0 +1,202 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types d'espace d'une chambre (CK_base_chambre_type_espace)
+const (
+	TypeEspaceConsultation    = "consultation"
+	TypeEspaceAdministration  = "administration"
+	TypeEspaceTechnique       = "technique"
+	TypeEspaceHospitalisation = "hospitalisation"
+	TypeEspaceBlocOperatoire  = "bloc_operatoire"
+)
+
+// Types de lit (CK_base_lit_type_lit)
+const (
+	TypeLitStandard    = "standard"
+	TypeLitMedicalise  = "medicalise"
+	TypeLitReanimation = "reanimation"
+)
+
+// CreateBuildingRequest représente la création d'un bâtiment (étages numérotés de 0, rez-de-chaussée, à nombre_etages - 1)
+type CreateBuildingRequest struct {
+	CodeBatiment string  `json:"code_batiment" validate:"required,min=1,max=50"`
+	NomBatiment  string  `json:"nom_batiment" validate:"required,min=2,max=255"`
+	Description  *string `json:"description"`
+	NombreEtages int     `json:"nombre_etages" validate:"required,min=1,max=200"`
+}
+
+// UpdateBuildingRequest représente la modification partielle d'un bâtiment (le code est immuable)
+// Le nombre d'étages ne peut pas descendre sous le plus haut étage occupé par une chambre
+type UpdateBuildingRequest struct {
+	NomBatiment  *string `json:"nom_batiment" validate:"omitempty,min=2,max=255"`
+	Description  *string `json:"description"`
+	NombreEtages *int    `json:"nombre_etages" validate:"omitempty,min=1,max=200"`
+}
+
+// CreateRoomCategoryRequest représente la création d'une catégorie de chambre et de son tarif
+type CreateRoomCategoryRequest struct {
+	CodeCategorie string  `json:"code_categorie" validate:"required,min=1,max=50"`
+	NomCategorie  string  `json:"nom_categorie" validate:"required,min=2,max=255"`
+	Description   *string `json:"description"`
+	Tarif         int     `json:"tarif" validate:"required,gt=0"`
+}
+
+// UpdateRoomCategoryRequest représente la modification partielle d'une catégorie (le code est immuable)
+type UpdateRoomCategoryRequest struct {
+	NomCategorie *string `json:"nom_categorie" validate:"omitempty,min=2,max=255"`
+	Description  *string `json:"description"`
+	Tarif        *int    `json:"tarif" validate:"omitempty,gt=0"`
+}
+
+// CreateRoomRequest représente la création d'une chambre sur un étage de son bâtiment
+type CreateRoomRequest struct {
+	BatimentID         uuid.UUID `json:"batiment_id" validate:"required"`
+	CategorieChambreID uuid.UUID `json:"categorie_chambre_id" validate:"required"`
+	NumeroChambre      string    `json:"numero_chambre" validate:"required,min=1,max=50"`
+	NomChambre         *string   `json:"nom_chambre" validate:"omitempty,max=255"`
+	NiveauEtage        int       `json:"niveau_etage" validate:"min=0"` // 0 = rez-de-chaussée
+	TypeEspace         string    `json:"type_espace" validate:"omitempty,oneof=consultation administration technique hospitalisation bloc_operatoire"`
+	CodeEspace         *string   `json:"code_espace" validate:"omitempty,max=50"`
+	TarifSpecial       *int      `json:"tarif_special" validate:"omitempty,gt=0"` // Tarif de la catégorie par défaut
+}
+
+// UpdateRoomRequest représente la modification partielle d'une chambre (bâtiment et numéro immuables)
+type UpdateRoomRequest struct {
+	CategorieChambreID *uuid.UUID `json:"categorie_chambre_id"`
+	NomChambre         *string    `json:"nom_chambre" validate:"omitempty,max=255"`
+	NiveauEtage        *int       `json:"niveau_etage" validate:"omitempty,min=0"`
+	TypeEspace         *string    `json:"type_espace" validate:"omitempty,oneof=consultation administration technique hospitalisation bloc_operatoire"`
+	CodeEspace         *string    `json:"code_espace" validate:"omitempty,max=50"`
+	TarifSpecial       *int       `json:"tarif_special" validate:"omitempty,gt=0"`
+}
+
+// CreateBedRequest représente l'ajout d'un lit dans une chambre (code unique dans l'établissement)
+type CreateBedRequest struct {
+	NumeroLit string `json:"numero_lit" validate:"required,min=1,max=50"`
+	CodeLit   string `json:"code_lit" validate:"required,min=1,max=50"`
+	TypeLit   string `json:"type_lit" validate:"omitempty,oneof=standard medicalise reanimation"` // standard par défaut
+}
+
+// UpdateBedRequest représente la modification partielle d'un lit
+type UpdateBedRequest struct {
+	NumeroLit *string `json:"numero_lit" validate:"omitempty,min=1,max=50"`
+	CodeLit   *string `json:"code_lit" validate:"omitempty,min=1,max=50"`
+	TypeLit   *string `json:"type_lit" validate:"omitempty,oneof=standard medicalise reanimation"`
+}
+
+// InfrastructureFilter représente les filtres des listes d'infrastructure
+type InfrastructureFilter struct {
+	BatimentID         string `form:"batiment_id" validate:"omitempty,uuid"`
+	CategorieChambreID string `form:"categorie_chambre_id" validate:"omitempty,uuid"`
+	InclureInactifs    bool   `form:"inclure_inactifs"`
+}
+
+// InfrastructureRef représente un élément d'infrastructure résumé
+type InfrastructureRef struct {
+	ID   uuid.UUID `json:"id"`
+	Code *string   `json:"code,omitempty"`
+	Nom  string    `json:"nom"`
+}
+
+// BuildingResponse représente un bâtiment et sa capacité
+type BuildingResponse struct {
+	ID             uuid.UUID `json:"id"`
+	CodeBatiment   *string   `json:"code_batiment,omitempty"`
+	NomBatiment    string    `json:"nom_batiment"`
+	Description    *string   `json:"description,omitempty"`
+	NombreEtages   int       `json:"nombre_etages"`
+	EstActif       bool      `json:"est_actif"`
+	NombreChambres int       `json:"nombre_chambres"` // Chambres actives
+	NombreLits     int       `json:"nombre_lits"`     // Lits actifs
+	LitsOccupes    int       `json:"lits_occupes"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// RoomCategoryResponse représente une catégorie de chambre
+type RoomCategoryResponse struct {
+	ID             uuid.UUID `json:"id"`
+	CodeCategorie  string    `json:"code_categorie"`
+	NomCategorie   string    `json:"nom_categorie"`
+	Description    *string   `json:"description,omitempty"`
+	Tarif          int       `json:"tarif"`
+	EstActif       bool      `json:"est_actif"`
+	NombreChambres int       `json:"nombre_chambres"` // Chambres actives
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// BedResponse représente un lit
+type BedResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ChambreID  uuid.UUID `json:"chambre_id"`
+	NumeroLit  string    `json:"numero_lit"`
+	CodeLit    *string   `json:"code_lit,omitempty"`
+	TypeLit    *string   `json:"type_lit,omitempty"`
+	EstOccupee bool      `json:"est_occupee"`
+	EstActif   bool      `json:"est_actif"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RoomResponse représente une chambre, sa localisation et ses lits
+type RoomResponse struct {
+	ID            uuid.UUID         `json:"id"`
+	Batiment      InfrastructureRef `json:"batiment"`
+	Categorie     InfrastructureRef `json:"categorie"`
+	NumeroChambre string            `json:"numero_chambre"`
+	NomChambre    *string           `json:"nom_chambre,omitempty"`
+	NiveauEtage   *int              `json:"niveau_etage,omitempty"`
+	TypeEspace    *string           `json:"type_espace,omitempty"`
+	CodeEspace    *string           `json:"code_espace,omitempty"`
+	TarifSpecial  int               `json:"tarif_special"`
+	EstOccupee    bool              `json:"est_occupee"`
+	EstActif      bool              `json:"est_actif"`
+	NombreLits    int               `json:"nombre_lits"` // Lits actifs
+	LitsOccupes   int               `json:"lits_occupes"`
+	Lits          []BedResponse     `json:"lits,omitempty"` // Détail d'une chambre uniquement
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// InfrastructureError représente un refus métier sur l'infrastructure physique
+type InfrastructureError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs d'infrastructure
+const (
+	ErrInfraBatimentIntrouvable  = "INFRASTRUCTURE_BATIMENT_INTROUVABLE"
+	ErrInfraCategorieIntrouvable = "INFRASTRUCTURE_CATEGORIE_INTROUVABLE"
+	ErrInfraChambreIntrouvable   = "INFRASTRUCTURE_CHAMBRE_INTROUVABLE"
+	ErrInfraLitIntrouvable       = "INFRASTRUCTURE_LIT_INTROUVABLE"
+	ErrInfraCodeExistant         = "INFRASTRUCTURE_CODE_EXISTANT"
+	ErrInfraNumeroExistant       = "INFRASTRUCTURE_NUMERO_EXISTANT"
+	ErrInfraEtageInvalide        = "INFRASTRUCTURE_ETAGE_INVALIDE"
+	ErrInfraParentInactif        = "INFRASTRUCTURE_PARENT_INACTIF"
+	ErrInfraLitOccupe            = "INFRASTRUCTURE_LIT_OCCUPE"
+	ErrInfraLitIndisponible      = "INFRASTRUCTURE_LIT_INDISPONIBLE"
+	ErrInfraLitLibre             = "INFRASTRUCTURE_LIT_LIBRE"
+	ErrInfraPatientIntrouvable   = "INFRASTRUCTURE_PATIENT_INTROUVABLE"
+	ErrInfraPatientNonAdmissible = "INFRASTRUCTURE_PATIENT_NON_ADMISSIBLE"
+	ErrInfraPatientDejaInstalle  = "INFRASTRUCTURE_PATIENT_DEJA_INSTALLE"
+)
+
+// NewInfrastructureError crée une nouvelle erreur d'infrastructure
+func NewInfrastructureError(code, message string) *InfrastructureError {
+	return &InfrastructureError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *InfrastructureError) Error() string {
+	return e.Message
+}
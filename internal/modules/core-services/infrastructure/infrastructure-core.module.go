@@ -0,0 +1,16 @@
+package infrastructure
+
+import (
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/modules/core-services/infrastructure/services"
+)
+
+// Module regroupe les services métier de l'infrastructure physique (SANS endpoints)
+// Core Service : bâtiments, étages, catégories de chambre, chambres et lits réutilisables par l'hospitalisation
+// Occupation des lits : historique patients_occupation_lit, état dérivé sur base_lit et base_chambre
+var Module = fx.Options(
+	fx.Provide(services.NewInfrastructureLayoutService), // CS-INF-001: Bâtiments, catégories, chambres, lits et arborescence par étage
+	fx.Provide(services.NewBedAllocationService),        // CS-INF-002: Attribution, libération et transfert atomiques des lits
+	fx.Provide(services.NewOccupancyBoardService),       // CS-INF-003: Tableau d'occupation (catégorie, bâtiment, type de lit)
+)
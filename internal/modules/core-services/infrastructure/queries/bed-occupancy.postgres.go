@@ -0,0 +1,237 @@
+package queries
+
+// BedOccupancyQueries contient les requêtes SQL de l'attribution des lits et du tableau d'occupation
+var BedOccupancyQueries = struct {
+	LockPatient            string
+	LockBeds               string
+	LockRooms              string
+	GetOpenOccupationByBed string
+	GetPatientCurrentBed   string
+	InsertOccupation       string
+	CloseOccupation        string
+	SetBedOccupee          string
+	SyncRoomOccupancy      string
+	GetOccupation          string
+	ListBedHistory         string
+	ListAvailableBeds      string
+	OccupancyBoard         string
+}{
+	// LockPatient - Patient à installer (sérialise les attributions concurrentes d'un même patient)
+	LockPatient: `
+		SELECT id, statut, COALESCE(est_decede, FALSE)
+		FROM patients_patient
+		WHERE code_patient = $1
+		FOR UPDATE;
+	`,
+
+	// LockBeds - Un ou deux lits (même identifiant répété pour un seul), verrouillés dans l'ordre des identifiants
+	LockBeds: `
+		SELECT
+			l.id,
+			l.chambre_id,
+			l.numero_lit,
+			COALESCE(l.est_occupee, FALSE),
+			COALESCE(l.est_actif, TRUE)
+		FROM base_lit l
+		WHERE l.etablissement_id = $1
+		AND l.id IN ($2, $3)
+		ORDER BY l.id
+		FOR UPDATE;
+	`,
+
+	// LockRooms - Chambres des lits verrouillées, bâtiment et catégorie protégés d'une désactivation concurrente
+	LockRooms: `
+		SELECT
+			c.id,
+			c.numero_chambre,
+			COALESCE(c.est_actif, TRUE),
+			b.nom_batiment,
+			COALESCE(b.est_actif, TRUE),
+			cc.nom_categorie,
+			COALESCE(cc.est_actif, TRUE)
+		FROM base_chambre c
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		WHERE c.etablissement_id = $1
+		AND c.id IN ($2, $3)
+		ORDER BY c.id
+		FOR UPDATE OF c
+		FOR SHARE OF b, cc;
+	`,
+
+	// GetOpenOccupationByBed - Occupation en cours d'un lit (UQ_patients_occupation_lit_lit_en_cours)
+	GetOpenOccupationByBed: `
+		SELECT id, patient_id, reference_sejour
+		FROM patients_occupation_lit
+		WHERE lit_id = $1
+		AND fin_le IS NULL;
+	`,
+
+	// GetPatientCurrentBed - Lit occupé par un patient, tous établissements confondus
+	GetPatientCurrentBed: `
+		SELECT l.numero_lit, c.numero_chambre, o.etablissement_id = $2
+		FROM patients_occupation_lit o
+		JOIN base_lit l ON l.id = o.lit_id
+		JOIN base_chambre c ON c.id = l.chambre_id
+		WHERE o.patient_id = $1
+		AND o.fin_le IS NULL;
+	`,
+
+	// InsertOccupation - Ouverture d'une occupation (fin_le NULL)
+	InsertOccupation: `
+		INSERT INTO patients_occupation_lit (
+			etablissement_id, lit_id, patient_id, reference_sejour, occupation_precedente_id,
+			commentaire, attribue_par
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		) RETURNING id;
+	`,
+
+	// CloseOccupation - Clôture d'une occupation (NOW() : même instant que l'ouverture suivante d'un transfert)
+	CloseOccupation: `
+		UPDATE patients_occupation_lit SET
+			fin_le = NOW(),
+			motif_fin = $2,
+			commentaire_fin = $3,
+			libere_par = $4
+		WHERE id = $1
+		AND fin_le IS NULL;
+	`,
+
+	// SetBedOccupee - État d'occupation d'un lit
+	SetBedOccupee: `
+		UPDATE base_lit SET
+			est_occupee = $2
+		WHERE id = $1;
+	`,
+
+	// SyncRoomOccupancy - Chambre occupée dès qu'un de ses lits l'est
+	SyncRoomOccupancy: `
+		UPDATE base_chambre c SET
+			est_occupee = EXISTS (
+				SELECT 1 FROM base_lit l
+				WHERE l.chambre_id = c.id
+				AND COALESCE(l.est_occupee, FALSE) = TRUE
+			)
+		WHERE c.id = $1;
+	`,
+
+	// GetOccupation - Occupation, localisation du lit et patient
+	GetOccupation: `
+		SELECT
+			o.id,
+			l.id, l.numero_lit, l.code_lit, l.type_lit,
+			c.id, c.numero_chambre, c.niveau_etage,
+			b.id, b.code_batiment, b.nom_batiment,
+			cc.id, cc.code_categorie, cc.nom_categorie,
+			p.id, p.code_patient, p.nom, p.prenoms,
+			o.reference_sejour,
+			o.occupation_precedente_id,
+			o.debut_le,
+			o.fin_le,
+			o.motif_fin,
+			o.commentaire,
+			o.commentaire_fin,
+			o.attribue_par,
+			o.libere_par
+		FROM patients_occupation_lit o
+		JOIN base_lit l ON l.id = o.lit_id
+		JOIN base_chambre c ON c.id = l.chambre_id
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		JOIN patients_patient p ON p.id = o.patient_id
+		WHERE o.id = $1
+		AND o.etablissement_id = $2;
+	`,
+
+	// ListBedHistory - Occupations d'un lit, de la plus récente à la plus ancienne
+	ListBedHistory: `
+		SELECT
+			o.id,
+			l.id, l.numero_lit, l.code_lit, l.type_lit,
+			c.id, c.numero_chambre, c.niveau_etage,
+			b.id, b.code_batiment, b.nom_batiment,
+			cc.id, cc.code_categorie, cc.nom_categorie,
+			p.id, p.code_patient, p.nom, p.prenoms,
+			o.reference_sejour,
+			o.occupation_precedente_id,
+			o.debut_le,
+			o.fin_le,
+			o.motif_fin,
+			o.commentaire,
+			o.commentaire_fin,
+			o.attribue_par,
+			o.libere_par
+		FROM patients_occupation_lit o
+		JOIN base_lit l ON l.id = o.lit_id
+		JOIN base_chambre c ON c.id = l.chambre_id
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		JOIN patients_patient p ON p.id = o.patient_id
+		WHERE o.lit_id = $1
+		AND o.etablissement_id = $2
+		ORDER BY o.debut_le DESC
+		LIMIT $3;
+	`,
+
+	// ListAvailableBeds - Lits libres attribuables (lit, chambre, bâtiment et catégorie actifs)
+	ListAvailableBeds: `
+		SELECT
+			l.id, l.numero_lit, l.code_lit, l.type_lit,
+			c.id, c.numero_chambre, c.niveau_etage,
+			b.id, b.code_batiment, b.nom_batiment,
+			cc.id, cc.code_categorie, cc.nom_categorie,
+			c.tarif_special
+		FROM base_lit l
+		JOIN base_chambre c ON c.id = l.chambre_id
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		WHERE l.etablissement_id = $1
+		AND COALESCE(l.est_occupee, FALSE) = FALSE
+		AND COALESCE(l.est_actif, TRUE) = TRUE
+		AND COALESCE(c.est_actif, TRUE) = TRUE
+		AND COALESCE(b.est_actif, TRUE) = TRUE
+		AND COALESCE(cc.est_actif, TRUE) = TRUE
+		AND ($2::text = '' OR b.id = $2::uuid)
+		AND ($3::text = '' OR cc.id = $3::uuid)
+		AND ($4::text = '' OR l.type_lit = $4)
+		ORDER BY b.nom_batiment, c.niveau_etage NULLS LAST, c.numero_chambre, l.numero_lit;
+	`,
+
+	// OccupancyBoard - Capacité et lits occupés par catégorie, par bâtiment et par type de lit
+	// Capacité : lits actifs des chambres, bâtiments et catégories actifs (périmètre attribuable)
+	OccupancyBoard: `
+		WITH lits AS (
+			SELECT
+				b.id AS batiment_id, b.code_batiment, b.nom_batiment,
+				cc.id AS categorie_id, cc.code_categorie, cc.nom_categorie,
+				COALESCE(l.type_lit, 'non_precise') AS type_lit,
+				COALESCE(l.est_occupee, FALSE) AS est_occupee
+			FROM base_lit l
+			JOIN base_chambre c ON c.id = l.chambre_id
+			JOIN base_batiment b ON b.id = c.batiment_id
+			JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+			WHERE l.etablissement_id = $1
+			AND COALESCE(l.est_actif, TRUE) = TRUE
+			AND COALESCE(c.est_actif, TRUE) = TRUE
+			AND COALESCE(b.est_actif, TRUE) = TRUE
+			AND COALESCE(cc.est_actif, TRUE) = TRUE
+			AND ($2::text = '' OR b.id = $2::uuid)
+		)
+		SELECT 'categorie', categorie_id, code_categorie, nom_categorie,
+			COUNT(*), COUNT(*) FILTER (WHERE est_occupee)
+		FROM lits
+		GROUP BY categorie_id, code_categorie, nom_categorie
+		UNION ALL
+		SELECT 'batiment', batiment_id, code_batiment, nom_batiment,
+			COUNT(*), COUNT(*) FILTER (WHERE est_occupee)
+		FROM lits
+		GROUP BY batiment_id, code_batiment, nom_batiment
+		UNION ALL
+		SELECT 'type_lit', NULL, type_lit, type_lit,
+			COUNT(*), COUNT(*) FILTER (WHERE est_occupee)
+		FROM lits
+		GROUP BY type_lit
+		ORDER BY 1, 4;
+	`,
+}
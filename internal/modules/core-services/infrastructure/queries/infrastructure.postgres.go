@@ -0,0 +1,433 @@
+package queries
+
+// InfrastructureQueries contient les requêtes SQL de l'infrastructure physique (bâtiments, catégories, chambres, lits)
+var InfrastructureQueries = struct {
+	ListBuildings     string
+	GetBuilding       string
+	LockBuilding      string
+	InsertBuilding    string
+	UpdateBuilding    string
+	SetBuildingActif  string
+	MaxRoomFloor      string
+	ListCategories    string
+	GetCategory       string
+	LockCategory      string
+	InsertCategory    string
+	UpdateCategory    string
+	SetCategoryActif  string
+	ListRooms         string
+	GetRoom           string
+	LockRoom          string
+	InsertRoom        string
+	UpdateRoom        string
+	SetRoomActif      string
+	ListBedsOfRoom    string
+	GetBed            string
+	LockBed           string
+	InsertBed         string
+	UpdateBed         string
+	SetBedActif       string
+	CountOccupiedBeds string
+	TreeRows          string
+}{
+	// ListBuildings - Bâtiments de l'établissement et leur capacité (lits actifs des chambres actives)
+	ListBuildings: `
+		SELECT
+			b.id,
+			b.code_batiment,
+			b.nom_batiment,
+			b.description,
+			COALESCE(b.nombre_etages, 1),
+			COALESCE(b.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_chambre c
+			 WHERE c.batiment_id = b.id AND COALESCE(c.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l JOIN base_chambre c ON c.id = l.chambre_id
+			 WHERE c.batiment_id = b.id AND COALESCE(c.est_actif, TRUE) = TRUE AND COALESCE(l.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l JOIN base_chambre c ON c.id = l.chambre_id
+			 WHERE c.batiment_id = b.id AND COALESCE(l.est_occupee, FALSE) = TRUE),
+			b.created_at,
+			b.updated_at
+		FROM base_batiment b
+		WHERE b.etablissement_id = $1
+		AND ($2::boolean OR COALESCE(b.est_actif, TRUE) = TRUE)
+		ORDER BY b.nom_batiment;
+	`,
+
+	// GetBuilding - Bâtiment de l'établissement
+	GetBuilding: `
+		SELECT
+			b.id,
+			b.code_batiment,
+			b.nom_batiment,
+			b.description,
+			COALESCE(b.nombre_etages, 1),
+			COALESCE(b.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_chambre c
+			 WHERE c.batiment_id = b.id AND COALESCE(c.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l JOIN base_chambre c ON c.id = l.chambre_id
+			 WHERE c.batiment_id = b.id AND COALESCE(c.est_actif, TRUE) = TRUE AND COALESCE(l.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l JOIN base_chambre c ON c.id = l.chambre_id
+			 WHERE c.batiment_id = b.id AND COALESCE(l.est_occupee, FALSE) = TRUE),
+			b.created_at,
+			b.updated_at
+		FROM base_batiment b
+		WHERE b.id = $1
+		AND b.etablissement_id = $2;
+	`,
+
+	// LockBuilding - Bâtiment modifié (verrou jusqu'à la fin de la transaction)
+	LockBuilding: `
+		SELECT nom_batiment, COALESCE(nombre_etages, 1), COALESCE(est_actif, TRUE)
+		FROM base_batiment
+		WHERE id = $1
+		AND etablissement_id = $2
+		FOR UPDATE;
+	`,
+
+	// InsertBuilding - Création d'un bâtiment (UQ_base_batiment_etablissement_code)
+	InsertBuilding: `
+		INSERT INTO base_batiment (
+			etablissement_id, code_batiment, nom_batiment, description, nombre_etages
+		) VALUES (
+			$1, $2, $3, $4, $5
+		) RETURNING id;
+	`,
+
+	// UpdateBuilding - Modification partielle (le code est immuable)
+	UpdateBuilding: `
+		UPDATE base_batiment SET
+			nom_batiment  = COALESCE($3, nom_batiment),
+			description   = COALESCE($4, description),
+			nombre_etages = COALESCE($5, nombre_etages)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetBuildingActif - Activation ou désactivation d'un bâtiment
+	SetBuildingActif: `
+		UPDATE base_batiment SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// MaxRoomFloor - Plus haut étage occupé par une chambre du bâtiment (actives ou non)
+	MaxRoomFloor: `
+		SELECT MAX(niveau_etage)
+		FROM base_chambre
+		WHERE batiment_id = $1;
+	`,
+
+	// ListCategories - Catégories de chambre de l'établissement
+	ListCategories: `
+		SELECT
+			cc.id,
+			cc.code_categorie,
+			cc.nom_categorie,
+			cc.description,
+			cc.tarif,
+			COALESCE(cc.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_chambre c
+			 WHERE c.categorie_chambre_id = cc.id AND COALESCE(c.est_actif, TRUE) = TRUE),
+			cc.created_at,
+			cc.updated_at
+		FROM base_categorie_chambre cc
+		WHERE cc.etablissement_id = $1
+		AND ($2::boolean OR COALESCE(cc.est_actif, TRUE) = TRUE)
+		ORDER BY cc.nom_categorie;
+	`,
+
+	// GetCategory - Catégorie de chambre de l'établissement
+	GetCategory: `
+		SELECT
+			cc.id,
+			cc.code_categorie,
+			cc.nom_categorie,
+			cc.description,
+			cc.tarif,
+			COALESCE(cc.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_chambre c
+			 WHERE c.categorie_chambre_id = cc.id AND COALESCE(c.est_actif, TRUE) = TRUE),
+			cc.created_at,
+			cc.updated_at
+		FROM base_categorie_chambre cc
+		WHERE cc.id = $1
+		AND cc.etablissement_id = $2;
+	`,
+
+	// LockCategory - Catégorie modifiée ou référencée (tarif par défaut d'une chambre)
+	LockCategory: `
+		SELECT nom_categorie, tarif, COALESCE(est_actif, TRUE)
+		FROM base_categorie_chambre
+		WHERE id = $1
+		AND etablissement_id = $2
+		FOR UPDATE;
+	`,
+
+	// InsertCategory - Création d'une catégorie (UQ_base_categorie_chambre_etablissement_code)
+	InsertCategory: `
+		INSERT INTO base_categorie_chambre (
+			etablissement_id, code_categorie, nom_categorie, description, tarif
+		) VALUES (
+			$1, $2, $3, $4, $5
+		) RETURNING id;
+	`,
+
+	// UpdateCategory - Modification partielle (le code est immuable)
+	UpdateCategory: `
+		UPDATE base_categorie_chambre SET
+			nom_categorie = COALESCE($3, nom_categorie),
+			description   = COALESCE($4, description),
+			tarif         = COALESCE($5, tarif)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetCategoryActif - Activation ou désactivation d'une catégorie
+	SetCategoryActif: `
+		UPDATE base_categorie_chambre SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// ListRooms - Chambres de l'établissement (bâtiment et catégorie optionnels)
+	ListRooms: `
+		SELECT
+			c.id,
+			b.id,
+			b.code_batiment,
+			b.nom_batiment,
+			cc.id,
+			cc.code_categorie,
+			cc.nom_categorie,
+			c.numero_chambre,
+			c.nom_chambre,
+			c.niveau_etage,
+			c.type_espace,
+			c.code_espace,
+			c.tarif_special,
+			COALESCE(c.est_occupee, FALSE),
+			COALESCE(c.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_lit l
+			 WHERE l.chambre_id = c.id AND COALESCE(l.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l
+			 WHERE l.chambre_id = c.id AND COALESCE(l.est_occupee, FALSE) = TRUE),
+			c.created_at,
+			c.updated_at
+		FROM base_chambre c
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		WHERE c.etablissement_id = $1
+		AND ($2::text = '' OR c.batiment_id = $2::uuid)
+		AND ($3::text = '' OR c.categorie_chambre_id = $3::uuid)
+		AND ($4::boolean OR COALESCE(c.est_actif, TRUE) = TRUE)
+		ORDER BY b.nom_batiment, c.niveau_etage NULLS LAST, c.numero_chambre;
+	`,
+
+	// GetRoom - Chambre de l'établissement
+	GetRoom: `
+		SELECT
+			c.id,
+			b.id,
+			b.code_batiment,
+			b.nom_batiment,
+			cc.id,
+			cc.code_categorie,
+			cc.nom_categorie,
+			c.numero_chambre,
+			c.nom_chambre,
+			c.niveau_etage,
+			c.type_espace,
+			c.code_espace,
+			c.tarif_special,
+			COALESCE(c.est_occupee, FALSE),
+			COALESCE(c.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_lit l
+			 WHERE l.chambre_id = c.id AND COALESCE(l.est_actif, TRUE) = TRUE),
+			(SELECT COUNT(*) FROM base_lit l
+			 WHERE l.chambre_id = c.id AND COALESCE(l.est_occupee, FALSE) = TRUE),
+			c.created_at,
+			c.updated_at
+		FROM base_chambre c
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		WHERE c.id = $1
+		AND c.etablissement_id = $2;
+	`,
+
+	// LockRoom - Chambre modifiée, avec l'état de ses parents (verrou sur la chambre seule)
+	LockRoom: `
+		SELECT
+			c.numero_chambre,
+			c.batiment_id,
+			b.nom_batiment,
+			COALESCE(b.nombre_etages, 1),
+			COALESCE(b.est_actif, TRUE),
+			COALESCE(cc.est_actif, TRUE),
+			COALESCE(c.est_actif, TRUE)
+		FROM base_chambre c
+		JOIN base_batiment b ON b.id = c.batiment_id
+		JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		WHERE c.id = $1
+		AND c.etablissement_id = $2
+		FOR UPDATE OF c;
+	`,
+
+	// InsertRoom - Création d'une chambre (trigger_chambre_niveau_etage en garde-fou)
+	InsertRoom: `
+		INSERT INTO base_chambre (
+			etablissement_id, batiment_id, categorie_chambre_id, numero_chambre, nom_chambre,
+			niveau_etage, type_espace, code_espace, tarif_special
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		) RETURNING id;
+	`,
+
+	// UpdateRoom - Modification partielle (bâtiment et numéro immuables)
+	UpdateRoom: `
+		UPDATE base_chambre SET
+			categorie_chambre_id = COALESCE($3, categorie_chambre_id),
+			nom_chambre          = COALESCE($4, nom_chambre),
+			niveau_etage         = COALESCE($5, niveau_etage),
+			type_espace          = COALESCE($6, type_espace),
+			code_espace          = COALESCE($7, code_espace),
+			tarif_special        = COALESCE($8, tarif_special)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetRoomActif - Activation ou désactivation d'une chambre
+	SetRoomActif: `
+		UPDATE base_chambre SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// ListBedsOfRoom - Lits d'une chambre
+	ListBedsOfRoom: `
+		SELECT
+			l.id,
+			l.chambre_id,
+			l.numero_lit,
+			l.code_lit,
+			l.type_lit,
+			COALESCE(l.est_occupee, FALSE),
+			COALESCE(l.est_actif, TRUE),
+			l.created_at,
+			l.updated_at
+		FROM base_lit l
+		WHERE l.chambre_id = $1
+		AND l.etablissement_id = $2
+		AND ($3::boolean OR COALESCE(l.est_actif, TRUE) = TRUE)
+		ORDER BY l.numero_lit;
+	`,
+
+	// GetBed - Lit de l'établissement
+	GetBed: `
+		SELECT
+			l.id,
+			l.chambre_id,
+			l.numero_lit,
+			l.code_lit,
+			l.type_lit,
+			COALESCE(l.est_occupee, FALSE),
+			COALESCE(l.est_actif, TRUE),
+			l.created_at,
+			l.updated_at
+		FROM base_lit l
+		WHERE l.id = $1
+		AND l.etablissement_id = $2;
+	`,
+
+	// LockBed - Lit modifié, avec l'état de sa chambre (verrou sur le lit seul)
+	LockBed: `
+		SELECT
+			l.numero_lit,
+			COALESCE(c.est_actif, TRUE),
+			COALESCE(l.est_occupee, FALSE),
+			COALESCE(l.est_actif, TRUE)
+		FROM base_lit l
+		JOIN base_chambre c ON c.id = l.chambre_id
+		WHERE l.id = $1
+		AND l.etablissement_id = $2
+		FOR UPDATE OF l;
+	`,
+
+	// InsertBed - Ajout d'un lit (UQ_base_lit_etablissement_code, UQ_base_lit_chambre_numero)
+	InsertBed: `
+		INSERT INTO base_lit (
+			etablissement_id, chambre_id, numero_lit, code_lit, type_lit
+		) VALUES (
+			$1, $2, $3, $4, $5
+		) RETURNING id;
+	`,
+
+	// UpdateBed - Modification partielle d'un lit
+	UpdateBed: `
+		UPDATE base_lit SET
+			numero_lit = COALESCE($3, numero_lit),
+			code_lit   = COALESCE($4, code_lit),
+			type_lit   = COALESCE($5, type_lit)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetBedActif - Activation ou désactivation d'un lit
+	SetBedActif: `
+		UPDATE base_lit SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// CountOccupiedBeds - Lits occupés d'un bâtiment, d'une catégorie ou d'une chambre
+	CountOccupiedBeds: `
+		SELECT COUNT(*)
+		FROM base_lit l
+		JOIN base_chambre c ON c.id = l.chambre_id
+		WHERE l.etablissement_id = $1
+		AND COALESCE(l.est_occupee, FALSE) = TRUE
+		AND ($2::uuid IS NULL OR c.batiment_id = $2)
+		AND ($3::uuid IS NULL OR c.categorie_chambre_id = $3)
+		AND ($4::uuid IS NULL OR c.id = $4);
+	`,
+
+	// TreeRows - Bâtiments, chambres et lits à plat, triés pour construire l'arborescence
+	// Sans inclure_inactifs, un élément inactif masque ses descendants
+	TreeRows: `
+		SELECT
+			b.id,
+			b.code_batiment,
+			b.nom_batiment,
+			COALESCE(b.nombre_etages, 1),
+			COALESCE(b.est_actif, TRUE),
+			c.id,
+			c.numero_chambre,
+			c.nom_chambre,
+			c.niveau_etage,
+			c.type_espace,
+			COALESCE(c.est_occupee, FALSE),
+			COALESCE(c.est_actif, TRUE),
+			cc.id,
+			cc.code_categorie,
+			cc.nom_categorie,
+			l.id,
+			l.numero_lit,
+			l.code_lit,
+			l.type_lit,
+			COALESCE(l.est_occupee, FALSE),
+			COALESCE(l.est_actif, TRUE)
+		FROM base_batiment b
+		LEFT JOIN base_chambre c ON c.batiment_id = b.id
+			AND ($3::boolean OR COALESCE(c.est_actif, TRUE) = TRUE)
+		LEFT JOIN base_categorie_chambre cc ON cc.id = c.categorie_chambre_id
+		LEFT JOIN base_lit l ON l.chambre_id = c.id
+			AND ($3::boolean OR COALESCE(l.est_actif, TRUE) = TRUE)
+		WHERE b.etablissement_id = $1
+		AND ($2::text = '' OR b.id = $2::uuid)
+		AND ($3::boolean OR COALESCE(b.est_actif, TRUE) = TRUE)
+		ORDER BY b.nom_batiment, b.id, c.niveau_etage NULLS LAST, c.numero_chambre, c.id, l.numero_lit;
+	`,
+}
@@ -0,0 +1,589 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	"soins-suite-core/internal/modules/core-services/infrastructure/queries"
+)
+
+// Statut patient admissible en hospitalisation (patients_patient.statut)
+const statutPatientActif = "actif"
+
+// Nombre maximal d'occupations retournées par l'historique d'un lit
+const historiqueLitLimite = 200
+
+// BedAllocationService attribue, libère et transfère les lits de façon atomique
+// Chaque opération verrouille lits et chambres (FOR UPDATE), bâtiment et catégorie (FOR SHARE) :
+// deux soignants ne peuvent pas attribuer le même lit, ni un lit d'une chambre en cours de désactivation
+// base_lit.est_occupee suit l'occupation en cours (patients_occupation_lit) et base_chambre.est_occupee en est dérivé
+type BedAllocationService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewBedAllocationService crée une nouvelle instance du service
+func NewBedAllocationService(db *postgres.Client) *BedAllocationService {
+	return &BedAllocationService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// AssignBed attribue un lit libre et attribuable à un patient qui n'occupe aucun autre lit
+func (s *BedAllocationService) AssignBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+	req *dto.AssignBedRequest,
+	userID uuid.UUID,
+) (*dto.BedOccupancyResponse, error) {
+	codePatient := strings.TrimSpace(req.CodePatient)
+
+	var occupationID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, err := lockAdmissiblePatient(ctx, tx, etablissementID, codePatient)
+		if err != nil {
+			return err
+		}
+
+		lits, err := lockBeds(ctx, tx, etablissementID, litID, litID)
+		if err != nil {
+			return err
+		}
+		lit := lits[litID]
+
+		chambres, err := lockBedRooms(ctx, tx, etablissementID, lit.chambreID, lit.chambreID)
+		if err != nil {
+			return err
+		}
+		if err := checkAssignable(lit, chambres[lit.chambreID]); err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.BedOccupancyQueries.InsertOccupation,
+			etablissementID,
+			litID,
+			patientID,
+			trimmed(req.ReferenceSejour),
+			nil,
+			req.Commentaire,
+			userID,
+		).Scan(&occupationID); err != nil {
+			if conflict := occupancyConflict(err); conflict != nil {
+				return conflict
+			}
+			return fmt.Errorf("failed to insert bed occupancy: %w", err)
+		}
+
+		return setBedOccupancy(ctx, tx, litID, lit.chambreID, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Bed assigned - Lit: %s, Patient: %s, Occupation: %s, User: %s\n",
+		litID, codePatient, occupationID, userID)
+
+	return s.getOccupation(ctx, etablissementID, occupationID)
+}
+
+// ReleaseBed clôt l'occupation en cours d'un lit (sortie, décès ou annulation) et le rend attribuable
+func (s *BedAllocationService) ReleaseBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+	req *dto.ReleaseBedRequest,
+	userID uuid.UUID,
+) (*dto.BedOccupancyResponse, error) {
+	var occupationID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		lits, err := lockBeds(ctx, tx, etablissementID, litID, litID)
+		if err != nil {
+			return err
+		}
+		lit := lits[litID]
+
+		if _, err := lockBedRooms(ctx, tx, etablissementID, lit.chambreID, lit.chambreID); err != nil {
+			return err
+		}
+
+		occupation, err := getOpenOccupation(ctx, tx, lit)
+		if err != nil {
+			return err
+		}
+		occupationID = occupation.id
+
+		if err := tx.Exec(ctx,
+			queries.BedOccupancyQueries.CloseOccupation,
+			occupation.id,
+			req.Motif,
+			req.Commentaire,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to close bed occupancy: %w", err)
+		}
+
+		return setBedOccupancy(ctx, tx, litID, lit.chambreID, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Bed released - Lit: %s, Occupation: %s, Motif: %s, User: %s\n",
+		litID, occupationID, req.Motif, userID)
+
+	return s.getOccupation(ctx, etablissementID, occupationID)
+}
+
+// TransferBed déplace l'occupant d'un lit vers un lit libre : l'occupation d'origine est close (motif transfert)
+// et une nouvelle occupation, chaînée à la précédente, est ouverte dans la même transaction
+func (s *BedAllocationService) TransferBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+	req *dto.TransferBedRequest,
+	userID uuid.UUID,
+) (*dto.BedOccupancyResponse, error) {
+	destinationID := req.LitDestinationID
+	if destinationID == litID {
+		return nil, dto.NewInfrastructureError(dto.ErrInfraLitIndisponible,
+			"Le lit de destination doit être différent du lit d'origine")
+	}
+
+	var occupationID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		lits, err := lockBeds(ctx, tx, etablissementID, litID, destinationID)
+		if err != nil {
+			return err
+		}
+		origine, destination := lits[litID], lits[destinationID]
+
+		chambres, err := lockBedRooms(ctx, tx, etablissementID, origine.chambreID, destination.chambreID)
+		if err != nil {
+			return err
+		}
+
+		occupation, err := getOpenOccupation(ctx, tx, origine)
+		if err != nil {
+			return err
+		}
+		if err := checkAssignable(destination, chambres[destination.chambreID]); err != nil {
+			return err
+		}
+
+		if err := tx.Exec(ctx,
+			queries.BedOccupancyQueries.CloseOccupation,
+			occupation.id,
+			dto.MotifFinTransfert,
+			req.Commentaire,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to close bed occupancy: %w", err)
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.BedOccupancyQueries.InsertOccupation,
+			etablissementID,
+			destinationID,
+			occupation.patientID,
+			occupation.referenceSejour,
+			occupation.id,
+			req.Commentaire,
+			userID,
+		).Scan(&occupationID); err != nil {
+			if conflict := occupancyConflict(err); conflict != nil {
+				return conflict
+			}
+			return fmt.Errorf("failed to insert bed occupancy: %w", err)
+		}
+
+		if err := setBedOccupancy(ctx, tx, litID, origine.chambreID, false); err != nil {
+			return err
+		}
+		return setBedOccupancy(ctx, tx, destinationID, destination.chambreID, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Bed transfer - De: %s, Vers: %s, Occupation: %s, User: %s\n",
+		litID, destinationID, occupationID, userID)
+
+	return s.getOccupation(ctx, etablissementID, occupationID)
+}
+
+// GetBedHistory retourne les occupations d'un lit, de la plus récente à la plus ancienne
+func (s *BedAllocationService) GetBedHistory(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+) ([]dto.BedOccupancyResponse, error) {
+	if _, err := scanBed(s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.GetBed,
+		litID,
+		etablissementID,
+	)); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraLitIntrouvable,
+				fmt.Sprintf("Lit introuvable: %s", litID))
+		}
+		return nil, fmt.Errorf("failed to get bed: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.BedOccupancyQueries.ListBedHistory,
+		litID,
+		etablissementID,
+		historiqueLitLimite,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bed history: %w", err)
+	}
+	defer rows.Close()
+
+	occupations := []dto.BedOccupancyResponse{}
+	for rows.Next() {
+		occupation, err := scanOccupation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bed occupancy: %w", err)
+		}
+		occupations = append(occupations, *occupation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate bed history: %w", err)
+	}
+
+	return occupations, nil
+}
+
+// ListAvailableBeds retourne les lits libres attribuables avec leur localisation et le tarif de la chambre
+func (s *BedAllocationService) ListAvailableBeds(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.AvailableBedFilter,
+) ([]dto.AvailableBed, error) {
+	rows, err := s.db.Query(ctx,
+		queries.BedOccupancyQueries.ListAvailableBeds,
+		etablissementID,
+		filter.BatimentID,
+		filter.CategorieChambreID,
+		filter.TypeLit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list available beds: %w", err)
+	}
+	defer rows.Close()
+
+	lits := []dto.AvailableBed{}
+	for rows.Next() {
+		var lit dto.AvailableBed
+		if err := rows.Scan(
+			&lit.LitID, &lit.NumeroLit, &lit.CodeLit, &lit.TypeLit,
+			&lit.ChambreID, &lit.NumeroChambre, &lit.NiveauEtage,
+			&lit.Batiment.ID, &lit.Batiment.Code, &lit.Batiment.Nom,
+			&lit.Categorie.ID, &lit.Categorie.Code, &lit.Categorie.Nom,
+			&lit.Tarif,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan available bed: %w", err)
+		}
+		lits = append(lits, lit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate available beds: %w", err)
+	}
+
+	return lits, nil
+}
+
+// getOccupation retourne une occupation de l'établissement
+func (s *BedAllocationService) getOccupation(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	occupationID uuid.UUID,
+) (*dto.BedOccupancyResponse, error) {
+	occupation, err := scanOccupation(s.db.QueryRow(ctx,
+		queries.BedOccupancyQueries.GetOccupation,
+		occupationID,
+		etablissementID,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bed occupancy: %w", err)
+	}
+	return occupation, nil
+}
+
+// litVerrouille représente un lit verrouillé pendant une attribution
+type litVerrouille struct {
+	id         uuid.UUID
+	chambreID  uuid.UUID
+	numero     string
+	estOccupee bool
+	estActif   bool
+}
+
+// chambreDeLit représente la chambre d'un lit verrouillé et l'état de ses parents
+type chambreDeLit struct {
+	numero          string
+	estActif        bool
+	batimentNom     string
+	batimentActif   bool
+	categorieNom    string
+	categorieActive bool
+}
+
+// occupationEnCours représente l'occupation en cours d'un lit
+type occupationEnCours struct {
+	id              uuid.UUID
+	patientID       uuid.UUID
+	referenceSejour *string
+}
+
+// lockAdmissiblePatient verrouille le dossier d'un patient actif qui n'occupe encore aucun lit
+func lockAdmissiblePatient(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	codePatient string,
+) (uuid.UUID, error) {
+	var patientID uuid.UUID
+	var statut string
+	var estDecede bool
+	err := tx.QueryRow(ctx,
+		queries.BedOccupancyQueries.LockPatient,
+		codePatient,
+	).Scan(&patientID, &statut, &estDecede)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, dto.NewInfrastructureError(dto.ErrInfraPatientIntrouvable,
+				fmt.Sprintf("Patient introuvable: %s", codePatient))
+		}
+		return uuid.Nil, fmt.Errorf("failed to lock patient %s: %w", codePatient, err)
+	}
+
+	if estDecede || statut != statutPatientActif {
+		return uuid.Nil, dto.NewInfrastructureError(dto.ErrInfraPatientNonAdmissible,
+			fmt.Sprintf("Le dossier %s est au statut %s : aucun lit ne peut lui être attribué", codePatient, statut))
+	}
+
+	var numeroLit, numeroChambre string
+	var memeEtablissement bool
+	err = tx.QueryRow(ctx,
+		queries.BedOccupancyQueries.GetPatientCurrentBed,
+		patientID,
+		etablissementID,
+	).Scan(&numeroLit, &numeroChambre, &memeEtablissement)
+	if err == nil {
+		if memeEtablissement {
+			return uuid.Nil, dto.NewInfrastructureError(dto.ErrInfraPatientDejaInstalle,
+				fmt.Sprintf("Le patient %s occupe déjà le lit %s (chambre %s) : utiliser le transfert",
+					codePatient, numeroLit, numeroChambre))
+		}
+		return uuid.Nil, dto.NewInfrastructureError(dto.ErrInfraPatientDejaInstalle,
+			fmt.Sprintf("Le patient %s occupe déjà un lit dans un autre établissement", codePatient))
+	}
+	if err != pgx.ErrNoRows {
+		return uuid.Nil, fmt.Errorf("failed to get patient current bed: %w", err)
+	}
+
+	return patientID, nil
+}
+
+// lockBeds verrouille un lit (identifiants identiques) ou deux lits, dans l'ordre des identifiants
+func lockBeds(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	premierID, secondID uuid.UUID,
+) (map[uuid.UUID]*litVerrouille, error) {
+	rows, err := tx.Query(ctx,
+		queries.BedOccupancyQueries.LockBeds,
+		etablissementID,
+		premierID,
+		secondID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock beds: %w", err)
+	}
+	defer rows.Close()
+
+	lits := make(map[uuid.UUID]*litVerrouille, 2)
+	for rows.Next() {
+		var lit litVerrouille
+		if err := rows.Scan(&lit.id, &lit.chambreID, &lit.numero, &lit.estOccupee, &lit.estActif); err != nil {
+			return nil, fmt.Errorf("failed to scan locked bed: %w", err)
+		}
+		lits[lit.id] = &lit
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate locked beds: %w", err)
+	}
+
+	for _, id := range []uuid.UUID{premierID, secondID} {
+		if _, ok := lits[id]; !ok {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraLitIntrouvable,
+				fmt.Sprintf("Lit introuvable: %s", id))
+		}
+	}
+
+	return lits, nil
+}
+
+// lockBedRooms verrouille les chambres des lits (après les lits) et protège leur bâtiment et leur catégorie
+func lockBedRooms(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	premierID, secondID uuid.UUID,
+) (map[uuid.UUID]*chambreDeLit, error) {
+	rows, err := tx.Query(ctx,
+		queries.BedOccupancyQueries.LockRooms,
+		etablissementID,
+		premierID,
+		secondID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock bed rooms: %w", err)
+	}
+	defer rows.Close()
+
+	chambres := make(map[uuid.UUID]*chambreDeLit, 2)
+	for rows.Next() {
+		var id uuid.UUID
+		var chambre chambreDeLit
+		if err := rows.Scan(
+			&id,
+			&chambre.numero,
+			&chambre.estActif,
+			&chambre.batimentNom,
+			&chambre.batimentActif,
+			&chambre.categorieNom,
+			&chambre.categorieActive,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan locked room: %w", err)
+		}
+		chambres[id] = &chambre
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate locked rooms: %w", err)
+	}
+
+	for _, id := range []uuid.UUID{premierID, secondID} {
+		if _, ok := chambres[id]; !ok {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraChambreIntrouvable,
+				fmt.Sprintf("Chambre introuvable: %s", id))
+		}
+	}
+
+	return chambres, nil
+}
+
+// checkAssignable vérifie qu'un lit verrouillé est libre et que lui, sa chambre, son bâtiment et sa catégorie sont actifs
+func checkAssignable(lit *litVerrouille, chambre *chambreDeLit) error {
+	switch {
+	case !lit.estActif:
+		return dto.NewInfrastructureError(dto.ErrInfraLitIndisponible,
+			fmt.Sprintf("Le lit %s est inactif", lit.numero))
+	case !chambre.estActif:
+		return dto.NewInfrastructureError(dto.ErrInfraLitIndisponible,
+			fmt.Sprintf("Lit %s : la chambre %s est inactive", lit.numero, chambre.numero))
+	case !chambre.batimentActif:
+		return dto.NewInfrastructureError(dto.ErrInfraLitIndisponible,
+			fmt.Sprintf("Lit %s : le bâtiment %s est inactif", lit.numero, chambre.batimentNom))
+	case !chambre.categorieActive:
+		return dto.NewInfrastructureError(dto.ErrInfraLitIndisponible,
+			fmt.Sprintf("Lit %s : la catégorie %s est inactive", lit.numero, chambre.categorieNom))
+	case lit.estOccupee:
+		return dto.NewInfrastructureError(dto.ErrInfraLitOccupe,
+			fmt.Sprintf("Le lit %s (chambre %s) est déjà occupé", lit.numero, chambre.numero))
+	}
+	return nil
+}
+
+// getOpenOccupation retourne l'occupation en cours d'un lit verrouillé
+func getOpenOccupation(ctx context.Context, tx *postgres.Transaction, lit *litVerrouille) (*occupationEnCours, error) {
+	var occupation occupationEnCours
+	err := tx.QueryRow(ctx,
+		queries.BedOccupancyQueries.GetOpenOccupationByBed,
+		lit.id,
+	).Scan(&occupation.id, &occupation.patientID, &occupation.referenceSejour)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraLitLibre,
+				fmt.Sprintf("Le lit %s n'a aucune occupation en cours", lit.numero))
+		}
+		return nil, fmt.Errorf("failed to get open bed occupancy: %w", err)
+	}
+	return &occupation, nil
+}
+
+// setBedOccupancy met à jour l'occupation d'un lit puis celle, dérivée, de sa chambre
+func setBedOccupancy(ctx context.Context, tx *postgres.Transaction, litID, chambreID uuid.UUID, occupe bool) error {
+	if err := tx.Exec(ctx,
+		queries.BedOccupancyQueries.SetBedOccupee,
+		litID,
+		occupe,
+	); err != nil {
+		return fmt.Errorf("failed to update bed occupancy: %w", err)
+	}
+
+	if err := tx.Exec(ctx,
+		queries.BedOccupancyQueries.SyncRoomOccupancy,
+		chambreID,
+	); err != nil {
+		return fmt.Errorf("failed to sync room occupancy: %w", err)
+	}
+
+	return nil
+}
+
+// scanOccupation lit une ligne d'occupation de lit
+func scanOccupation(row pgx.Row) (*dto.BedOccupancyResponse, error) {
+	var occupation dto.BedOccupancyResponse
+	lit := &occupation.Lit
+	if err := row.Scan(
+		&occupation.ID,
+		&lit.LitID, &lit.NumeroLit, &lit.CodeLit, &lit.TypeLit,
+		&lit.ChambreID, &lit.NumeroChambre, &lit.NiveauEtage,
+		&lit.Batiment.ID, &lit.Batiment.Code, &lit.Batiment.Nom,
+		&lit.Categorie.ID, &lit.Categorie.Code, &lit.Categorie.Nom,
+		&occupation.Patient.ID, &occupation.Patient.CodePatient, &occupation.Patient.Nom, &occupation.Patient.Prenoms,
+		&occupation.ReferenceSejour,
+		&occupation.OccupationPrecedenteID,
+		&occupation.DebutLe,
+		&occupation.FinLe,
+		&occupation.MotifFin,
+		&occupation.Commentaire,
+		&occupation.CommentaireFin,
+		&occupation.AttribuePar,
+		&occupation.LiberePar,
+	); err != nil {
+		return nil, err
+	}
+	return &occupation, nil
+}
+
+// occupancyConflict traduit les violations des index d'occupation en cours (nil si l'erreur est d'une autre nature)
+func occupancyConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+
+	if strings.ToLower(pgErr.ConstraintName) == "uq_patients_occupation_lit_patient_en_cours" {
+		return dto.NewInfrastructureError(dto.ErrInfraPatientDejaInstalle, "Le patient occupe déjà un lit")
+	}
+	return dto.NewInfrastructureError(dto.ErrInfraLitOccupe, "Le lit est déjà occupé")
+}
@@ -0,0 +1,1019 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	"soins-suite-core/internal/modules/core-services/infrastructure/queries"
+)
+
+// InfrastructureLayoutService gère l'infrastructure physique d'un établissement :
+// bâtiments et leurs étages, catégories de chambre, chambres et lits
+// Invariants : une chambre est sur un étage de son bâtiment (0 = rez-de-chaussée), un code lit est unique
+// dans l'établissement, et un élément contenant un lit occupé ne peut pas être désactivé
+type InfrastructureLayoutService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewInfrastructureLayoutService crée une nouvelle instance du service
+func NewInfrastructureLayoutService(db *postgres.Client) *InfrastructureLayoutService {
+	return &InfrastructureLayoutService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// ListBuildings retourne les bâtiments et leur capacité (inactifs inclus sur demande)
+func (s *InfrastructureLayoutService) ListBuildings(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	includeInactive bool,
+) ([]dto.BuildingResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.InfrastructureQueries.ListBuildings,
+		etablissementID,
+		includeInactive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buildings: %w", err)
+	}
+	defer rows.Close()
+
+	batiments := []dto.BuildingResponse{}
+	for rows.Next() {
+		batiment, err := scanBuilding(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan building: %w", err)
+		}
+		batiments = append(batiments, *batiment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate buildings: %w", err)
+	}
+
+	return batiments, nil
+}
+
+// GetBuilding retourne un bâtiment
+func (s *InfrastructureLayoutService) GetBuilding(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	batimentID uuid.UUID,
+) (*dto.BuildingResponse, error) {
+	batiment, err := scanBuilding(s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.GetBuilding,
+		batimentID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraBatimentIntrouvable,
+				fmt.Sprintf("Bâtiment introuvable: %s", batimentID))
+		}
+		return nil, fmt.Errorf("failed to get building: %w", err)
+	}
+	return batiment, nil
+}
+
+// CreateBuilding crée un bâtiment (code unique dans l'établissement)
+func (s *InfrastructureLayoutService) CreateBuilding(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateBuildingRequest,
+	userID uuid.UUID,
+) (*dto.BuildingResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.CodeBatiment))
+
+	var batimentID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.InsertBuilding,
+		etablissementID,
+		code,
+		strings.TrimSpace(req.NomBatiment),
+		req.Description,
+		req.NombreEtages,
+	).Scan(&batimentID)
+	if err != nil {
+		if conflict := infrastructureConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to insert building: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Building created - ID: %s, Code: %s, User: %s\n", batimentID, code, userID)
+
+	return s.GetBuilding(ctx, etablissementID, batimentID)
+}
+
+// UpdateBuilding modifie un bâtiment
+// Le nombre d'étages ne peut pas descendre sous le plus haut étage portant une chambre
+func (s *InfrastructureLayoutService) UpdateBuilding(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	batimentID uuid.UUID,
+	req *dto.UpdateBuildingRequest,
+	userID uuid.UUID,
+) (*dto.BuildingResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if _, err := lockBuilding(ctx, tx, etablissementID, batimentID); err != nil {
+			return err
+		}
+
+		if req.NombreEtages != nil {
+			var etageMax *int
+			if err := tx.QueryRow(ctx,
+				queries.InfrastructureQueries.MaxRoomFloor,
+				batimentID,
+			).Scan(&etageMax); err != nil {
+				return fmt.Errorf("failed to get highest room floor: %w", err)
+			}
+			if etageMax != nil && *etageMax >= *req.NombreEtages {
+				return dto.NewInfrastructureError(dto.ErrInfraEtageInvalide,
+					fmt.Sprintf("Des chambres sont rattachées à l'étage « %s » : le bâtiment doit compter au moins %d étage(s)",
+						floorLabel(*etageMax), *etageMax+1))
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.UpdateBuilding,
+			batimentID,
+			etablissementID,
+			trimmed(req.NomBatiment),
+			req.Description,
+			req.NombreEtages,
+		); err != nil {
+			return fmt.Errorf("failed to update building: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Building updated - ID: %s, User: %s\n", batimentID, userID)
+
+	return s.GetBuilding(ctx, etablissementID, batimentID)
+}
+
+// SetBuildingActive active ou désactive un bâtiment (refusé tant qu'un de ses lits est occupé)
+func (s *InfrastructureLayoutService) SetBuildingActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	batimentID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.BuildingResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		batiment, err := lockBuilding(ctx, tx, etablissementID, batimentID)
+		if err != nil {
+			return err
+		}
+
+		if !actif {
+			if err := checkNoOccupiedBed(ctx, tx, etablissementID, &batimentID, nil, nil,
+				fmt.Sprintf("le bâtiment %s", batiment.nom)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.SetBuildingActif,
+			batimentID,
+			etablissementID,
+			actif,
+		); err != nil {
+			return fmt.Errorf("failed to update building status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Building status changed - ID: %s, Actif: %t, User: %s\n", batimentID, actif, userID)
+
+	return s.GetBuilding(ctx, etablissementID, batimentID)
+}
+
+// ListCategories retourne les catégories de chambre (inactives incluses sur demande)
+func (s *InfrastructureLayoutService) ListCategories(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	includeInactive bool,
+) ([]dto.RoomCategoryResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.InfrastructureQueries.ListCategories,
+		etablissementID,
+		includeInactive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list room categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories := []dto.RoomCategoryResponse{}
+	for rows.Next() {
+		categorie, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan room category: %w", err)
+		}
+		categories = append(categories, *categorie)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate room categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// GetCategory retourne une catégorie de chambre
+func (s *InfrastructureLayoutService) GetCategory(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	categorieID uuid.UUID,
+) (*dto.RoomCategoryResponse, error) {
+	categorie, err := scanCategory(s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.GetCategory,
+		categorieID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraCategorieIntrouvable,
+				fmt.Sprintf("Catégorie de chambre introuvable: %s", categorieID))
+		}
+		return nil, fmt.Errorf("failed to get room category: %w", err)
+	}
+	return categorie, nil
+}
+
+// CreateCategory crée une catégorie de chambre (code unique dans l'établissement)
+func (s *InfrastructureLayoutService) CreateCategory(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateRoomCategoryRequest,
+	userID uuid.UUID,
+) (*dto.RoomCategoryResponse, error) {
+	code := strings.ToUpper(strings.TrimSpace(req.CodeCategorie))
+
+	var categorieID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.InsertCategory,
+		etablissementID,
+		code,
+		strings.TrimSpace(req.NomCategorie),
+		req.Description,
+		req.Tarif,
+	).Scan(&categorieID)
+	if err != nil {
+		if conflict := infrastructureConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to insert room category: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Room category created - ID: %s, Code: %s, User: %s\n", categorieID, code, userID)
+
+	return s.GetCategory(ctx, etablissementID, categorieID)
+}
+
+// UpdateCategory modifie une catégorie (le tarif spécial des chambres existantes est conservé)
+func (s *InfrastructureLayoutService) UpdateCategory(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	categorieID uuid.UUID,
+	req *dto.UpdateRoomCategoryRequest,
+	userID uuid.UUID,
+) (*dto.RoomCategoryResponse, error) {
+	if _, err := s.GetCategory(ctx, etablissementID, categorieID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.InfrastructureQueries.UpdateCategory,
+		categorieID,
+		etablissementID,
+		trimmed(req.NomCategorie),
+		req.Description,
+		req.Tarif,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update room category: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Room category updated - ID: %s, User: %s\n", categorieID, userID)
+
+	return s.GetCategory(ctx, etablissementID, categorieID)
+}
+
+// SetCategoryActive active ou désactive une catégorie (refusé tant qu'un lit de ses chambres est occupé)
+// Une catégorie inactive n'est plus proposée pour de nouvelles chambres
+func (s *InfrastructureLayoutService) SetCategoryActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	categorieID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.RoomCategoryResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		categorie, err := lockCategory(ctx, tx, etablissementID, categorieID)
+		if err != nil {
+			return err
+		}
+
+		if !actif {
+			if err := checkNoOccupiedBed(ctx, tx, etablissementID, nil, &categorieID, nil,
+				fmt.Sprintf("la catégorie %s", categorie.nom)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.SetCategoryActif,
+			categorieID,
+			etablissementID,
+			actif,
+		); err != nil {
+			return fmt.Errorf("failed to update room category status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Room category status changed - ID: %s, Actif: %t, User: %s\n", categorieID, actif, userID)
+
+	return s.GetCategory(ctx, etablissementID, categorieID)
+}
+
+// ListRooms retourne les chambres filtrées par bâtiment ou catégorie
+func (s *InfrastructureLayoutService) ListRooms(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.InfrastructureFilter,
+) ([]dto.RoomResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.InfrastructureQueries.ListRooms,
+		etablissementID,
+		filter.BatimentID,
+		filter.CategorieChambreID,
+		filter.InclureInactifs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rooms: %w", err)
+	}
+	defer rows.Close()
+
+	chambres := []dto.RoomResponse{}
+	for rows.Next() {
+		chambre, err := scanRoom(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan room: %w", err)
+		}
+		chambres = append(chambres, *chambre)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate rooms: %w", err)
+	}
+
+	return chambres, nil
+}
+
+// GetRoom retourne une chambre et tous ses lits
+func (s *InfrastructureLayoutService) GetRoom(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	chambreID uuid.UUID,
+) (*dto.RoomResponse, error) {
+	chambre, err := scanRoom(s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.GetRoom,
+		chambreID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraChambreIntrouvable,
+				fmt.Sprintf("Chambre introuvable: %s", chambreID))
+		}
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.InfrastructureQueries.ListBedsOfRoom,
+		chambreID,
+		etablissementID,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list room beds: %w", err)
+	}
+	defer rows.Close()
+
+	chambre.Lits = []dto.BedResponse{}
+	for rows.Next() {
+		lit, err := scanBed(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bed: %w", err)
+		}
+		chambre.Lits = append(chambre.Lits, *lit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate room beds: %w", err)
+	}
+
+	return chambre, nil
+}
+
+// CreateRoom crée une chambre sur un étage d'un bâtiment actif, dans une catégorie active
+// Sans tarif spécial, la chambre reprend le tarif de sa catégorie
+func (s *InfrastructureLayoutService) CreateRoom(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateRoomRequest,
+	userID uuid.UUID,
+) (*dto.RoomResponse, error) {
+	typeEspace := req.TypeEspace
+	if typeEspace == "" {
+		typeEspace = dto.TypeEspaceHospitalisation
+	}
+
+	var chambreID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		batiment, err := lockBuilding(ctx, tx, etablissementID, req.BatimentID)
+		if err != nil {
+			return err
+		}
+		if !batiment.estActif {
+			return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+				fmt.Sprintf("Le bâtiment %s est inactif", batiment.nom))
+		}
+		if err := checkFloor(batiment, req.NiveauEtage); err != nil {
+			return err
+		}
+
+		categorie, err := lockCategory(ctx, tx, etablissementID, req.CategorieChambreID)
+		if err != nil {
+			return err
+		}
+		if !categorie.estActif {
+			return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+				fmt.Sprintf("La catégorie %s est inactive", categorie.nom))
+		}
+
+		tarif := categorie.tarif
+		if req.TarifSpecial != nil {
+			tarif = *req.TarifSpecial
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.InfrastructureQueries.InsertRoom,
+			etablissementID,
+			req.BatimentID,
+			req.CategorieChambreID,
+			strings.TrimSpace(req.NumeroChambre),
+			trimmed(req.NomChambre),
+			req.NiveauEtage,
+			typeEspace,
+			trimmed(req.CodeEspace),
+			tarif,
+		).Scan(&chambreID); err != nil {
+			if conflict := infrastructureConflict(err); conflict != nil {
+				return conflict
+			}
+			return fmt.Errorf("failed to insert room: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Room created - ID: %s, Numero: %s, User: %s\n", chambreID, req.NumeroChambre, userID)
+
+	return s.GetRoom(ctx, etablissementID, chambreID)
+}
+
+// UpdateRoom modifie une chambre (étage revalidé, nouvelle catégorie active exigée)
+func (s *InfrastructureLayoutService) UpdateRoom(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	chambreID uuid.UUID,
+	req *dto.UpdateRoomRequest,
+	userID uuid.UUID,
+) (*dto.RoomResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		chambre, err := lockRoom(ctx, tx, etablissementID, chambreID)
+		if err != nil {
+			return err
+		}
+
+		if req.NiveauEtage != nil {
+			if err := checkFloor(&batimentVerrouille{nom: chambre.batimentNom, nombreEtages: chambre.nombreEtages},
+				*req.NiveauEtage); err != nil {
+				return err
+			}
+		}
+
+		if req.CategorieChambreID != nil {
+			categorie, err := lockCategory(ctx, tx, etablissementID, *req.CategorieChambreID)
+			if err != nil {
+				return err
+			}
+			if !categorie.estActif {
+				return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+					fmt.Sprintf("La catégorie %s est inactive", categorie.nom))
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.UpdateRoom,
+			chambreID,
+			etablissementID,
+			req.CategorieChambreID,
+			trimmed(req.NomChambre),
+			req.NiveauEtage,
+			req.TypeEspace,
+			trimmed(req.CodeEspace),
+			req.TarifSpecial,
+		); err != nil {
+			return fmt.Errorf("failed to update room: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Room updated - ID: %s, User: %s\n", chambreID, userID)
+
+	return s.GetRoom(ctx, etablissementID, chambreID)
+}
+
+// SetRoomActive active ou désactive une chambre
+// La réactivation exige un bâtiment et une catégorie actifs ; la désactivation, aucun lit occupé
+func (s *InfrastructureLayoutService) SetRoomActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	chambreID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.RoomResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		chambre, err := lockRoom(ctx, tx, etablissementID, chambreID)
+		if err != nil {
+			return err
+		}
+
+		if actif && !(chambre.batimentActif && chambre.categorieActive) {
+			return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+				fmt.Sprintf("Chambre %s : bâtiment et catégorie doivent être actifs", chambre.numero))
+		}
+		if !actif {
+			if err := checkNoOccupiedBed(ctx, tx, etablissementID, nil, nil, &chambreID,
+				fmt.Sprintf("la chambre %s", chambre.numero)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.SetRoomActif,
+			chambreID,
+			etablissementID,
+			actif,
+		); err != nil {
+			return fmt.Errorf("failed to update room status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Room status changed - ID: %s, Actif: %t, User: %s\n", chambreID, actif, userID)
+
+	return s.GetRoom(ctx, etablissementID, chambreID)
+}
+
+// GetBed retourne un lit
+func (s *InfrastructureLayoutService) GetBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+) (*dto.BedResponse, error) {
+	lit, err := scanBed(s.db.QueryRow(ctx,
+		queries.InfrastructureQueries.GetBed,
+		litID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraLitIntrouvable,
+				fmt.Sprintf("Lit introuvable: %s", litID))
+		}
+		return nil, fmt.Errorf("failed to get bed: %w", err)
+	}
+	return lit, nil
+}
+
+// CreateBed ajoute un lit dans une chambre active (code unique dans l'établissement, numéro unique dans la chambre)
+func (s *InfrastructureLayoutService) CreateBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	chambreID uuid.UUID,
+	req *dto.CreateBedRequest,
+	userID uuid.UUID,
+) (*dto.BedResponse, error) {
+	typeLit := req.TypeLit
+	if typeLit == "" {
+		typeLit = dto.TypeLitStandard
+	}
+	code := strings.ToUpper(strings.TrimSpace(req.CodeLit))
+
+	var litID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		chambre, err := lockRoom(ctx, tx, etablissementID, chambreID)
+		if err != nil {
+			return err
+		}
+		if !chambre.estActif {
+			return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+				fmt.Sprintf("La chambre %s est inactive", chambre.numero))
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.InfrastructureQueries.InsertBed,
+			etablissementID,
+			chambreID,
+			strings.TrimSpace(req.NumeroLit),
+			code,
+			typeLit,
+		).Scan(&litID); err != nil {
+			if conflict := infrastructureConflict(err); conflict != nil {
+				return conflict
+			}
+			return fmt.Errorf("failed to insert bed: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Bed created - ID: %s, Code: %s, User: %s\n", litID, code, userID)
+
+	return s.GetBed(ctx, etablissementID, litID)
+}
+
+// UpdateBed modifie le numéro, le code ou le type d'un lit
+func (s *InfrastructureLayoutService) UpdateBed(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+	req *dto.UpdateBedRequest,
+	userID uuid.UUID,
+) (*dto.BedResponse, error) {
+	if _, err := s.GetBed(ctx, etablissementID, litID); err != nil {
+		return nil, err
+	}
+
+	var code *string
+	if req.CodeLit != nil {
+		upper := strings.ToUpper(strings.TrimSpace(*req.CodeLit))
+		code = &upper
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.InfrastructureQueries.UpdateBed,
+		litID,
+		etablissementID,
+		trimmed(req.NumeroLit),
+		code,
+		req.TypeLit,
+	); err != nil {
+		if conflict := infrastructureConflict(err); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to update bed: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Bed updated - ID: %s, User: %s\n", litID, userID)
+
+	return s.GetBed(ctx, etablissementID, litID)
+}
+
+// SetBedActive active ou désactive un lit (réactivation dans une chambre active, désactivation d'un lit libre)
+func (s *InfrastructureLayoutService) SetBedActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	litID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.BedResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		var numero string
+		var chambreActive, estOccupee, estActif bool
+		err := tx.QueryRow(ctx,
+			queries.InfrastructureQueries.LockBed,
+			litID,
+			etablissementID,
+		).Scan(&numero, &chambreActive, &estOccupee, &estActif)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewInfrastructureError(dto.ErrInfraLitIntrouvable,
+					fmt.Sprintf("Lit introuvable: %s", litID))
+			}
+			return fmt.Errorf("failed to lock bed: %w", err)
+		}
+
+		if actif && !chambreActive {
+			return dto.NewInfrastructureError(dto.ErrInfraParentInactif,
+				fmt.Sprintf("Lit %s : la chambre est inactive", numero))
+		}
+		if !actif && estOccupee {
+			return dto.NewInfrastructureError(dto.ErrInfraLitOccupe,
+				fmt.Sprintf("Le lit %s est occupé : à libérer avant désactivation", numero))
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InfrastructureQueries.SetBedActif,
+			litID,
+			etablissementID,
+			actif,
+		); err != nil {
+			return fmt.Errorf("failed to update bed status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Bed status changed - ID: %s, Actif: %t, User: %s\n", litID, actif, userID)
+
+	return s.GetBed(ctx, etablissementID, litID)
+}
+
+// batimentVerrouille représente un bâtiment verrouillé pendant une modification
+type batimentVerrouille struct {
+	nom          string
+	nombreEtages int
+	estActif     bool
+}
+
+// categorieVerrouillee représente une catégorie verrouillée pendant une modification
+type categorieVerrouillee struct {
+	nom      string
+	tarif    int
+	estActif bool
+}
+
+// chambreVerrouillee représente une chambre verrouillée et l'état de ses parents
+type chambreVerrouillee struct {
+	numero          string
+	batimentID      uuid.UUID
+	batimentNom     string
+	nombreEtages    int
+	batimentActif   bool
+	categorieActive bool
+	estActif        bool
+}
+
+// lockBuilding verrouille un bâtiment de l'établissement
+func lockBuilding(ctx context.Context, tx *postgres.Transaction, etablissementID, batimentID uuid.UUID) (*batimentVerrouille, error) {
+	var batiment batimentVerrouille
+	err := tx.QueryRow(ctx,
+		queries.InfrastructureQueries.LockBuilding,
+		batimentID,
+		etablissementID,
+	).Scan(&batiment.nom, &batiment.nombreEtages, &batiment.estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraBatimentIntrouvable,
+				fmt.Sprintf("Bâtiment introuvable: %s", batimentID))
+		}
+		return nil, fmt.Errorf("failed to lock building: %w", err)
+	}
+	return &batiment, nil
+}
+
+// lockCategory verrouille une catégorie de chambre de l'établissement
+func lockCategory(ctx context.Context, tx *postgres.Transaction, etablissementID, categorieID uuid.UUID) (*categorieVerrouillee, error) {
+	var categorie categorieVerrouillee
+	err := tx.QueryRow(ctx,
+		queries.InfrastructureQueries.LockCategory,
+		categorieID,
+		etablissementID,
+	).Scan(&categorie.nom, &categorie.tarif, &categorie.estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraCategorieIntrouvable,
+				fmt.Sprintf("Catégorie de chambre introuvable: %s", categorieID))
+		}
+		return nil, fmt.Errorf("failed to lock room category: %w", err)
+	}
+	return &categorie, nil
+}
+
+// lockRoom verrouille une chambre de l'établissement
+func lockRoom(ctx context.Context, tx *postgres.Transaction, etablissementID, chambreID uuid.UUID) (*chambreVerrouillee, error) {
+	var chambre chambreVerrouillee
+	err := tx.QueryRow(ctx,
+		queries.InfrastructureQueries.LockRoom,
+		chambreID,
+		etablissementID,
+	).Scan(
+		&chambre.numero,
+		&chambre.batimentID,
+		&chambre.batimentNom,
+		&chambre.nombreEtages,
+		&chambre.batimentActif,
+		&chambre.categorieActive,
+		&chambre.estActif,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInfrastructureError(dto.ErrInfraChambreIntrouvable,
+				fmt.Sprintf("Chambre introuvable: %s", chambreID))
+		}
+		return nil, fmt.Errorf("failed to lock room: %w", err)
+	}
+	return &chambre, nil
+}
+
+// checkFloor vérifie que l'étage appartient au bâtiment (0 = rez-de-chaussée ... nombre_etages - 1)
+func checkFloor(batiment *batimentVerrouille, niveau int) error {
+	if niveau < 0 || niveau >= batiment.nombreEtages {
+		return dto.NewInfrastructureError(dto.ErrInfraEtageInvalide,
+			fmt.Sprintf("Le bâtiment %s compte %d étage(s) : niveau %d invalide (0 = rez-de-chaussée à %d)",
+				batiment.nom, batiment.nombreEtages, niveau, batiment.nombreEtages-1))
+	}
+	return nil
+}
+
+// checkNoOccupiedBed refuse une désactivation tant qu'un lit du périmètre est occupé
+func checkNoOccupiedBed(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	batimentID, categorieID, chambreID *uuid.UUID,
+	perimetre string,
+) error {
+	var occupes int
+	if err := tx.QueryRow(ctx,
+		queries.InfrastructureQueries.CountOccupiedBeds,
+		etablissementID,
+		batimentID,
+		categorieID,
+		chambreID,
+	).Scan(&occupes); err != nil {
+		return fmt.Errorf("failed to count occupied beds: %w", err)
+	}
+	if occupes > 0 {
+		return dto.NewInfrastructureError(dto.ErrInfraLitOccupe,
+			fmt.Sprintf("%d lit(s) occupé(s) dans %s : à libérer avant désactivation", occupes, perimetre))
+	}
+	return nil
+}
+
+// floorLabel libellé d'un étage (0 = rez-de-chaussée), commun aux messages et à l'arborescence
+func floorLabel(niveau int) string {
+	if niveau == 0 {
+		return "Rez-de-chaussée"
+	}
+	return fmt.Sprintf("Étage %d", niveau)
+}
+
+// scanBuilding lit une ligne de bâtiment
+func scanBuilding(row pgx.Row) (*dto.BuildingResponse, error) {
+	var batiment dto.BuildingResponse
+	if err := row.Scan(
+		&batiment.ID,
+		&batiment.CodeBatiment,
+		&batiment.NomBatiment,
+		&batiment.Description,
+		&batiment.NombreEtages,
+		&batiment.EstActif,
+		&batiment.NombreChambres,
+		&batiment.NombreLits,
+		&batiment.LitsOccupes,
+		&batiment.CreatedAt,
+		&batiment.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &batiment, nil
+}
+
+// scanCategory lit une ligne de catégorie de chambre
+func scanCategory(row pgx.Row) (*dto.RoomCategoryResponse, error) {
+	var categorie dto.RoomCategoryResponse
+	if err := row.Scan(
+		&categorie.ID,
+		&categorie.CodeCategorie,
+		&categorie.NomCategorie,
+		&categorie.Description,
+		&categorie.Tarif,
+		&categorie.EstActif,
+		&categorie.NombreChambres,
+		&categorie.CreatedAt,
+		&categorie.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &categorie, nil
+}
+
+// scanRoom lit une ligne de chambre
+func scanRoom(row pgx.Row) (*dto.RoomResponse, error) {
+	var chambre dto.RoomResponse
+	if err := row.Scan(
+		&chambre.ID,
+		&chambre.Batiment.ID,
+		&chambre.Batiment.Code,
+		&chambre.Batiment.Nom,
+		&chambre.Categorie.ID,
+		&chambre.Categorie.Code,
+		&chambre.Categorie.Nom,
+		&chambre.NumeroChambre,
+		&chambre.NomChambre,
+		&chambre.NiveauEtage,
+		&chambre.TypeEspace,
+		&chambre.CodeEspace,
+		&chambre.TarifSpecial,
+		&chambre.EstOccupee,
+		&chambre.EstActif,
+		&chambre.NombreLits,
+		&chambre.LitsOccupes,
+		&chambre.CreatedAt,
+		&chambre.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &chambre, nil
+}
+
+// scanBed lit une ligne de lit
+func scanBed(row pgx.Row) (*dto.BedResponse, error) {
+	var lit dto.BedResponse
+	if err := row.Scan(
+		&lit.ID,
+		&lit.ChambreID,
+		&lit.NumeroLit,
+		&lit.CodeLit,
+		&lit.TypeLit,
+		&lit.EstOccupee,
+		&lit.EstActif,
+		&lit.CreatedAt,
+		&lit.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &lit, nil
+}
+
+// infrastructureConflict traduit les violations d'unicité (nil si l'erreur est d'une autre nature)
+func infrastructureConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+
+	switch strings.ToLower(pgErr.ConstraintName) {
+	case "uq_base_chambre_batiment_numero":
+		return dto.NewInfrastructureError(dto.ErrInfraNumeroExistant, "Ce numéro de chambre existe déjà dans le bâtiment")
+	case "uq_base_lit_chambre_numero":
+		return dto.NewInfrastructureError(dto.ErrInfraNumeroExistant, "Ce numéro de lit existe déjà dans la chambre")
+	case "uq_base_lit_etablissement_code":
+		return dto.NewInfrastructureError(dto.ErrInfraCodeExistant, "Ce code lit est déjà utilisé dans l'établissement")
+	default:
+		return dto.NewInfrastructureError(dto.ErrInfraCodeExistant, "Ce code est déjà utilisé dans l'établissement")
+	}
+}
+
+// trimmed retire les espaces d'une valeur optionnelle
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*value)
+	return &t
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	"soins-suite-core/internal/modules/core-services/infrastructure/queries"
+)
+
+// libelleEtageNonPrecise regroupe les chambres sans étage renseigné ou hors du bâtiment (données antérieures)
+const libelleEtageNonPrecise = "Étage non précisé"
+
+// GetTree construit l'arborescence bâtiment › étage › chambre › lit
+// Chaque bâtiment présente tous ses étages, même vides ; la capacité compte les lits actifs des chambres actives
+func (s *InfrastructureLayoutService) GetTree(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.TreeFilter,
+) ([]dto.TreeBuilding, error) {
+	rows, err := s.db.Query(ctx,
+		queries.InfrastructureQueries.TreeRows,
+		etablissementID,
+		filter.BatimentID,
+		filter.InclureInactifs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load infrastructure tree: %w", err)
+	}
+	defer rows.Close()
+
+	batiments := []dto.TreeBuilding{}
+	var chambreCourante uuid.UUID
+	var etageCourant, chambreIndex int
+
+	for rows.Next() {
+		var (
+			batiment                               dto.TreeBuilding
+			chambreID, categorieID, litID          *uuid.UUID
+			numeroChambre, nomCategorie, numeroLit *string
+			nomChambre, typeEspace, codeCategorie  *string
+			codeLit, typeLit                       *string
+			niveauEtage                            *int
+			chambreOccupee, chambreActive          bool
+			litOccupe, litActif                    bool
+		)
+		if err := rows.Scan(
+			&batiment.ID,
+			&batiment.CodeBatiment,
+			&batiment.NomBatiment,
+			&batiment.NombreEtages,
+			&batiment.EstActif,
+			&chambreID,
+			&numeroChambre,
+			&nomChambre,
+			&niveauEtage,
+			&typeEspace,
+			&chambreOccupee,
+			&chambreActive,
+			&categorieID,
+			&codeCategorie,
+			&nomCategorie,
+			&litID,
+			&numeroLit,
+			&codeLit,
+			&typeLit,
+			&litOccupe,
+			&litActif,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan infrastructure tree row: %w", err)
+		}
+
+		if len(batiments) == 0 || batiments[len(batiments)-1].ID != batiment.ID {
+			batiment.Etages = make([]dto.TreeFloor, 0, batiment.NombreEtages)
+			for niveau := 0; niveau < batiment.NombreEtages; niveau++ {
+				n := niveau
+				batiment.Etages = append(batiment.Etages, dto.TreeFloor{
+					NiveauEtage: &n,
+					Libelle:     floorLabel(niveau),
+					Chambres:    []dto.TreeRoom{},
+				})
+			}
+			batiments = append(batiments, batiment)
+			chambreCourante = uuid.Nil
+		}
+		courant := &batiments[len(batiments)-1]
+
+		if chambreID == nil {
+			continue
+		}
+
+		if *chambreID != chambreCourante {
+			etageCourant = courant.NombreEtages
+			if niveauEtage != nil && *niveauEtage >= 0 && *niveauEtage < courant.NombreEtages {
+				etageCourant = *niveauEtage
+			} else if len(courant.Etages) == courant.NombreEtages {
+				courant.Etages = append(courant.Etages, dto.TreeFloor{
+					Libelle:  libelleEtageNonPrecise,
+					Chambres: []dto.TreeRoom{},
+				})
+			}
+
+			categorie := dto.InfrastructureRef{Code: codeCategorie}
+			if categorieID != nil {
+				categorie.ID = *categorieID
+			}
+			if nomCategorie != nil {
+				categorie.Nom = *nomCategorie
+			}
+
+			etage := &courant.Etages[etageCourant]
+			etage.Chambres = append(etage.Chambres, dto.TreeRoom{
+				ID:            *chambreID,
+				NumeroChambre: *numeroChambre,
+				NomChambre:    nomChambre,
+				Categorie:     categorie,
+				TypeEspace:    typeEspace,
+				EstOccupee:    chambreOccupee,
+				EstActif:      chambreActive,
+				Lits:          []dto.TreeBed{},
+			})
+			chambreIndex = len(etage.Chambres) - 1
+			chambreCourante = *chambreID
+		}
+
+		if litID == nil {
+			continue
+		}
+
+		chambre := &courant.Etages[etageCourant].Chambres[chambreIndex]
+		chambre.Lits = append(chambre.Lits, dto.TreeBed{
+			ID:         *litID,
+			NumeroLit:  *numeroLit,
+			CodeLit:    codeLit,
+			TypeLit:    typeLit,
+			EstOccupee: litOccupe,
+			EstActif:   litActif,
+		})
+		if litActif && chambre.EstActif {
+			chambre.Capacite++
+		}
+		if litOccupe {
+			chambre.LitsOccupes++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate infrastructure tree: %w", err)
+	}
+
+	// Cumul des capacités chambre › étage › bâtiment
+	for b := range batiments {
+		for e := range batiments[b].Etages {
+			etage := &batiments[b].Etages[e]
+			for _, chambre := range etage.Chambres {
+				etage.Capacite += chambre.Capacite
+				etage.LitsOccupes += chambre.LitsOccupes
+			}
+			batiments[b].Capacite += etage.Capacite
+			batiments[b].LitsOccupes += etage.LitsOccupes
+		}
+	}
+
+	return batiments, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	"soins-suite-core/internal/modules/core-services/infrastructure/queries"
+)
+
+// libellesTypeLit libellés des types de lit au tableau d'occupation
+var libellesTypeLit = map[string]string{
+	dto.TypeLitStandard:    "Standard",
+	dto.TypeLitMedicalise:  "Médicalisé",
+	dto.TypeLitReanimation: "Réanimation",
+	dto.TypeLitNonPrecise:  "Non précisé",
+}
+
+// OccupancyBoardService calcule le tableau d'occupation des lits à partir de l'état courant (aucun cache)
+type OccupancyBoardService struct {
+	db *postgres.Client
+}
+
+// NewOccupancyBoardService crée une nouvelle instance du service
+func NewOccupancyBoardService(db *postgres.Client) *OccupancyBoardService {
+	return &OccupancyBoardService{
+		db: db,
+	}
+}
+
+// GetBoard retourne capacité, lits occupés et libres par catégorie, par bâtiment et par type de lit
+// Le total est celui des bâtiments : chaque lit attribuable y est compté une seule fois
+func (s *OccupancyBoardService) GetBoard(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.OccupancyBoardFilter,
+) (*dto.OccupancyBoard, error) {
+	rows, err := s.db.Query(ctx,
+		queries.BedOccupancyQueries.OccupancyBoard,
+		etablissementID,
+		filter.BatimentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute occupancy board: %w", err)
+	}
+	defer rows.Close()
+
+	tableau := &dto.OccupancyBoard{
+		GenereLe:     time.Now(),
+		ParCategorie: []dto.OccupancyBoardLine{},
+		ParBatiment:  []dto.OccupancyBoardLine{},
+		ParTypeLit:   []dto.OccupancyBoardLine{},
+	}
+
+	for rows.Next() {
+		var axe string
+		var ligne dto.OccupancyBoardLine
+		if err := rows.Scan(
+			&axe,
+			&ligne.ID,
+			&ligne.Code,
+			&ligne.Libelle,
+			&ligne.Capacite,
+			&ligne.Occupes,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan occupancy board line: %w", err)
+		}
+		ligne.OccupancyCounts = occupancyCounts(ligne.Capacite, ligne.Occupes)
+
+		switch axe {
+		case "categorie":
+			tableau.ParCategorie = append(tableau.ParCategorie, ligne)
+		case "batiment":
+			tableau.ParBatiment = append(tableau.ParBatiment, ligne)
+			tableau.Total.Capacite += ligne.Capacite
+			tableau.Total.Occupes += ligne.Occupes
+		case "type_lit":
+			if libelle, ok := libellesTypeLit[ligne.Libelle]; ok {
+				ligne.Libelle = libelle
+			}
+			tableau.ParTypeLit = append(tableau.ParTypeLit, ligne)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate occupancy board: %w", err)
+	}
+
+	tableau.Total = occupancyCounts(tableau.Total.Capacite, tableau.Total.Occupes)
+
+	return tableau, nil
+}
+
+// occupancyCounts complète lits libres et taux d'occupation (pourcentage arrondi au dixième)
+func occupancyCounts(capacite, occupes int) dto.OccupancyCounts {
+	compteurs := dto.OccupancyCounts{
+		Capacite: capacite,
+		Occupes:  occupes,
+		Libres:   capacite - occupes,
+	}
+	if capacite > 0 {
+		compteurs.TauxOccupation = math.Round(float64(occupes)*1000/float64(capacite)) / 10
+	}
+	return compteurs
+}
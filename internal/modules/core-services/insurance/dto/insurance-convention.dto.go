@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	catalogDTO "soins-suite-core/internal/modules/core-services/catalog/dto"
+)
+
+// Niveaux de convention (une convention sur la prestation l'emporte sur celle de son type)
+const (
+	NiveauConventionPrestation     = "prestation"
+	NiveauConventionTypePrestation = "type_prestation"
+)
+
+// Statuts d'une convention par rapport à la date du jour
+const (
+	StatutConventionPassee    = "passee"
+	StatutConventionEnVigueur = "en_vigueur"
+	StatutConventionPlanifiee = "planifiee"
+)
+
+// Origines du montant d'un acte soumis au calcul de prise en charge
+const (
+	OrigineMontantTarif = "tarif" // Tarif applicable résolu par le catalogue
+	OrigineMontantSaisi = "saisi" // Montant fourni par l'appelant (acte déjà tarifé)
+)
+
+// ConventionTerms représente les conditions de prise en charge d'une convention
+type ConventionTerms struct {
+	TauxCouverture        float64 `json:"taux_couverture" validate:"required,gt=0,lte=100"` // Pourcentage, 2 décimales
+	PlafondParActe        *int    `json:"plafond_par_acte" validate:"omitempty,gt=0"`       // Part organisme maximale (FCFA)
+	AccordPrealableRequis bool    `json:"accord_prealable_requis"`
+	DateDebutValidite     string  `json:"date_debut_validite" validate:"required,datetime=2006-01-02"`
+	DateFinValidite       *string `json:"date_fin_validite" validate:"omitempty,datetime=2006-01-02"` // Incluse, NULL = sans fin
+}
+
+// CreateConventionRequest représente une convention sur un type de prestation ou une prestation (exactement un)
+type CreateConventionRequest struct {
+	TypePrestationID     *uuid.UUID `json:"type_prestation_id"`
+	PrestationMedicaleID *uuid.UUID `json:"prestation_medicale_id"`
+	ConventionTerms
+}
+
+// ConventionFilter représente les filtres des conventions d'un organisme
+type ConventionFilter struct {
+	EnVigueurLe      string `form:"en_vigueur_le" validate:"omitempty,datetime=2006-01-02"`
+	InclureInactives bool   `form:"inclure_inactives"`
+}
+
+// ConventionTarget représente la cible d'une convention
+type ConventionTarget struct {
+	ID      uuid.UUID `json:"id"`
+	Code    string    `json:"code"`
+	Libelle string    `json:"libelle"`
+}
+
+// ConventionResponse représente une convention de prise en charge
+type ConventionResponse struct {
+	ID                    uuid.UUID        `json:"id"`
+	AssuranceID           uuid.UUID        `json:"assurance_id"`
+	Niveau                string           `json:"niveau"` // prestation, type_prestation
+	Cible                 ConventionTarget `json:"cible"`
+	TauxCouverture        float64          `json:"taux_couverture"`
+	PlafondParActe        *int             `json:"plafond_par_acte,omitempty"`
+	AccordPrealableRequis bool             `json:"accord_prealable_requis"`
+	DateDebutValidite     string           `json:"date_debut_validite"`
+	DateFinValidite       *string          `json:"date_fin_validite,omitempty"`
+	Statut                string           `json:"statut"` // passee, en_vigueur, planifiee
+	EstActif              bool             `json:"est_actif"`
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
+}
+
+// CoverageRequest représente le calcul de prise en charge d'un acte (maintenant et tarif en vigueur par défaut)
+type CoverageRequest struct {
+	PrestationID string     `form:"prestation_id" validate:"required,uuid"`
+	Montant      *int       `form:"montant" validate:"omitempty,gt=0"`
+	Instant      *time.Time `form:"instant" time_format:"2006-01-02T15:04:05"`
+}
+
+// InsurerInfo représente un organisme payeur résumé
+type InsurerInfo struct {
+	ID   uuid.UUID `json:"id"`
+	Code string    `json:"code"`
+	Nom  string    `json:"nom"`
+}
+
+// CoverageResult représente la répartition d'un acte entre l'organisme et le patient
+// PartAssurance + PartPatient = Montant ; la part patient est le ticket modérateur
+type CoverageResult struct {
+	Assurance      InsurerInfo `json:"assurance"`
+	PrestationID   uuid.UUID   `json:"prestation_id"`
+	CodePrestation string      `json:"code_prestation"`
+	Libelle        string      `json:"libelle"`
+	Instant        time.Time   `json:"instant"`
+
+	Montant        int                        `json:"montant"`
+	Unite          string                     `json:"unite"`
+	OrigineMontant string                     `json:"origine_montant"` // tarif, saisi
+	Tarif          *catalogDTO.ResolvedTariff `json:"tarif,omitempty"`
+
+	Couvert               bool                `json:"couvert"`
+	Convention            *ConventionResponse `json:"convention,omitempty"`
+	PartAssurance         int                 `json:"part_assurance"`
+	PartPatient           int                 `json:"part_patient"`
+	PlafondAtteint        bool                `json:"plafond_atteint"`
+	AccordPrealableRequis bool                `json:"accord_prealable_requis"`
+	Explication           string              `json:"explication"`
+}
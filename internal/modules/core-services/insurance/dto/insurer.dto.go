@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Types d'organisme payeur (CK_base_assurance_type_organisme)
+const (
+	TypeOrganismePublique       = "publique"
+	TypeOrganismePrivee         = "privee"
+	TypeOrganismeMutuelle       = "mutuelle"
+	TypeOrganismeInternationale = "internationale"
+)
+
+// DelaiPaiementDefaut délai de règlement appliqué quand il n'est pas précisé (jours)
+const DelaiPaiementDefaut = 30
+
+// CreateInsurerRequest représente l'enregistrement d'un organisme payeur
+type CreateInsurerRequest struct {
+	CodeOrganisme      string  `json:"code_organisme" validate:"required,min=2,max=50"`
+	NomOfficiel        string  `json:"nom_officiel" validate:"required,min=2,max=255"`
+	NomCourt           *string `json:"nom_court" validate:"omitempty,max=100"`
+	TypeOrganisme      string  `json:"type_organisme" validate:"required,oneof=publique privee mutuelle internationale"`
+	Adresse            *string `json:"adresse"`
+	Telephone          *string `json:"telephone" validate:"omitempty,max=20"`
+	Email              *string `json:"email" validate:"omitempty,email,max=255"`
+	ContactFacturation *string `json:"contact_facturation" validate:"omitempty,max=255"`
+	DelaiPaiementJours *int    `json:"delai_paiement_jours" validate:"omitempty,min=0,max=365"` // 30 par défaut
+}
+
+// UpdateInsurerRequest représente la modification partielle d'un organisme (le code est immuable)
+type UpdateInsurerRequest struct {
+	NomOfficiel        *string `json:"nom_officiel" validate:"omitempty,min=2,max=255"`
+	NomCourt           *string `json:"nom_court" validate:"omitempty,max=100"`
+	TypeOrganisme      *string `json:"type_organisme" validate:"omitempty,oneof=publique privee mutuelle internationale"`
+	Adresse            *string `json:"adresse"`
+	Telephone          *string `json:"telephone" validate:"omitempty,max=20"`
+	Email              *string `json:"email" validate:"omitempty,email,max=255"`
+	ContactFacturation *string `json:"contact_facturation" validate:"omitempty,max=255"`
+	DelaiPaiementJours *int    `json:"delai_paiement_jours" validate:"omitempty,min=0,max=365"`
+}
+
+// InsurerFilter représente les filtres de la liste des organismes
+type InsurerFilter struct {
+	TypeOrganisme   string `form:"type_organisme" validate:"omitempty,oneof=publique privee mutuelle internationale"`
+	Recherche       string `form:"recherche"` // Code, nom officiel ou nom court
+	InclureInactifs bool   `form:"inclure_inactifs"`
+}
+
+// InsurerResponse représente un organisme payeur
+type InsurerResponse struct {
+	ID                       uuid.UUID `json:"id"`
+	CodeOrganisme            string    `json:"code_organisme"`
+	NomOfficiel              string    `json:"nom_officiel"`
+	NomCourt                 *string   `json:"nom_court,omitempty"`
+	TypeOrganisme            *string   `json:"type_organisme,omitempty"`
+	Adresse                  *string   `json:"adresse,omitempty"`
+	Telephone                *string   `json:"telephone,omitempty"`
+	Email                    *string   `json:"email,omitempty"`
+	ContactFacturation       *string   `json:"contact_facturation,omitempty"`
+	DelaiPaiementJours       int       `json:"delai_paiement_jours"`
+	EstActif                 bool      `json:"est_actif"`
+	NombreConventionsActives int       `json:"nombre_conventions_actives"`
+	NombreCouverturesActives int       `json:"nombre_couvertures_actives"` // Patients couverts (patients_patient_assurance)
+	CreatedAt                time.Time `json:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at"`
+}
+
+// InsuranceError représente un refus métier sur les organismes payeurs et leurs conventions
+type InsuranceError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs des assurances
+const (
+	ErrAssuranceIntrouvable            = "ASSURANCE_INTROUVABLE"
+	ErrAssuranceCodeExistant           = "ASSURANCE_CODE_EXISTANT"
+	ErrAssuranceInactive               = "ASSURANCE_INACTIVE"
+	ErrConventionIntrouvable           = "CONVENTION_INTROUVABLE"
+	ErrConventionCibleInvalide         = "CONVENTION_CIBLE_INVALIDE"
+	ErrConventionPeriodeInvalide       = "CONVENTION_PERIODE_INVALIDE"
+	ErrConventionChevauchement         = "CONVENTION_CHEVAUCHEMENT"
+	ErrPriseEnChargePrestationInvalide = "PRISE_EN_CHARGE_PRESTATION_INVALIDE"
+)
+
+// NewInsuranceError crée une nouvelle erreur des assurances
+func NewInsuranceError(code, message string) *InsuranceError {
+	return &InsuranceError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *InsuranceError) Error() string {
+	return e.Message
+}
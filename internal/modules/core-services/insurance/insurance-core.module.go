@@ -0,0 +1,15 @@
+package insurance
+
+import (
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/modules/core-services/insurance/services"
+)
+
+// Module regroupe les services métier des organismes payeurs (SANS endpoints)
+// Core Service : organismes, conventions et prise en charge réutilisables par l'accueil, la caisse et la facturation
+var Module = fx.Options(
+	fx.Provide(services.NewInsurerService),             // CS-ASS-001: Organismes payeurs
+	fx.Provide(services.NewInsuranceConventionService), // CS-ASS-002: Conventions (taux, plafond, accord préalable, validité)
+	fx.Provide(services.NewCoverageCalculatorService),  // CS-ASS-003: Part organisme et ticket modérateur d'un acte
+)
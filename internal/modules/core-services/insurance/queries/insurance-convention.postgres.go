@@ -0,0 +1,195 @@
+package queries
+
+// InsuranceConventionQueries contient les requêtes SQL des conventions et du calcul de prise en charge
+var InsuranceConventionQueries = struct {
+	ListConventions           string
+	GetConvention             string
+	LockConvention            string
+	GetTypePrestationTarget   string
+	GetPrestationTarget       string
+	FindOverlappingConvention string
+	InsertConvention          string
+	UpdateConvention          string
+	SetConventionActif        string
+	GetInsurerForCoverage     string
+	GetPrestationForCoverage  string
+	FindApplicableConvention  string
+}{
+	// ListConventions - Conventions d'un organisme (en vigueur à une date optionnelle, inactives sur demande)
+	ListConventions: `
+		SELECT
+			c.id,
+			c.assurance_id,
+			CASE WHEN c.prestation_medicale_id IS NOT NULL THEN 'prestation' ELSE 'type_prestation' END,
+			COALESCE(p.id, tp.id),
+			COALESCE(p.code_prestation, tp.code_type),
+			COALESCE(p.libelle, tp.libelle),
+			c.taux_couverture::float8,
+			c.plafond_par_acte,
+			COALESCE(c.accord_prealable_requis, FALSE),
+			to_char(c.date_debut_validite, 'YYYY-MM-DD'),
+			to_char(c.date_fin_validite, 'YYYY-MM-DD'),
+			CASE
+				WHEN c.date_debut_validite > CURRENT_DATE THEN 'planifiee'
+				WHEN c.date_fin_validite < CURRENT_DATE THEN 'passee'
+				ELSE 'en_vigueur'
+			END,
+			COALESCE(c.est_actif, TRUE),
+			c.created_at,
+			c.updated_at
+		FROM base_convention_assurance c
+		LEFT JOIN base_type_prestation tp ON tp.id = c.type_prestation_id
+		LEFT JOIN base_prestation_medicale p ON p.id = c.prestation_medicale_id
+		WHERE c.assurance_id = $1
+		AND c.etablissement_id = $2
+		AND ($3::text = '' OR (c.date_debut_validite <= $3::date
+			AND (c.date_fin_validite IS NULL OR c.date_fin_validite >= $3::date)))
+		AND ($4::boolean OR COALESCE(c.est_actif, TRUE) = TRUE)
+		ORDER BY
+			CASE WHEN c.prestation_medicale_id IS NOT NULL THEN 2 ELSE 1 END,
+			COALESCE(p.libelle, tp.libelle),
+			c.date_debut_validite DESC;
+	`,
+
+	// GetConvention - Convention d'un organisme
+	GetConvention: `
+		SELECT
+			c.id,
+			c.assurance_id,
+			CASE WHEN c.prestation_medicale_id IS NOT NULL THEN 'prestation' ELSE 'type_prestation' END,
+			COALESCE(p.id, tp.id),
+			COALESCE(p.code_prestation, tp.code_type),
+			COALESCE(p.libelle, tp.libelle),
+			c.taux_couverture::float8,
+			c.plafond_par_acte,
+			COALESCE(c.accord_prealable_requis, FALSE),
+			to_char(c.date_debut_validite, 'YYYY-MM-DD'),
+			to_char(c.date_fin_validite, 'YYYY-MM-DD'),
+			CASE
+				WHEN c.date_debut_validite > CURRENT_DATE THEN 'planifiee'
+				WHEN c.date_fin_validite < CURRENT_DATE THEN 'passee'
+				ELSE 'en_vigueur'
+			END,
+			COALESCE(c.est_actif, TRUE),
+			c.created_at,
+			c.updated_at
+		FROM base_convention_assurance c
+		LEFT JOIN base_type_prestation tp ON tp.id = c.type_prestation_id
+		LEFT JOIN base_prestation_medicale p ON p.id = c.prestation_medicale_id
+		WHERE c.id = $1
+		AND c.assurance_id = $2
+		AND c.etablissement_id = $3;
+	`,
+
+	// LockConvention - Convention à modifier (cible, période et activation pour le contrôle de chevauchement)
+	LockConvention: `
+		SELECT
+			type_prestation_id,
+			prestation_medicale_id,
+			to_char(date_debut_validite, 'YYYY-MM-DD'),
+			to_char(date_fin_validite, 'YYYY-MM-DD'),
+			COALESCE(est_actif, TRUE)
+		FROM base_convention_assurance
+		WHERE id = $1
+		AND assurance_id = $2
+		AND etablissement_id = $3
+		FOR UPDATE;
+	`,
+
+	// GetTypePrestationTarget - Type de prestation ciblé par une convention
+	GetTypePrestationTarget: `
+		SELECT libelle, COALESCE(est_actif, TRUE)
+		FROM base_type_prestation
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// GetPrestationTarget - Prestation ciblée par une convention
+	GetPrestationTarget: `
+		SELECT libelle, COALESCE(est_actif, TRUE)
+		FROM base_prestation_medicale
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// FindOverlappingConvention - Convention active de même cible dont la période [début, fin] chevauche
+	FindOverlappingConvention: `
+		SELECT
+			to_char(date_debut_validite, 'YYYY-MM-DD'),
+			to_char(date_fin_validite, 'YYYY-MM-DD')
+		FROM base_convention_assurance
+		WHERE assurance_id = $1
+		AND type_prestation_id IS NOT DISTINCT FROM $2
+		AND prestation_medicale_id IS NOT DISTINCT FROM $3
+		AND COALESCE(est_actif, TRUE) = TRUE
+		AND id <> $6
+		AND daterange(date_debut_validite, date_fin_validite, '[]')
+			&& daterange($4::date, $5::date, '[]')
+		ORDER BY date_debut_validite
+		LIMIT 1;
+	`,
+
+	// InsertConvention - Nouvelle convention (trigger_convention_assurance_chevauchement en garde-fou)
+	InsertConvention: `
+		INSERT INTO base_convention_assurance (
+			etablissement_id, assurance_id, type_prestation_id, prestation_medicale_id,
+			taux_couverture, plafond_par_acte, accord_prealable_requis,
+			date_debut_validite, date_fin_validite, created_by, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8::date, $9::date, $10, $10
+		) RETURNING id;
+	`,
+
+	// UpdateConvention - Remplacement des conditions (la cible est immuable)
+	UpdateConvention: `
+		UPDATE base_convention_assurance SET
+			taux_couverture         = $4,
+			plafond_par_acte        = $5,
+			accord_prealable_requis = $6,
+			date_debut_validite     = $7::date,
+			date_fin_validite       = $8::date,
+			updated_by              = $9
+		WHERE id = $1
+		AND assurance_id = $2
+		AND etablissement_id = $3;
+	`,
+
+	// SetConventionActif - Activation ou résiliation d'une convention
+	SetConventionActif: `
+		UPDATE base_convention_assurance SET
+			est_actif  = $4,
+			updated_by = $5
+		WHERE id = $1
+		AND assurance_id = $2
+		AND etablissement_id = $3;
+	`,
+
+	// GetInsurerForCoverage - Organisme payeur du calcul (résumé et activation)
+	GetInsurerForCoverage: `
+		SELECT id, code_organisme, nom_officiel, COALESCE(est_actif, TRUE)
+		FROM base_assurance
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// GetPrestationForCoverage - Prestation soumise au calcul et son type (cible des conventions)
+	GetPrestationForCoverage: `
+		SELECT id, code_prestation, libelle, type_prestation_id, COALESCE(est_actif, TRUE)
+		FROM base_prestation_medicale
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// FindApplicableConvention - Convention active à la date de l'acte (prestation avant type de prestation)
+	FindApplicableConvention: `
+		SELECT c.id
+		FROM base_convention_assurance c
+		WHERE c.assurance_id = $1
+		AND COALESCE(c.est_actif, TRUE) = TRUE
+		AND (c.prestation_medicale_id = $2 OR c.type_prestation_id = $3)
+		AND c.date_debut_validite <= $4::date
+		AND (c.date_fin_validite IS NULL OR c.date_fin_validite >= $4::date)
+		ORDER BY CASE WHEN c.prestation_medicale_id IS NOT NULL THEN 1 ELSE 2 END
+		LIMIT 1;
+	`,
+}
@@ -0,0 +1,110 @@
+package queries
+
+// InsurerQueries contient les requêtes SQL des organismes payeurs
+var InsurerQueries = struct {
+	ListInsurers    string
+	GetInsurer      string
+	LockInsurer     string
+	InsertInsurer   string
+	UpdateInsurer   string
+	SetInsurerActif string
+}{
+	// ListInsurers - Organismes de l'établissement (type et recherche optionnels, inactifs sur demande)
+	ListInsurers: `
+		SELECT
+			a.id,
+			a.code_organisme,
+			a.nom_officiel,
+			a.nom_court,
+			a.type_organisme,
+			a.adresse,
+			a.telephone,
+			a.email,
+			a.contact_facturation,
+			COALESCE(a.delai_paiement_jours, 30),
+			COALESCE(a.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_convention_assurance c
+			 WHERE c.assurance_id = a.id AND COALESCE(c.est_actif, TRUE) = TRUE
+			 AND (c.date_fin_validite IS NULL OR c.date_fin_validite >= CURRENT_DATE)),
+			(SELECT COUNT(*) FROM patients_patient_assurance pa
+			 WHERE pa.assurance_id = a.id AND pa.est_actif = TRUE),
+			a.created_at,
+			a.updated_at
+		FROM base_assurance a
+		WHERE a.etablissement_id = $1
+		AND ($2::text = '' OR a.type_organisme = $2)
+		AND ($3::text = '' OR a.code_organisme ILIKE '%' || $3 || '%'
+			OR a.nom_officiel ILIKE '%' || $3 || '%'
+			OR a.nom_court ILIKE '%' || $3 || '%')
+		AND ($4::boolean OR COALESCE(a.est_actif, TRUE) = TRUE)
+		ORDER BY a.nom_officiel;
+	`,
+
+	// GetInsurer - Organisme de l'établissement
+	GetInsurer: `
+		SELECT
+			a.id,
+			a.code_organisme,
+			a.nom_officiel,
+			a.nom_court,
+			a.type_organisme,
+			a.adresse,
+			a.telephone,
+			a.email,
+			a.contact_facturation,
+			COALESCE(a.delai_paiement_jours, 30),
+			COALESCE(a.est_actif, TRUE),
+			(SELECT COUNT(*) FROM base_convention_assurance c
+			 WHERE c.assurance_id = a.id AND COALESCE(c.est_actif, TRUE) = TRUE
+			 AND (c.date_fin_validite IS NULL OR c.date_fin_validite >= CURRENT_DATE)),
+			(SELECT COUNT(*) FROM patients_patient_assurance pa
+			 WHERE pa.assurance_id = a.id AND pa.est_actif = TRUE),
+			a.created_at,
+			a.updated_at
+		FROM base_assurance a
+		WHERE a.id = $1
+		AND a.etablissement_id = $2;
+	`,
+
+	// LockInsurer - Organisme dont les conventions sont modifiées (sérialise les contrôles de chevauchement)
+	LockInsurer: `
+		SELECT code_organisme, nom_officiel, COALESCE(est_actif, TRUE)
+		FROM base_assurance
+		WHERE id = $1
+		AND etablissement_id = $2
+		FOR UPDATE;
+	`,
+
+	// InsertInsurer - Enregistrement d'un organisme (UQ_base_assurance_etablissement_code)
+	InsertInsurer: `
+		INSERT INTO base_assurance (
+			etablissement_id, code_organisme, nom_officiel, nom_court, type_organisme,
+			adresse, telephone, email, contact_facturation, delai_paiement_jours
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+		) RETURNING id;
+	`,
+
+	// UpdateInsurer - Modification partielle (le code est immuable)
+	UpdateInsurer: `
+		UPDATE base_assurance SET
+			nom_officiel         = COALESCE($3, nom_officiel),
+			nom_court            = COALESCE($4, nom_court),
+			type_organisme       = COALESCE($5, type_organisme),
+			adresse              = COALESCE($6, adresse),
+			telephone            = COALESCE($7, telephone),
+			email                = COALESCE($8, email),
+			contact_facturation  = COALESCE($9, contact_facturation),
+			delai_paiement_jours = COALESCE($10, delai_paiement_jours)
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+
+	// SetInsurerActif - Activation ou désactivation d'un organisme
+	SetInsurerActif: `
+		UPDATE base_assurance SET
+			est_actif = $3
+		WHERE id = $1
+		AND etablissement_id = $2;
+	`,
+}
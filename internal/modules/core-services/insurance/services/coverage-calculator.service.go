@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	catalogServices "soins-suite-core/internal/modules/core-services/catalog/services"
+	"soins-suite-core/internal/modules/core-services/insurance/dto"
+	"soins-suite-core/internal/modules/core-services/insurance/queries"
+)
+
+// uniteMontantDefaut unité d'un montant fourni par l'appelant
+const uniteMontantDefaut = "FCFA"
+
+// CoverageCalculatorService répartit le prix d'un acte entre l'organisme payeur et le patient
+// Base commune de la caisse et de la facturation des organismes : la part patient est le ticket modérateur
+type CoverageCalculatorService struct {
+	db             *postgres.Client
+	tariffResolver *catalogServices.TariffResolverService
+}
+
+// NewCoverageCalculatorService crée une nouvelle instance du service
+func NewCoverageCalculatorService(
+	db *postgres.Client,
+	tariffResolver *catalogServices.TariffResolverService,
+) *CoverageCalculatorService {
+	return &CoverageCalculatorService{
+		db:             db,
+		tariffResolver: tariffResolver,
+	}
+}
+
+// CalculateCoverage calcule la prise en charge d'une prestation par un organisme à un instant donné
+// Le montant est celui fourni (acte déjà tarifé) ou, à défaut, le tarif applicable à l'instant
+// La convention sur la prestation l'emporte sur celle de son type ; sans convention, le patient paie tout
+// Part organisme = montant × taux (arrondie au franc inférieur), limitée au plafond par acte
+func (s *CoverageCalculatorService) CalculateCoverage(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	prestationID uuid.UUID,
+	instant time.Time,
+	montant *int,
+) (*dto.CoverageResult, error) {
+	result := &dto.CoverageResult{
+		PrestationID: prestationID,
+		Instant:      instant,
+	}
+
+	var assuranceActive bool
+	err := s.db.QueryRow(ctx,
+		queries.InsuranceConventionQueries.GetInsurerForCoverage,
+		assuranceID,
+		etablissementID,
+	).Scan(&result.Assurance.ID, &result.Assurance.Code, &result.Assurance.Nom, &assuranceActive)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInsuranceError(dto.ErrAssuranceIntrouvable,
+				fmt.Sprintf("Organisme payeur introuvable: %s", assuranceID))
+		}
+		return nil, fmt.Errorf("failed to get insurer: %w", err)
+	}
+	if !assuranceActive {
+		return nil, dto.NewInsuranceError(dto.ErrAssuranceInactive,
+			fmt.Sprintf("L'organisme %s (%s) est inactif", result.Assurance.Nom, result.Assurance.Code))
+	}
+
+	var typePrestationID uuid.UUID
+	var prestationActive bool
+	err = s.db.QueryRow(ctx,
+		queries.InsuranceConventionQueries.GetPrestationForCoverage,
+		prestationID,
+		etablissementID,
+	).Scan(&result.PrestationID, &result.CodePrestation, &result.Libelle, &typePrestationID, &prestationActive)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInsuranceError(dto.ErrPriseEnChargePrestationInvalide,
+				fmt.Sprintf("Prestation introuvable: %s", prestationID))
+		}
+		return nil, fmt.Errorf("failed to get prestation: %w", err)
+	}
+	if !prestationActive {
+		return nil, dto.NewInsuranceError(dto.ErrPriseEnChargePrestationInvalide,
+			fmt.Sprintf("La prestation %s est retirée du catalogue", result.CodePrestation))
+	}
+
+	if montant != nil {
+		result.Montant = *montant
+		result.Unite = uniteMontantDefaut
+		result.OrigineMontant = dto.OrigineMontantSaisi
+	} else {
+		tarif, err := s.tariffResolver.ResolveTariff(ctx, etablissementID, prestationID, instant)
+		if err != nil {
+			return nil, err
+		}
+		result.Montant = tarif.Montant
+		result.Unite = tarif.Unite
+		result.OrigineMontant = dto.OrigineMontantTarif
+		result.Tarif = tarif
+	}
+
+	var conventionID uuid.UUID
+	err = s.db.QueryRow(ctx,
+		queries.InsuranceConventionQueries.FindApplicableConvention,
+		assuranceID,
+		prestationID,
+		typePrestationID,
+		instant.Format("2006-01-02"),
+	).Scan(&conventionID)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to find applicable convention: %w", err)
+	}
+	if err == nil {
+		convention, err := scanConvention(s.db.QueryRow(ctx,
+			queries.InsuranceConventionQueries.GetConvention,
+			conventionID,
+			assuranceID,
+			etablissementID,
+		))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applicable convention: %w", err)
+		}
+		result.Convention = convention
+	}
+
+	applyConvention(result)
+
+	return result, nil
+}
+
+// applyConvention calcule les parts organisme et patient selon la convention
+func applyConvention(result *dto.CoverageResult) {
+	convention := result.Convention
+	if convention == nil {
+		result.PartPatient = result.Montant
+		result.Explication = fmt.Sprintf("Aucune convention de %s ne couvre cette prestation : à la charge du patient",
+			result.Assurance.Nom)
+		return
+	}
+
+	result.Couvert = true
+	result.AccordPrealableRequis = convention.AccordPrealableRequis
+
+	tauxCentiemes := int64(math.Round(convention.TauxCouverture * 100))
+	partAssurance := int(int64(result.Montant) * tauxCentiemes / 10000)
+
+	result.Explication = fmt.Sprintf("Prise en charge à %s %% (convention %s)",
+		formatTaux(convention.TauxCouverture), libelleNiveau(convention.Niveau))
+
+	if convention.PlafondParActe != nil && partAssurance > *convention.PlafondParActe {
+		partAssurance = *convention.PlafondParActe
+		result.PlafondAtteint = true
+		result.Explication = fmt.Sprintf("Prise en charge à %s %% plafonnée à %d %s par acte (convention %s)",
+			formatTaux(convention.TauxCouverture), *convention.PlafondParActe, result.Unite,
+			libelleNiveau(convention.Niveau))
+	}
+
+	result.PartAssurance = partAssurance
+	result.PartPatient = result.Montant - partAssurance
+
+	if convention.AccordPrealableRequis {
+		result.Explication += " ; accord préalable de l'organisme requis"
+	}
+}
+
+// libelleNiveau décrit le niveau de la convention appliquée
+func libelleNiveau(niveau string) string {
+	if niveau == dto.NiveauConventionPrestation {
+		return "sur la prestation"
+	}
+	return "sur le type de prestation"
+}
+
+// formatTaux affiche un taux sans décimales inutiles (80, 72.5, 66.67)
+func formatTaux(taux float64) string {
+	return fmt.Sprintf("%g", math.Round(taux*100)/100)
+}
@@ -0,0 +1,420 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/insurance/dto"
+	"soins-suite-core/internal/modules/core-services/insurance/queries"
+)
+
+// InsuranceConventionService gère les conventions de prise en charge d'un organisme payeur
+// Invariant : pour un organisme et une cible (type de prestation ou prestation), les conventions actives
+// ont des périodes de validité disjointes ; les contrôles sont sérialisés par un verrou sur l'organisme
+type InsuranceConventionService struct {
+	db        *postgres.Client
+	txManager *postgres.TransactionManager
+}
+
+// NewInsuranceConventionService crée une nouvelle instance du service
+func NewInsuranceConventionService(db *postgres.Client) *InsuranceConventionService {
+	return &InsuranceConventionService{
+		db:        db,
+		txManager: postgres.NewTransactionManager(db),
+	}
+}
+
+// ListConventions retourne les conventions d'un organisme
+func (s *InsuranceConventionService) ListConventions(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	filter *dto.ConventionFilter,
+) ([]dto.ConventionResponse, error) {
+	if _, err := getInsurer(ctx, s.db, etablissementID, assuranceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.InsuranceConventionQueries.ListConventions,
+		assuranceID,
+		etablissementID,
+		filter.EnVigueurLe,
+		filter.InclureInactives,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conventions: %w", err)
+	}
+	defer rows.Close()
+
+	conventions := []dto.ConventionResponse{}
+	for rows.Next() {
+		convention, err := scanConvention(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan convention: %w", err)
+		}
+		conventions = append(conventions, *convention)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate conventions: %w", err)
+	}
+
+	return conventions, nil
+}
+
+// GetConvention retourne une convention d'un organisme
+func (s *InsuranceConventionService) GetConvention(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	conventionID uuid.UUID,
+) (*dto.ConventionResponse, error) {
+	convention, err := scanConvention(s.db.QueryRow(ctx,
+		queries.InsuranceConventionQueries.GetConvention,
+		conventionID,
+		assuranceID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInsuranceError(dto.ErrConventionIntrouvable,
+				fmt.Sprintf("Convention introuvable: %s", conventionID))
+		}
+		return nil, fmt.Errorf("failed to get convention: %w", err)
+	}
+	return convention, nil
+}
+
+// CreateConvention ajoute une convention sur un type de prestation ou une prestation active
+// L'organisme doit être actif et la période ne doit chevaucher aucune convention active de même cible
+func (s *InsuranceConventionService) CreateConvention(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	req *dto.CreateConventionRequest,
+	userID uuid.UUID,
+) (*dto.ConventionResponse, error) {
+	if (req.TypePrestationID == nil) == (req.PrestationMedicaleID == nil) {
+		return nil, dto.NewInsuranceError(dto.ErrConventionCibleInvalide,
+			"Une convention cible soit un type de prestation, soit une prestation (exactement un des deux)")
+	}
+	if err := validatePeriod(req.DateDebutValidite, req.DateFinValidite); err != nil {
+		return nil, err
+	}
+
+	var conventionID uuid.UUID
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if err := lockInsurer(ctx, tx, etablissementID, assuranceID, true); err != nil {
+			return err
+		}
+
+		if err := checkConventionTarget(ctx, tx, etablissementID, req.TypePrestationID, req.PrestationMedicaleID); err != nil {
+			return err
+		}
+
+		if err := checkOverlap(ctx, tx, assuranceID, req.TypePrestationID, req.PrestationMedicaleID,
+			req.DateDebutValidite, req.DateFinValidite, uuid.Nil); err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.InsuranceConventionQueries.InsertConvention,
+			etablissementID,
+			assuranceID,
+			req.TypePrestationID,
+			req.PrestationMedicaleID,
+			roundTaux(req.TauxCouverture),
+			req.PlafondParActe,
+			req.AccordPrealableRequis,
+			req.DateDebutValidite,
+			req.DateFinValidite,
+			userID,
+		).Scan(&conventionID); err != nil {
+			return fmt.Errorf("failed to insert convention: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Insurance convention created - ID: %s, Assurance: %s, Taux: %.2f, User: %s\n",
+		conventionID, assuranceID, req.TauxCouverture, userID)
+
+	return s.GetConvention(ctx, etablissementID, assuranceID, conventionID)
+}
+
+// UpdateConvention remplace les conditions d'une convention (taux, plafond, accord préalable, période)
+// La cible est immuable : une nouvelle cible fait l'objet d'une nouvelle convention
+func (s *InsuranceConventionService) UpdateConvention(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	conventionID uuid.UUID,
+	req *dto.ConventionTerms,
+	userID uuid.UUID,
+) (*dto.ConventionResponse, error) {
+	if err := validatePeriod(req.DateDebutValidite, req.DateFinValidite); err != nil {
+		return nil, err
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if err := lockInsurer(ctx, tx, etablissementID, assuranceID, true); err != nil {
+			return err
+		}
+
+		locked, err := lockConvention(ctx, tx, etablissementID, assuranceID, conventionID)
+		if err != nil {
+			return err
+		}
+
+		if locked.estActif {
+			if err := checkOverlap(ctx, tx, assuranceID, locked.typePrestationID, locked.prestationID,
+				req.DateDebutValidite, req.DateFinValidite, conventionID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InsuranceConventionQueries.UpdateConvention,
+			conventionID,
+			assuranceID,
+			etablissementID,
+			roundTaux(req.TauxCouverture),
+			req.PlafondParActe,
+			req.AccordPrealableRequis,
+			req.DateDebutValidite,
+			req.DateFinValidite,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to update convention: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Insurance convention updated - ID: %s, Taux: %.2f, User: %s\n",
+		conventionID, req.TauxCouverture, userID)
+
+	return s.GetConvention(ctx, etablissementID, assuranceID, conventionID)
+}
+
+// SetConventionActive réactive ou résilie une convention
+// La réactivation revérifie l'absence de chevauchement avec les conventions actives de même cible
+func (s *InsuranceConventionService) SetConventionActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	conventionID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.ConventionResponse, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if err := lockInsurer(ctx, tx, etablissementID, assuranceID, actif); err != nil {
+			return err
+		}
+
+		locked, err := lockConvention(ctx, tx, etablissementID, assuranceID, conventionID)
+		if err != nil {
+			return err
+		}
+
+		if actif && !locked.estActif {
+			if err := checkOverlap(ctx, tx, assuranceID, locked.typePrestationID, locked.prestationID,
+				locked.dateDebut, locked.dateFin, conventionID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(ctx,
+			queries.InsuranceConventionQueries.SetConventionActif,
+			conventionID,
+			assuranceID,
+			etablissementID,
+			actif,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to update convention status: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Insurance convention status changed - ID: %s, Actif: %t, User: %s\n", conventionID, actif, userID)
+
+	return s.GetConvention(ctx, etablissementID, assuranceID, conventionID)
+}
+
+// conventionVerrouillee représente la convention verrouillée pendant sa modification
+type conventionVerrouillee struct {
+	typePrestationID *uuid.UUID
+	prestationID     *uuid.UUID
+	dateDebut        string
+	dateFin          *string
+	estActif         bool
+}
+
+// lockInsurer verrouille l'organisme jusqu'à la fin de la transaction (actif exigé sur demande)
+func lockInsurer(ctx context.Context, tx *postgres.Transaction, etablissementID, assuranceID uuid.UUID, exigerActif bool) error {
+	var code, nom string
+	var estActif bool
+	err := tx.QueryRow(ctx,
+		queries.InsurerQueries.LockInsurer,
+		assuranceID,
+		etablissementID,
+	).Scan(&code, &nom, &estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewInsuranceError(dto.ErrAssuranceIntrouvable,
+				fmt.Sprintf("Organisme payeur introuvable: %s", assuranceID))
+		}
+		return fmt.Errorf("failed to lock insurer: %w", err)
+	}
+	if exigerActif && !estActif {
+		return dto.NewInsuranceError(dto.ErrAssuranceInactive,
+			fmt.Sprintf("L'organisme %s (%s) est inactif : à réactiver d'abord", nom, code))
+	}
+	return nil
+}
+
+// lockConvention verrouille une convention de l'organisme
+func lockConvention(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID, assuranceID, conventionID uuid.UUID,
+) (*conventionVerrouillee, error) {
+	var locked conventionVerrouillee
+	err := tx.QueryRow(ctx,
+		queries.InsuranceConventionQueries.LockConvention,
+		conventionID,
+		assuranceID,
+		etablissementID,
+	).Scan(&locked.typePrestationID, &locked.prestationID, &locked.dateDebut, &locked.dateFin, &locked.estActif)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInsuranceError(dto.ErrConventionIntrouvable,
+				fmt.Sprintf("Convention introuvable: %s", conventionID))
+		}
+		return nil, fmt.Errorf("failed to lock convention: %w", err)
+	}
+	return &locked, nil
+}
+
+// checkConventionTarget vérifie que la cible existe dans l'établissement et est active
+func checkConventionTarget(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	etablissementID uuid.UUID,
+	typePrestationID, prestationID *uuid.UUID,
+) error {
+	query, cibleID, nature, inactif := queries.InsuranceConventionQueries.GetTypePrestationTarget, typePrestationID,
+		"Type de prestation", "Type de prestation inactif"
+	if prestationID != nil {
+		query, cibleID, nature, inactif = queries.InsuranceConventionQueries.GetPrestationTarget, prestationID,
+			"Prestation", "Prestation retirée du catalogue"
+	}
+
+	var libelle string
+	var estActif bool
+	if err := tx.QueryRow(ctx, query, *cibleID, etablissementID).Scan(&libelle, &estActif); err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewInsuranceError(dto.ErrConventionCibleInvalide,
+				fmt.Sprintf("%s introuvable: %s", nature, *cibleID))
+		}
+		return fmt.Errorf("failed to get convention target: %w", err)
+	}
+	if !estActif {
+		return dto.NewInsuranceError(dto.ErrConventionCibleInvalide,
+			fmt.Sprintf("%s : %s", inactif, libelle))
+	}
+	return nil
+}
+
+// checkOverlap refuse une période qui chevauche une autre convention active de même cible
+func checkOverlap(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	assuranceID uuid.UUID,
+	typePrestationID, prestationID *uuid.UUID,
+	dateDebut string,
+	dateFin *string,
+	exclureID uuid.UUID,
+) error {
+	var debut string
+	var fin *string
+	err := tx.QueryRow(ctx,
+		queries.InsuranceConventionQueries.FindOverlappingConvention,
+		assuranceID,
+		typePrestationID,
+		prestationID,
+		dateDebut,
+		dateFin,
+		exclureID,
+	).Scan(&debut, &fin)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check convention overlap: %w", err)
+	}
+
+	periode := fmt.Sprintf("à partir du %s", debut)
+	if fin != nil {
+		periode = fmt.Sprintf("du %s au %s", debut, *fin)
+	}
+	return dto.NewInsuranceError(dto.ErrConventionChevauchement,
+		fmt.Sprintf("La période chevauche une convention active sur la même cible (%s)", periode))
+}
+
+// validatePeriod vérifie que la fin de validité (incluse) n'est pas antérieure au début
+// Les dates sont au format AAAA-MM-JJ (validées en amont), leur ordre lexical est chronologique
+func validatePeriod(dateDebut string, dateFin *string) error {
+	if dateFin != nil && *dateFin < dateDebut {
+		return dto.NewInsuranceError(dto.ErrConventionPeriodeInvalide,
+			fmt.Sprintf("La fin de validité (%s) précède le début (%s)", *dateFin, dateDebut))
+	}
+	return nil
+}
+
+// roundTaux arrondit le taux de couverture au centième (NUMERIC(5,2))
+func roundTaux(taux float64) float64 {
+	return math.Round(taux*100) / 100
+}
+
+// scanConvention lit une ligne de convention
+func scanConvention(row pgx.Row) (*dto.ConventionResponse, error) {
+	var convention dto.ConventionResponse
+	if err := row.Scan(
+		&convention.ID,
+		&convention.AssuranceID,
+		&convention.Niveau,
+		&convention.Cible.ID,
+		&convention.Cible.Code,
+		&convention.Cible.Libelle,
+		&convention.TauxCouverture,
+		&convention.PlafondParActe,
+		&convention.AccordPrealableRequis,
+		&convention.DateDebutValidite,
+		&convention.DateFinValidite,
+		&convention.Statut,
+		&convention.EstActif,
+		&convention.CreatedAt,
+		&convention.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &convention, nil
+}
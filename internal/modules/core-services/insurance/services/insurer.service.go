@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/insurance/dto"
+	"soins-suite-core/internal/modules/core-services/insurance/queries"
+)
+
+// InsurerService gère les organismes payeurs d'un établissement (assurances publiques, privées, mutuelles)
+// Un organisme inactif reste consultable mais n'accepte plus de nouvelles couvertures ni de calcul de prise en charge
+type InsurerService struct {
+	db *postgres.Client
+}
+
+// NewInsurerService crée une nouvelle instance du service
+func NewInsurerService(db *postgres.Client) *InsurerService {
+	return &InsurerService{
+		db: db,
+	}
+}
+
+// ListInsurers retourne les organismes de l'établissement
+func (s *InsurerService) ListInsurers(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	filter *dto.InsurerFilter,
+) ([]dto.InsurerResponse, error) {
+	rows, err := s.db.Query(ctx,
+		queries.InsurerQueries.ListInsurers,
+		etablissementID,
+		filter.TypeOrganisme,
+		strings.TrimSpace(filter.Recherche),
+		filter.InclureInactifs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list insurers: %w", err)
+	}
+	defer rows.Close()
+
+	organismes := []dto.InsurerResponse{}
+	for rows.Next() {
+		organisme, err := scanInsurer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan insurer: %w", err)
+		}
+		organismes = append(organismes, *organisme)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate insurers: %w", err)
+	}
+
+	return organismes, nil
+}
+
+// GetInsurer retourne un organisme de l'établissement
+func (s *InsurerService) GetInsurer(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+) (*dto.InsurerResponse, error) {
+	return getInsurer(ctx, s.db, etablissementID, assuranceID)
+}
+
+// CreateInsurer enregistre un organisme (code unique dans l'établissement)
+func (s *InsurerService) CreateInsurer(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.CreateInsurerRequest,
+	userID uuid.UUID,
+) (*dto.InsurerResponse, error) {
+	delai := dto.DelaiPaiementDefaut
+	if req.DelaiPaiementJours != nil {
+		delai = *req.DelaiPaiementJours
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.CodeOrganisme))
+
+	var assuranceID uuid.UUID
+	err := s.db.QueryRow(ctx,
+		queries.InsurerQueries.InsertInsurer,
+		etablissementID,
+		code,
+		strings.TrimSpace(req.NomOfficiel),
+		trimmed(req.NomCourt),
+		req.TypeOrganisme,
+		req.Adresse,
+		trimmed(req.Telephone),
+		trimmed(req.Email),
+		trimmed(req.ContactFacturation),
+		delai,
+	).Scan(&assuranceID)
+	if err != nil {
+		if conflict := insurerConflict(err, code); conflict != nil {
+			return nil, conflict
+		}
+		return nil, fmt.Errorf("failed to insert insurer: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Insurer created - ID: %s, Code: %s, User: %s\n", assuranceID, code, userID)
+
+	return s.GetInsurer(ctx, etablissementID, assuranceID)
+}
+
+// UpdateInsurer modifie les informations d'un organisme
+func (s *InsurerService) UpdateInsurer(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	req *dto.UpdateInsurerRequest,
+	userID uuid.UUID,
+) (*dto.InsurerResponse, error) {
+	if _, err := s.GetInsurer(ctx, etablissementID, assuranceID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.InsurerQueries.UpdateInsurer,
+		assuranceID,
+		etablissementID,
+		trimmed(req.NomOfficiel),
+		trimmed(req.NomCourt),
+		req.TypeOrganisme,
+		req.Adresse,
+		trimmed(req.Telephone),
+		trimmed(req.Email),
+		trimmed(req.ContactFacturation),
+		req.DelaiPaiementJours,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update insurer: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Insurer updated - ID: %s, User: %s\n", assuranceID, userID)
+
+	return s.GetInsurer(ctx, etablissementID, assuranceID)
+}
+
+// SetInsurerActive active ou désactive un organisme
+// Les couvertures patients existantes sont conservées ; la réponse indique combien restent actives
+func (s *InsurerService) SetInsurerActive(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+	actif bool,
+	userID uuid.UUID,
+) (*dto.InsurerResponse, error) {
+	if _, err := s.GetInsurer(ctx, etablissementID, assuranceID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Exec(ctx,
+		queries.InsurerQueries.SetInsurerActif,
+		assuranceID,
+		etablissementID,
+		actif,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update insurer status: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Insurer status changed - ID: %s, Actif: %t, User: %s\n", assuranceID, actif, userID)
+
+	return s.GetInsurer(ctx, etablissementID, assuranceID)
+}
+
+// getInsurer charge un organisme de l'établissement (partagé avec les conventions)
+func getInsurer(
+	ctx context.Context,
+	db *postgres.Client,
+	etablissementID uuid.UUID,
+	assuranceID uuid.UUID,
+) (*dto.InsurerResponse, error) {
+	organisme, err := scanInsurer(db.QueryRow(ctx,
+		queries.InsurerQueries.GetInsurer,
+		assuranceID,
+		etablissementID,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewInsuranceError(dto.ErrAssuranceIntrouvable,
+				fmt.Sprintf("Organisme payeur introuvable: %s", assuranceID))
+		}
+		return nil, fmt.Errorf("failed to get insurer: %w", err)
+	}
+	return organisme, nil
+}
+
+// scanInsurer lit une ligne d'organisme
+func scanInsurer(row pgx.Row) (*dto.InsurerResponse, error) {
+	var organisme dto.InsurerResponse
+	if err := row.Scan(
+		&organisme.ID,
+		&organisme.CodeOrganisme,
+		&organisme.NomOfficiel,
+		&organisme.NomCourt,
+		&organisme.TypeOrganisme,
+		&organisme.Adresse,
+		&organisme.Telephone,
+		&organisme.Email,
+		&organisme.ContactFacturation,
+		&organisme.DelaiPaiementJours,
+		&organisme.EstActif,
+		&organisme.NombreConventionsActives,
+		&organisme.NombreCouverturesActives,
+		&organisme.CreatedAt,
+		&organisme.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &organisme, nil
+}
+
+// insurerConflict traduit la violation d'unicité du code organisme (nil si l'erreur est d'une autre nature)
+func insurerConflict(err error, code string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return nil
+	}
+	return dto.NewInsuranceError(dto.ErrAssuranceCodeExistant,
+		fmt.Sprintf("Le code organisme %s est déjà utilisé dans l'établissement", code))
+}
+
+// trimmed retire les espaces d'une valeur optionnelle
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	t := strings.TrimSpace(*value)
+	return &t
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Constantes pour les types d'accès journalisés
+const (
+	AccessTypeConsultation = "consultation"
+	AccessTypeCarte        = "carte"
+	AccessTypeExportFhir   = "export_fhir"
+)
+
+// Constantes pour l'origine des données servies
+const (
+	AccessSourceCache    = "cache"
+	AccessSourceDatabase = "database"
+)
+
+// PatientAccessContext identifie l'auteur et le contexte applicatif d'une lecture de dossier
+// Absent (nil) pour les lectures internes sans session : l'accès reste journalisé sans auteur
+type PatientAccessContext struct {
+	UtilisateurID   *uuid.UUID
+	EtablissementID *uuid.UUID
+	ModuleCode      string
+	RubriqueCode    string
+	TypeAcces       string // consultation, carte, export_fhir (consultation par défaut)
+}
+
+// PatientAccessRecord représente une ligne du journal en attente d'écriture par lot
+type PatientAccessRecord struct {
+	PatientID       *uuid.UUID
+	CodePatient     string
+	UtilisateurID   *uuid.UUID
+	EtablissementID *uuid.UUID
+	ModuleCode      *string
+	RubriqueCode    *string
+	TypeAcces       string
+	Source          string
+	AccedeLe        time.Time
+}
+
+// AccessLogFilter représente les filtres de consultation du journal des accès (rubrique AUDIT_SECURITE)
+type AccessLogFilter struct {
+	EtablissementID *uuid.UUID `form:"-"`
+	CodePatient     *string    `form:"code_patient"` // Inclut les accès aux dossiers fusionnés dans ce dossier
+	UtilisateurID   *uuid.UUID `form:"utilisateur_id"`
+	DateDebut       *time.Time `form:"date_debut" time_format:"2006-01-02"`
+	DateFin         *time.Time `form:"date_fin" time_format:"2006-01-02"` // Journée incluse
+	Page            int        `form:"page"`
+	Limit           int        `form:"limit"`
+}
+
+// AccessLogEntry représente un accès journalisé à un dossier patient
+type AccessLogEntry struct {
+	ID              uuid.UUID  `json:"id"`
+	CodePatient     string     `json:"code_patient"`
+	Utilisateur     *UserInfo  `json:"utilisateur,omitempty"`
+	EtablissementID *uuid.UUID `json:"etablissement_id,omitempty"`
+	ModuleCode      *string    `json:"module_code,omitempty"`
+	RubriqueCode    *string    `json:"rubrique_code,omitempty"`
+	TypeAcces       string     `json:"type_acces"`
+	Source          string     `json:"source"`
+	AccedeLe        time.Time  `json:"accede_le"`
+}
+
+// AccessLogResponse représente une page du journal des accès (plus récents en premier)
+type AccessLogResponse struct {
+	Acces      []AccessLogEntry `json:"acces"`
+	Pagination PaginationInfo   `json:"pagination"`
+}
@@ -0,0 +1,170 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Valeurs de remplacement de l'identité anonymisée (colonnes obligatoires de patients_patient)
+const (
+	AnonymizedNom         = "ANONYME"
+	AnonymizedTelephone   = "0000000000"
+	AnonymizedAdresse     = "Anonymisée"
+	AnonymizedValeur      = "[anonymisé]" // Valeurs d'identité expurgées de l'historique
+	AnonymizedPrefixe     = "ANON-"       // Préfixe des pseudonymes aléatoires (prénoms, numéros d'assuré)
+	AnonymizedGenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+)
+
+// AnonymizedIdentityFields liste les champs d'identité pseudonymisés ou effacés
+// Le code patient, le sexe, l'année de naissance et les rattachements cliniques sont conservés
+var AnonymizedIdentityFields = []string{
+	"nom", "prenoms", "date_naissance", "nom_jeune_fille", "lieu_naissance",
+	"type_piece_identite_id", "cni_nni", "numero_piece_identite",
+	"telephone_principal", "telephone_secondaire", "email",
+	"adresse_complete", "quartier", "commune",
+	"personnes_a_contacter", "signalement",
+}
+
+// CreateAnonymizationRequest représente une demande d'effacement d'un patient (loi 2013-450)
+type CreateAnonymizationRequest struct {
+	CodePatient string `json:"code_patient" validate:"required"`
+	Motif       string `json:"motif" validate:"required,min=5,max=1000"`
+}
+
+// RejectAnonymizationRequest représente le rejet motivé d'une demande d'effacement
+type RejectAnonymizationRequest struct {
+	Motif string `json:"motif" validate:"required,min=5,max=1000"`
+}
+
+// AnonymizationFilter représente les filtres de la liste des demandes d'effacement
+type AnonymizationFilter struct {
+	CodePatient *string `form:"code_patient"`
+	Statut      *string `form:"statut" validate:"omitempty,oneof=en_attente executee rejetee"`
+	Page        int     `form:"page"`
+	Limit       int     `form:"limit"`
+}
+
+// AnonymizationEntry représente une demande d'effacement et son traitement
+type AnonymizationEntry struct {
+	ID                 uuid.UUID  `json:"id"`
+	CodePatient        string     `json:"code_patient"`
+	Motif              string     `json:"motif"`
+	Statut             string     `json:"statut"`
+	DemandePar         *UserInfo  `json:"demande_par,omitempty"`
+	DemandeLe          time.Time  `json:"demande_le"`
+	TraitePar          *UserInfo  `json:"traite_par,omitempty"`
+	TraiteLe           *time.Time `json:"traite_le,omitempty"`
+	MotifRejet         *string    `json:"motif_rejet,omitempty"`
+	DossiersAnonymises []string   `json:"dossiers_anonymises"`
+}
+
+// AnonymizationListResponse représente une page de demandes d'effacement (plus récentes en premier)
+type AnonymizationListResponse struct {
+	Demandes   []AnonymizationEntry `json:"demandes"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// AnonymizationResult représente le résultat de l'exécution d'une demande approuvée
+type AnonymizationResult struct {
+	DemandeID                  uuid.UUID `json:"demande_id"`
+	CodePatient                string    `json:"code_patient"`
+	DossiersAnonymises         []string  `json:"dossiers_anonymises"` // Dossier demandé + dossiers fusionnés vers lui
+	CouverturesPseudonymisees  int       `json:"couvertures_pseudonymisees"`
+	EntreesHistoriqueExpurgees int       `json:"entrees_historique_expurgees"`
+	JournalNumero              int64     `json:"journal_numero"`
+	AnonymiseLe                time.Time `json:"anonymise_le"`
+}
+
+// AnonymizationJournalVerification représente le résultat du contrôle d'intégrité du journal
+type AnonymizationJournalVerification struct {
+	Valide      bool    `json:"valide"`
+	Entrees     int64   `json:"entrees"`
+	DernierHash string  `json:"dernier_hash"` // À conserver hors base pour détecter une troncature
+	RuptureA    *int64  `json:"rupture_a,omitempty"`
+	Message     *string `json:"message,omitempty"`
+}
+
+// AnonymizedExtractRequest représente les critères d'un extrait anonymisé pour la recherche
+type AnonymizedExtractRequest struct {
+	DateDebut       *time.Time `json:"date_debut"` // Création du dossier
+	DateFin         *time.Time `json:"date_fin"`
+	InclureDecedes  bool       `json:"inclure_decedes"`
+	InclureArchives bool       `json:"inclure_archives"`
+	Motif           string     `json:"motif" validate:"required,min=5,max=1000"` // Projet de recherche
+}
+
+// AnonymizedExtract représente le fichier CSV produit et son empreinte journalisée
+type AnonymizedExtract struct {
+	Contenu       []byte
+	ContentType   string
+	NomFichier    string
+	Lignes        int
+	Empreinte     string // SHA-256 du fichier, inscrite au journal
+	JournalNumero int64
+}
+
+// Constantes pour les statuts de demande d'effacement
+const (
+	AnonymizationStatutEnAttente = "en_attente"
+	AnonymizationStatutExecutee  = "executee"
+	AnonymizationStatutRejetee   = "rejetee"
+)
+
+// Constantes pour les opérations du journal d'anonymisation
+const (
+	AnonymizationOpDemande       = "demande"
+	AnonymizationOpRejet         = "rejet"
+	AnonymizationOpAnonymisation = "anonymisation"
+	AnonymizationOpExtraction    = "extraction"
+)
+
+// PatientAnonymizationError représente un refus de demande ou d'exécution d'anonymisation
+type PatientAnonymizationError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs d'anonymisation
+const (
+	ErrAnonymisationPatientIntrouvable = "ANONYMISATION_PATIENT_INTROUVABLE"
+	ErrAnonymisationDemandeIntrouvable = "ANONYMISATION_DEMANDE_INTROUVABLE"
+	ErrAnonymisationDejaAnonymise      = "ANONYMISATION_DEJA_ANONYMISE"
+	ErrAnonymisationDejaDemandee       = "ANONYMISATION_DEJA_DEMANDEE"
+	ErrAnonymisationDejaTraitee        = "ANONYMISATION_DEJA_TRAITEE"
+	ErrAnonymisationDossierFusionne    = "ANONYMISATION_DOSSIER_FUSIONNE"
+	ErrAnonymisationQuatreYeux         = "ANONYMISATION_APPROBATEUR_DEMANDEUR"
+)
+
+// NewPatientAnonymizationError crée une nouvelle erreur d'anonymisation
+func NewPatientAnonymizationError(code, message string) *PatientAnonymizationError {
+	return &PatientAnonymizationError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientAnonymizationError) Error() string {
+	return e.Message
+}
+
+// PatientAnonymizedError représente une écriture refusée sur un dossier anonymisé (identité effacée)
+type PatientAnonymizedError struct {
+	CodePatient string `json:"code_patient"`
+	Message     string `json:"message"`
+}
+
+// Error implémente l'interface error
+func (e *PatientAnonymizedError) Error() string {
+	return e.Message
+}
+
+// NewPatientAnonymizedError crée une nouvelle erreur patient anonymisé
+func NewPatientAnonymizedError(codePatient string) *PatientAnonymizedError {
+	return &PatientAnonymizedError{
+		CodePatient: codePatient,
+		Message:     fmt.Sprintf("Le dossier '%s' a été anonymisé : son identité ne peut plus être modifiée", codePatient),
+	}
+}
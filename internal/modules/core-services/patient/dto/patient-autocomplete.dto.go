@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatientAutocompleteRequest représente une saisie en cours dans la zone de recherche de l'accueil
+type PatientAutocompleteRequest struct {
+	Q     string `form:"q" validate:"required,min=2,max=100"` // Début de nom, prénom, code patient ou téléphone
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// PatientAutocompleteResult représente une suggestion allégée (sans assurances ni adresse)
+type PatientAutocompleteResult struct {
+	ID                 uuid.UUID `json:"id"`
+	CodePatient        string    `json:"code_patient"`
+	Nom                string    `json:"nom"`
+	Prenoms            string    `json:"prenoms"`
+	DateNaissance      time.Time `json:"date_naissance"`
+	Sexe               string    `json:"sexe"`
+	TelephonePrincipal string    `json:"telephone_principal"`
+	EstProvisoire      bool      `json:"est_provisoire"`
+}
+
+// PatientAutocompleteResponse représente les suggestions d'une saisie (les plus pertinentes en premier)
+type PatientAutocompleteResponse struct {
+	Suggestions     []PatientAutocompleteResult `json:"suggestions"`
+	ExecutionTimeMs int                         `json:"execution_time_ms"`
+}
+
+// Constantes de l'autocomplétion
+const (
+	AutocompleteDefaultLimit   = 8
+	AutocompletePhoneMinDigits = 4 // En deçà, une saisie numérique est traitée comme un code patient
+)
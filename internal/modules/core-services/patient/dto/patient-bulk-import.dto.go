@@ -0,0 +1,106 @@
+package dto
+
+// BulkImportOptions représente les options d'un import CSV de patients
+type BulkImportOptions struct {
+	DryRun    bool // Rapport uniquement, aucune insertion
+	TailleLot int  // Nombre de patients insérés par transaction
+}
+
+// BulkImportReport représente le rapport ligne par ligne d'un import CSV
+type BulkImportReport struct {
+	DryRun           bool                  `json:"dry_run"`
+	TotalLignes      int                   `json:"total_lignes"`
+	Acceptees        int                   `json:"acceptees"`
+	Importees        int                   `json:"importees"`
+	Rejetees         int                   `json:"rejetees"`
+	DoublonsSuspects int                   `json:"doublons_suspects"`
+	Tronque          bool                  `json:"tronque"` // Lignes au-delà de la limite non traitées
+	Lignes           []BulkImportRowReport `json:"lignes"`
+	DureeMs          int                   `json:"duree_ms"`
+}
+
+// BulkImportRowReport représente le verdict d'une ligne du fichier
+type BulkImportRowReport struct {
+	Ligne        int                  `json:"ligne"` // Numéro de ligne dans le fichier (en-tête = 1)
+	Statut       string               `json:"statut"`
+	Nom          string               `json:"nom"`
+	Prenoms      string               `json:"prenoms"`
+	CodePatient  *string              `json:"code_patient,omitempty"`
+	Raisons      []string             `json:"raisons,omitempty"`
+	Erreurs      []ValidationError    `json:"erreurs,omitempty"`
+	Doublons     []PotentialDuplicate `json:"doublons,omitempty"`
+	DoublonLigne *int                 `json:"doublon_ligne,omitempty"` // Doublon d'une ligne précédente du fichier
+}
+
+// Constantes pour les statuts de ligne d'import
+const (
+	ImportLigneAcceptee       = "acceptee" // Dry-run : serait importée
+	ImportLigneImportee       = "importee"
+	ImportLigneRejetee        = "rejetee"
+	ImportLigneDoublonSuspect = "doublon_suspect"
+)
+
+// Colonnes CSV reconnues (en-tête insensible à la casse)
+const (
+	ImportColNom                   = "nom"
+	ImportColPrenoms               = "prenoms"
+	ImportColDateNaissance         = "date_naissance"
+	ImportColEstDateSupposee       = "est_date_supposee"
+	ImportColSexe                  = "sexe"
+	ImportColNationalite           = "nationalite"            // Code ISO alpha-3
+	ImportColSituationMatrimoniale = "situation_matrimoniale" // Code référentiel
+	ImportColTypePieceIdentite     = "type_piece_identite"    // Code référentiel
+	ImportColCniNni                = "cni_nni"
+	ImportColNumeroPieceIdentite   = "numero_piece_identite"
+	ImportColLieuNaissance         = "lieu_naissance"
+	ImportColNomJeuneFille         = "nom_jeune_fille"
+	ImportColTelephonePrincipal    = "telephone_principal"
+	ImportColTelephoneSecondaire   = "telephone_secondaire"
+	ImportColEmail                 = "email"
+	ImportColAdresseComplete       = "adresse_complete"
+	ImportColQuartier              = "quartier"
+	ImportColVille                 = "ville"
+	ImportColCommune               = "commune"
+	ImportColPaysResidence         = "pays_residence"
+	ImportColProfession            = "profession" // Code référentiel
+	ImportColContactNomPrenoms     = "contact_nom_prenoms"
+	ImportColContactTelephone      = "contact_telephone"
+	ImportColContactAffiliation    = "contact_affiliation" // Code référentiel
+)
+
+// ImportRequiredColumns liste les colonnes obligatoires de l'en-tête
+var ImportRequiredColumns = []string{
+	ImportColNom,
+	ImportColPrenoms,
+	ImportColDateNaissance,
+	ImportColSexe,
+	ImportColNationalite,
+	ImportColSituationMatrimoniale,
+	ImportColTelephonePrincipal,
+	ImportColAdresseComplete,
+}
+
+// BulkImportError représente une erreur bloquante au niveau du fichier
+type BulkImportError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs d'import
+const (
+	ErrImportFichierVide       = "IMPORT_FICHIER_VIDE"
+	ErrImportColonnesManquante = "IMPORT_COLONNES_MANQUANTES"
+)
+
+// NewBulkImportError crée une nouvelle erreur d'import
+func NewBulkImportError(code, message string) *BulkImportError {
+	return &BulkImportError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *BulkImportError) Error() string {
+	return e.Message
+}
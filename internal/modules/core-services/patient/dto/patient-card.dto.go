@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatientCardLayout représente la mise en page de la carte patient d'un établissement
+type PatientCardLayout struct {
+	EtablissementID       uuid.UUID  `json:"etablissement_id"`
+	Titre                 string     `json:"titre"`
+	CouleurPrincipale     string     `json:"couleur_principale"`
+	CouleurTexte          string     `json:"couleur_texte"`
+	AfficherLogo          bool       `json:"afficher_logo"`
+	AfficherQRCode        bool       `json:"afficher_qr_code"`
+	AfficherCodeBarre     bool       `json:"afficher_code_barre"`
+	AfficherDateNaissance bool       `json:"afficher_date_naissance"`
+	AfficherSexe          bool       `json:"afficher_sexe"`
+	AfficherTelephone     bool       `json:"afficher_telephone"`
+	MentionPied           *string    `json:"mention_pied"`
+	ResolutionDPI         int        `json:"resolution_dpi"`
+	LogoEnregistre        bool       `json:"logo_enregistre"`      // Logo copié lors du dernier enregistrement
+	Logo                  []byte     `json:"-"`                    // Image PNG/JPEG imprimée sur la carte
+	UpdatedAt             *time.Time `json:"updated_at,omitempty"` // Absent = mise en page par défaut
+}
+
+// DefaultPatientCardLayout retourne la mise en page appliquée tant que l'établissement n'en a pas défini
+func DefaultPatientCardLayout(etablissementID uuid.UUID) *PatientCardLayout {
+	return &PatientCardLayout{
+		EtablissementID:       etablissementID,
+		Titre:                 "CARTE PATIENT",
+		CouleurPrincipale:     "#0B6E4F",
+		CouleurTexte:          "#1A1A1A",
+		AfficherLogo:          true,
+		AfficherQRCode:        true,
+		AfficherCodeBarre:     true,
+		AfficherDateNaissance: true,
+		AfficherSexe:          true,
+		AfficherTelephone:     false,
+		ResolutionDPI:         300,
+	}
+}
+
+// UpdatePatientCardLayoutRequest représente la mise en page complète de la carte (remplacement)
+type UpdatePatientCardLayoutRequest struct {
+	Titre                 string  `json:"titre" validate:"required,max=40"`
+	CouleurPrincipale     string  `json:"couleur_principale" validate:"required,hexcolor,len=7"`
+	CouleurTexte          string  `json:"couleur_texte" validate:"required,hexcolor,len=7"`
+	AfficherLogo          bool    `json:"afficher_logo"`
+	AfficherQRCode        bool    `json:"afficher_qr_code"`
+	AfficherCodeBarre     bool    `json:"afficher_code_barre"`
+	AfficherDateNaissance bool    `json:"afficher_date_naissance"`
+	AfficherSexe          bool    `json:"afficher_sexe"`
+	AfficherTelephone     bool    `json:"afficher_telephone"`
+	MentionPied           *string `json:"mention_pied" validate:"omitempty,max=120"`
+	ResolutionDPI         int     `json:"resolution_dpi" validate:"required,min=150,max=600"`
+}
+
+// RenderPatientCardRequest représente une demande d'impression de carte patient
+type RenderPatientCardRequest struct {
+	Format string `form:"format" json:"format" validate:"omitempty,oneof=pdf png"`
+}
+
+// Constantes pour les formats de carte patient
+const (
+	PatientCardFormatPDF = "pdf"
+	PatientCardFormatPNG = "png"
+)
+
+// PatientCardDocument représente une carte patient rendue, prête à être téléchargée
+type PatientCardDocument struct {
+	Contenu     []byte
+	ContentType string
+	NomFichier  string
+}
+
+// PatientCardQRPayload représente le contenu du QR code de la carte
+// Sous-ensemble de SearchPatientRequest : le JSON scanné est envoyé tel quel à la recherche patient
+type PatientCardQRPayload struct {
+	CodePatient string `json:"code_patient"`
+}
+
+// EstablishmentCardInfo représente les informations d'établissement imprimées sur la carte
+type EstablishmentCardInfo struct {
+	Nom              string
+	NomCourt         string
+	LogoDocumentsURL *string
+}
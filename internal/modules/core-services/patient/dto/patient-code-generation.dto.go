@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // CodeGenerationRequest représente une demande de génération de code patient
 type CodeGenerationRequest struct {
@@ -11,12 +14,13 @@ type CodeGenerationRequest struct {
 type CodeGenerationResponse struct {
 	CodePatient       string    `json:"code_patient"`
 	EtablissementCode string    `json:"etablissement_code"`
-	Annee            int       `json:"annee"`
+	Annee            int       `json:"annee"` // 0 = séquence continue (format sans année)
 	Numero           int       `json:"numero"`
 	Suffixe          string    `json:"suffixe"`
+	CleControle      string    `json:"cle_controle,omitempty"` // Présente si activée pour l'établissement
 	NombreGeneres    int64     `json:"nombre_generes"`
 	GeneratedAt      time.Time `json:"generated_at"`
-	Source           string    `json:"source"` // "redis" ou "postgres"
+	Source           string    `json:"source"` // "redis", "postgres" ou "bloc"
 	GenerationTimeMs int       `json:"generation_time_ms"`
 }
 
@@ -34,12 +38,62 @@ type CodeGenerationStats struct {
 	EtablissementCode     string  `json:"etablissement_code"`
 	Annee                int     `json:"annee"`
 	NombreGeneres        int64   `json:"nombre_generes"`
-	CapaciteUtilisee     float64 `json:"capacite_utilisee_pct"` // Pourcentage de 17.5M
+	FormatCode           string  `json:"format_code"`
+	CapaciteUtilisee     float64 `json:"capacite_utilisee_pct"` // Pourcentage de la capacité du format
 	DernierCode          string  `json:"dernier_code"`
 	ProchainCode         string  `json:"prochain_code"`
 	JoursAvantEpuisement *int    `json:"jours_avant_epuisement,omitempty"`
 }
 
+// PatientCodeConfiguration représente le paramétrage des codes patient d'un établissement
+type PatientCodeConfiguration struct {
+	EtablissementCode string     `json:"etablissement_code"`
+	FormatCode        string     `json:"format_code"`
+	SequenceAnnuelle  bool       `json:"sequence_annuelle"` // false = séquence continue (format sans année)
+	Capacite          int64      `json:"capacite"`          // Codes disponibles par séquence
+	ExempleCode       string     `json:"exemple_code"`
+	CleControle       bool       `json:"cle_controle"`
+	TailleBloc        int        `json:"taille_bloc"` // 0 = allocation code par code
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdatePatientCodeConfigurationRequest représente la modification du paramétrage des codes patient
+// S'applique aux codes générés ensuite : les codes existants ne sont jamais réécrits
+type UpdatePatientCodeConfigurationRequest struct {
+	CleControle *bool   `json:"cle_controle" validate:"required"`
+	FormatCode  *string `json:"format_code" validate:"omitempty,max=60"` // Absent = format actuel conservé
+	TailleBloc  *int    `json:"taille_bloc" validate:"omitempty,min=0,max=10000"` // Absent = valeur actuelle conservée
+}
+
+// PatientCodeSuggestion représente un dossier existant proche d'un code mal saisi
+type PatientCodeSuggestion struct {
+	CodePatient   string    `json:"code_patient"`
+	Nom           string    `json:"nom"`
+	Prenoms       string    `json:"prenoms"`
+	DateNaissance time.Time `json:"date_naissance"`
+}
+
+// PatientCodeMistypedError représente un code dont la clé de contrôle est fausse (erreur de saisie)
+type PatientCodeMistypedError struct {
+	CodePatient string                  `json:"code_patient"`
+	Message     string                  `json:"message"`
+	Suggestions []PatientCodeSuggestion `json:"suggestions"`
+}
+
+// NewPatientCodeMistypedError crée une nouvelle erreur de code mal saisi
+func NewPatientCodeMistypedError(codePatient string, suggestions []PatientCodeSuggestion) *PatientCodeMistypedError {
+	return &PatientCodeMistypedError{
+		CodePatient: codePatient,
+		Message:     fmt.Sprintf("Clé de contrôle invalide pour le code '%s' : code probablement mal saisi", codePatient),
+		Suggestions: suggestions,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientCodeMistypedError) Error() string {
+	return e.Message
+}
+
 // CodeGenerationError représente les erreurs spécifiques à la génération de codes
 type CodeGenerationError struct {
 	Code           string `json:"code"`
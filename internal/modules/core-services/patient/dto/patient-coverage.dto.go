@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AddCoverageRequest représente l'ajout d'une couverture d'assurance à un patient existant
+type AddCoverageRequest struct {
+	AssuranceID           uuid.UUID `json:"assurance_id" validate:"required"`
+	NumeroAssure          string    `json:"numero_assure" validate:"required,min=3,max=100"`
+	TypeBeneficiaire      string    `json:"type_beneficiaire" validate:"required,oneof=principal ayant_droit"`
+	NumeroAssurePrincipal *string   `json:"numero_assure_principal" validate:"required_if=TypeBeneficiaire ayant_droit,omitempty,max=100"`
+	LienAvecPrincipal     *string   `json:"lien_avec_principal" validate:"required_if=TypeBeneficiaire ayant_droit,omitempty,oneof=conjoint enfant parent autre"`
+}
+
+// DeactivateCoverageRequest représente l'inactivation d'une couverture
+type DeactivateCoverageRequest struct {
+	MotifInactivation string `json:"motif_inactivation" validate:"required,min=3,max=255"`
+}
+
+// ListCoveragesRequest représente les filtres de la liste des couvertures d'un patient
+type ListCoveragesRequest struct {
+	IncludeInactive bool `form:"include_inactive" json:"include_inactive"`
+}
+
+// PatientCoverage représente une couverture d'assurance d'un patient (active ou inactive)
+type PatientCoverage struct {
+	ID                    uuid.UUID     `json:"id"`
+	Assurance             ReferenceInfo `json:"assurance"`
+	NumeroAssure          string        `json:"numero_assure"`
+	TypeBeneficiaire      string        `json:"type_beneficiaire"`
+	NumeroAssurePrincipal *string       `json:"numero_assure_principal"`
+	LienAvecPrincipal     *string       `json:"lien_avec_principal"`
+	EstActif              bool          `json:"est_actif"`
+	MotifInactivation     *string       `json:"motif_inactivation,omitempty"`
+	InactiveLe            *time.Time    `json:"inactive_le,omitempty"`
+	CreatedAt             time.Time     `json:"created_at"`
+}
+
+// PatientCoveragesResponse représente les couvertures d'un patient
+type PatientCoveragesResponse struct {
+	CodePatient string            `json:"code_patient"`
+	EstAssure   bool              `json:"est_assure"`
+	Couvertures []PatientCoverage `json:"couvertures"`
+}
+
+// PatientCoverageResult représente le résultat d'un ajout ou d'une inactivation de couverture
+type PatientCoverageResult struct {
+	CodePatient string          `json:"code_patient"`
+	EstAssure   bool            `json:"est_assure"` // Valeur resynchronisée après l'opération
+	Couverture  PatientCoverage `json:"couverture"`
+}
+
+// Constantes pour les types de bénéficiaire
+const (
+	BeneficiairePrincipal  = "principal"
+	BeneficiaireAyantDroit = "ayant_droit"
+)
+
+// PatientCoverageError représente une opération sur les couvertures refusée par une règle métier
+type PatientCoverageError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs de couverture
+const (
+	ErrCouvertureAssuranceInvalide    = "COUVERTURE_ASSURANCE_INVALIDE"
+	ErrCouvertureDejaActive           = "COUVERTURE_DEJA_ACTIVE"
+	ErrCouverturePrincipalIntrouvable = "COUVERTURE_PRINCIPAL_INTROUVABLE"
+	ErrCouvertureIntrouvable          = "COUVERTURE_INTROUVABLE"
+	ErrCouvertureDejaInactive         = "COUVERTURE_DEJA_INACTIVE"
+	ErrCouverturePatientNonModifiable = "COUVERTURE_PATIENT_NON_MODIFIABLE"
+)
+
+// NewPatientCoverageError crée une nouvelle erreur de couverture
+func NewPatientCoverageError(code, message string) *PatientCoverageError {
+	return &PatientCoverageError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientCoverageError) Error() string {
+	return e.Message
+}
@@ -86,6 +86,9 @@ type PatientResponse struct {
 	EstAssure               bool                  `json:"est_assure"`
 	Assurances             []AssuranceResponse   `json:"assurances,omitempty"`
 
+	// Identité provisoire (patient inconnu à réconcilier)
+	EstProvisoire           bool       `json:"est_provisoire"`
+
 	// Métadonnées
 	EtablissementCreateurID uuid.UUID `json:"etablissement_createur_id"`
 	Statut                 string     `json:"statut"`
@@ -131,13 +134,13 @@ type UpdatePatientRequest struct {
 	Ville          *string `json:"ville,omitempty"`
 	Commune        *string `json:"commune,omitempty"`
 
-	// STATUT & MÉTADONNÉES
-	Statut            *string `json:"statut,omitempty" validate:"omitempty,oneof=actif inactif decede archive"`
-	EstDecede         *bool   `json:"est_decede,omitempty"`
-	DateDeces         *time.Time `json:"date_deces,omitempty"`
+	// STATUT : non modifiable ici, voir PatientLifecycleService (décès, désactivation, archivage)
 
 	// PERSONNES À CONTACTER (remplacement complet si fourni)
 	PersonnesAContacter *[]PersonneContact `json:"personnes_a_contacter,omitempty"`
+
+	// HISTORIQUE (motif obligatoire, conservé dans patients_patient_historique)
+	Motif string `json:"motif" validate:"required,min=3,max=500"`
 }
 
 // PatientCreationResult représente le résultat de création d'un patient avec métadonnées
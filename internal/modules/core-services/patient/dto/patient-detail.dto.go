@@ -18,6 +18,25 @@ type PatientDetailResponse struct {
 	TypePieceIdentite   *ReferenceInfo `json:"type_piece_identite,omitempty"`
 	Profession          *ReferenceInfo `json:"profession,omitempty"`
 
+	// IDENTITÉ COMPLÉMENTAIRE
+	CniNni              *string `json:"cni_nni,omitempty"`
+	NumeroPieceIdentite *string `json:"numero_piece_identite,omitempty"`
+	LieuNaissance       *string `json:"lieu_naissance,omitempty"`
+	NomJeuneFille       *string `json:"nom_jeune_fille,omitempty"`
+
+	// LOCALISATION DÉTAILLÉE
+	Quartier      *string `json:"quartier,omitempty"`
+	Ville         *string `json:"ville,omitempty"`
+	Commune       *string `json:"commune,omitempty"`
+	PaysResidence string  `json:"pays_residence"`
+
+	// DÉCÈS
+	EstDecede bool       `json:"est_decede"`
+	DateDeces *time.Time `json:"date_deces,omitempty"`
+
+	// PATIENT INCONNU : signalement saisi à l'admission (identité provisoire)
+	Signalement *string `json:"signalement,omitempty"`
+
 	// PERSONNES À CONTACTER
 	PersonnesAContacter []PersonneContactDetail `json:"personnes_a_contacter"`
 
@@ -28,6 +47,9 @@ type PatientDetailResponse struct {
 	LoadedFrom   string    `json:"loaded_from"` // "cache" ou "database"
 	LoadTime     int       `json:"load_time_ms"`
 	LastUpdated  time.Time `json:"last_updated"`
+
+	// FUSION : code demandé si redirigé vers le dossier survivant
+	RedirigeDepuis *string `json:"redirige_depuis,omitempty"`
 }
 
 // PersonneContactDetail représente une personne à contacter avec références enrichies
@@ -101,6 +123,10 @@ type PatientCacheData struct {
 	// Assurance
 	EstAssure bool `json:"est_assure"`
 
+	// Identité provisoire (patient inconnu)
+	EstProvisoire bool    `json:"est_provisoire"`
+	Signalement   *string `json:"signalement,omitempty"`
+
 	// Statut
 	Statut    string     `json:"statut"`
 	EstDecede bool       `json:"est_decede"`
@@ -128,6 +154,9 @@ type GetPatientByCodeRequest struct {
 	IncludeAssurances     bool   `json:"include_assurances" default:"true"`
 	IncludePersonnesContact bool   `json:"include_personnes_contact" default:"true"`
 	ForceRefreshCache     bool   `json:"force_refresh_cache" default:"false"`
+
+	// Contexte journalisé dans patients_acces_journal (auteur, module, rubrique)
+	Acces *PatientAccessContext `json:"-"`
 }
 
 // PatientNotFoundError représente une erreur lorsque le patient n'est pas trouvé
@@ -0,0 +1,151 @@
+package dto
+
+import "strings"
+
+// FhirPatient représente une ressource FHIR R4 Patient (sous-ensemble utilisé par Soins Suite)
+type FhirPatient struct {
+	ResourceType     string               `json:"resourceType"`
+	ID               string               `json:"id,omitempty"`
+	Meta             *FhirMeta            `json:"meta,omitempty"`
+	Extension        []FhirExtension      `json:"extension,omitempty"`
+	Identifier       []FhirIdentifier     `json:"identifier,omitempty"`
+	Active           *bool                `json:"active,omitempty"`
+	Name             []FhirHumanName      `json:"name,omitempty"`
+	Telecom          []FhirContactPoint   `json:"telecom,omitempty"`
+	Gender           string               `json:"gender,omitempty"`
+	BirthDate        string               `json:"birthDate,omitempty"`
+	DeceasedBoolean  *bool                `json:"deceasedBoolean,omitempty"`
+	DeceasedDateTime *string              `json:"deceasedDateTime,omitempty"`
+	Address          []FhirAddress        `json:"address,omitempty"`
+	MaritalStatus    *FhirCodeableConcept `json:"maritalStatus,omitempty"`
+	Contact          []FhirPatientContact `json:"contact,omitempty"`
+}
+
+// FhirMeta représente les métadonnées d'une ressource FHIR
+type FhirMeta struct {
+	LastUpdated string `json:"lastUpdated,omitempty"`
+}
+
+// FhirExtension représente une extension FHIR (valeur typée ou extensions imbriquées)
+type FhirExtension struct {
+	URL                  string               `json:"url"`
+	Extension            []FhirExtension      `json:"extension,omitempty"`
+	ValueString          *string              `json:"valueString,omitempty"`
+	ValueBoolean         *bool                `json:"valueBoolean,omitempty"`
+	ValueCodeableConcept *FhirCodeableConcept `json:"valueCodeableConcept,omitempty"`
+	ValueAddress         *FhirAddress         `json:"valueAddress,omitempty"`
+}
+
+// FhirIdentifier représente un identifiant métier FHIR
+type FhirIdentifier struct {
+	Use    string               `json:"use,omitempty"`
+	Type   *FhirCodeableConcept `json:"type,omitempty"`
+	System string               `json:"system,omitempty"`
+	Value  string               `json:"value"`
+}
+
+// FhirHumanName représente un nom FHIR
+type FhirHumanName struct {
+	Use    string   `json:"use,omitempty"`
+	Text   string   `json:"text,omitempty"`
+	Family string   `json:"family,omitempty"`
+	Given  []string `json:"given,omitempty"`
+}
+
+// FullText retourne le nom complet (text si fourni, sinon prénoms + nom)
+func (n FhirHumanName) FullText() string {
+	if strings.TrimSpace(n.Text) != "" {
+		return strings.TrimSpace(n.Text)
+	}
+	return strings.TrimSpace(strings.Join(append(append([]string{}, n.Given...), n.Family), " "))
+}
+
+// FhirContactPoint représente un moyen de contact FHIR (téléphone, email)
+type FhirContactPoint struct {
+	System string `json:"system,omitempty"` // phone, email
+	Value  string `json:"value,omitempty"`
+	Use    string `json:"use,omitempty"` // home, mobile
+	Rank   int    `json:"rank,omitempty"`
+}
+
+// FhirAddress représente une adresse FHIR
+type FhirAddress struct {
+	Extension []FhirExtension `json:"extension,omitempty"`
+	Use       string          `json:"use,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Text      string          `json:"text,omitempty"`
+	Line      []string        `json:"line,omitempty"`
+	City      string          `json:"city,omitempty"`
+	Country   string          `json:"country,omitempty"`
+}
+
+// FhirCodeableConcept représente un concept codé FHIR
+type FhirCodeableConcept struct {
+	Coding []FhirCoding `json:"coding,omitempty"`
+	Text   string       `json:"text,omitempty"`
+}
+
+// FhirCoding représente un code dans un système de codification
+type FhirCoding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// CodeFor retourne le premier code du système demandé
+func (c *FhirCodeableConcept) CodeFor(system string) string {
+	if c == nil {
+		return ""
+	}
+	for _, coding := range c.Coding {
+		if coding.System == system && coding.Code != "" {
+			return coding.Code
+		}
+	}
+	return ""
+}
+
+// FhirPatientContact représente une personne à contacter FHIR
+type FhirPatientContact struct {
+	Relationship []FhirCodeableConcept `json:"relationship,omitempty"`
+	Name         *FhirHumanName        `json:"name,omitempty"`
+	Telecom      []FhirContactPoint    `json:"telecom,omitempty"`
+}
+
+// Systèmes d'identification et extensions FHIR
+const (
+	FhirSystemCodePatient           = "https://soins-suite.ci/fhir/sid/code-patient"
+	FhirSystemCniNni                = "https://soins-suite.ci/fhir/sid/cni-nni"
+	FhirSystemPieceIdentite         = "https://soins-suite.ci/fhir/sid/piece-identite"
+	FhirSystemTypePieceIdentite     = "https://soins-suite.ci/fhir/CodeSystem/type-piece-identite"
+	FhirSystemSituationMatrimoniale = "https://soins-suite.ci/fhir/CodeSystem/situation-matrimoniale"
+	FhirSystemAffiliation           = "https://soins-suite.ci/fhir/CodeSystem/affiliation"
+	FhirSystemV3MaritalStatus       = "http://terminology.hl7.org/CodeSystem/v3-MaritalStatus"
+	FhirSystemV2ContactRole         = "http://terminology.hl7.org/CodeSystem/v2-0131"
+	FhirSystemIso3166               = "urn:iso:std:iso:3166"
+
+	FhirExtensionNationalite   = "http://hl7.org/fhir/StructureDefinition/patient-nationality"
+	FhirExtensionLieuNaissance = "http://hl7.org/fhir/StructureDefinition/patient-birthPlace"
+	FhirExtensionDateSupposee  = "https://soins-suite.ci/fhir/StructureDefinition/date-naissance-supposee"
+	FhirExtensionCommune       = "https://soins-suite.ci/fhir/StructureDefinition/address-commune"
+	FhirExtensionQuartier      = "https://soins-suite.ci/fhir/StructureDefinition/address-quartier"
+)
+
+// FhirImportError représente une ressource FHIR non convertible en patient Soins Suite
+type FhirImportError struct {
+	Message string   `json:"message"`
+	Issues  []string `json:"issues"`
+}
+
+// NewFhirImportError crée une nouvelle erreur d'import FHIR
+func NewFhirImportError(issues []string) *FhirImportError {
+	return &FhirImportError{
+		Message: "Ressource FHIR Patient non conforme",
+		Issues:  issues,
+	}
+}
+
+// Error implémente l'interface error
+func (e *FhirImportError) Error() string {
+	return e.Message + ": " + strings.Join(e.Issues, "; ")
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FieldChange représente la valeur d'un champ avant et après modification
+type FieldChange struct {
+	Avant interface{} `json:"avant"`
+	Apres interface{} `json:"apres"`
+}
+
+// PatientHistoryEntry représente une modification du dossier patient (diff champ par champ)
+type PatientHistoryEntry struct {
+	ID               uuid.UUID              `json:"id"`
+	TypeModification string                 `json:"type_modification"`
+	Modifications    map[string]FieldChange `json:"modifications"`
+	Motif            string                 `json:"motif"`
+	ModifiePar       *UserInfo              `json:"modifie_par,omitempty"`
+	ModifieLe        time.Time              `json:"modifie_le"`
+}
+
+// PatientHistoryFilter représente les filtres de consultation de l'historique d'un patient
+type PatientHistoryFilter struct {
+	Champ *string `form:"champ"` // Ex: "nom" pour la chronologie d'un seul champ
+	Page  int     `form:"page"`
+	Limit int     `form:"limit"`
+}
+
+// PatientHistoryResponse représente la chronologie paginée d'un patient
+type PatientHistoryResponse struct {
+	CodePatient string                `json:"code_patient"`
+	Historique  []PatientHistoryEntry `json:"historique"`
+	Pagination  PaginationInfo        `json:"pagination"`
+}
+
+// PatientStateAtDateResponse représente le dossier tel qu'il était à une date donnée
+type PatientStateAtDateResponse struct {
+	CodePatient           string                 `json:"code_patient"`
+	Date                  time.Time              `json:"date"`
+	Etat                  map[string]interface{} `json:"etat"`
+	ModificationsAnnulees int                    `json:"modifications_annulees"` // Modifications postérieures à la date
+}
+
+// Constantes pour les types de modification historisés
+const (
+	HistoryTypeModification     = "modification"
+	HistoryTypeFusion           = "fusion"
+	HistoryTypeAnnulationFusion = "annulation_fusion"
+	HistoryTypeDeclarationDeces = "declaration_deces"
+	HistoryTypeDesactivation    = "desactivation"
+	HistoryTypeArchivage        = "archivage"
+	HistoryTypeReactivation     = "reactivation"
+	HistoryTypeCouverture       = "couverture"
+	HistoryTypeReconciliation   = "reconciliation"
+	HistoryTypeAnonymisation    = "anonymisation"
+)
+
+// PatientNotExistingAtDateError représente une demande d'état antérieure à la création du dossier
+type PatientNotExistingAtDateError struct {
+	CodePatient string    `json:"code_patient"`
+	CreatedAt   time.Time `json:"created_at"`
+	Message     string    `json:"message"`
+}
+
+// Error implémente l'interface error
+func (e *PatientNotExistingAtDateError) Error() string {
+	return e.Message
+}
+
+// NewPatientNotExistingAtDateError crée une nouvelle erreur de date antérieure à la création
+func NewPatientNotExistingAtDateError(codePatient string, createdAt time.Time) *PatientNotExistingAtDateError {
+	return &PatientNotExistingAtDateError{
+		CodePatient: codePatient,
+		CreatedAt:   createdAt,
+		Message: fmt.Sprintf("Le patient '%s' n'existait pas à cette date (créé le %s)",
+			codePatient, createdAt.Format("2006-01-02")),
+	}
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeclareDeathRequest représente une déclaration de décès certifiée par un médecin
+type DeclareDeathRequest struct {
+	DateDeces          time.Time `json:"date_deces" validate:"required"`
+	CauseDeces         string    `json:"cause_deces" validate:"required,min=3,max=1000"`
+	MedecinDeclarantID uuid.UUID `json:"medecin_declarant_id" validate:"required"`
+	Motif              string    `json:"motif,omitempty" validate:"omitempty,max=500"`
+}
+
+// LifecycleTransitionRequest représente une désactivation, un archivage ou une réactivation
+type LifecycleTransitionRequest struct {
+	Motif string `json:"motif" validate:"required,min=3,max=500"`
+}
+
+// PatientLifecycleResult représente le résultat d'une transition de statut
+type PatientLifecycleResult struct {
+	CodePatient     string    `json:"code_patient"`
+	Transition      string    `json:"transition"`
+	StatutPrecedent string    `json:"statut_precedent"`
+	Statut          string    `json:"statut"`
+	EffectueLe      time.Time `json:"effectue_le"`
+}
+
+// PatientLifecycleError représente un refus de transition de statut
+type PatientLifecycleError struct {
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	StatutActuel string `json:"statut_actuel,omitempty"`
+	StatutCible  string `json:"statut_cible,omitempty"`
+}
+
+// Constantes pour les statuts patient
+const (
+	PatientStatutActif   = "actif"
+	PatientStatutInactif = "inactif"
+	PatientStatutDecede  = "decede"
+	PatientStatutArchive = "archive"
+)
+
+// Constantes pour les erreurs de cycle de vie
+const (
+	ErrLifecycleTransitionInterdite = "LIFECYCLE_TRANSITION_INTERDITE"
+	ErrLifecyclePatientDecede       = "LIFECYCLE_PATIENT_DECEDE"
+	ErrLifecyclePatientFusionne     = "LIFECYCLE_PATIENT_FUSIONNE"
+	ErrLifecycleMedecinInvalide     = "LIFECYCLE_MEDECIN_INVALIDE"
+	ErrLifecycleDateDecesInvalide   = "LIFECYCLE_DATE_DECES_INVALIDE"
+)
+
+// NewPatientLifecycleError crée une nouvelle erreur de cycle de vie
+func NewPatientLifecycleError(code, message, statutActuel, statutCible string) *PatientLifecycleError {
+	return &PatientLifecycleError{
+		Code:         code,
+		Message:      message,
+		StatutActuel: statutActuel,
+		StatutCible:  statutCible,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientLifecycleError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergePatientsRequest représente une demande de fusion de deux dossiers confirmés comme doublons
+type MergePatientsRequest struct {
+	CodePatientSurvivant string `json:"code_patient_survivant" validate:"required"`
+	CodePatientFusionne  string `json:"code_patient_fusionne" validate:"required"`
+	Motif                string `json:"motif" validate:"required,min=5,max=1000"`
+}
+
+// UndoMergeRequest représente une demande d'annulation de fusion (superviseur)
+type UndoMergeRequest struct {
+	Motif string `json:"motif" validate:"required,min=5,max=1000"`
+}
+
+// MergeHistoryFilter représente les filtres de consultation de l'historique des fusions
+type MergeHistoryFilter struct {
+	CodePatient *string `form:"code_patient"`
+	Statut      *string `form:"statut" validate:"omitempty,oneof=effectuee annulee"`
+	Page        int     `form:"page"`
+	Limit       int     `form:"limit"`
+}
+
+// MergePatientsResult représente le résultat d'une fusion
+type MergePatientsResult struct {
+	FusionID                    uuid.UUID `json:"fusion_id"`
+	CodePatientSurvivant        string    `json:"code_patient_survivant"`
+	CodePatientFusionne         string    `json:"code_patient_fusionne"`
+	AssurancesTransferees       int       `json:"assurances_transferees"`
+	AssurancesNonTransferees    int       `json:"assurances_non_transferees"` // Doublons de couverture restés sur le dossier archivé
+	PersonnesAContacterAjoutees int       `json:"personnes_a_contacter_ajoutees"`
+	FusionneLe                  time.Time `json:"fusionne_le"`
+}
+
+// MergeHistoryEntry représente une ligne de l'historique des fusions
+type MergeHistoryEntry struct {
+	ID                    uuid.UUID  `json:"id"`
+	CodePatientSurvivant  string     `json:"code_patient_survivant"`
+	CodePatientFusionne   string     `json:"code_patient_fusionne"`
+	EtablissementID       uuid.UUID  `json:"etablissement_id"`
+	Motif                 string     `json:"motif"`
+	AssurancesTransferees int        `json:"assurances_transferees"`
+	Statut                string     `json:"statut"`
+	FusionnePar           *UserInfo  `json:"fusionne_par,omitempty"`
+	FusionneLe            time.Time  `json:"fusionne_le"`
+	AnnulePar             *UserInfo  `json:"annule_par,omitempty"`
+	AnnuleLe              *time.Time `json:"annule_le,omitempty"`
+	MotifAnnulation       *string    `json:"motif_annulation,omitempty"`
+}
+
+// MergeHistoryResponse représente une page de l'historique des fusions
+type MergeHistoryResponse struct {
+	Fusions    []MergeHistoryEntry `json:"fusions"`
+	Pagination PaginationInfo      `json:"pagination"`
+}
+
+// PatientMergeError représente les erreurs métier de fusion/annulation
+type PatientMergeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs de fusion
+const (
+	ErrMergeSamePatient       = "MERGE_SAME_PATIENT"
+	ErrMergePatientArchived   = "MERGE_PATIENT_ARCHIVED"
+	ErrMergeNotFound          = "MERGE_NOT_FOUND"
+	ErrMergeAlreadyUndone     = "MERGE_ALREADY_UNDONE"
+	ErrMergeSurvivorNotActive = "MERGE_SURVIVOR_NOT_ACTIVE"
+	ErrMergeStateInconsistent = "MERGE_STATE_INCONSISTENT"
+	ErrMergeSurvivorModified  = "MERGE_SURVIVOR_MODIFIED"
+)
+
+// Constantes pour les statuts de fusion
+const (
+	MergeStatutEffectuee = "effectuee"
+	MergeStatutAnnulee   = "annulee"
+)
+
+// NewPatientMergeError crée une nouvelle erreur de fusion
+func NewPatientMergeError(code, message string) *PatientMergeError {
+	return &PatientMergeError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientMergeError) Error() string {
+	return e.Message
+}
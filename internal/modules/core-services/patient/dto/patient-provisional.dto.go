@@ -0,0 +1,173 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identité factice d'un patient inconnu (colonnes obligatoires de patients_patient)
+const (
+	ProvisionalNom                   = "INCONNU"
+	ProvisionalPrenoms               = "X"
+	ProvisionalTelephone             = "0000000000"
+	ProvisionalAdresse               = "Inconnue"
+	ProvisionalNationaliteCode       = "XXX"     // Nationalité non spécifiée (ICAO)
+	ProvisionalSituationMatrimoniale = "INCONNU" // Code du référentiel situation matrimoniale
+)
+
+// RegisterUnknownPatientRequest représente l'admission d'un patient non identifié (urgences)
+// Seuls le sexe et l'âge apparent sont requis, l'identité est complétée lors de la réconciliation
+type RegisterUnknownPatientRequest struct {
+	Sexe        string  `json:"sexe" validate:"required,oneof=M F"`
+	AgeEstime   *int    `json:"age_estime" validate:"required,min=0,max=120"`
+	Signalement *string `json:"signalement" validate:"omitempty,max=2000"`
+
+	// Référentiels (optionnels : codes XXX / INCONNU par défaut)
+	NationaliteID           *uuid.UUID `json:"nationalite_id"`
+	SituationMatrimonialeID *uuid.UUID `json:"situation_matrimoniale_id"`
+}
+
+// CompleteProvisionalIdentityRequest représente la réconciliation par identité complétée
+// Mêmes exigences qu'une création (validations et anti-doublon), sans les couvertures d'assurance
+type CompleteProvisionalIdentityRequest struct {
+	// IDENTITÉ
+	Nom                     string    `json:"nom" validate:"required,min=2,max=255"`
+	Prenoms                 string    `json:"prenoms" validate:"required,min=2,max=255"`
+	DateNaissance           time.Time `json:"date_naissance" validate:"required"`
+	EstDateSupposee         bool      `json:"est_date_supposee"`
+	Sexe                    string    `json:"sexe" validate:"required,oneof=M F"`
+	NationaliteID           uuid.UUID `json:"nationalite_id" validate:"required"`
+	SituationMatrimonialeID uuid.UUID `json:"situation_matrimoniale_id" validate:"required"`
+
+	// PIÈCE D'IDENTITÉ
+	TypePieceIdentiteID *uuid.UUID `json:"type_piece_identite_id"`
+	CniNni              *string    `json:"cni_nni"`
+	NumeroPieceIdentite *string    `json:"numero_piece_identite"`
+	LieuNaissance       *string    `json:"lieu_naissance"`
+	NomJeuneFille       *string    `json:"nom_jeune_fille"`
+
+	// CONTACT
+	TelephonePrincipal  string  `json:"telephone_principal" validate:"required,e164"`
+	TelephoneSecondaire *string `json:"telephone_secondaire"`
+	Email               *string `json:"email" validate:"omitempty,email"`
+
+	// LOCALISATION
+	AdresseComplete string  `json:"adresse_complete" validate:"required"`
+	Quartier        *string `json:"quartier"`
+	Ville           *string `json:"ville"`
+	Commune         *string `json:"commune"`
+	PaysResidence   string  `json:"pays_residence"`
+
+	// SOCIO-PROFESSIONNEL
+	ProfessionID *uuid.UUID `json:"profession_id"`
+
+	// PERSONNES À CONTACTER
+	PersonnesAContacter []PersonneContact `json:"personnes_a_contacter"`
+
+	// Confirmation explicite malgré un doublon probable (sinon la fusion est proposée)
+	IgnorerDoublons bool   `json:"ignorer_doublons"`
+	Motif           string `json:"motif" validate:"required,min=3,max=500"`
+}
+
+// ToCreatePatientRequest convertit l'identité complétée pour les validations de création
+func (r *CompleteProvisionalIdentityRequest) ToCreatePatientRequest() *CreatePatientRequest {
+	return &CreatePatientRequest{
+		Nom:                     r.Nom,
+		Prenoms:                 r.Prenoms,
+		DateNaissance:           r.DateNaissance,
+		EstDateSupposee:         r.EstDateSupposee,
+		Sexe:                    r.Sexe,
+		NationaliteID:           r.NationaliteID,
+		SituationMatrimonialeID: r.SituationMatrimonialeID,
+		TypePieceIdentiteID:     r.TypePieceIdentiteID,
+		CniNni:                  r.CniNni,
+		NumeroPieceIdentite:     r.NumeroPieceIdentite,
+		LieuNaissance:           r.LieuNaissance,
+		NomJeuneFille:           r.NomJeuneFille,
+		TelephonePrincipal:      r.TelephonePrincipal,
+		TelephoneSecondaire:     r.TelephoneSecondaire,
+		Email:                   r.Email,
+		AdresseComplete:         r.AdresseComplete,
+		Quartier:                r.Quartier,
+		Ville:                   r.Ville,
+		Commune:                 r.Commune,
+		PaysResidence:           r.PaysResidence,
+		ProfessionID:            r.ProfessionID,
+		PersonnesAContacter:     r.PersonnesAContacter,
+	}
+}
+
+// MergeProvisionalPatientRequest représente la réconciliation par fusion vers un dossier existant
+type MergeProvisionalPatientRequest struct {
+	CodePatientExistant string `json:"code_patient_existant" validate:"required"`
+	Motif               string `json:"motif" validate:"required,min=5,max=1000"`
+}
+
+// ProvisionalPatientFilter représente les filtres de la liste des patients inconnus à réconcilier
+type ProvisionalPatientFilter struct {
+	EtablissementID *uuid.UUID `form:"-"`
+	Page            int        `form:"page"`
+	Limit           int        `form:"limit"`
+}
+
+// ProvisionalPatientEntry représente un patient inconnu en attente de réconciliation
+type ProvisionalPatientEntry struct {
+	CodePatient     string    `json:"code_patient"`
+	Sexe            string    `json:"sexe"`
+	AgeEstime       int       `json:"age_estime"`
+	Signalement     *string   `json:"signalement,omitempty"`
+	Statut          string    `json:"statut"`
+	EtablissementID uuid.UUID `json:"etablissement_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	AttenteHeures   int       `json:"attente_heures"` // Ancienneté du dossier provisoire
+	CreatedBy       *UserInfo `json:"created_by,omitempty"`
+}
+
+// ProvisionalPatientListResponse représente la liste paginée des patients inconnus (plus anciens en premier)
+type ProvisionalPatientListResponse struct {
+	Patients   []ProvisionalPatientEntry `json:"patients"`
+	Pagination PaginationInfo            `json:"pagination"`
+}
+
+// ReconciliationResult représente le résultat d'une réconciliation de patient inconnu
+type ReconciliationResult struct {
+	CodePatientProvisoire string               `json:"code_patient_provisoire"`
+	Mode                  string               `json:"mode"`              // identite, fusion
+	CodePatient           string               `json:"code_patient"`      // Dossier à utiliser désormais
+	Patient               *PatientResponse     `json:"patient,omitempty"` // Mode identite
+	Fusion                *MergePatientsResult `json:"fusion,omitempty"`  // Mode fusion
+	ReconcilieLe          time.Time            `json:"reconcilie_le"`
+}
+
+// Constantes pour les modes de réconciliation
+const (
+	ReconciliationModeIdentite = "identite"
+	ReconciliationModeFusion   = "fusion"
+)
+
+// ProvisionalPatientError représente un refus d'admission ou de réconciliation d'un patient inconnu
+type ProvisionalPatientError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs patient inconnu
+const (
+	ErrProvisoireNonProvisoire      = "PROVISOIRE_DOSSIER_NON_PROVISOIRE"
+	ErrProvisoireCibleProvisoire    = "PROVISOIRE_CIBLE_PROVISOIRE"
+	ErrProvisoireReferenceManquante = "PROVISOIRE_REFERENCE_MANQUANTE"
+)
+
+// NewProvisionalPatientError crée une nouvelle erreur patient inconnu
+func NewProvisionalPatientError(code, message string) *ProvisionalPatientError {
+	return &ProvisionalPatientError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *ProvisionalPatientError) Error() string {
+	return e.Message
+}
@@ -35,6 +35,10 @@ type SearchPatientRequest struct {
 	SortBy     string `json:"sort_by" validate:"oneof=nom created_at score" default:"score"`
 	SortOrder  string `json:"sort_order" validate:"oneof=asc desc" default:"desc"`
 
+	// PAGINATION PAR CURSEUR (keyset) : valeur de pagination.next_cursor de la page précédente
+	// Disponible pour les tris par pertinence ou date de création décroissants
+	Cursor string `json:"cursor"`
+
 	// OPTIONS
 	IncludeAssurances bool `json:"include_assurances"`
 }
@@ -44,6 +48,9 @@ type SearchPatientResponse struct {
 	Patients   []PatientSearchResult `json:"patients"`
 	Pagination PaginationInfo        `json:"pagination"`
 	SearchInfo SearchMetadata        `json:"search_info"`
+
+	// Code saisi avec une clé de contrôle fausse : dossiers existants les plus proches
+	Suggestions []PatientCodeSuggestion `json:"suggestions,omitempty"`
 }
 
 // PatientSearchResult représente un patient dans les résultats de recherche
@@ -74,6 +81,9 @@ type PaginationInfo struct {
 	TotalPages   int  `json:"total_pages"`
 	HasNext      bool `json:"has_next"`
 	HasPrevious  bool `json:"has_previous"`
+
+	// Curseur opaque de la page suivante (pagination keyset de la recherche patient)
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // SearchMetadata contient les métadonnées d'une recherche
@@ -167,6 +177,15 @@ func (r *SearchPatientRequest) IsEmpty() bool {
 		r.EtablissementCreateur == nil
 }
 
+// UsesKeyset indique si la recherche est paginée par curseur plutôt que par OFFSET
+// Première page (sans curseur) incluse : elle fournit le curseur de la page suivante
+func (r *SearchPatientRequest) UsesKeyset() bool {
+	if r.SortOrder != "desc" || (r.SortBy != "score" && r.SortBy != "created_at") {
+		return false
+	}
+	return r.Cursor != "" || r.Page == 1
+}
+
 // GetOffset calcule l'offset pour la pagination
 func (r *SearchPatientRequest) GetOffset() int {
 	return (r.Page - 1) * r.Limit
@@ -217,4 +236,29 @@ func NewSearchMetadata(searchType string, executionTimeMs int, totalResults int,
 		TotalResults:    totalResults,
 		AppliedFilters:  appliedFilters,
 	}
-}
\ No newline at end of file
+}
+
+// PatientSearchError représente les critères invalides d'une recherche ou d'une vérification de doublon
+type PatientSearchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Constantes pour les erreurs de recherche
+const (
+	ErrSearchInvalidCriteria = "INVALID_SEARCH_CRITERIA"
+	ErrDuplicateCheckInvalid = "INVALID_DUPLICATE_CHECK"
+)
+
+// NewPatientSearchError crée une nouvelle erreur de recherche
+func NewPatientSearchError(code, message string) *PatientSearchError {
+	return &PatientSearchError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Error implémente l'interface error
+func (e *PatientSearchError) Error() string {
+	return e.Message
+}
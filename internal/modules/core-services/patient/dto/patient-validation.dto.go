@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -41,6 +42,36 @@ type MatchDetail struct {
 	DateNaissanceMatch int `json:"date_naissance_match"` // Score 0-100
 	TelephoneMatch    int  `json:"telephone_match"`     // Score 0-100
 	ScoreGlobal      int  `json:"score_global"`        // Score 0-100
+
+	// Signaux détectés par le moteur de rapprochement
+	InversionNomPrenoms bool          `json:"inversion_nom_prenoms"`
+	InversionJourMois   bool          `json:"inversion_jour_mois"`
+	Signaux             []MatchSignal `json:"signaux"`
+}
+
+// MatchSignal représente l'explication d'un signal de rapprochement
+type MatchSignal struct {
+	Signal      string `json:"signal"`      // NOM, PRENOMS, DATE_NAISSANCE, TELEPHONE, INVERSION_NOM_PRENOMS
+	Score       int    `json:"score"`       // Score 0-100
+	Explication string `json:"explication"` // Ex: "Même prononciation (KUADIO)"
+}
+
+// Constantes pour les signaux de rapprochement
+const (
+	MatchSignalNom                 = "NOM"
+	MatchSignalPrenoms             = "PRENOMS"
+	MatchSignalDateNaissance       = "DATE_NAISSANCE"
+	MatchSignalTelephone           = "TELEPHONE"
+	MatchSignalInversionNomPrenoms = "INVERSION_NOM_PRENOMS"
+)
+
+// NewMatchSignal crée un nouveau signal de rapprochement
+func NewMatchSignal(signal string, score int, explication string) MatchSignal {
+	return MatchSignal{
+		Signal:      signal,
+		Score:       score,
+		Explication: explication,
+	}
 }
 
 // ValidationError représente une erreur de validation métier
@@ -99,4 +130,33 @@ func NewValidationError(field, code, message string, value interface{}) Validati
 		Message: message,
 		Value:   value,
 	}
-}
\ No newline at end of file
+}
+// InvalidPatientDataError représente un échec de validation métier des données patient
+type InvalidPatientDataError struct {
+	Errors []ValidationError `json:"errors"`
+}
+
+// Error implémente l'interface error
+func (e *InvalidPatientDataError) Error() string {
+	return fmt.Sprintf("invalid patient data: %d errors", len(e.Errors))
+}
+
+// NewInvalidPatientDataError crée une nouvelle erreur de validation des données patient
+func NewInvalidPatientDataError(errors []ValidationError) *InvalidPatientDataError {
+	return &InvalidPatientDataError{Errors: errors}
+}
+
+// PatientDuplicateError représente un blocage de création pour cause de doublon probable
+type PatientDuplicateError struct {
+	Result *DuplicateCheckResponse `json:"duplicate_check"`
+}
+
+// Error implémente l'interface error
+func (e *PatientDuplicateError) Error() string {
+	return fmt.Sprintf("patient duplicate detected with score %d - creation blocked", e.Result.HighestScore)
+}
+
+// NewPatientDuplicateError crée une nouvelle erreur de doublon bloquant
+func NewPatientDuplicateError(result *DuplicateCheckResponse) *PatientDuplicateError {
+	return &PatientDuplicateError{Result: result}
+}
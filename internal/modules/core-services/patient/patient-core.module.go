@@ -10,11 +10,24 @@ import (
 // IMPORTANT: Ce module ne contient PAS de controllers (Core Services sans endpoints)
 var Module = fx.Options(
 	// Services Core - Ordre d'injection important (dépendances)
+	fx.Provide(services.NewPatientHistoryService),       // CS-P-007: Historique append-only des modifications
+	fx.Provide(services.NewPatientAccessLogService),     // CS-P-014: Journal persistant des accès (secret médical)
 	fx.Provide(services.NewPatientCodeGeneratorService), // CS-P-001: Génération codes (CRITIQUE)
 	fx.Provide(services.NewPatientValidationService),    // CS-P-005: Validation et anti-doublon
 	fx.Provide(services.NewPatientCreationService),      // CS-P-002: Création complète de patient
 	fx.Provide(services.NewPatientSearchService),        // CS-P-002: Recherche multi-critères
 	fx.Provide(services.NewPatientCacheService),         // CS-P-003: Cache Redis intelligent
+	fx.Provide(services.NewPatientMergeService),         // CS-P-006: Fusion de doublons (annulable)
+	fx.Provide(services.NewPatientLifecycleService),     // CS-P-008: Transitions de statut (décès, archivage)
+	fx.Provide(services.NewPatientFhirService),          // CS-P-009: Export/import FHIR R4 Patient
+	fx.Provide(services.NewPatientBulkImportService),    // CS-P-010: Import CSV en masse (dry-run)
+	fx.Provide(services.NewPatientCoverageService),      // CS-P-011: Couvertures d'assurance (principal, ayants droit)
+	fx.Provide(services.NewPatientCardService),          // CS-P-012: Carte patient imprimable (PDF/PNG)
+	fx.Provide(services.NewPatientProvisionalService),   // CS-P-013: Patients inconnus (urgences) et réconciliation
+	fx.Provide(services.NewPatientAnonymizationService), // CS-P-015: Effacement et anonymisation (loi 2013-450)
+
+	// Écriture par lots du journal des accès (démarrage et vidage à l'arrêt)
+	fx.Invoke(services.RegisterAccessLogLifecycle),
 
 	// Services Core complètement implémentés selon spécifications
 )
\ No newline at end of file
@@ -0,0 +1,77 @@
+package queries
+
+// PatientAccessLogQueries contient les requêtes SQL du journal persistant des accès aux dossiers patient
+var PatientAccessLogQueries = struct {
+	InsertAccessBatch string
+	ListAccessLog     string
+	CountAccessLog    string
+}{
+	// InsertAccessBatch - Écriture d'un lot d'accès en une seule instruction (tableaux parallèles)
+	InsertAccessBatch: `
+		INSERT INTO patients_acces_journal (
+			patient_id, code_patient, utilisateur_id, etablissement_id,
+			module_code, rubrique_code, type_acces, source, accede_le
+		)
+		SELECT *
+		FROM unnest(
+			$1::uuid[], $2::text[], $3::uuid[], $4::uuid[],
+			$5::text[], $6::text[], $7::text[], $8::text[], $9::timestamp[]
+		);
+	`,
+
+	// ListAccessLog - Journal paginé filtré par patient, utilisateur et période
+	// Le filtre patient couvre aussi les dossiers fusionnés dans le dossier demandé (chaîne fusionne_vers_patient_id)
+	// $5 = date de fin incluse (comparaison stricte au lendemain)
+	ListAccessLog: `
+		WITH RECURSIVE dossiers AS (
+			SELECT id
+			FROM patients_patient
+			WHERE $2::text IS NOT NULL
+			AND code_patient = $2
+			UNION
+			SELECT p.id
+			FROM patients_patient p
+			JOIN dossiers d ON p.fusionne_vers_patient_id = d.id
+		)
+		SELECT
+			j.id,
+			j.code_patient,
+			j.etablissement_id,
+			j.module_code,
+			j.rubrique_code,
+			j.type_acces,
+			j.source,
+			j.accede_le,
+			u.id, u.nom, u.prenoms
+		FROM patients_acces_journal j
+		LEFT JOIN user_utilisateur u ON j.utilisateur_id = u.id
+		WHERE ($1::uuid IS NULL OR j.etablissement_id = $1)
+		AND ($2::text IS NULL OR j.code_patient = $2 OR j.patient_id IN (SELECT id FROM dossiers))
+		AND ($3::uuid IS NULL OR j.utilisateur_id = $3)
+		AND ($4::date IS NULL OR j.accede_le >= $4::date)
+		AND ($5::date IS NULL OR j.accede_le < $5::date + 1)
+		ORDER BY j.accede_le DESC
+		LIMIT $6 OFFSET $7;
+	`,
+
+	// CountAccessLog - Total pour pagination du journal
+	CountAccessLog: `
+		WITH RECURSIVE dossiers AS (
+			SELECT id
+			FROM patients_patient
+			WHERE $2::text IS NOT NULL
+			AND code_patient = $2
+			UNION
+			SELECT p.id
+			FROM patients_patient p
+			JOIN dossiers d ON p.fusionne_vers_patient_id = d.id
+		)
+		SELECT COUNT(*)
+		FROM patients_acces_journal j
+		WHERE ($1::uuid IS NULL OR j.etablissement_id = $1)
+		AND ($2::text IS NULL OR j.code_patient = $2 OR j.patient_id IN (SELECT id FROM dossiers))
+		AND ($3::uuid IS NULL OR j.utilisateur_id = $3)
+		AND ($4::date IS NULL OR j.accede_le >= $4::date)
+		AND ($5::date IS NULL OR j.accede_le < $5::date + 1);
+	`,
+}
@@ -0,0 +1,267 @@
+package queries
+
+// PatientAnonymizationQueries contient les requêtes SQL de l'effacement et de l'anonymisation (loi 2013-450)
+var PatientAnonymizationQueries = struct {
+	GetPatientAnonymizationState string
+	InsertDemande                string
+	LockDemande                  string
+	RejectDemande                string
+	CompleteDemande              string
+	GetDemande                   string
+	ListDemandes                 string
+	CountDemandes                string
+	LockMergedFamily             string
+	AnonymizePatient             string
+	PseudonymizeAssurances       string
+	EnableHistoryRedaction       string
+	RedactHistory                string
+	ClearFusionContacts          string
+	LockJournal                  string
+	GetLastJournalEntry          string
+	InsertJournalEntry           string
+	ListJournal                  string
+	SelectExtractRows            string
+}{
+	// GetPatientAnonymizationState - Contrôles préalables à une demande d'effacement
+	GetPatientAnonymizationState: `
+		SELECT id, code_patient, est_anonymise, fusionne_vers_patient_id IS NOT NULL
+		FROM patients_patient
+		WHERE code_patient = $1;
+	`,
+
+	// InsertDemande - Enregistrement d'une demande en attente d'approbation
+	InsertDemande: `
+		INSERT INTO patients_anonymisation_demande (
+			patient_id, code_patient, motif, demande_par
+		) VALUES (
+			$1, $2, $3, $4
+		) RETURNING id, demande_le;
+	`,
+
+	// LockDemande - Verrouille une demande pour traitement (approbation ou rejet)
+	LockDemande: `
+		SELECT id, patient_id, code_patient, statut, demande_par
+		FROM patients_anonymisation_demande
+		WHERE id = $1
+		FOR UPDATE;
+	`,
+
+	// RejectDemande - Clôture d'une demande rejetée
+	RejectDemande: `
+		UPDATE patients_anonymisation_demande SET
+			statut      = 'rejetee',
+			traite_par  = $2,
+			traite_le   = NOW(),
+			motif_rejet = $3
+		WHERE id = $1
+		AND statut = 'en_attente';
+	`,
+
+	// CompleteDemande - Clôture d'une demande exécutée avec les dossiers anonymisés
+	CompleteDemande: `
+		UPDATE patients_anonymisation_demande SET
+			statut              = 'executee',
+			traite_par          = $2,
+			traite_le           = $3,
+			dossiers_anonymises = $4
+		WHERE id = $1
+		AND statut = 'en_attente';
+	`,
+
+	// GetDemande - Demande et traitement (après création ou rejet)
+	GetDemande: `
+		SELECT
+			d.id,
+			d.code_patient,
+			d.motif,
+			d.statut,
+			d.demande_le,
+			d.traite_le,
+			d.motif_rejet,
+			d.dossiers_anonymises,
+			dp.id, dp.nom, dp.prenoms,
+			tp.id, tp.nom, tp.prenoms
+		FROM patients_anonymisation_demande d
+		LEFT JOIN user_utilisateur dp ON d.demande_par = dp.id
+		LEFT JOIN user_utilisateur tp ON d.traite_par = tp.id
+		WHERE d.id = $1;
+	`,
+
+	// ListDemandes - Demandes paginées (plus récentes en premier)
+	ListDemandes: `
+		SELECT
+			d.id,
+			d.code_patient,
+			d.motif,
+			d.statut,
+			d.demande_le,
+			d.traite_le,
+			d.motif_rejet,
+			d.dossiers_anonymises,
+			dp.id, dp.nom, dp.prenoms,
+			tp.id, tp.nom, tp.prenoms
+		FROM patients_anonymisation_demande d
+		LEFT JOIN user_utilisateur dp ON d.demande_par = dp.id
+		LEFT JOIN user_utilisateur tp ON d.traite_par = tp.id
+		WHERE ($1::text IS NULL OR d.code_patient = $1)
+		AND ($2::text IS NULL OR d.statut = $2)
+		ORDER BY d.demande_le DESC
+		LIMIT $3 OFFSET $4;
+	`,
+
+	// CountDemandes - Total pour pagination
+	CountDemandes: `
+		SELECT COUNT(*)
+		FROM patients_anonymisation_demande d
+		WHERE ($1::text IS NULL OR d.code_patient = $1)
+		AND ($2::text IS NULL OR d.statut = $2);
+	`,
+
+	// LockMergedFamily - Dossier demandé et dossiers fusionnés vers lui (fusions successives), verrouillés
+	// Un doublon fusionné porte l'identité de la même personne : il est anonymisé avec le survivant
+	LockMergedFamily: `
+		WITH RECURSIVE famille AS (
+			SELECT id FROM patients_patient WHERE id = $1
+			UNION
+			SELECT p.id
+			FROM patients_patient p
+			JOIN famille f ON p.fusionne_vers_patient_id = f.id
+		)
+		SELECT p.id, p.code_patient, p.est_anonymise
+		FROM patients_patient p
+		WHERE p.id IN (SELECT id FROM famille)
+		ORDER BY p.created_at
+		FOR UPDATE OF p;
+	`,
+
+	// AnonymizePatient - Pseudonymisation irréversible de l'identité (code patient et liens cliniques conservés)
+	// Paramètres: $1 = id, $2 = nom, $3 = prénoms pseudonymes, $4 = téléphone, $5 = adresse, $6 = utilisateur, $7 = date
+	AnonymizePatient: `
+		UPDATE patients_patient SET
+			nom                    = $2,
+			prenoms                = $3,
+			date_naissance         = date_trunc('year', date_naissance)::date,
+			est_date_supposee      = TRUE,
+			nom_jeune_fille        = NULL,
+			lieu_naissance         = NULL,
+			type_piece_identite_id = NULL,
+			cni_nni                = NULL,
+			numero_piece_identite  = NULL,
+			telephone_principal    = $4,
+			telephone_secondaire   = NULL,
+			email                  = NULL,
+			adresse_complete       = $5,
+			quartier               = NULL,
+			commune                = NULL,
+			personnes_a_contacter  = '[]'::jsonb,
+			signalement            = NULL,
+			est_anonymise          = TRUE,
+			anonymise_le           = $7,
+			updated_by             = $6,
+			updated_at             = NOW()
+		WHERE id = $1
+		AND est_anonymise = FALSE;
+	`,
+
+	// PseudonymizeAssurances - Numéros d'assuré remplacés par des pseudonymes aléatoires
+	PseudonymizeAssurances: `
+		UPDATE patients_patient_assurance SET
+			numero_assure           = $2 || upper(substr(md5(uuid_generate_v4()::text), 1, 12)),
+			numero_assure_principal = CASE WHEN numero_assure_principal IS NULL THEN NULL ELSE $3 END,
+			updated_at              = NOW()
+		WHERE patient_id = ANY($1::uuid[])
+		RETURNING id;
+	`,
+
+	// EnableHistoryRedaction - Autorise l'expurgation de l'historique pour la transaction courante uniquement
+	EnableHistoryRedaction: `
+		SELECT set_config('soins_suite.anonymisation', 'on', true);
+	`,
+
+	// RedactHistory - Remplace les valeurs d'identité des diffs historisés (le reste du diff est conservé)
+	RedactHistory: `
+		UPDATE patients_patient_historique h SET
+			modifications = (
+				SELECT jsonb_object_agg(
+					e.key,
+					CASE WHEN e.key = ANY($2::text[])
+						THEN jsonb_build_object('avant', $3::text, 'apres', $3::text)
+						ELSE e.value
+					END
+				)
+				FROM jsonb_each(h.modifications) e
+			)
+		WHERE h.patient_id = ANY($1::uuid[])
+		AND h.modifications ?| $2::text[]
+		RETURNING h.id;
+	`,
+
+	// ClearFusionContacts - Efface les personnes à contacter conservées pour l'annulation des fusions
+	ClearFusionContacts: `
+		UPDATE patients_fusion_historique
+		SET
+			personnes_a_contacter_survivant_avant = '[]'::jsonb,
+			personnes_a_contacter_ajoutees = '[]'::jsonb
+		WHERE patient_survivant_id = ANY($1::uuid[])
+		OR patient_fusionne_id = ANY($1::uuid[]);
+	`,
+
+	// LockJournal - Sérialise les écritures du journal chaîné (verrou consultatif de transaction)
+	LockJournal: `
+		SELECT pg_advisory_xact_lock(hashtext('patients_anonymisation_journal'));
+	`,
+
+	// GetLastJournalEntry - Maillon le plus récent de la chaîne
+	GetLastJournalEntry: `
+		SELECT numero, hash
+		FROM patients_anonymisation_journal
+		ORDER BY numero DESC
+		LIMIT 1;
+	`,
+
+	// InsertJournalEntry - Ajout d'un maillon (hash calculé par le service)
+	InsertJournalEntry: `
+		INSERT INTO patients_anonymisation_journal (
+			numero, operation, demande_id, code_patient, details,
+			effectue_par, effectue_le, hash_precedent, hash
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		);
+	`,
+
+	// ListJournal - Chaîne complète dans l'ordre pour vérification
+	ListJournal: `
+		SELECT numero, operation, demande_id, code_patient, details,
+		       effectue_par, effectue_le, hash_precedent, hash
+		FROM patients_anonymisation_journal
+		ORDER BY numero ASC;
+	`,
+
+	// SelectExtractRows - Données non identifiantes pour l'extrait de recherche
+	// Paramètres: $1 = établissement créateur, $2/$3 = période de création, $4 = décédés, $5 = archivés
+	SelectExtractRows: `
+		SELECT
+			p.code_patient,
+			p.sexe,
+			EXTRACT(YEAR FROM p.date_naissance)::int,
+			n.code,
+			sm.code,
+			pr.code,
+			p.ville,
+			p.pays_residence,
+			p.est_assure,
+			p.statut,
+			EXTRACT(YEAR FROM p.date_deces)::int,
+			EXTRACT(YEAR FROM p.created_at)::int
+		FROM patients_patient p
+		LEFT JOIN ref_nationalite n ON p.nationalite_id = n.id
+		LEFT JOIN ref_situation_matrimoniale sm ON p.situation_matrimoniale_id = sm.id
+		LEFT JOIN ref_profession pr ON p.profession_id = pr.id
+		WHERE p.est_provisoire = FALSE
+		AND ($1::uuid IS NULL OR p.etablissement_createur_id = $1)
+		AND ($2::timestamp IS NULL OR p.created_at >= $2)
+		AND ($3::timestamp IS NULL OR p.created_at <= $3)
+		AND ($4::boolean OR p.est_decede = FALSE)
+		AND ($5::boolean OR p.statut <> 'archive');
+	`,
+}
@@ -0,0 +1,19 @@
+package queries
+
+// PatientBulkImportQueries contient les requêtes SQL de l'import CSV de patients
+var PatientBulkImportQueries = struct {
+	LoadReferenceCodes string
+}{
+	// LoadReferenceCodes - Référentiels actifs indexés par code (chargés une fois par import)
+	LoadReferenceCodes: `
+		SELECT 'nationalite' as referentiel, code, id FROM ref_nationalite WHERE est_actif = true
+		UNION ALL
+		SELECT 'situation_matrimoniale', code, id FROM ref_situation_matrimoniale WHERE est_actif = true
+		UNION ALL
+		SELECT 'type_piece_identite', code, id FROM ref_type_piece_identite WHERE est_actif = true
+		UNION ALL
+		SELECT 'profession', code, id FROM ref_profession WHERE est_actif = true
+		UNION ALL
+		SELECT 'affiliation', code, id FROM ref_affiliation WHERE est_actif = true;
+	`,
+}
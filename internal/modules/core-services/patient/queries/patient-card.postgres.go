@@ -0,0 +1,68 @@
+package queries
+
+// PatientCardQueries contient les requêtes SQL de la carte patient imprimée
+var PatientCardQueries = struct {
+	GetEstablishmentCardInfo string
+	GetCardLayout            string
+	UpsertCardLayout         string
+}{
+	// GetEstablishmentCardInfo - Nom et logo documents de l'établissement
+	GetEstablishmentCardInfo: `
+		SELECT
+			nom,
+			nom_court,
+			logo_documents_url
+		FROM base_etablissement
+		WHERE id = $1;
+	`,
+
+	// GetCardLayout - Mise en page définie par l'établissement (aucune ligne = mise en page par défaut)
+	GetCardLayout: `
+		SELECT
+			titre,
+			couleur_principale,
+			couleur_texte,
+			afficher_logo,
+			afficher_qr_code,
+			afficher_code_barre,
+			afficher_date_naissance,
+			afficher_sexe,
+			afficher_telephone,
+			mention_pied,
+			resolution_dpi,
+			logo_contenu,
+			updated_at
+		FROM patients_carte_configuration
+		WHERE etablissement_id = $1;
+	`,
+
+	// UpsertCardLayout - Création ou remplacement de la mise en page
+	UpsertCardLayout: `
+		INSERT INTO patients_carte_configuration (
+			etablissement_id, titre, couleur_principale, couleur_texte,
+			afficher_logo, afficher_qr_code, afficher_code_barre,
+			afficher_date_naissance, afficher_sexe, afficher_telephone,
+			mention_pied, resolution_dpi, logo_contenu, logo_source_url, updated_by
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+		)
+		ON CONFLICT (etablissement_id)
+		DO UPDATE SET
+			titre = EXCLUDED.titre,
+			couleur_principale = EXCLUDED.couleur_principale,
+			couleur_texte = EXCLUDED.couleur_texte,
+			afficher_logo = EXCLUDED.afficher_logo,
+			afficher_qr_code = EXCLUDED.afficher_qr_code,
+			afficher_code_barre = EXCLUDED.afficher_code_barre,
+			afficher_date_naissance = EXCLUDED.afficher_date_naissance,
+			afficher_sexe = EXCLUDED.afficher_sexe,
+			afficher_telephone = EXCLUDED.afficher_telephone,
+			mention_pied = EXCLUDED.mention_pied,
+			resolution_dpi = EXCLUDED.resolution_dpi,
+			logo_contenu = EXCLUDED.logo_contenu,
+			logo_source_url = EXCLUDED.logo_source_url,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at;
+	`,
+}
@@ -6,6 +6,13 @@ var PatientCodeGenerationQueries = struct {
 	GenerateNextCodeFromPostgres     string
 	InitializeYearlySequence        string
 	UpdateSequenceAfterGeneration   string
+	GetCodeConfiguration            string
+	UpsertCodeConfiguration         string
+	FindPatientsByCodes             string
+	ListCheckedCodeFormats          string
+	EnsureSequence                  string
+	LockSequence                    string
+	ReserveBlock                    string
 }{
 	/**
 	 * Récupère l'état actuel de la séquence pour un établissement/année
@@ -24,21 +31,22 @@ var PatientCodeGenerationQueries = struct {
 	/**
 	 * Génère le prochain code patient de manière atomique avec PostgreSQL
 	 * Utilise UPSERT pour gérer l'initialisation + incrémentation en une seule opération
-	 * Paramètres: $1 = etablissement_code, $2 = annee
+	 * Paramètres: $1 = etablissement_code, $2 = annee (0 = séquence continue),
+	 *             $3 = compteur maximal du format, $4 = largeur du bloc alphabétique, $5 = suffixe initial
 	 * Retour: nouveau_numero, nouveau_suffixe, nombre_total_generes
 	 */
 	GenerateNextCodeFromPostgres: `
 		INSERT INTO patients_code_sequences (etablissement_code, annee, dernier_numero, dernier_suffixe, nombre_generes)
-		VALUES ($1, $2, 1, 'AAA', 1)
+		VALUES ($1, $2, 1, $5, 1)
 		ON CONFLICT (etablissement_code, annee)
 		DO UPDATE SET
 			dernier_numero = CASE
-				WHEN patients_code_sequences.dernier_numero = 999 THEN 1
+				WHEN patients_code_sequences.dernier_numero >= $3 THEN 1
 				ELSE patients_code_sequences.dernier_numero + 1
 			END,
 			dernier_suffixe = CASE
-				WHEN patients_code_sequences.dernier_numero = 999
-				THEN next_alpha_suffix(patients_code_sequences.dernier_suffixe)
+				WHEN patients_code_sequences.dernier_numero >= $3
+				THEN next_alpha_suffix(patients_code_sequences.dernier_suffixe, $4)
 				ELSE patients_code_sequences.dernier_suffixe
 			END,
 			nombre_generes = patients_code_sequences.nombre_generes + 1,
@@ -60,6 +68,7 @@ var PatientCodeGenerationQueries = struct {
 
 	/**
 	 * Met à jour la séquence après génération réussie (utilisé pour synchronisation Redis)
+	 * Jamais de retour en arrière : un bloc réservé entre-temps par une autre instance est préservé
 	 * Paramètres: $1 = etablissement_code, $2 = annee, $3 = nouveau_numero, $4 = nouveau_suffixe
 	 * Retour: confirmation mise à jour
 	 */
@@ -71,6 +80,106 @@ var PatientCodeGenerationQueries = struct {
 			nombre_generes = nombre_generes + 1,
 			updated_at = NOW()
 		WHERE etablissement_code = $1 AND annee = $2
+		AND (lpad(dernier_suffixe, 4, 'A'), dernier_numero) < (lpad($4, 4, 'A'), $3)
 		RETURNING dernier_numero, dernier_suffixe, nombre_generes
 	`,
-}
\ No newline at end of file
+	/**
+	 * Paramétrage des codes patient d'un établissement
+	 * Paramètres: $1 = etablissement_code
+	 * Retour: format_code, cle_controle, taille_bloc, updated_at (aucune ligne = paramétrage par défaut)
+	 */
+	GetCodeConfiguration: `
+		SELECT
+			format_code,
+			cle_controle,
+			taille_bloc,
+			updated_at
+		FROM patients_code_configuration
+		WHERE etablissement_code = $1
+	`,
+
+	/**
+	 * Crée ou modifie le paramétrage des codes patient d'un établissement
+	 * Paramètres: $1 = etablissement_code, $2 = format_code, $3 = cle_controle, $4 = taille_bloc, $5 = updated_by
+	 * Retour: format_code, cle_controle, taille_bloc, updated_at
+	 */
+	UpsertCodeConfiguration: `
+		INSERT INTO patients_code_configuration (etablissement_code, format_code, cle_controle, taille_bloc, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (etablissement_code)
+		DO UPDATE SET
+			format_code = EXCLUDED.format_code,
+			cle_controle = EXCLUDED.cle_controle,
+			taille_bloc = EXCLUDED.taille_bloc,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING format_code, cle_controle, taille_bloc, updated_at
+	`,
+
+	/**
+	 * Dossiers existants parmi une liste de codes candidats (suggestions sur code mal saisi)
+	 * Paramètres: $1 = codes candidats, $2 = limite
+	 * Retour: code_patient, nom, prenoms, date_naissance
+	 */
+	FindPatientsByCodes: `
+		SELECT
+			code_patient,
+			nom,
+			prenoms,
+			date_naissance
+		FROM patients_patient
+		WHERE code_patient = ANY($1::text[])
+		ORDER BY code_patient
+		LIMIT $2
+	`,
+
+	/**
+	 * Formats des établissements ayant activé la clé de contrôle (vérification des codes saisis)
+	 * Retour: etablissement_code, format_code
+	 */
+	ListCheckedCodeFormats: `
+		SELECT
+			etablissement_code,
+			format_code
+		FROM patients_code_configuration
+		WHERE cle_controle = true
+	`,
+
+	/**
+	 * Crée la séquence si elle n'existe pas encore (allocation par blocs)
+	 * Paramètres: $1 = etablissement_code, $2 = annee, $3 = suffixe initial
+	 */
+	EnsureSequence: `
+		INSERT INTO patients_code_sequences (etablissement_code, annee, dernier_numero, dernier_suffixe, nombre_generes)
+		VALUES ($1, $2, 0, $3, 0)
+		ON CONFLICT (etablissement_code, annee) DO NOTHING
+	`,
+
+	/**
+	 * Verrouille la séquence le temps de réserver un bloc (un verrou par bloc, pas par code)
+	 * Paramètres: $1 = etablissement_code, $2 = annee
+	 * Retour: dernier_numero, dernier_suffixe
+	 */
+	LockSequence: `
+		SELECT
+			dernier_numero,
+			dernier_suffixe
+		FROM patients_code_sequences
+		WHERE etablissement_code = $1 AND annee = $2
+		FOR UPDATE
+	`,
+
+	/**
+	 * Avance la séquence jusqu'à la fin du bloc réservé
+	 * Paramètres: $1 = etablissement_code, $2 = annee, $3 = numero de fin, $4 = suffixe de fin, $5 = taille du bloc
+	 */
+	ReserveBlock: `
+		UPDATE patients_code_sequences
+		SET
+			dernier_numero = $3,
+			dernier_suffixe = $4,
+			nombre_generes = nombre_generes + $5,
+			updated_at = NOW()
+		WHERE etablissement_code = $1 AND annee = $2
+	`,
+}
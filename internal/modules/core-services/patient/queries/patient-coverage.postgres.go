@@ -0,0 +1,183 @@
+package queries
+
+// PatientCoverageQueries contient toutes les requêtes SQL de gestion des couvertures d'assurance
+var PatientCoverageQueries = struct {
+	LockPatientForCoverage   string
+	GetPatientCoverageStatus string
+	ValidateAssurance        string
+	ExistsActiveCoverage     string
+	ExistsPrincipalNumber    string
+	FindPrincipalCoverage    string
+	InsertCoverage           string
+	LockCoverage             string
+	DeactivateCoverage       string
+	CountActiveDependents    string
+	SyncEstAssure            string
+	ListCoverages            string
+}{
+	// LockPatientForCoverage - Verrouille le dossier pendant la modification de ses couvertures
+	LockPatientForCoverage: `
+		SELECT
+			id,
+			statut,
+			COALESCE(est_decede, false),
+			est_anonymise
+		FROM patients_patient
+		WHERE code_patient = $1
+		FOR UPDATE;
+	`,
+
+	// GetPatientCoverageStatus - Identifiant et indicateur est_assure d'un dossier
+	GetPatientCoverageStatus: `
+		SELECT id, est_assure
+		FROM patients_patient
+		WHERE code_patient = $1;
+	`,
+
+	// ValidateAssurance - Assurance active de l'établissement (code et nom pour la réponse)
+	ValidateAssurance: `
+		SELECT id, code_organisme, nom_officiel
+		FROM base_assurance
+		WHERE id = $1
+		AND etablissement_id = $2
+		AND est_actif = true;
+	`,
+
+	// ExistsActiveCoverage - Le patient a-t-il déjà une couverture active auprès de cette assurance
+	ExistsActiveCoverage: `
+		SELECT EXISTS (
+			SELECT 1
+			FROM patients_patient_assurance
+			WHERE patient_id = $1
+			AND assurance_id = $2
+			AND est_actif = true
+		);
+	`,
+
+	// ExistsPrincipalNumber - Numéro d'assuré principal déjà attribué à un autre patient
+	ExistsPrincipalNumber: `
+		SELECT EXISTS (
+			SELECT 1
+			FROM patients_patient_assurance
+			WHERE assurance_id = $1
+			AND numero_assure = $2
+			AND type_beneficiaire = 'principal'
+			AND est_actif = true
+			AND patient_id <> $3
+		);
+	`,
+
+	// FindPrincipalCoverage - Assuré principal actif (dossier non fusionné, non archivé) d'un ayant droit
+	FindPrincipalCoverage: `
+		SELECT pa.patient_id
+		FROM patients_patient_assurance pa
+		JOIN patients_patient p ON pa.patient_id = p.id
+		WHERE pa.assurance_id = $1
+		AND pa.numero_assure = $2
+		AND pa.type_beneficiaire = 'principal'
+		AND pa.est_actif = true
+		AND p.statut <> 'archive'
+		AND p.fusionne_vers_patient_id IS NULL
+		LIMIT 1;
+	`,
+
+	// InsertCoverage - Ajout d'une couverture active
+	InsertCoverage: `
+		INSERT INTO patients_patient_assurance (
+			patient_id, assurance_id, numero_assure, type_beneficiaire,
+			numero_assure_principal, lien_avec_principal, est_actif, created_by, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, true, $7, NOW(), NOW()
+		)
+		RETURNING id, created_at;
+	`,
+
+	// LockCoverage - Verrouille une couverture du patient et retourne son détail
+	LockCoverage: `
+		SELECT
+			pa.id,
+			pa.assurance_id,
+			COALESCE(a.code_organisme, ''),
+			COALESCE(a.nom_officiel, 'Assurance inconnue'),
+			pa.numero_assure,
+			pa.type_beneficiaire,
+			pa.numero_assure_principal,
+			pa.lien_avec_principal,
+			COALESCE(pa.est_actif, false),
+			pa.motif_inactivation,
+			pa.inactive_le,
+			pa.created_at
+		FROM patients_patient_assurance pa
+		LEFT JOIN base_assurance a ON pa.assurance_id = a.id
+		WHERE pa.id = $1
+		AND pa.patient_id = $2
+		FOR UPDATE OF pa;
+	`,
+
+	// DeactivateCoverage - Inactivation motivée (la ligne est conservée pour l'historique de facturation)
+	DeactivateCoverage: `
+		UPDATE patients_patient_assurance
+		SET
+			est_actif = false,
+			motif_inactivation = $2,
+			inactive_le = NOW(),
+			inactive_par = $3,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING inactive_le;
+	`,
+
+	// CountActiveDependents - Ayants droit actifs encore rattachés à un numéro d'assuré principal
+	CountActiveDependents: `
+		SELECT COUNT(*)
+		FROM patients_patient_assurance
+		WHERE assurance_id = $1
+		AND numero_assure_principal = $2
+		AND type_beneficiaire = 'ayant_droit'
+		AND est_actif = true;
+	`,
+
+	// SyncEstAssure - Aligne est_assure sur l'existence d'une couverture active (mise à jour seulement si changement)
+	SyncEstAssure: `
+		WITH etat AS (
+			SELECT EXISTS (
+				SELECT 1
+				FROM patients_patient_assurance
+				WHERE patient_id = $1
+				AND est_actif = true
+			) as est_assure
+		), maj AS (
+			UPDATE patients_patient p
+			SET
+				est_assure = etat.est_assure,
+				updated_by = $2
+			FROM etat
+			WHERE p.id = $1
+			AND p.est_assure IS DISTINCT FROM etat.est_assure
+			RETURNING p.id
+		)
+		SELECT est_assure FROM etat;
+	`,
+
+	// ListCoverages - Couvertures d'un patient (inactives incluses si $2)
+	ListCoverages: `
+		SELECT
+			pa.id,
+			pa.assurance_id,
+			COALESCE(a.code_organisme, ''),
+			COALESCE(a.nom_officiel, 'Assurance inconnue'),
+			pa.numero_assure,
+			pa.type_beneficiaire,
+			pa.numero_assure_principal,
+			pa.lien_avec_principal,
+			COALESCE(pa.est_actif, false),
+			pa.motif_inactivation,
+			pa.inactive_le,
+			pa.created_at
+		FROM patients_patient_assurance pa
+		LEFT JOIN base_assurance a ON pa.assurance_id = a.id
+		WHERE pa.patient_id = $1
+		AND ($2 OR pa.est_actif = true)
+		ORDER BY pa.est_actif DESC, pa.created_at;
+	`,
+}
@@ -3,7 +3,7 @@ package queries
 // PatientCreationQueries contient toutes les requêtes SQL pour la création de patients
 var PatientCreationQueries = struct {
 	CreatePatientWithValidation                  string
-	CheckDuplicateWithScoring                   string
+	SelectDuplicateCandidates                   string
 	GetPatientByCodeWithAssurances              string
 	InsertPatientAssurances                     string
 	ValidateReferenceDataExists                 string
@@ -28,55 +28,12 @@ var PatientCreationQueries = struct {
 		           telephone_principal, adresse_complete, est_assure, statut, created_at;
 	`,
 
-	// CheckDuplicateWithScoring - Vérification intelligente des doublons avec scoring
-	CheckDuplicateWithScoring: `
-		WITH patient_scores AS (
-			SELECT
-				id,
-				code_patient,
-				nom,
-				prenoms,
-				date_naissance,
-				sexe,
-				telephone_principal,
-				adresse_complete,
-				est_assure,
-				statut,
-				created_at,
-				-- Score textuel nom/prenoms avec normalisation
-				GREATEST(
-					similarity(unaccent(LOWER(nom)), unaccent(LOWER($1))),
-					similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($2)))
-				) * 100 as score_textuel,
-				-- Score date de naissance
-				CASE
-					WHEN date_naissance = $3::date THEN 100
-					WHEN ABS(EXTRACT(DAYS FROM date_naissance - $3::date)) <= 7 THEN 90
-					WHEN ABS(EXTRACT(DAYS FROM date_naissance - $3::date)) <= 30 THEN 70
-					WHEN ABS(EXTRACT(DAYS FROM date_naissance - $3::date)) <= 365 THEN 30
-					ELSE 0
-				END as score_date,
-				-- Score téléphone
-				CASE
-					WHEN telephone_principal = $4 THEN 100
-					WHEN $4 IS NOT NULL AND LENGTH($4) > 8 AND 
-						 telephone_principal LIKE '%' || RIGHT($4, 8) || '%' THEN 80
-					ELSE 0
-				END as score_telephone,
-				-- Détails de matching pour debug
-				similarity(unaccent(LOWER(nom)), unaccent(LOWER($1))) * 100 as nom_match,
-				similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($2))) * 100 as prenoms_match,
-				ABS(EXTRACT(DAYS FROM date_naissance - $3::date)) as jours_ecart_naissance
-			FROM patients_patient
-			WHERE statut NOT IN ('archive', 'decede')
-			AND (
-				-- Filtres pour limiter la recherche aux candidats potentiels
-				similarity(unaccent(LOWER(nom)), unaccent(LOWER($1))) > 0.3 OR
-				similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($2))) > 0.3 OR
-				ABS(EXTRACT(DAYS FROM date_naissance - $3::date)) <= 365 OR
-				($4 IS NOT NULL AND telephone_principal = $4)
-			)
-		)
+	// SelectDuplicateCandidates - Présélection large des candidats doublons (scoring effectué côté Go)
+	// Inclut l'inversion nom/prénoms, les variantes de date (jour/mois inversés, chiffres de l'année transposés),
+	// un écart de quelques jours et les 8 derniers chiffres du téléphone
+	// Le tri cumule les signaux pour que les graphies phonétiques à date ou téléphone concordants
+	// passent avant les simples homonymes approximatifs
+	SelectDuplicateCandidates: `
 		SELECT
 			id,
 			code_patient,
@@ -88,17 +45,37 @@ var PatientCreationQueries = struct {
 			adresse_complete,
 			est_assure,
 			statut,
-			created_at,
-			-- Score global pondéré
-			((score_textuel * 0.4) + (score_date * 0.4) + (score_telephone * 0.2))::integer as score_global,
-			-- Détails de matching
-			nom_match::integer,
-			prenoms_match::integer,
-			score_date::integer as date_naissance_match,
-			score_telephone::integer as telephone_match
-		FROM patient_scores
-		WHERE ((score_textuel * 0.4) + (score_date * 0.4) + (score_telephone * 0.2)) >= $5
-		ORDER BY score_global DESC
+			created_at
+		FROM patients_patient
+		WHERE statut NOT IN ('archive', 'decede')
+		AND est_provisoire = FALSE
+		AND (
+			similarity(unaccent(LOWER(nom)), unaccent(LOWER($1))) > 0.3 OR
+			similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($2))) > 0.3 OR
+			similarity(unaccent(LOWER(nom)), unaccent(LOWER($2))) > 0.3 OR
+			similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($1))) > 0.3 OR
+			date_naissance BETWEEN $3::date - 7 AND $3::date + 7 OR
+			date_naissance = ANY($4::date[]) OR
+			($5::text IS NOT NULL AND RIGHT(regexp_replace(telephone_principal, '[^0-9]', '', 'g'), 8) = $5)
+		)
+		ORDER BY (
+			GREATEST(
+				similarity(unaccent(LOWER(nom)), unaccent(LOWER($1))),
+				similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($2))),
+				similarity(unaccent(LOWER(nom)), unaccent(LOWER($2))),
+				similarity(unaccent(LOWER(prenoms)), unaccent(LOWER($1)))
+			)
+			+ CASE
+				WHEN date_naissance = $3::date THEN 1
+				WHEN date_naissance = ANY($4::date[]) THEN 0.9
+				WHEN date_naissance BETWEEN $3::date - 7 AND $3::date + 7 THEN 0.8
+				ELSE 0
+			END
+			+ CASE
+				WHEN $5::text IS NOT NULL AND RIGHT(regexp_replace(telephone_principal, '[^0-9]', '', 'g'), 8) = $5 THEN 0.5
+				ELSE 0
+			END
+		) DESC
 		LIMIT $6;
 	`,
 
@@ -129,7 +106,7 @@ var PatientCreationQueries = struct {
 					jsonb_build_object(
 						'id', pa.id,
 						'assurance_id', a.id,
-						'assurance_nom', a.nom_assurance,
+						'assurance_nom', a.nom_officiel,
 						'numero_assure', pa.numero_assure,
 						'type_beneficiaire', pa.type_beneficiaire,
 						'numero_assure_principal', pa.numero_assure_principal,
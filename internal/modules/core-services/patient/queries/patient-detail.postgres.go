@@ -40,6 +40,8 @@ var PatientDetailQueries = struct {
 				p.profession_id,
 				p.personnes_a_contacter,
 				p.est_assure,
+				p.est_provisoire,
+				p.signalement,
 				p.statut,
 				p.est_decede,
 				p.date_deces,
@@ -87,8 +89,8 @@ var PatientDetailQueries = struct {
 					jsonb_build_object(
 						'id', pa.id,
 						'assurance_id', a.id,
-						'assurance_code', COALESCE(a.code_assurance, ''),
-						'assurance_nom', COALESCE(a.nom_assurance, 'Assurance inconnue'),
+						'assurance_code', COALESCE(a.code_organisme, ''),
+						'assurance_nom', COALESCE(a.nom_officiel, 'Assurance inconnue'),
 						'numero_assure', pa.numero_assure,
 						'type_beneficiaire', pa.type_beneficiaire,
 						'numero_assure_principal', pa.numero_assure_principal,
@@ -131,6 +133,8 @@ var PatientDetailQueries = struct {
 			pd.profession_id,
 			pd.personnes_a_contacter,
 			pd.est_assure,
+			pd.est_provisoire,
+			pd.signalement,
 			pd.statut,
 			pd.est_decede,
 			pd.date_deces,
@@ -210,8 +214,8 @@ var PatientDetailQueries = struct {
 		SELECT
 			pa.id,
 			a.id as assurance_id,
-			COALESCE(a.code_assurance, '') as assurance_code,
-			COALESCE(a.nom_assurance, 'Assurance inconnue') as assurance_nom,
+			COALESCE(a.code_organisme, '') as assurance_code,
+			COALESCE(a.nom_officiel, 'Assurance inconnue') as assurance_nom,
 			pa.numero_assure,
 			pa.type_beneficiaire,
 			pa.numero_assure_principal,
@@ -0,0 +1,23 @@
+package queries
+
+// PatientFhirQueries contient les requêtes SQL de conversion FHIR R4 (résolution des codes référentiels)
+var PatientFhirQueries = struct {
+	ResolveReferenceCodes string
+	ListAffiliations      string
+}{
+	// ResolveReferenceCodes - Traduit les codes FHIR en identifiants référentiels (NULL si inconnu)
+	ResolveReferenceCodes: `
+		SELECT
+			(SELECT id FROM ref_nationalite WHERE code = $1 AND est_actif = true) as nationalite_id,
+			(SELECT id FROM ref_situation_matrimoniale WHERE code = $2 AND est_actif = true) as situation_matrimoniale_id,
+			(SELECT id FROM ref_type_piece_identite WHERE code = $3 AND est_actif = true) as type_piece_identite_id;
+	`,
+
+	// ListAffiliations - Référentiel des liens de parenté (table courte, chargée en entier)
+	ListAffiliations: `
+		SELECT id, code, nom
+		FROM ref_affiliation
+		WHERE est_actif = true
+		ORDER BY ordre_affichage, nom;
+	`,
+}
@@ -0,0 +1,78 @@
+package queries
+
+// PatientHistoryQueries contient toutes les requêtes SQL pour l'historique des modifications patient
+var PatientHistoryQueries = struct {
+	LockPatientSnapshotByCode string
+	GetPatientSnapshotByID    string
+	GetPatientSnapshotByCode  string
+	InsertHistoryEntry        string
+	ListHistory               string
+	CountHistory              string
+	ListHistorySince          string
+}{
+	// LockPatientSnapshotByCode - Verrouille le dossier et retourne son état complet (avant modification)
+	LockPatientSnapshotByCode: `
+		SELECT p.id, to_jsonb(p) - 'search_vector'
+		FROM patients_patient p
+		WHERE p.code_patient = $1
+		AND p.statut != 'archive'
+		FOR UPDATE;
+	`,
+
+	// GetPatientSnapshotByID - État complet d'un dossier (après modification, dans la transaction)
+	GetPatientSnapshotByID: `
+		SELECT to_jsonb(p) - 'search_vector'
+		FROM patients_patient p
+		WHERE p.id = $1;
+	`,
+
+	// GetPatientSnapshotByCode - État courant d'un dossier pour reconstitution à date
+	GetPatientSnapshotByCode: `
+		SELECT p.id, p.created_at, to_jsonb(p) - 'search_vector'
+		FROM patients_patient p
+		WHERE p.code_patient = $1;
+	`,
+
+	// InsertHistoryEntry - Ajout d'une entrée d'historique (table append-only)
+	InsertHistoryEntry: `
+		INSERT INTO patients_patient_historique (
+			patient_id, code_patient, type_modification, modifications, motif, modifie_par
+		) VALUES (
+			$1, $2, $3, $4::jsonb, $5, $6
+		);
+	`,
+
+	// ListHistory - Chronologie paginée (filtre optionnel sur un champ)
+	ListHistory: `
+		SELECT
+			h.id,
+			h.type_modification,
+			h.modifications::text,
+			h.motif,
+			h.modifie_le,
+			u.id, u.nom, u.prenoms
+		FROM patients_patient_historique h
+		LEFT JOIN user_utilisateur u ON h.modifie_par = u.id
+		WHERE h.patient_id = $1
+		AND ($2::text IS NULL OR h.modifications ? $2)
+		ORDER BY h.modifie_le DESC
+		LIMIT $3 OFFSET $4;
+	`,
+
+	// CountHistory - Total pour pagination de la chronologie
+	CountHistory: `
+		SELECT COUNT(*)
+		FROM patients_patient_historique h
+		WHERE h.patient_id = $1
+		AND ($2::text IS NULL OR h.modifications ? $2);
+	`,
+
+	// ListHistorySince - Modifications postérieures à une date (plus récente en premier) pour reconstitution
+	ListHistorySince: `
+		SELECT h.modifications::text
+		FROM patients_patient_historique h
+		WHERE h.patient_id = $1
+		AND h.modifie_le > $2
+		ORDER BY h.modifie_le DESC;
+	`,
+}
@@ -0,0 +1,65 @@
+package queries
+
+// PatientLifecycleQueries contient toutes les requêtes SQL des transitions de statut patient
+var PatientLifecycleQueries = struct {
+	LockPatientStatus        string
+	ValidateMedecinDeclarant string
+	DeclareDeath             string
+	UpdateStatus             string
+}{
+	// LockPatientStatus - Verrouille un dossier (archivés inclus) et retourne son état de cycle de vie
+	LockPatientStatus: `
+		SELECT
+			id,
+			statut,
+			COALESCE(est_decede, false),
+			date_naissance,
+			fusionne_vers_patient_id,
+			est_anonymise
+		FROM patients_patient
+		WHERE code_patient = $1
+		FOR UPDATE;
+	`,
+
+	// ValidateMedecinDeclarant - Vérifie que le déclarant est un médecin actif de l'établissement
+	ValidateMedecinDeclarant: `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_utilisateur
+			WHERE id = $1
+			AND etablissement_id = $2
+			AND est_medecin = true
+			AND statut = 'actif'
+		);
+	`,
+
+	// DeclareDeath - Passe le dossier au statut decede avec les informations de certification
+	DeclareDeath: `
+		UPDATE patients_patient
+		SET
+			statut = 'decede',
+			est_decede = true,
+			date_deces = $2,
+			cause_deces = $3,
+			medecin_declarant_deces_id = $4,
+			deces_declare_le = NOW(),
+			updated_by = $5,
+			updated_at = NOW()
+		WHERE id = $1
+		AND est_anonymise = FALSE
+		RETURNING updated_at;
+	`,
+
+	// UpdateStatus - Change le statut (désactivation, archivage, réactivation)
+	// Un dossier anonymisé peut être désactivé ou archivé, jamais réactivé
+	UpdateStatus: `
+		UPDATE patients_patient
+		SET
+			statut = $2,
+			updated_by = $3,
+			updated_at = NOW()
+		WHERE id = $1
+		AND (est_anonymise = FALSE OR $2 != 'actif')
+		RETURNING updated_at;
+	`,
+}
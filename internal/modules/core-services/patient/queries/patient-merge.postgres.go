@@ -0,0 +1,232 @@
+package queries
+
+// PatientMergeQueries contient toutes les requêtes SQL pour la fusion de doublons patients
+var PatientMergeQueries = struct {
+	LockPatientByCode        string
+	TransferAssurances       string
+	CountAssurancesRestantes string
+	UpdateSurvivorAfterMerge string
+	ArchiveMergedPatient     string
+	InsertMergeHistory       string
+	LockMergeHistory         string
+	RestoreAssurances        string
+	LockSurvivorForUndo      string
+	RestoreSurvivor          string
+	RestoreMergedPatient     string
+	MarkMergeUndone          string
+	IsMergedCode             string
+	ResolveMergeRedirect     string
+	ListMergeHistory         string
+	CountMergeHistory        string
+}{
+	// LockPatientByCode - Verrouille un dossier patient pour la durée de la fusion
+	LockPatientByCode: `
+		SELECT
+			id,
+			code_patient,
+			statut,
+			est_assure,
+			est_anonymise,
+			personnes_a_contacter::text
+		FROM patients_patient
+		WHERE code_patient = $1
+		FOR UPDATE;
+	`,
+
+	// TransferAssurances - Déplace les couvertures vers le survivant
+	// Les couvertures déjà présentes sur le survivant (même assurance, même état) restent sur le dossier archivé
+	TransferAssurances: `
+		UPDATE patients_patient_assurance pa
+		SET patient_id = $2
+		WHERE pa.patient_id = $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM patients_patient_assurance s
+			WHERE s.patient_id = $2
+			AND s.assurance_id = pa.assurance_id
+			AND s.est_actif IS NOT DISTINCT FROM pa.est_actif
+		)
+		RETURNING pa.id;
+	`,
+
+	// CountAssurancesRestantes - Compte les couvertures non transférées
+	CountAssurancesRestantes: `
+		SELECT COUNT(*)
+		FROM patients_patient_assurance
+		WHERE patient_id = $1;
+	`,
+
+	// UpdateSurvivorAfterMerge - Met à jour les contacts et le flag assuré du survivant
+	UpdateSurvivorAfterMerge: `
+		UPDATE patients_patient
+		SET
+			personnes_a_contacter = $2::jsonb,
+			est_assure = est_assure OR EXISTS (
+				SELECT 1 FROM patients_patient_assurance
+				WHERE patient_id = $1 AND est_actif = true
+			),
+			updated_by = $3
+		WHERE id = $1;
+	`,
+
+	// ArchiveMergedPatient - Archive le dossier fusionné avec pointeur vers le survivant
+	ArchiveMergedPatient: `
+		UPDATE patients_patient
+		SET
+			statut = 'archive',
+			fusionne_vers_patient_id = $2,
+			updated_by = $3
+		WHERE id = $1;
+	`,
+
+	// InsertMergeHistory - Enregistre la fusion avec l'état nécessaire à son annulation
+	InsertMergeHistory: `
+		INSERT INTO patients_fusion_historique (
+			patient_survivant_id, patient_fusionne_id,
+			code_patient_survivant, code_patient_fusionne,
+			etablissement_id, motif,
+			statut_fusionne_avant, est_assure_survivant_avant,
+			personnes_a_contacter_survivant_avant, personnes_a_contacter_ajoutees,
+			assurances_transferees, statut, fusionne_par
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9::jsonb, $10::jsonb, $11::uuid[], 'effectuee', $12
+		)
+		RETURNING id, fusionne_le;
+	`,
+
+	// LockMergeHistory - Verrouille une fusion pour annulation
+	LockMergeHistory: `
+		SELECT
+			id,
+			patient_survivant_id,
+			patient_fusionne_id,
+			code_patient_survivant,
+			code_patient_fusionne,
+			statut_fusionne_avant,
+			est_assure_survivant_avant,
+			personnes_a_contacter_ajoutees::text,
+			assurances_transferees,
+			statut
+		FROM patients_fusion_historique
+		WHERE id = $1
+		FOR UPDATE;
+	`,
+
+	// RestoreAssurances - Restitue les couvertures transférées au dossier d'origine
+	RestoreAssurances: `
+		UPDATE patients_patient_assurance
+		SET patient_id = $1
+		WHERE id = ANY($2::uuid[])
+		AND patient_id = $3;
+	`,
+
+	// LockSurvivorForUndo - Verrouille le survivant et lit ses contacts courants avant annulation
+	LockSurvivorForUndo: `
+		SELECT
+			statut,
+			personnes_a_contacter::text
+		FROM patients_patient
+		WHERE id = $1
+		FOR UPDATE;
+	`,
+
+	// RestoreSurvivor - Retire les contacts ajoutés par la fusion et réaligne le flag assuré
+	// À exécuter après restitution des couvertures : est_assure n'est conservé que s'il l'était avant la fusion
+	// et ne l'a pas perdu depuis, ou si une couverture active reste rattachée au survivant
+	RestoreSurvivor: `
+		UPDATE patients_patient
+		SET
+			personnes_a_contacter = $2::jsonb,
+			est_assure = ($3 AND est_assure) OR EXISTS (
+				SELECT 1 FROM patients_patient_assurance
+				WHERE patient_id = $1 AND est_actif = true
+			),
+			updated_by = $4
+		WHERE id = $1;
+	`,
+
+	// RestoreMergedPatient - Réactive le dossier fusionné (uniquement s'il pointe toujours vers le survivant)
+	RestoreMergedPatient: `
+		UPDATE patients_patient
+		SET
+			statut = $2,
+			fusionne_vers_patient_id = NULL,
+			updated_by = $3
+		WHERE id = $1
+		AND fusionne_vers_patient_id = $4
+		RETURNING id;
+	`,
+
+	// MarkMergeUndone - Marque la fusion comme annulée
+	MarkMergeUndone: `
+		UPDATE patients_fusion_historique
+		SET
+			statut = 'annulee',
+			annule_par = $2,
+			annule_le = NOW(),
+			motif_annulation = $3
+		WHERE id = $1;
+	`,
+
+	// IsMergedCode - Pré-contrôle indexé : le code désigne-t-il un dossier fusionné ?
+	IsMergedCode: `
+		SELECT EXISTS (
+			SELECT 1
+			FROM patients_patient
+			WHERE code_patient = $1
+			AND fusionne_vers_patient_id IS NOT NULL
+		);
+	`,
+
+	// ResolveMergeRedirect - Suit la chaîne de fusion jusqu'au dossier survivant final
+	ResolveMergeRedirect: `
+		WITH RECURSIVE chaine AS (
+			SELECT id, code_patient, fusionne_vers_patient_id, 0 AS profondeur
+			FROM patients_patient
+			WHERE code_patient = $1
+			UNION ALL
+			SELECT p.id, p.code_patient, p.fusionne_vers_patient_id, c.profondeur + 1
+			FROM patients_patient p
+			JOIN chaine c ON p.id = c.fusionne_vers_patient_id
+			WHERE c.profondeur < 10
+		)
+		SELECT code_patient
+		FROM chaine
+		WHERE profondeur > 0
+		AND fusionne_vers_patient_id IS NULL
+		ORDER BY profondeur DESC
+		LIMIT 1;
+	`,
+
+	// ListMergeHistory - Historique paginé des fusions
+	ListMergeHistory: `
+		SELECT
+			f.id,
+			f.code_patient_survivant,
+			f.code_patient_fusionne,
+			f.etablissement_id,
+			f.motif,
+			COALESCE(array_length(f.assurances_transferees, 1), 0),
+			f.statut,
+			f.fusionne_le,
+			f.annule_le,
+			f.motif_annulation,
+			fp.id, fp.nom, fp.prenoms,
+			ap.id, ap.nom, ap.prenoms
+		FROM patients_fusion_historique f
+		LEFT JOIN user_utilisateur fp ON f.fusionne_par = fp.id
+		LEFT JOIN user_utilisateur ap ON f.annule_par = ap.id
+		WHERE ($1::text IS NULL OR f.code_patient_survivant = $1 OR f.code_patient_fusionne = $1)
+		AND ($2::text IS NULL OR f.statut = $2)
+		ORDER BY f.fusionne_le DESC
+		LIMIT $3 OFFSET $4;
+	`,
+
+	// CountMergeHistory - Total pour pagination de l'historique
+	CountMergeHistory: `
+		SELECT COUNT(*)
+		FROM patients_fusion_historique f
+		WHERE ($1::text IS NULL OR f.code_patient_survivant = $1 OR f.code_patient_fusionne = $1)
+		AND ($2::text IS NULL OR f.statut = $2);
+	`,
+}
@@ -0,0 +1,109 @@
+package queries
+
+// PatientProvisionalQueries contient les requêtes SQL des patients inconnus (admission urgences et réconciliation)
+var PatientProvisionalQueries = struct {
+	ResolveProvisionalReferences string
+	InsertProvisionalPatient     string
+	GetProvisionalState          string
+	CompleteProvisionalIdentity  string
+	ListProvisionalPatients      string
+	CountProvisionalPatients     string
+}{
+	// ResolveProvisionalReferences - Référentiels par défaut d'une identité inconnue (NULL si absents)
+	ResolveProvisionalReferences: `
+		SELECT
+			(SELECT id FROM ref_nationalite WHERE code = $1 AND est_actif = true) as nationalite_id,
+			(SELECT id FROM ref_situation_matrimoniale WHERE code = $2 AND est_actif = true) as situation_matrimoniale_id;
+	`,
+
+	// InsertProvisionalPatient - Création d'un dossier à identité factice, marqué provisoire
+	InsertProvisionalPatient: `
+		INSERT INTO patients_patient (
+			code_patient, etablissement_createur_id,
+			nom, prenoms, date_naissance, est_date_supposee, sexe,
+			nationalite_id, situation_matrimoniale_id,
+			telephone_principal, adresse_complete,
+			est_provisoire, signalement,
+			statut, created_by
+		) VALUES (
+			$1, $2, $3, $4, $5, TRUE, $6, $7, $8, $9, $10, TRUE, $11, 'actif', $12
+		) RETURNING id, code_patient, nom, prenoms, date_naissance, est_date_supposee, sexe,
+		          telephone_principal, adresse_complete, est_assure, etablissement_createur_id,
+		          est_provisoire, statut, created_at;
+	`,
+
+	// GetProvisionalState - Indicateur provisoire et statut d'un dossier (contrôle avant fusion)
+	GetProvisionalState: `
+		SELECT est_provisoire, statut
+		FROM patients_patient
+		WHERE code_patient = $1;
+	`,
+
+	// CompleteProvisionalIdentity - Remplace l'identité factice et lève l'indicateur provisoire
+	// Paramètres: $1 = id, $2..$23 = identité complète, $24 = utilisateur
+	CompleteProvisionalIdentity: `
+		UPDATE patients_patient SET
+			nom                       = $2,
+			prenoms                   = $3,
+			date_naissance            = $4,
+			est_date_supposee         = $5,
+			sexe                      = $6,
+			nationalite_id            = $7,
+			situation_matrimoniale_id = $8,
+			type_piece_identite_id    = $9,
+			cni_nni                   = $10,
+			numero_piece_identite     = $11,
+			lieu_naissance            = $12,
+			nom_jeune_fille           = $13,
+			telephone_principal       = $14,
+			telephone_secondaire      = $15,
+			email                     = $16,
+			adresse_complete          = $17,
+			quartier                  = $18,
+			ville                     = $19,
+			commune                   = $20,
+			pays_residence            = $21,
+			profession_id             = $22,
+			personnes_a_contacter     = $23::jsonb,
+			est_provisoire            = FALSE,
+			reconcilie_le             = NOW(),
+			reconcilie_par            = $24,
+			updated_by                = $24,
+			updated_at                = NOW()
+		WHERE id = $1
+		AND est_provisoire = TRUE
+		AND est_anonymise = FALSE
+		RETURNING id, code_patient, nom, prenoms, date_naissance, est_date_supposee, sexe,
+		          telephone_principal, telephone_secondaire, email, adresse_complete,
+		          est_assure, etablissement_createur_id, est_provisoire, statut, created_at, reconcilie_le;
+	`,
+
+	// ListProvisionalPatients - Patients inconnus non réconciliés, plus anciens en premier
+	ListProvisionalPatients: `
+		SELECT
+			p.code_patient,
+			p.sexe,
+			p.date_naissance,
+			p.signalement,
+			p.statut,
+			p.etablissement_createur_id,
+			p.created_at,
+			u.id, u.nom, u.prenoms
+		FROM patients_patient p
+		LEFT JOIN user_utilisateur u ON p.created_by = u.id
+		WHERE p.est_provisoire = TRUE
+		AND p.statut = 'actif'
+		AND ($1::uuid IS NULL OR p.etablissement_createur_id = $1)
+		ORDER BY p.created_at ASC
+		LIMIT $2 OFFSET $3;
+	`,
+
+	// CountProvisionalPatients - Total pour pagination
+	CountProvisionalPatients: `
+		SELECT COUNT(*)
+		FROM patients_patient p
+		WHERE p.est_provisoire = TRUE
+		AND p.statut = 'actif'
+		AND ($1::uuid IS NULL OR p.etablissement_createur_id = $1);
+	`,
+}
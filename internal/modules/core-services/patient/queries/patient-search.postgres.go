@@ -2,12 +2,16 @@ package queries
 
 // PatientSearchQueries contient toutes les requêtes SQL pour la recherche de patients
 var PatientSearchQueries = struct {
-	SearchPatientsFullText        string
-	SearchPatientsByCriteria      string
-	GetPatientByCodeFromCache     string
-	CountPatientsFullText         string
-	CountPatientsByCriteria       string
-	SearchPatientsWithAssurances  string
+	SearchPatientsFullText         string
+	SearchPatientsByCriteria       string
+	GetPatientByCodeFromCache      string
+	CountPatientsFullText          string
+	CountPatientsByCriteria        string
+	SearchPatientsWithAssurances   string
+	SearchPatientsFullTextKeyset   string
+	SearchPatientsByCriteriaKeyset string
+	GetActiveAssurancesByPatients  string
+	AutocompletePatients           string
 }{
 	// SearchPatientsFullText - Recherche full-text optimisée avec scoring
 	SearchPatientsFullText: `
@@ -218,7 +222,7 @@ var PatientSearchQueries = struct {
 				jsonb_agg(
 					jsonb_build_object(
 						'id', pa.id,
-						'assurance_nom', COALESCE(a.nom_assurance, 'Assurance inconnue'),
+						'assurance_nom', COALESCE(a.nom_officiel, 'Assurance inconnue'),
 						'numero_assure', pa.numero_assure,
 						'type_beneficiaire', pa.type_beneficiaire,
 						'est_actif', pa.est_actif
@@ -237,4 +241,138 @@ var PatientSearchQueries = struct {
 		LEFT JOIN patient_assurances pa ON pr.id = pa.patient_id
 		ORDER BY pr.score DESC, pr.created_at DESC;
 	`,
+	// SearchPatientsFullTextKeyset - Recherche full-text paginée par curseur (score, created_at, id)
+	// Paramètres: $1..$11 = critères (cf. SearchPatientsFullText), $12 = tri, $13..$15 = curseur, $16 = limite
+	SearchPatientsFullTextKeyset: `
+		SELECT
+			r.id,
+			r.code_patient,
+			r.nom,
+			r.prenoms,
+			r.date_naissance,
+			r.sexe,
+			r.telephone_principal,
+			r.adresse_complete,
+			r.est_assure,
+			r.statut,
+			r.created_at,
+			r.score,
+			r.cle_score
+		FROM (
+			SELECT
+				p.*,
+				ts_rank(p.search_vector, plainto_tsquery('french', $1))::float8 as score,
+				CASE
+					WHEN $12 = 'score' THEN ts_rank(p.search_vector, plainto_tsquery('french', $1))::float8
+					ELSE 0::float8
+				END as cle_score
+			FROM patients_patient p
+			WHERE p.statut = ANY($2::text[])  -- Statuts autorisés
+				AND p.search_vector @@ plainto_tsquery('french', $1)
+				AND ($3::text IS NULL OR p.nom ILIKE '%' || $3 || '%')
+				AND ($4::text IS NULL OR p.prenoms ILIKE '%' || $4 || '%')
+				AND ($5::text IS NULL OR p.telephone_principal = $5)
+				AND ($6::date IS NULL OR p.date_naissance >= $6)
+				AND ($7::date IS NULL OR p.date_naissance <= $7)
+				AND ($8::char IS NULL OR p.sexe = $8)
+				AND ($9::text IS NULL OR p.cni_nni = $9)
+				AND ($10::boolean IS NULL OR p.est_assure = $10)
+				AND ($11::uuid IS NULL OR p.etablissement_createur_id = $11)
+		) r
+		WHERE ($13::float8 IS NULL OR (r.cle_score, r.created_at, r.id) < ($13::float8, $14::timestamp, $15::uuid))
+		ORDER BY r.cle_score DESC, r.created_at DESC, r.id DESC
+		LIMIT $16;
+	`,
+
+	// SearchPatientsByCriteriaKeyset - Recherche par critères paginée par curseur (created_at, id)
+	// Paramètres: $1..$11 = critères (cf. SearchPatientsByCriteria), $12..$13 = curseur, $14 = limite
+	SearchPatientsByCriteriaKeyset: `
+		SELECT
+			p.id,
+			p.code_patient,
+			p.nom,
+			p.prenoms,
+			p.date_naissance,
+			p.sexe,
+			p.telephone_principal,
+			p.adresse_complete,
+			p.est_assure,
+			p.statut,
+			p.created_at,
+			NULL::float8 as score,  -- Pas de score pour recherche par critères
+			0::float8 as cle_score
+		FROM patients_patient p
+		WHERE p.statut = ANY($1::text[])  -- Statuts autorisés
+			AND ($2::text IS NULL OR p.nom ILIKE '%' || $2 || '%')
+			AND ($3::text IS NULL OR p.prenoms ILIKE '%' || $3 || '%')
+			AND ($4::text IS NULL OR p.telephone_principal = $4)
+			AND ($5::date IS NULL OR p.date_naissance >= $5)
+			AND ($6::date IS NULL OR p.date_naissance <= $6)
+			AND ($7::date IS NULL OR p.date_naissance = $7)
+			AND ($8::char IS NULL OR p.sexe = $8)
+			AND ($9::text IS NULL OR p.cni_nni = $9)
+			AND ($10::boolean IS NULL OR p.est_assure = $10)
+			AND ($11::uuid IS NULL OR p.etablissement_createur_id = $11)
+			AND ($12::timestamp IS NULL OR (p.created_at, p.id) < ($12::timestamp, $13::uuid))
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $14;
+	`,
+
+	// GetActiveAssurancesByPatients - Assurances actives d'une page de résultats (pagination par curseur)
+	GetActiveAssurancesByPatients: `
+		SELECT
+			pa.patient_id,
+			jsonb_agg(
+				jsonb_build_object(
+					'id', pa.id,
+					'assurance_nom', COALESCE(a.nom_officiel, 'Assurance inconnue'),
+					'numero_assure', pa.numero_assure,
+					'type_beneficiaire', pa.type_beneficiaire,
+					'est_actif', pa.est_actif
+				) ORDER BY pa.created_at
+			) as assurances
+		FROM patients_patient_assurance pa
+		LEFT JOIN base_assurance a ON pa.assurance_id = a.id
+		WHERE pa.patient_id = ANY($1::uuid[])
+		AND pa.est_actif = true
+		GROUP BY pa.patient_id;
+	`,
+
+	// AutocompletePatients - Saisie assistée de l'accueil (index trigramme nom, prénoms, code, téléphone)
+	// Téléphones stockés au format E.164 : les chiffres saisis (numéro local) sont cherchés en contenu
+	// Paramètres: $1 = saisie (motif LIKE échappé), $2 = chiffres du téléphone, $3/$4 = mots saisis,
+	//             $5 = saisie brute (similarité), $6 = limite
+	AutocompletePatients: `
+		SELECT
+			p.id,
+			p.code_patient,
+			p.nom,
+			p.prenoms,
+			p.date_naissance,
+			p.sexe,
+			p.telephone_principal,
+			p.est_provisoire
+		FROM patients_patient p
+		WHERE p.statut = 'actif'
+			AND (
+				p.code_patient ILIKE $1 || '%'
+				OR ($2::text IS NOT NULL AND p.telephone_principal LIKE '%' || $2 || '%')
+				OR (
+					(p.nom ILIKE '%' || $3 || '%' OR p.prenoms ILIKE '%' || $3 || '%')
+					AND ($4::text IS NULL OR p.nom ILIKE '%' || $4 || '%' OR p.prenoms ILIKE '%' || $4 || '%')
+				)
+			)
+		ORDER BY
+			CASE
+				WHEN upper(p.code_patient) = upper($5) THEN 0
+				WHEN p.code_patient ILIKE $1 || '%' THEN 1
+				WHEN $2::text IS NOT NULL AND p.telephone_principal LIKE '%' || $2 || '%' THEN 1
+				WHEN p.nom ILIKE $3 || '%' THEN 2
+				ELSE 3
+			END,
+			similarity(p.nom || ' ' || p.prenoms, $5) DESC,
+			p.nom,
+			p.prenoms
+		LIMIT $6;
+	`,
 }
\ No newline at end of file
@@ -0,0 +1,44 @@
+package queries
+
+// PatientUpdateQueries contient toutes les requêtes SQL pour la modification de patients (CS-P-004)
+var PatientUpdateQueries = struct {
+	UpdatePatientPartial        string
+	ValidateUpdateReferenceData string
+}{
+	// UpdatePatientPartial - Mise à jour partielle (seuls les champs non NULL sont modifiés)
+	// Les patients archivés ou anonymisés ne sont jamais modifiables
+	// Le statut et le décès relèvent exclusivement du cycle de vie (PatientLifecycleQueries)
+	UpdatePatientPartial: `
+		UPDATE patients_patient SET
+			nom                       = COALESCE($2, nom),
+			prenoms                   = COALESCE($3, prenoms),
+			date_naissance            = COALESCE($4::date, date_naissance),
+			est_date_supposee         = COALESCE($5, est_date_supposee),
+			sexe                      = COALESCE($6, sexe),
+			nationalite_id            = COALESCE($7, nationalite_id),
+			situation_matrimoniale_id = COALESCE($8, situation_matrimoniale_id),
+			telephone_principal       = COALESCE($9, telephone_principal),
+			telephone_secondaire      = COALESCE($10, telephone_secondaire),
+			email                     = COALESCE($11, email),
+			adresse_complete          = COALESCE($12, adresse_complete),
+			quartier                  = COALESCE($13, quartier),
+			ville                     = COALESCE($14, ville),
+			commune                   = COALESCE($15, commune),
+			personnes_a_contacter     = COALESCE($16::jsonb, personnes_a_contacter),
+			updated_by                = $17,
+			updated_at                = NOW()
+		WHERE code_patient = $1
+		AND statut != 'archive'
+		AND est_anonymise = FALSE
+		RETURNING id, code_patient, nom, prenoms, date_naissance, est_date_supposee, sexe,
+		          telephone_principal, telephone_secondaire, email, adresse_complete,
+		          est_assure, etablissement_createur_id, statut, created_at;
+	`,
+
+	// ValidateUpdateReferenceData - Validation des références modifiées (NULL = non modifiée)
+	ValidateUpdateReferenceData: `
+		SELECT
+			($1::uuid IS NULL OR EXISTS (SELECT 1 FROM ref_nationalite WHERE id = $1 AND est_actif = true)) as nationalite_exists,
+			($2::uuid IS NULL OR EXISTS (SELECT 1 FROM ref_situation_matrimoniale WHERE id = $2 AND est_actif = true)) as situation_matrimoniale_exists;
+	`,
+}
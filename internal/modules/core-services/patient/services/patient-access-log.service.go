@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// Paramètres d'écriture par lots du journal des accès
+const (
+	accessLogBufferSize    = 10000           // Accès en attente avant débordement
+	accessLogBatchSize     = 500             // Lignes par INSERT
+	accessLogFlushInterval = 2 * time.Second // Délai max avant écriture d'un lot incomplet
+	accessLogWriteTimeout  = 10 * time.Second
+)
+
+// PatientAccessLogService journalise de façon persistante les accès aux dossiers patient
+// Les accès sont mis en file sans bloquer la lecture puis écrits par lots en arrière-plan
+type PatientAccessLogService struct {
+	db       *postgres.Client
+	buffer   chan dto.PatientAccessRecord
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	started  atomic.Bool
+	debordes atomic.Int64 // Accès non mis en file (tampon plein), tracés dans les logs uniquement
+}
+
+// NewPatientAccessLogService crée une nouvelle instance du service
+func NewPatientAccessLogService(db *postgres.Client) *PatientAccessLogService {
+	return &PatientAccessLogService{
+		db:     db,
+		buffer: make(chan dto.PatientAccessRecord, accessLogBufferSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// RegisterAccessLogLifecycle démarre l'écriture par lots avec l'application et vide la file à l'arrêt
+func RegisterAccessLogLifecycle(lc fx.Lifecycle, s *PatientAccessLogService) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			s.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+}
+
+// Start lance la boucle d'écriture (idempotent)
+func (s *PatientAccessLogService) Start() {
+	if s.started.CompareAndSwap(false, true) {
+		go s.run()
+	}
+}
+
+// Stop interrompt la boucle après écriture des accès encore en file
+func (s *PatientAccessLogService) Stop(ctx context.Context) error {
+	if !s.started.Load() {
+		return nil
+	}
+	s.once.Do(func() { close(s.stop) })
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("access log flush interrupted: %w", ctx.Err())
+	}
+}
+
+// Record met un accès en file d'écriture sans jamais bloquer l'appelant
+func (s *PatientAccessLogService) Record(record dto.PatientAccessRecord) {
+	if record.TypeAcces == "" {
+		record.TypeAcces = dto.AccessTypeConsultation
+	}
+	if record.AccedeLe.IsZero() {
+		record.AccedeLe = time.Now()
+	}
+
+	select {
+	case s.buffer <- record:
+	default:
+		// Tampon plein : l'accès reste tracé dans les logs applicatifs
+		total := s.debordes.Add(1)
+		s.logFallback(record, fmt.Sprintf("buffer full, %d dropped", total))
+	}
+}
+
+// run regroupe les accès en lots, écrits à taille atteinte ou à échéance
+func (s *PatientAccessLogService) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(accessLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]dto.PatientAccessRecord, 0, accessLogBatchSize)
+	for {
+		select {
+		case record := <-s.buffer:
+			batch = append(batch, record)
+			if len(batch) >= accessLogBatchSize {
+				batch = s.flush(batch)
+			}
+		case <-ticker.C:
+			batch = s.flush(batch)
+		case <-s.stop:
+			// Vidage final de la file avant arrêt
+			for {
+				select {
+				case record := <-s.buffer:
+					batch = append(batch, record)
+					if len(batch) >= accessLogBatchSize {
+						batch = s.flush(batch)
+					}
+				default:
+					s.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush écrit un lot en une seule instruction et retourne le lot vidé
+func (s *PatientAccessLogService) flush(batch []dto.PatientAccessRecord) []dto.PatientAccessRecord {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	// uuid.NullUUID : pgx n'encode pas les *uuid.UUID nil dans un tableau
+	patientIDs := make([]uuid.NullUUID, len(batch))
+	codes := make([]string, len(batch))
+	utilisateurIDs := make([]uuid.NullUUID, len(batch))
+	etablissementIDs := make([]uuid.NullUUID, len(batch))
+	modules := make([]*string, len(batch))
+	rubriques := make([]*string, len(batch))
+	types := make([]string, len(batch))
+	sources := make([]string, len(batch))
+	dates := make([]time.Time, len(batch))
+
+	for i, record := range batch {
+		patientIDs[i] = nullUUID(record.PatientID)
+		codes[i] = record.CodePatient
+		utilisateurIDs[i] = nullUUID(record.UtilisateurID)
+		etablissementIDs[i] = nullUUID(record.EtablissementID)
+		modules[i] = record.ModuleCode
+		rubriques[i] = record.RubriqueCode
+		types[i] = record.TypeAcces
+		sources[i] = record.Source
+		dates[i] = record.AccedeLe
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), accessLogWriteTimeout)
+	defer cancel()
+
+	err := s.db.Exec(ctx,
+		queries.PatientAccessLogQueries.InsertAccessBatch,
+		patientIDs, codes, utilisateurIDs, etablissementIDs,
+		modules, rubriques, types, sources, dates,
+	)
+	if err != nil {
+		fmt.Printf("[AUDIT] Access log batch write failed - Entries: %d, Error: %v\n", len(batch), err)
+		for _, record := range batch {
+			s.logFallback(record, "batch write failed")
+		}
+	}
+
+	return batch[:0]
+}
+
+// logFallback trace un accès non persisté dans les logs applicatifs
+func (s *PatientAccessLogService) logFallback(record dto.PatientAccessRecord, reason string) {
+	utilisateur := "-"
+	if record.UtilisateurID != nil {
+		utilisateur = record.UtilisateurID.String()
+	}
+	fmt.Printf("[AUDIT] Patient accessed (not persisted: %s) - Code: %s, User: %s, Type: %s, Source: %s, Time: %s\n",
+		reason, record.CodePatient, utilisateur, record.TypeAcces, record.Source,
+		record.AccedeLe.Format(time.RFC3339))
+}
+
+// ListAccessLog retourne le journal paginé des accès (plus récents en premier)
+func (s *PatientAccessLogService) ListAccessLog(
+	ctx context.Context,
+	filter *dto.AccessLogFilter,
+) (*dto.AccessLogResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PatientAccessLogQueries.CountAccessLog,
+		filter.EtablissementID,
+		filter.CodePatient,
+		filter.UtilisateurID,
+		filter.DateDebut,
+		filter.DateFin,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count access log: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientAccessLogQueries.ListAccessLog,
+		filter.EtablissementID,
+		filter.CodePatient,
+		filter.UtilisateurID,
+		filter.DateDebut,
+		filter.DateFin,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access log: %w", err)
+	}
+	defer rows.Close()
+
+	acces := []dto.AccessLogEntry{}
+	for rows.Next() {
+		var entry dto.AccessLogEntry
+		var utilisateurID *uuid.UUID
+		var utilisateurNom, utilisateurPrenoms *string
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CodePatient,
+			&entry.EtablissementID,
+			&entry.ModuleCode,
+			&entry.RubriqueCode,
+			&entry.TypeAcces,
+			&entry.Source,
+			&entry.AccedeLe,
+			&utilisateurID, &utilisateurNom, &utilisateurPrenoms,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan access log: %w", err)
+		}
+
+		entry.Utilisateur = buildUserInfo(utilisateurID, utilisateurNom, utilisateurPrenoms)
+		acces = append(acces, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate access log: %w", err)
+	}
+
+	return &dto.AccessLogResponse{
+		Acces:      acces,
+		Pagination: dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// withAccessType retourne une copie du contexte d'accès avec le type imposé par l'appelant
+func withAccessType(acces *dto.PatientAccessContext, typeAcces string) *dto.PatientAccessContext {
+	copie := dto.PatientAccessContext{TypeAcces: typeAcces}
+	if acces != nil {
+		copie = *acces
+		copie.TypeAcces = typeAcces
+	}
+	return &copie
+}
+
+// nullUUID convertit un identifiant optionnel pour l'encodage en tableau
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
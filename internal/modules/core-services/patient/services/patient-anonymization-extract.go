@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// anonymizedExtractHeader colonnes de l'extrait de recherche (aucune donnée directement identifiante)
+var anonymizedExtractHeader = []string{
+	"pseudo_id", "sexe", "annee_naissance", "nationalite", "situation_matrimoniale",
+	"profession", "ville", "pays_residence", "est_assure", "statut",
+	"annee_deces", "annee_creation",
+}
+
+// ExportAnonymizedExtract produit un extrait CSV anonymisé pour la recherche et le journalise
+// pseudo_id = HMAC-SHA256(code patient) avec une clé aléatoire propre à l'extrait et jamais conservée :
+// stable dans le fichier, non recoupable avec le référentiel ni avec un autre extrait
+func (s *PatientAnonymizationService) ExportAnonymizedExtract(
+	ctx context.Context,
+	etablissementID *uuid.UUID,
+	req *dto.AnonymizedExtractRequest,
+	userID uuid.UUID,
+) (*dto.AnonymizedExtract, error) {
+	cle := make([]byte, 32)
+	if _, err := rand.Read(cle); err != nil {
+		return nil, fmt.Errorf("failed to generate extract key: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientAnonymizationQueries.SelectExtractRows,
+		etablissementID,
+		req.DateDebut,
+		req.DateFin,
+		req.InclureDecedes,
+		req.InclureArchives,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select extract rows: %w", err)
+	}
+	defer rows.Close()
+
+	lignes := [][]string{}
+	for rows.Next() {
+		var codePatient, sexe, statut string
+		var anneeNaissance, anneeCreation int
+		var anneeDeces *int
+		var nationalite, situation, profession, ville, pays *string
+		var estAssure bool
+
+		if err := rows.Scan(
+			&codePatient, &sexe, &anneeNaissance,
+			&nationalite, &situation, &profession,
+			&ville, &pays, &estAssure, &statut,
+			&anneeDeces, &anneeCreation,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan extract row: %w", err)
+		}
+
+		mac := hmac.New(sha256.New, cle)
+		mac.Write([]byte(codePatient))
+
+		lignes = append(lignes, []string{
+			hex.EncodeToString(mac.Sum(nil))[:16],
+			sexe,
+			strconv.Itoa(anneeNaissance),
+			optionalCell(nationalite),
+			optionalCell(situation),
+			optionalCell(profession),
+			optionalCell(ville),
+			optionalCell(pays),
+			strconv.FormatBool(estAssure),
+			statut,
+			optionalIntCell(anneeDeces),
+			strconv.Itoa(anneeCreation),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read extract rows: %w", err)
+	}
+
+	// Tri par pseudonyme : l'ordre des lignes ne trahit pas l'ordre de création des dossiers
+	sort.Slice(lignes, func(i, j int) bool { return lignes[i][0] < lignes[j][0] })
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = ';'
+	if err := writer.Write(anonymizedExtractHeader); err != nil {
+		return nil, fmt.Errorf("failed to write extract: %w", err)
+	}
+	if err := writer.WriteAll(lignes); err != nil {
+		return nil, fmt.Errorf("failed to write extract: %w", err)
+	}
+
+	empreinte := sha256.Sum256(buffer.Bytes())
+	extract := &dto.AnonymizedExtract{
+		Contenu:     buffer.Bytes(),
+		ContentType: "text/csv; charset=utf-8",
+		NomFichier:  fmt.Sprintf("extrait-anonymise-%s.csv", time.Now().Format("20060102-150405")),
+		Lignes:      len(lignes),
+		Empreinte:   hex.EncodeToString(empreinte[:]),
+	}
+
+	// Journalisation : critères, volume et empreinte du fichier remis
+	details := map[string]interface{}{
+		"motif":            req.Motif,
+		"lignes":           extract.Lignes,
+		"empreinte":        extract.Empreinte,
+		"inclure_decedes":  req.InclureDecedes,
+		"inclure_archives": req.InclureArchives,
+	}
+	if etablissementID != nil {
+		details["etablissement_id"] = etablissementID.String()
+	}
+	if req.DateDebut != nil {
+		details["date_debut"] = req.DateDebut.Format(time.RFC3339)
+	}
+	if req.DateFin != nil {
+		details["date_fin"] = req.DateFin.Format(time.RFC3339)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		numero, err := appendAnonymizationJournal(ctx, tx, dto.AnonymizationOpExtraction, nil, nil, details, userID)
+		extract.JournalNumero = numero
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Anonymized extract produced - Lines: %d, SHA-256: %s, User: %s, Journal: %d\n",
+		extract.Lignes, extract.Empreinte, userID, extract.JournalNumero)
+
+	return extract, nil
+}
+
+// optionalCell retourne la valeur ou une cellule vide
+func optionalCell(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// optionalIntCell retourne l'entier ou une cellule vide
+func optionalIntCell(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// anonymizationJournalEntry représente un maillon du journal chaîné des anonymisations
+type anonymizationJournalEntry struct {
+	numero        int64
+	operation     string
+	demandeID     *uuid.UUID
+	codePatient   *string
+	details       string
+	effectuePar   uuid.UUID
+	effectueLe    time.Time
+	hashPrecedent string
+	hash          string
+}
+
+// computeHash calcule l'empreinte SHA-256 du maillon (tous les champs + maillon précédent)
+func (e *anonymizationJournalEntry) computeHash() string {
+	demandeID := ""
+	if e.demandeID != nil {
+		demandeID = e.demandeID.String()
+	}
+	codePatient := ""
+	if e.codePatient != nil {
+		codePatient = *e.codePatient
+	}
+
+	payload := strings.Join([]string{
+		strconv.FormatInt(e.numero, 10),
+		e.operation,
+		demandeID,
+		codePatient,
+		e.details,
+		e.effectuePar.String(),
+		e.effectueLe.UTC().Format(time.RFC3339Nano),
+		e.hashPrecedent,
+	}, "|")
+
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])
+}
+
+// appendAnonymizationJournal ajoute un maillon dans la transaction de l'opération journalisée
+// Le verrou consultatif sérialise les écritures : la chaîne reste continue sous concurrence
+func appendAnonymizationJournal(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	operation string,
+	demandeID *uuid.UUID,
+	codePatient *string,
+	details map[string]interface{},
+	userID uuid.UUID,
+) (int64, error) {
+	if err := tx.Exec(ctx, queries.PatientAnonymizationQueries.LockJournal); err != nil {
+		return 0, fmt.Errorf("failed to lock anonymization journal: %w", err)
+	}
+
+	var dernierNumero int64
+	hashPrecedent := dto.AnonymizedGenesisHash
+	err := tx.QueryRow(ctx, queries.PatientAnonymizationQueries.GetLastJournalEntry).Scan(&dernierNumero, &hashPrecedent)
+	if err != nil && err != pgx.ErrNoRows {
+		return 0, fmt.Errorf("failed to read anonymization journal: %w", err)
+	}
+
+	// JSON canonique (clés triées par encoding/json) : les octets hachés sont ceux stockés
+	data, err := json.Marshal(details)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal anonymization journal details: %w", err)
+	}
+
+	entry := anonymizationJournalEntry{
+		numero:        dernierNumero + 1,
+		operation:     operation,
+		demandeID:     demandeID,
+		codePatient:   codePatient,
+		details:       string(data),
+		effectuePar:   userID,
+		effectueLe:    time.Now().UTC().Truncate(time.Microsecond), // Précision TIMESTAMP PostgreSQL
+		hashPrecedent: hashPrecedent,
+	}
+	entry.hash = entry.computeHash()
+
+	if err := tx.Exec(ctx,
+		queries.PatientAnonymizationQueries.InsertJournalEntry,
+		entry.numero,
+		entry.operation,
+		entry.demandeID,
+		entry.codePatient,
+		entry.details,
+		entry.effectuePar,
+		entry.effectueLe,
+		entry.hashPrecedent,
+		entry.hash,
+	); err != nil {
+		return 0, fmt.Errorf("failed to append anonymization journal: %w", err)
+	}
+
+	return entry.numero, nil
+}
+
+// VerifyJournal recalcule la chaîne complète et signale le premier maillon altéré
+// Une troncature en fin de chaîne se détecte en comparant dernier_hash à une copie conservée hors base
+func (s *PatientAnonymizationService) VerifyJournal(ctx context.Context) (*dto.AnonymizationJournalVerification, error) {
+	rows, err := s.db.Query(ctx, queries.PatientAnonymizationQueries.ListJournal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anonymization journal: %w", err)
+	}
+	defer rows.Close()
+
+	result := &dto.AnonymizationJournalVerification{
+		Valide:      true,
+		DernierHash: dto.AnonymizedGenesisHash,
+	}
+
+	for rows.Next() {
+		var entry anonymizationJournalEntry
+		if err := rows.Scan(
+			&entry.numero,
+			&entry.operation,
+			&entry.demandeID,
+			&entry.codePatient,
+			&entry.details,
+			&entry.effectuePar,
+			&entry.effectueLe,
+			&entry.hashPrecedent,
+			&entry.hash,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan anonymization journal: %w", err)
+		}
+
+		var rupture string
+		switch {
+		case entry.numero != result.Entrees+1:
+			rupture = fmt.Sprintf("Maillon %d manquant (numéro %d trouvé)", result.Entrees+1, entry.numero)
+		case entry.hashPrecedent != result.DernierHash:
+			rupture = fmt.Sprintf("Maillon %d non rattaché au maillon précédent", entry.numero)
+		case entry.computeHash() != entry.hash:
+			rupture = fmt.Sprintf("Maillon %d altéré (empreinte invalide)", entry.numero)
+		}
+		if rupture != "" {
+			numero := entry.numero
+			result.Valide = false
+			result.RuptureA = &numero
+			result.Message = &rupture
+			return result, nil
+		}
+
+		result.Entrees++
+		result.DernierHash = entry.hash
+	}
+
+	return result, nil
+}
@@ -0,0 +1,478 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientAnonymizationService gère l'effacement des données personnelles (loi 2013-450)
+// Demande puis approbation par un second administrateur, pseudonymisation irréversible de l'identité
+// (code patient et rattachements cliniques conservés), journal chaîné inviolable des opérations
+type PatientAnonymizationService struct {
+	db             *postgres.Client
+	txManager      *postgres.TransactionManager
+	historyService *PatientHistoryService
+	cacheService   *PatientCacheService
+}
+
+// NewPatientAnonymizationService crée une nouvelle instance du service
+func NewPatientAnonymizationService(
+	db *postgres.Client,
+	historyService *PatientHistoryService,
+	cacheService *PatientCacheService,
+) *PatientAnonymizationService {
+	return &PatientAnonymizationService{
+		db:             db,
+		txManager:      postgres.NewTransactionManager(db),
+		historyService: historyService,
+		cacheService:   cacheService,
+	}
+}
+
+// CreateRequest enregistre une demande d'effacement, en attente d'approbation
+func (s *PatientAnonymizationService) CreateRequest(
+	ctx context.Context,
+	req *dto.CreateAnonymizationRequest,
+	userID uuid.UUID,
+) (*dto.AnonymizationEntry, error) {
+	var patientID uuid.UUID
+	var codePatient string
+	var estAnonymise, estFusionne bool
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientAnonymizationQueries.GetPatientAnonymizationState,
+		req.CodePatient,
+	).Scan(&patientID, &codePatient, &estAnonymise, &estFusionne)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientAnonymizationError(dto.ErrAnonymisationPatientIntrouvable,
+				fmt.Sprintf("Patient '%s' introuvable", req.CodePatient))
+		}
+		return nil, fmt.Errorf("failed to check patient for anonymization: %w", err)
+	}
+	if estAnonymise {
+		return nil, dto.NewPatientAnonymizationError(dto.ErrAnonymisationDejaAnonymise,
+			fmt.Sprintf("Le patient '%s' est déjà anonymisé", codePatient))
+	}
+	if estFusionne {
+		// Le dossier survivant porte la demande : ses dossiers fusionnés sont anonymisés avec lui
+		return nil, dto.NewPatientAnonymizationError(dto.ErrAnonymisationDossierFusionne,
+			fmt.Sprintf("Le dossier '%s' a été fusionné : la demande doit porter sur le dossier survivant", codePatient))
+	}
+
+	var demandeID uuid.UUID
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		var demandeLe time.Time
+		if err := tx.QueryRow(ctx,
+			queries.PatientAnonymizationQueries.InsertDemande,
+			patientID,
+			codePatient,
+			req.Motif,
+			userID,
+		).Scan(&demandeID, &demandeLe); err != nil {
+			// Index unique : une seule demande en attente par patient
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return dto.NewPatientAnonymizationError(dto.ErrAnonymisationDejaDemandee,
+					fmt.Sprintf("Une demande d'effacement est déjà en attente pour le patient '%s'", codePatient))
+			}
+			return fmt.Errorf("failed to insert anonymization request: %w", err)
+		}
+
+		_, err := appendAnonymizationJournal(ctx, tx, dto.AnonymizationOpDemande, &demandeID, &codePatient,
+			map[string]interface{}{"motif": req.Motif}, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Anonymization requested - Patient: %s, Demande: %s, User: %s\n",
+		codePatient, demandeID, userID)
+
+	return s.getRequest(ctx, demandeID)
+}
+
+// RejectRequest clôture une demande sans anonymisation (motif obligatoire)
+func (s *PatientAnonymizationService) RejectRequest(
+	ctx context.Context,
+	demandeID uuid.UUID,
+	req *dto.RejectAnonymizationRequest,
+	userID uuid.UUID,
+) (*dto.AnonymizationEntry, error) {
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		_, codePatient, err := s.lockPendingRequest(ctx, tx, demandeID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec(ctx,
+			queries.PatientAnonymizationQueries.RejectDemande,
+			demandeID,
+			userID,
+			req.Motif,
+		); err != nil {
+			return fmt.Errorf("failed to reject anonymization request: %w", err)
+		}
+
+		_, err = appendAnonymizationJournal(ctx, tx, dto.AnonymizationOpRejet, &demandeID, &codePatient,
+			map[string]interface{}{"motif": req.Motif}, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Anonymization rejected - Demande: %s, User: %s\n", demandeID, userID)
+
+	return s.getRequest(ctx, demandeID)
+}
+
+// ApproveRequest approuve et exécute une demande : opération irréversible
+// Le dossier demandé et les dossiers fusionnés vers lui sont anonymisés dans une seule transaction
+func (s *PatientAnonymizationService) ApproveRequest(
+	ctx context.Context,
+	demandeID uuid.UUID,
+	userID uuid.UUID,
+) (*dto.AnonymizationResult, error) {
+	result := &dto.AnonymizationResult{
+		DemandeID:          demandeID,
+		DossiersAnonymises: []string{},
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, codePatient, err := s.lockPendingRequest(ctx, tx, demandeID, userID)
+		if err != nil {
+			return err
+		}
+		result.CodePatient = codePatient
+		result.AnonymiseLe = time.Now().UTC().Truncate(time.Microsecond)
+
+		// 1. Famille de dossiers (demandé + fusionnés), verrouillée
+		patientIDs, codes, err := s.lockMergedFamily(ctx, tx, patientID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Expurgation des valeurs d'identité de l'historique existant et des fusions
+		if err := tx.Exec(ctx, queries.PatientAnonymizationQueries.EnableHistoryRedaction); err != nil {
+			return fmt.Errorf("failed to enable history redaction: %w", err)
+		}
+		rows, err := tx.Query(ctx,
+			queries.PatientAnonymizationQueries.RedactHistory,
+			patientIDs,
+			dto.AnonymizedIdentityFields,
+			dto.AnonymizedValeur,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to redact patient history: %w", err)
+		}
+		for rows.Next() {
+			result.EntreesHistoriqueExpurgees++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to redact patient history: %w", err)
+		}
+
+		if err := tx.Exec(ctx, queries.PatientAnonymizationQueries.ClearFusionContacts, patientIDs); err != nil {
+			return fmt.Errorf("failed to clear merge contacts: %w", err)
+		}
+
+		// 3. Pseudonymisation de chaque dossier, historisée sans les valeurs d'identité d'origine
+		for i, id := range patientIDs {
+			if err := s.anonymizePatient(ctx, tx, id, codes[i], demandeID, result.AnonymiseLe, userID); err != nil {
+				return err
+			}
+		}
+		result.DossiersAnonymises = codes
+
+		// 4. Numéros d'assuré
+		rows, err = tx.Query(ctx,
+			queries.PatientAnonymizationQueries.PseudonymizeAssurances,
+			patientIDs,
+			dto.AnonymizedPrefixe,
+			dto.AnonymizedNom,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to pseudonymize insurances: %w", err)
+		}
+		for rows.Next() {
+			result.CouverturesPseudonymisees++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to pseudonymize insurances: %w", err)
+		}
+
+		// 5. Clôture de la demande et maillon du journal
+		if err := tx.Exec(ctx,
+			queries.PatientAnonymizationQueries.CompleteDemande,
+			demandeID,
+			userID,
+			result.AnonymiseLe,
+			codes,
+		); err != nil {
+			return fmt.Errorf("failed to complete anonymization request: %w", err)
+		}
+
+		result.JournalNumero, err = appendAnonymizationJournal(ctx, tx, dto.AnonymizationOpAnonymisation,
+			&demandeID, &codePatient, map[string]interface{}{
+				"dossiers":             codes,
+				"couvertures":          result.CouverturesPseudonymisees,
+				"historique_expurge":   result.EntreesHistoriqueExpurgees,
+				"champs_pseudonymises": dto.AnonymizedIdentityFields,
+			}, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Caches Redis : aucune copie de l'identité d'origine ne doit subsister
+	for _, code := range result.DossiersAnonymises {
+		if err := s.cacheService.InvalidatePatientCache(ctx, code); err != nil {
+			fmt.Printf("[CACHE] Failed to invalidate anonymized patient %s: %v\n", code, err)
+		}
+		if err := s.cacheService.InvalidateSearchCaches(ctx, code); err != nil {
+			fmt.Printf("[CACHE] Failed to invalidate search caches for %s: %v\n", code, err)
+		}
+	}
+
+	fmt.Printf("[AUDIT] Patient anonymized - Patient: %s, Dossiers: %v, Demande: %s, Approved by: %s, Journal: %d\n",
+		result.CodePatient, result.DossiersAnonymises, demandeID, userID, result.JournalNumero)
+
+	return result, nil
+}
+
+// ListRequests retourne les demandes d'effacement paginées
+func (s *PatientAnonymizationService) ListRequests(
+	ctx context.Context,
+	filter *dto.AnonymizationFilter,
+) (*dto.AnonymizationListResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PatientAnonymizationQueries.CountDemandes,
+		filter.CodePatient,
+		filter.Statut,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count anonymization requests: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientAnonymizationQueries.ListDemandes,
+		filter.CodePatient,
+		filter.Statut,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anonymization requests: %w", err)
+	}
+	defer rows.Close()
+
+	demandes := []dto.AnonymizationEntry{}
+	for rows.Next() {
+		entry, err := scanAnonymizationEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		demandes = append(demandes, *entry)
+	}
+
+	return &dto.AnonymizationListResponse{
+		Demandes:   demandes,
+		Pagination: dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// lockPendingRequest verrouille une demande en attente et applique le principe des quatre yeux
+func (s *PatientAnonymizationService) lockPendingRequest(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	demandeID uuid.UUID,
+	userID uuid.UUID,
+) (uuid.UUID, string, error) {
+	var id, patientID, demandePar uuid.UUID
+	var codePatient, statut string
+
+	err := tx.QueryRow(ctx,
+		queries.PatientAnonymizationQueries.LockDemande,
+		demandeID,
+	).Scan(&id, &patientID, &codePatient, &statut, &demandePar)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, "", dto.NewPatientAnonymizationError(dto.ErrAnonymisationDemandeIntrouvable,
+				fmt.Sprintf("Demande d'effacement '%s' introuvable", demandeID))
+		}
+		return uuid.Nil, "", fmt.Errorf("failed to lock anonymization request: %w", err)
+	}
+
+	if statut != dto.AnonymizationStatutEnAttente {
+		return uuid.Nil, "", dto.NewPatientAnonymizationError(dto.ErrAnonymisationDejaTraitee,
+			fmt.Sprintf("La demande d'effacement a déjà été traitée (statut: %s)", statut))
+	}
+	if demandePar == userID {
+		return uuid.Nil, "", dto.NewPatientAnonymizationError(dto.ErrAnonymisationQuatreYeux,
+			"La demande doit être traitée par un autre administrateur que son auteur")
+	}
+
+	return patientID, codePatient, nil
+}
+
+// lockMergedFamily retourne le dossier demandé et les dossiers fusionnés vers lui, verrouillés
+func (s *PatientAnonymizationService) lockMergedFamily(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+) ([]uuid.UUID, []string, error) {
+	rows, err := tx.Query(ctx, queries.PatientAnonymizationQueries.LockMergedFamily, patientID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock merged patients: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	var codes []string
+	for rows.Next() {
+		var id uuid.UUID
+		var code string
+		var estAnonymise bool
+		if err := rows.Scan(&id, &code, &estAnonymise); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan merged patients: %w", err)
+		}
+		if id == patientID && estAnonymise {
+			return nil, nil, dto.NewPatientAnonymizationError(dto.ErrAnonymisationDejaAnonymise,
+				fmt.Sprintf("Le patient '%s' est déjà anonymisé", code))
+		}
+		ids = append(ids, id)
+		codes = append(codes, code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to lock merged patients: %w", err)
+	}
+
+	return ids, codes, nil
+}
+
+// anonymizePatient pseudonymise un dossier et historise le changement avec des valeurs d'origine expurgées
+func (s *PatientAnonymizationService) anonymizePatient(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+	codePatient string,
+	demandeID uuid.UUID,
+	anonymiseLe time.Time,
+	userID uuid.UUID,
+) error {
+	avant, err := s.historyService.Snapshot(ctx, tx, patientID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot patient before anonymization: %w", err)
+	}
+	if estAnonymise, _ := avant["est_anonymise"].(bool); estAnonymise {
+		return nil // Dossier fusionné déjà anonymisé lors d'une demande antérieure
+	}
+
+	pseudonyme, err := randomPseudonym()
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ctx,
+		queries.PatientAnonymizationQueries.AnonymizePatient,
+		patientID,
+		dto.AnonymizedNom,
+		pseudonyme,
+		dto.AnonymizedTelephone,
+		dto.AnonymizedAdresse,
+		userID,
+		anonymiseLe,
+	); err != nil {
+		return fmt.Errorf("failed to anonymize patient: %w", err)
+	}
+
+	apres, err := s.historyService.Snapshot(ctx, tx, patientID)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot anonymized patient: %w", err)
+	}
+
+	// L'historique ne doit pas conserver l'identité d'origine : valeurs "avant" expurgées
+	for _, champ := range dto.AnonymizedIdentityFields {
+		if !reflect.DeepEqual(avant[champ], apres[champ]) {
+			avant[champ] = dto.AnonymizedValeur
+		}
+	}
+
+	return s.historyService.RecordChanges(ctx, tx, patientID, codePatient,
+		dto.HistoryTypeAnonymisation, avant, apres,
+		fmt.Sprintf("Anonymisation (loi 2013-450) - demande %s", demandeID), userID)
+}
+
+// getRequest relit une demande avec ses auteurs
+func (s *PatientAnonymizationService) getRequest(ctx context.Context, demandeID uuid.UUID) (*dto.AnonymizationEntry, error) {
+	rows, err := s.db.Query(ctx, queries.PatientAnonymizationQueries.GetDemande, demandeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load anonymization request: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, dto.NewPatientAnonymizationError(dto.ErrAnonymisationDemandeIntrouvable,
+			fmt.Sprintf("Demande d'effacement '%s' introuvable", demandeID))
+	}
+	return scanAnonymizationEntry(rows)
+}
+
+// scanAnonymizationEntry lit une ligne de GetDemande / ListDemandes
+func scanAnonymizationEntry(rows pgx.Rows) (*dto.AnonymizationEntry, error) {
+	var entry dto.AnonymizationEntry
+	var demandeParID, traiteParID *uuid.UUID
+	var demandeParNom, demandeParPrenoms, traiteParNom, traiteParPrenoms *string
+
+	if err := rows.Scan(
+		&entry.ID,
+		&entry.CodePatient,
+		&entry.Motif,
+		&entry.Statut,
+		&entry.DemandeLe,
+		&entry.TraiteLe,
+		&entry.MotifRejet,
+		&entry.DossiersAnonymises,
+		&demandeParID, &demandeParNom, &demandeParPrenoms,
+		&traiteParID, &traiteParNom, &traiteParPrenoms,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan anonymization request: %w", err)
+	}
+
+	entry.DemandePar = buildUserInfo(demandeParID, demandeParNom, demandeParPrenoms)
+	entry.TraitePar = buildUserInfo(traiteParID, traiteParNom, traiteParPrenoms)
+	return &entry, nil
+}
+
+// randomPseudonym génère un pseudonyme aléatoire sans lien calculable avec l'identité d'origine
+func randomPseudonym() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate pseudonym: %w", err)
+	}
+	return dto.AnonymizedPrefixe + fmt.Sprintf("%X", buf), nil
+}
@@ -0,0 +1,559 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientBulkImportService importe en masse des patients depuis un fichier CSV (migration d'établissement)
+// Chaque ligne passe par la validation métier et l'anti-doublon avant insertion par lots
+type PatientBulkImportService struct {
+	db            *postgres.Client
+	txManager     *postgres.TransactionManager
+	validator     *PatientValidationService
+	codeGenerator *PatientCodeGeneratorService
+	matcher       *PatientMatchingEngine
+}
+
+// NewPatientBulkImportService crée une nouvelle instance du service
+func NewPatientBulkImportService(
+	db *postgres.Client,
+	validator *PatientValidationService,
+	codeGenerator *PatientCodeGeneratorService,
+) *PatientBulkImportService {
+	return &PatientBulkImportService{
+		db:            db,
+		txManager:     postgres.NewTransactionManager(db),
+		validator:     validator,
+		codeGenerator: codeGenerator,
+		matcher:       NewPatientMatchingEngine(),
+	}
+}
+
+// Limites de l'import CSV
+const (
+	maxImportLignes        = 50000
+	defaultImportTailleLot = 100
+	maxImportTailleLot     = 500
+)
+
+// Formats de date acceptés dans les fichiers (ISO puis format français)
+var importDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
+// importReferences indexe les référentiels actifs : référentiel -> code -> id
+type importReferences map[string]map[string]uuid.UUID
+
+// pendingImportRow représente une ligne acceptée en attente d'insertion
+type pendingImportRow struct {
+	report *dto.BulkImportRowReport
+	req    *dto.CreatePatientRequest
+}
+
+// ImportCSV lit le fichier en flux et produit un rapport ligne par ligne
+// En dry-run, aucune insertion ni génération de code n'est effectuée
+func (s *PatientBulkImportService) ImportCSV(
+	ctx context.Context,
+	source io.Reader,
+	etablissementCode string,
+	etablissementID uuid.UUID,
+	options dto.BulkImportOptions,
+	userID uuid.UUID,
+) (*dto.BulkImportReport, error) {
+	startTime := time.Now()
+
+	if options.TailleLot <= 0 {
+		options.TailleLot = defaultImportTailleLot
+	}
+	if options.TailleLot > maxImportTailleLot {
+		options.TailleLot = maxImportTailleLot
+	}
+
+	// 1. En-tête : détection du séparateur (Excel FR exporte en ';') et des colonnes
+	buffered := bufio.NewReader(source)
+	headerLine, err := buffered.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	if strings.TrimSpace(headerLine) == "" {
+		return nil, dto.NewBulkImportError(dto.ErrImportFichierVide, "Le fichier est vide")
+	}
+
+	separateur := s.detectSeparator(headerLine)
+	colonnes, err := s.parseHeader(headerLine, separateur)
+	if err != nil {
+		return nil, err
+	}
+
+	references, err := s.loadReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = separateur
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	report := &dto.BulkImportReport{
+		DryRun: options.DryRun,
+		Lignes: []dto.BulkImportRowReport{},
+	}
+	var pending []pendingImportRow
+	var reports []*dto.BulkImportRowReport
+	vues := make(map[string]int) // Identité normalisée -> ligne acceptée (doublons internes au fichier)
+
+	// 2. Traitement ligne par ligne
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if readErr != nil && !errors.As(readErr, &parseErr) {
+			return nil, fmt.Errorf("failed to read CSV: %w", readErr)
+		}
+
+		if report.TotalLignes >= maxImportLignes {
+			report.Tronque = true
+			break
+		}
+		report.TotalLignes++
+
+		// Numéro de ligne dans le fichier (l'en-tête a été lu séparément)
+		var ligne int
+		if parseErr != nil {
+			ligne = parseErr.StartLine + 1
+		} else {
+			ligne, _ = reader.FieldPos(0)
+			ligne++
+		}
+
+		rowReport := &dto.BulkImportRowReport{Ligne: ligne}
+		reports = append(reports, rowReport)
+
+		if parseErr != nil {
+			rowReport.Statut = dto.ImportLigneRejetee
+			rowReport.Raisons = []string{fmt.Sprintf("Ligne CSV illisible: %v", parseErr.Err)}
+			continue
+		}
+
+		pendingRow, err := s.processRow(ctx, record, colonnes, references, etablissementID, vues, rowReport)
+		if err != nil {
+			return nil, err
+		}
+		if pendingRow == nil {
+			continue
+		}
+
+		if options.DryRun {
+			rowReport.Statut = dto.ImportLigneAcceptee
+			continue
+		}
+
+		pending = append(pending, *pendingRow)
+		if len(pending) >= options.TailleLot {
+			s.insertBatch(ctx, pending, etablissementCode, etablissementID, userID)
+			pending = nil
+		}
+	}
+
+	if len(pending) > 0 {
+		s.insertBatch(ctx, pending, etablissementCode, etablissementID, userID)
+	}
+
+	// 3. Synthèse
+	for _, rowReport := range reports {
+		switch rowReport.Statut {
+		case dto.ImportLigneAcceptee:
+			report.Acceptees++
+		case dto.ImportLigneImportee:
+			report.Acceptees++
+			report.Importees++
+		case dto.ImportLigneRejetee:
+			report.Rejetees++
+		case dto.ImportLigneDoublonSuspect:
+			report.DoublonsSuspects++
+		}
+		report.Lignes = append(report.Lignes, *rowReport)
+	}
+	report.DureeMs = int(time.Since(startTime).Milliseconds())
+
+	fmt.Printf("[AUDIT] Patient CSV import - Etablissement: %s, DryRun: %t, Lignes: %d, Importees: %d, Rejetees: %d, Doublons: %d, By: %s\n",
+		etablissementCode, options.DryRun, report.TotalLignes, report.Importees, report.Rejetees, report.DoublonsSuspects, userID)
+
+	return report, nil
+}
+
+// processRow convertit, valide et contrôle l'anti-doublon d'une ligne
+// Retourne nil si la ligne est rejetée ou suspecte (verdict porté par rowReport)
+func (s *PatientBulkImportService) processRow(
+	ctx context.Context,
+	record []string,
+	colonnes map[string]int,
+	references importReferences,
+	etablissementID uuid.UUID,
+	vues map[string]int,
+	rowReport *dto.BulkImportRowReport,
+) (*pendingImportRow, error) {
+	req, raisons := s.buildRequest(record, colonnes, references)
+	rowReport.Nom = req.Nom
+	rowReport.Prenoms = req.Prenoms
+
+	if len(raisons) > 0 {
+		rowReport.Statut = dto.ImportLigneRejetee
+		rowReport.Raisons = raisons
+		return nil, nil
+	}
+
+	// Validation métier (même circuit que la création unitaire)
+	validation, err := s.validator.ValidatePatientData(ctx, req, etablissementID)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.IsValid {
+		rowReport.Statut = dto.ImportLigneRejetee
+		rowReport.Erreurs = validation.Errors
+		for _, validationErr := range validation.Errors {
+			rowReport.Raisons = append(rowReport.Raisons, validationErr.Message)
+		}
+		return nil, nil
+	}
+
+	// Doublon interne au fichier
+	cle := s.identityKey(req)
+	if ligneOrigine, ok := vues[cle]; ok {
+		rowReport.Statut = dto.ImportLigneDoublonSuspect
+		rowReport.DoublonLigne = &ligneOrigine
+		rowReport.Raisons = []string{fmt.Sprintf("Même identité que la ligne %d du fichier", ligneOrigine)}
+		return nil, nil
+	}
+
+	// Doublon avec le référentiel existant
+	duplicates, err := s.validator.CheckPatientDuplicate(ctx, &dto.DuplicateCheckRequest{
+		Nom:                req.Nom,
+		Prenoms:            req.Prenoms,
+		DateNaissance:      req.DateNaissance,
+		TelephonePrincipal: &req.TelephonePrincipal,
+		ScoreMinimum:       70,
+		LimiteResultats:    5,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if duplicates.HasDuplicates && duplicates.Recommendation != dto.RecommendationAllow {
+		rowReport.Statut = dto.ImportLigneDoublonSuspect
+		rowReport.Doublons = duplicates.PotentialMatches
+		rowReport.Raisons = []string{fmt.Sprintf("Doublon probable d'un patient existant (score %d)", duplicates.HighestScore)}
+		return nil, nil
+	}
+
+	vues[cle] = rowReport.Ligne
+	return &pendingImportRow{report: rowReport, req: req}, nil
+}
+
+// buildRequest convertit une ligne CSV en demande de création
+func (s *PatientBulkImportService) buildRequest(
+	record []string,
+	colonnes map[string]int,
+	references importReferences,
+) (*dto.CreatePatientRequest, []string) {
+	var raisons []string
+	valeur := func(colonne string) string {
+		index, ok := colonnes[colonne]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+	optionnel := func(colonne string) *string {
+		if v := valeur(colonne); v != "" {
+			return &v
+		}
+		return nil
+	}
+	reference := func(referentiel, colonne string, requis bool) *uuid.UUID {
+		code := strings.ToUpper(valeur(colonne))
+		if code == "" {
+			if requis {
+				raisons = append(raisons, fmt.Sprintf("Colonne '%s' requise", colonne))
+			}
+			return nil
+		}
+		id, ok := references[referentiel][code]
+		if !ok {
+			raisons = append(raisons, fmt.Sprintf("Code '%s' inconnu pour '%s'", code, colonne))
+			return nil
+		}
+		return &id
+	}
+
+	req := &dto.CreatePatientRequest{
+		Nom:                 strings.ToUpper(valeur(dto.ImportColNom)),
+		Prenoms:             valeur(dto.ImportColPrenoms),
+		Sexe:                strings.ToUpper(valeur(dto.ImportColSexe)),
+		CniNni:              optionnel(dto.ImportColCniNni),
+		NumeroPieceIdentite: optionnel(dto.ImportColNumeroPieceIdentite),
+		LieuNaissance:       optionnel(dto.ImportColLieuNaissance),
+		NomJeuneFille:       optionnel(dto.ImportColNomJeuneFille),
+		TelephonePrincipal:  s.cleanPhone(valeur(dto.ImportColTelephonePrincipal)),
+		Email:               optionnel(dto.ImportColEmail),
+		AdresseComplete:     valeur(dto.ImportColAdresseComplete),
+		Quartier:            optionnel(dto.ImportColQuartier),
+		Ville:               optionnel(dto.ImportColVille),
+		Commune:             optionnel(dto.ImportColCommune),
+		PaysResidence:       valeur(dto.ImportColPaysResidence),
+		PersonnesAContacter: []dto.PersonneContact{},
+	}
+	if req.PaysResidence == "" {
+		req.PaysResidence = defaultPaysResidence
+	}
+	if telephone := s.cleanPhone(valeur(dto.ImportColTelephoneSecondaire)); telephone != "" {
+		req.TelephoneSecondaire = &telephone
+	}
+
+	if req.Sexe != "M" && req.Sexe != "F" {
+		raisons = append(raisons, fmt.Sprintf("Sexe '%s' invalide (M ou F)", valeur(dto.ImportColSexe)))
+	}
+
+	dateNaissance, err := s.parseDate(valeur(dto.ImportColDateNaissance))
+	if err != nil {
+		raisons = append(raisons, err.Error())
+	}
+	req.DateNaissance = dateNaissance
+	req.EstDateSupposee = s.parseBool(valeur(dto.ImportColEstDateSupposee))
+
+	if id := reference("nationalite", dto.ImportColNationalite, true); id != nil {
+		req.NationaliteID = *id
+	}
+	if id := reference("situation_matrimoniale", dto.ImportColSituationMatrimoniale, true); id != nil {
+		req.SituationMatrimonialeID = *id
+	}
+	req.TypePieceIdentiteID = reference("type_piece_identite", dto.ImportColTypePieceIdentite, false)
+	req.ProfessionID = reference("profession", dto.ImportColProfession, false)
+
+	// Personne à contacter (une seule par ligne, optionnelle)
+	if contactNom := valeur(dto.ImportColContactNomPrenoms); contactNom != "" {
+		contact := dto.PersonneContact{
+			NomPrenoms: contactNom,
+			Telephone:  s.cleanPhone(valeur(dto.ImportColContactTelephone)),
+		}
+		if contact.Telephone == "" {
+			raisons = append(raisons, fmt.Sprintf("Colonne '%s' requise avec '%s'", dto.ImportColContactTelephone, dto.ImportColContactNomPrenoms))
+		}
+		if id := reference("affiliation", dto.ImportColContactAffiliation, true); id != nil {
+			contact.AffiliationID = *id
+		}
+		req.PersonnesAContacter = append(req.PersonnesAContacter, contact)
+	}
+
+	return req, raisons
+}
+
+// insertBatch génère les codes puis insère le lot en une transaction
+// Un échec d'insertion rejette tout le lot (codes générés perdus, comme pour une création échouée)
+func (s *PatientBulkImportService) insertBatch(
+	ctx context.Context,
+	batch []pendingImportRow,
+	etablissementCode string,
+	etablissementID uuid.UUID,
+	userID uuid.UUID,
+) {
+	var codees []pendingImportRow
+	for _, row := range batch {
+		generation, err := s.codeGenerator.GeneratePatientCode(ctx, etablissementCode)
+		if err != nil {
+			row.report.Statut = dto.ImportLigneRejetee
+			row.report.Raisons = []string{fmt.Sprintf("Génération du code patient impossible: %v", err)}
+			continue
+		}
+		code := generation.CodePatient
+		row.report.CodePatient = &code
+		codees = append(codees, row)
+	}
+
+	if len(codees) == 0 {
+		return
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		for _, row := range codees {
+			if err := s.insertPatient(ctx, tx, row, etablissementID, userID); err != nil {
+				return fmt.Errorf("ligne %d: %w", row.report.Ligne, err)
+			}
+		}
+		return nil
+	})
+
+	for _, row := range codees {
+		if err != nil {
+			row.report.Statut = dto.ImportLigneRejetee
+			row.report.CodePatient = nil
+			row.report.Raisons = []string{fmt.Sprintf("Échec d'insertion du lot: %v", err)}
+			continue
+		}
+		row.report.Statut = dto.ImportLigneImportee
+	}
+}
+
+// insertPatient insère un patient du lot (même requête que la création unitaire)
+func (s *PatientBulkImportService) insertPatient(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	row pendingImportRow,
+	etablissementID uuid.UUID,
+	userID uuid.UUID,
+) error {
+	req := row.req
+
+	personnesJSON, err := json.Marshal(req.PersonnesAContacter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal personnes_a_contacter: %w", err)
+	}
+
+	return tx.Exec(ctx,
+		queries.PatientCreationQueries.CreatePatientWithValidation,
+		uuid.New(),                  // $1
+		*row.report.CodePatient,     // $2
+		etablissementID,             // $3
+		req.Nom,                     // $4
+		req.Prenoms,                 // $5
+		req.DateNaissance,           // $6
+		req.EstDateSupposee,         // $7
+		req.Sexe,                    // $8
+		req.NationaliteID,           // $9
+		req.SituationMatrimonialeID, // $10
+		req.TypePieceIdentiteID,     // $11
+		req.CniNni,                  // $12
+		req.NumeroPieceIdentite,     // $13
+		req.LieuNaissance,           // $14
+		req.NomJeuneFille,           // $15
+		req.TelephonePrincipal,      // $16
+		req.TelephoneSecondaire,     // $17
+		req.Email,                   // $18
+		req.AdresseComplete,         // $19
+		req.Quartier,                // $20
+		req.Ville,                   // $21
+		req.Commune,                 // $22
+		req.PaysResidence,           // $23
+		req.ProfessionID,            // $24
+		string(personnesJSON),       // $25
+		false,                       // $26 - couvertures saisies après import
+		"actif",                     // $27
+		userID,                      // $28
+	)
+}
+
+// detectSeparator retient ';' si l'en-tête en contient plus que de ','
+func (s *PatientBulkImportService) detectSeparator(headerLine string) rune {
+	if strings.Count(headerLine, ";") > strings.Count(headerLine, ",") {
+		return ';'
+	}
+	return ','
+}
+
+// parseHeader indexe les colonnes et vérifie la présence des colonnes obligatoires
+func (s *PatientBulkImportService) parseHeader(headerLine string, separateur rune) (map[string]int, error) {
+	headerReader := csv.NewReader(strings.NewReader(headerLine))
+	headerReader.Comma = separateur
+	header, err := headerReader.Read()
+	if err != nil {
+		return nil, dto.NewBulkImportError(dto.ErrImportColonnesManquante,
+			fmt.Sprintf("En-tête CSV illisible: %v", err))
+	}
+
+	colonnes := make(map[string]int, len(header))
+	for i, nom := range header {
+		nom = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(nom, "\ufeff")))
+		if nom != "" {
+			colonnes[nom] = i
+		}
+	}
+
+	var manquantes []string
+	for _, requise := range dto.ImportRequiredColumns {
+		if _, ok := colonnes[requise]; !ok {
+			manquantes = append(manquantes, requise)
+		}
+	}
+	if len(manquantes) > 0 {
+		return nil, dto.NewBulkImportError(dto.ErrImportColonnesManquante,
+			fmt.Sprintf("Colonnes obligatoires manquantes: %s", strings.Join(manquantes, ", ")))
+	}
+
+	return colonnes, nil
+}
+
+// loadReferences charge les référentiels actifs indexés par code
+func (s *PatientBulkImportService) loadReferences(ctx context.Context) (importReferences, error) {
+	rows, err := s.db.Query(ctx, queries.PatientBulkImportQueries.LoadReferenceCodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reference codes: %w", err)
+	}
+	defer rows.Close()
+
+	references := make(importReferences)
+	for rows.Next() {
+		var referentiel, code string
+		var id uuid.UUID
+		if err := rows.Scan(&referentiel, &code, &id); err != nil {
+			return nil, fmt.Errorf("failed to scan reference code: %w", err)
+		}
+		if references[referentiel] == nil {
+			references[referentiel] = make(map[string]uuid.UUID)
+		}
+		references[referentiel][strings.ToUpper(code)] = id
+	}
+
+	return references, rows.Err()
+}
+
+// identityKey construit la clé phonétique nom + prénoms + date de naissance
+func (s *PatientBulkImportService) identityKey(req *dto.CreatePatientRequest) string {
+	return s.matcher.PhoneticKey(req.Nom) + "|" +
+		s.matcher.PhoneticKey(req.Prenoms) + "|" +
+		req.DateNaissance.Format("2006-01-02")
+}
+
+// cleanPhone retire les séparateurs usuels des tableurs (espaces, points, tirets)
+func (s *PatientBulkImportService) cleanPhone(telephone string) string {
+	return strings.NewReplacer(" ", "", ".", "", "-", "", "\u00a0", "").Replace(telephone)
+}
+
+func (s *PatientBulkImportService) parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("Colonne '%s' requise", dto.ImportColDateNaissance)
+	}
+	for _, layout := range importDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Date de naissance '%s' invalide (AAAA-MM-JJ ou JJ/MM/AAAA)", value)
+}
+
+func (s *PatientBulkImportService) parseBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "1", "true", "oui", "o", "x":
+		return true
+	default:
+		return false
+	}
+}
@@ -15,22 +15,34 @@ import (
 	"soins-suite-core/internal/modules/core-services/patient/queries"
 )
 
+// Paramètres de redirection des codes fusionnés
+const (
+	mergeRedirectTTL     = 24 * time.Hour
+	maxMergeRedirectHops = 10 // Aligné sur la profondeur max de ResolveMergeRedirect
+)
+
 // PatientCacheService gère la récupération cache-first des patients avec données complètes
 type PatientCacheService struct {
-	db        *postgres.Client
-	redis     *redis.Client
-	redisKeys *PatientRedisKeys
+	db            *postgres.Client
+	redis         *redis.Client
+	redisKeys     *PatientRedisKeys
+	codeGenerator *PatientCodeGeneratorService // Vérification des clés de contrôle selon les formats
+	accessLog     *PatientAccessLogService     // Journal persistant des accès (écriture par lots)
 }
 
 // NewPatientCacheService crée une nouvelle instance du service
 func NewPatientCacheService(
 	db *postgres.Client,
 	redis *redis.Client,
+	codeGenerator *PatientCodeGeneratorService,
+	accessLog *PatientAccessLogService,
 ) *PatientCacheService {
 	return &PatientCacheService{
-		db:        db,
-		redis:     redis,
-		redisKeys: NewPatientRedisKeys(),
+		db:            db,
+		redis:         redis,
+		redisKeys:     NewPatientRedisKeys(),
+		codeGenerator: codeGenerator,
+		accessLog:     accessLog,
 	}
 }
 
@@ -47,16 +59,89 @@ func (s *PatientCacheService) GetPatientByCode(
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	// 0. Clé de contrôle fausse : suggestions plutôt qu'un simple « introuvable »
+	if hasCheck, valid := s.codeGenerator.VerifyCheckCharacter(ctx, req.CodePatient); hasCheck && !valid {
+		suggestions, err := s.codeGenerator.FindCodeSuggestions(ctx, req.CodePatient)
+		if err != nil {
+			return nil, err
+		}
+		return nil, dto.NewPatientCodeMistypedError(req.CodePatient, suggestions)
+	}
+
+	// Redirection transparente des codes fusionnés vers le dossier survivant
+	codeDemande := req.CodePatient
+	if codeSurvivant := s.resolveMergedCode(ctx, req.CodePatient); codeSurvivant != "" {
+		redirectedReq := *req
+		redirectedReq.CodePatient = codeSurvivant
+		req = &redirectedReq
+	}
+
 	// 1. Stratégie cache-first (sauf si force refresh demandé)
+	var response *dto.PatientDetailResponse
+	var err error
 	if !req.ForceRefreshCache {
-		if cachedResponse, err := s.getPatientFromCache(ctx, req, startTime); err == nil {
-			return cachedResponse, nil
-		}
+		response, err = s.getPatientFromCache(ctx, req, startTime)
 		// Erreur cache ignorée - fallback vers base de données
 	}
 
 	// 2. Fallback PostgreSQL + cache warming
-	return s.getPatientFromDatabaseWithCaching(ctx, req, startTime)
+	if response == nil || err != nil {
+		response, err = s.getPatientFromDatabaseWithCaching(ctx, req, startTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if req.CodePatient != codeDemande {
+		response.RedirigeDepuis = &codeDemande
+	}
+
+	return response, nil
+}
+
+// resolveMergedCode retourne le code du dossier survivant si le code demandé a été fusionné
+// Redis en premier (clé posée lors de la fusion), puis PostgreSQL pour les dossiers archivés
+func (s *PatientCacheService) resolveMergedCode(ctx context.Context, codePatient string) string {
+	// Suivi de la chaîne de redirections Redis (fusions successives A → B → C)
+	codeCourant := codePatient
+	for i := 0; i < maxMergeRedirectHops; i++ {
+		codeSuivant, err := s.redis.Get(ctx, s.redisKeys.PatientRedirectKey(codeCourant)).Result()
+		if err != nil || codeSuivant == "" {
+			break
+		}
+		codeCourant = codeSuivant
+	}
+	if codeCourant != codePatient {
+		return codeCourant
+	}
+
+	// Un dossier en cache n'est jamais un dossier fusionné (invalidé lors de la fusion)
+	if exists, err := s.redis.Exists(ctx, s.redisKeys.PatientDetailCacheKey(codePatient)).Result(); err == nil && exists > 0 {
+		return ""
+	}
+
+	// Pré-contrôle indexé : la CTE récursive n'est exécutée que pour un code réellement fusionné
+	var estFusionne bool
+	if err := s.db.QueryRow(ctx,
+		queries.PatientMergeQueries.IsMergedCode,
+		codePatient,
+	).Scan(&estFusionne); err != nil || !estFusionne {
+		return ""
+	}
+
+	var codeSurvivant string
+	err := s.db.QueryRow(ctx,
+		queries.PatientMergeQueries.ResolveMergeRedirect,
+		codePatient,
+	).Scan(&codeSurvivant)
+	if err != nil {
+		return "" // Pas de fusion (pgx.ErrNoRows) ou erreur - résolution normale du code
+	}
+
+	// Réchauffer la redirection pour les prochains accès (best effort)
+	s.redis.Set(ctx, s.redisKeys.PatientRedirectKey(codePatient), codeSurvivant, mergeRedirectTTL)
+
+	return codeSurvivant
 }
 
 // getPatientFromCache tente de récupérer le patient depuis Redis
@@ -89,8 +174,8 @@ func (s *PatientCacheService) getPatientFromCache(
 	detailResponse.LoadedFrom = "cache"
 	detailResponse.LoadTime = int(time.Since(startTime).Milliseconds())
 
-	// Audit access pour patients sensibles (asynchrone)
-	go s.auditPatientAccess(context.Background(), req.CodePatient, "cache_hit")
+	// Journal des accès (mise en file non bloquante, écriture par lots)
+	s.auditPatientAccess(req, detailResponse, dto.AccessSourceCache)
 
 	return detailResponse, nil
 }
@@ -123,8 +208,8 @@ func (s *PatientCacheService) getPatientFromDatabaseWithCaching(
 	// Cache warming asynchrone pour optimiser les prochains accès
 	go s.warmPatientInCache(context.Background(), detailResponse)
 
-	// Audit access (asynchrone)
-	go s.auditPatientAccess(context.Background(), req.CodePatient, "database_load")
+	// Journal des accès (mise en file non bloquante, écriture par lots)
+	s.auditPatientAccess(req, detailResponse, dto.AccessSourceDatabase)
 
 	return detailResponse, nil
 }
@@ -182,7 +267,7 @@ func (s *PatientCacheService) loadPatientFromDatabase(
 	var assurancesJSON string
 
 	err := row.Scan(
-		// Données patient principales (32 champs)
+		// Données patient principales (34 champs)
 		&patient.ID,
 		&patient.CodePatient,
 		&patient.EtablissementCreateur,
@@ -209,6 +294,8 @@ func (s *PatientCacheService) loadPatientFromDatabase(
 		&patient.ProfessionID,
 		&patient.PersonnesAContacter,
 		&patient.EstAssure,
+		&patient.EstProvisoire,
+		&patient.Signalement,
 		&patient.Statut,
 		&patient.EstDecede,
 		&patient.DateDeces,
@@ -312,6 +399,7 @@ func (s *PatientCacheService) loadPatientFromDatabase(
 			Email:                   patient.Email,
 			AdresseComplete:         patient.AdresseComplete,
 			EstAssure:               patient.EstAssure,
+			EstProvisoire:           patient.EstProvisoire,
 			EtablissementCreateurID: patient.EtablissementCreateur,
 			Statut:                  patient.Statut,
 			CreatedAt:               patient.CreatedAt,
@@ -321,6 +409,17 @@ func (s *PatientCacheService) loadPatientFromDatabase(
 		SituationMatrimoniale: situationRef,
 		TypePieceIdentite:   pieceRef,
 		Profession:          professionRef,
+		CniNni:              patient.CniNni,
+		NumeroPieceIdentite: patient.NumeroPieceIdentite,
+		LieuNaissance:       patient.LieuNaissance,
+		NomJeuneFille:       patient.NomJeuneFille,
+		Quartier:            patient.Quartier,
+		Ville:               patient.Ville,
+		Commune:             patient.Commune,
+		PaysResidence:       patient.PaysResidence,
+		EstDecede:           patient.EstDecede,
+		DateDeces:           patient.DateDeces,
+		Signalement:         patient.Signalement,
 		LastUpdated:         patient.UpdatedAt,
 	}
 
@@ -379,6 +478,32 @@ func (s *PatientCacheService) InvalidatePatientCache(ctx context.Context, codePa
 	return nil
 }
 
+// InvalidateSearchCaches invalide le cache établissement du patient et les recherches en cache
+// Appelé lors des changements de statut (un patient décédé ou archivé ne doit plus sortir des recherches)
+func (s *PatientCacheService) InvalidateSearchCaches(ctx context.Context, codePatient string) error {
+	patterns := []string{
+		s.redisKeys.PatientCachePattern(codePatient),
+		s.redisKeys.PatientSearchCachePattern(),
+	}
+
+	supprimees := 0
+	for _, pattern := range patterns {
+		iter := s.redis.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			if err := s.redis.Del(ctx, iter.Val()).Err(); err != nil {
+				return fmt.Errorf("failed to invalidate search cache: %w", err)
+			}
+			supprimees++
+		}
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("failed to scan search caches: %w", err)
+		}
+	}
+
+	fmt.Printf("[CACHE] Patient search caches invalidated - Code: %s, Keys: %d\n", codePatient, supprimees)
+	return nil
+}
+
 // Méthodes utilitaires privées
 
 func (s *PatientCacheService) validateGetPatientRequest(req *dto.GetPatientByCodeRequest) error {
@@ -442,6 +567,7 @@ func (s *PatientCacheService) convertToPatientCacheData(patient *dto.PatientDeta
 		Email:                   patient.Patient.Email,
 		AdresseComplete:         patient.Patient.AdresseComplete,
 		EstAssure:               patient.Patient.EstAssure,
+		EstProvisoire:           patient.Patient.EstProvisoire,
 		Statut:                  patient.Patient.Statut,
 		CreatedAt:               patient.Patient.CreatedAt,
 		UpdatedAt:               patient.LastUpdated,
@@ -463,6 +589,7 @@ func (s *PatientCacheService) serializePatientForCache(cacheData *dto.PatientCac
 		"telephone_principal":       cacheData.TelephonePrincipal,
 		"adresse_complete":          cacheData.AdresseComplete,
 		"est_assure":                fmt.Sprintf("%t", cacheData.EstAssure),
+		"est_provisoire":            fmt.Sprintf("%t", cacheData.EstProvisoire),
 		"statut":                    cacheData.Statut,
 		"created_at":                cacheData.CreatedAt.Format(time.RFC3339),
 		"updated_at":                cacheData.UpdatedAt.Format(time.RFC3339),
@@ -470,8 +597,32 @@ func (s *PatientCacheService) serializePatientForCache(cacheData *dto.PatientCac
 	}, nil
 }
 
-func (s *PatientCacheService) auditPatientAccess(ctx context.Context, codePatient, accessType string) {
-	// Log pour audit access selon spécifications CS-P-003
-	fmt.Printf("[AUDIT] Patient accessed - Code: %s, Type: %s, Time: %s\n",
-		codePatient, accessType, time.Now().Format(time.RFC3339))
+// auditPatientAccess journalise l'accès selon spécifications CS-P-003 (qui, quel dossier, d'où, quelle source)
+func (s *PatientCacheService) auditPatientAccess(
+	req *dto.GetPatientByCodeRequest,
+	patient *dto.PatientDetailResponse,
+	source string,
+) {
+	record := dto.PatientAccessRecord{
+		CodePatient: req.CodePatient,
+		Source:      source,
+		AccedeLe:    time.Now(),
+	}
+	if patient.Patient.ID != uuid.Nil {
+		patientID := patient.Patient.ID
+		record.PatientID = &patientID
+	}
+	if acces := req.Acces; acces != nil {
+		record.UtilisateurID = acces.UtilisateurID
+		record.EtablissementID = acces.EtablissementID
+		if acces.ModuleCode != "" {
+			record.ModuleCode = &acces.ModuleCode
+		}
+		if acces.RubriqueCode != "" {
+			record.RubriqueCode = &acces.RubriqueCode
+		}
+		record.TypeAcces = acces.TypeAcces
+	}
+
+	s.accessLog.Record(record)
 }
\ No newline at end of file
@@ -0,0 +1,425 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+// Format carte bancaire ISO/IEC 7810 ID-1 (en millimètres)
+const (
+	cardWidthMM  = 85.6
+	cardHeightMM = 54.0
+	cardMarginMM = 4.0
+)
+
+// Zones de la carte (en millimètres)
+const (
+	cardHeaderHeightMM  = 13.0
+	cardQRSizeMM        = 22.0
+	cardQRTopMM         = 15.5
+	cardBarcodeTopMM    = 38.0
+	cardBarcodeHeightMM = 7.0
+	cardBarcodeMaxMM    = 52.0
+)
+
+// patientCardData regroupe les informations imprimées sur la carte
+type patientCardData struct {
+	CodePatient   string
+	Nom           string
+	Prenoms       string
+	DateNaissance string
+	Sexe          string
+	Telephone     string
+	Etablissement string
+	Logo          image.Image // nil si absent ou non affiché
+}
+
+// cardCanvas abstrait la surface de dessin (PDF vectoriel ou PNG matriciel)
+// Coordonnées en millimètres depuis le coin supérieur gauche, tailles de police en points
+type cardCanvas interface {
+	fillRect(x, y, w, h float64, c color.RGBA)
+	text(x, baseline float64, s string, size float64, bold bool, c color.RGBA)
+	textWidth(s string, size float64, bold bool) float64
+	drawImage(img image.Image, x, y, w, h float64)
+	// snap ajuste une dimension à la grille de sortie (pixels entiers en PNG)
+	snap(v float64) float64
+	finish() ([]byte, error)
+}
+
+// patientCardRenderer dessine la carte patient selon la mise en page de l'établissement
+type patientCardRenderer struct {
+	regular *opentype.Font
+	bold    *opentype.Font
+}
+
+// newPatientCardRenderer charge les polices Go embarquées (aucune dépendance système)
+func newPatientCardRenderer() (*patientCardRenderer, error) {
+	regular, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regular font: %w", err)
+	}
+	bold, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bold font: %w", err)
+	}
+	return &patientCardRenderer{regular: regular, bold: bold}, nil
+}
+
+// render produit la carte au format demandé
+func (r *patientCardRenderer) render(data *patientCardData, layout *dto.PatientCardLayout, format string) ([]byte, error) {
+	var canvas cardCanvas
+	switch format {
+	case dto.PatientCardFormatPNG:
+		canvas = newPNGCardCanvas(r, layout.ResolutionDPI)
+	default:
+		canvas = newPDFCardCanvas(data.CodePatient)
+	}
+
+	if err := r.draw(canvas, data, layout); err != nil {
+		return nil, err
+	}
+	return canvas.finish()
+}
+
+// draw dessine les zones de la carte : bandeau, identité, QR code, code-barres et pied
+func (r *patientCardRenderer) draw(c cardCanvas, data *patientCardData, layout *dto.PatientCardLayout) error {
+	primary := parseHexColor(layout.CouleurPrincipale)
+	textColor := parseHexColor(layout.CouleurTexte)
+	white := color.RGBA{255, 255, 255, 255}
+	grey := color.RGBA{110, 110, 110, 255}
+
+	c.fillRect(0, 0, cardWidthMM, cardHeightMM, white)
+
+	// Bandeau : logo, établissement et titre
+	c.fillRect(0, 0, cardWidthMM, cardHeaderHeightMM, primary)
+	headerX := cardMarginMM
+	if layout.AfficherLogo && data.Logo != nil {
+		logoH := cardHeaderHeightMM - 3
+		bounds := data.Logo.Bounds()
+		logoW := logoH * float64(bounds.Dx()) / float64(bounds.Dy())
+		if logoW > 20 {
+			logoW, logoH = 20, 20*float64(bounds.Dy())/float64(bounds.Dx())
+		}
+		c.drawImage(data.Logo, cardMarginMM, (cardHeaderHeightMM-logoH)/2, logoW, logoH)
+		headerX += logoW + 2
+	}
+	headerWidth := cardWidthMM - cardMarginMM - headerX
+	c.text(headerX, 5.6, fitText(c, data.Etablissement, 8.5, true, headerWidth), 8.5, true, white)
+	c.text(headerX, 10.2, fitText(c, layout.Titre, 7, false, headerWidth), 7, false, white)
+
+	// Identité
+	identityWidth := cardWidthMM - 2*cardMarginMM
+	if layout.AfficherQRCode {
+		identityWidth = cardWidthMM - cardMarginMM - cardQRSizeMM - 2*cardMarginMM
+	}
+	y := cardHeaderHeightMM + 5.5
+	c.text(cardMarginMM, y, fitText(c, strings.ToUpper(data.Nom), 10, true, identityWidth), 10, true, textColor)
+	y += 4.3
+	c.text(cardMarginMM, y, fitText(c, data.Prenoms, 9, false, identityWidth), 9, false, textColor)
+
+	var details []string
+	if layout.AfficherDateNaissance && data.DateNaissance != "" {
+		details = append(details, "Né(e) le "+data.DateNaissance)
+	}
+	if layout.AfficherSexe && data.Sexe != "" {
+		details = append(details, "Sexe : "+data.Sexe)
+	}
+	if layout.AfficherTelephone && data.Telephone != "" {
+		details = append(details, "Tél. : "+data.Telephone)
+	}
+	for _, detail := range details {
+		y += 3.6
+		c.text(cardMarginMM, y, fitText(c, detail, 7, false, identityWidth), 7, false, grey)
+	}
+
+	// QR code : payload JSON directement exploitable par la recherche patient
+	if layout.AfficherQRCode {
+		payload, err := json.Marshal(dto.PatientCardQRPayload{CodePatient: data.CodePatient})
+		if err != nil {
+			return fmt.Errorf("failed to build QR payload: %w", err)
+		}
+		code, err := qr.Encode(string(payload), qr.M, qr.Auto)
+		if err != nil {
+			return fmt.Errorf("failed to encode QR code: %w", err)
+		}
+		drawModules(c, code, cardWidthMM-cardMarginMM-cardQRSizeMM, cardQRTopMM, cardQRSizeMM, cardQRSizeMM, textColor)
+	}
+
+	// Code-barres Code128 et code en clair
+	if layout.AfficherCodeBarre {
+		code, err := code128.Encode(data.CodePatient)
+		if err != nil {
+			return fmt.Errorf("failed to encode barcode: %w", err)
+		}
+		modules := code.Bounds().Dx()
+		moduleWidth := c.snap(cardBarcodeMaxMM / float64(modules))
+		if moduleWidth <= 0 {
+			return fmt.Errorf("patient code too long for barcode: %s", data.CodePatient)
+		}
+		drawModules(c, code, cardMarginMM, cardBarcodeTopMM, moduleWidth*float64(modules), cardBarcodeHeightMM, textColor)
+	}
+	codeY := cardBarcodeTopMM + cardBarcodeHeightMM + 3.2
+	if !layout.AfficherCodeBarre {
+		codeY = cardBarcodeTopMM + 3.5
+	}
+	c.text(cardMarginMM, codeY, data.CodePatient, 8, true, textColor)
+
+	// Pied de carte
+	if layout.MentionPied != nil && *layout.MentionPied != "" {
+		c.text(cardMarginMM, cardHeightMM-1.6, fitText(c, *layout.MentionPied, 5.5, false, cardWidthMM-2*cardMarginMM), 5.5, false, grey)
+	}
+
+	return nil
+}
+
+// drawModules dessine un code-barres 1D ou 2D module par module (rendu net, sans mise à l'échelle d'image)
+func drawModules(c cardCanvas, code barcode.Barcode, x, y, w, h float64, ink color.RGBA) {
+	bounds := code.Bounds()
+	cols, rows := bounds.Dx(), bounds.Dy()
+	moduleW := c.snap(w / float64(cols))
+	moduleH := h / float64(rows)
+	if rows > 1 {
+		// Modules carrés pour le QR code
+		moduleH = moduleW
+	}
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			if !isDarkModule(code.At(bounds.Min.X+col, bounds.Min.Y+row)) {
+				continue
+			}
+			// Regroupe les modules sombres consécutifs en un seul rectangle
+			run := 1
+			for col+run < cols && isDarkModule(code.At(bounds.Min.X+col+run, bounds.Min.Y+row)) {
+				run++
+			}
+			c.fillRect(x+float64(col)*moduleW, y+float64(row)*moduleH, float64(run)*moduleW, moduleH, ink)
+			col += run - 1
+		}
+	}
+}
+
+// isDarkModule indique si un module de code-barres est sombre
+func isDarkModule(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r+g+b < 3*0x8000
+}
+
+// fitText tronque le texte avec des points de suspension pour tenir dans la largeur donnée
+func fitText(c cardCanvas, s string, size float64, bold bool, maxWidth float64) string {
+	if c.textWidth(s, size, bold) <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if c.textWidth(candidate, size, bold) <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// parseHexColor convertit une couleur #RRGGBB (validée en amont) en RGBA
+func parseHexColor(hex string) color.RGBA {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(hex) != 7 {
+		return color.RGBA{0, 0, 0, 255}
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 255}
+}
+
+// ============================================================================
+// RENDU PNG
+// ============================================================================
+
+// pngCardCanvas dessine la carte en image matricielle à la résolution de l'établissement
+type pngCardCanvas struct {
+	renderer *patientCardRenderer
+	img      *image.RGBA
+	pxPerMM  float64
+	dpi      float64
+	faces    map[string]font.Face
+}
+
+func newPNGCardCanvas(renderer *patientCardRenderer, dpi int) *pngCardCanvas {
+	pxPerMM := float64(dpi) / 25.4
+	width := int(math.Round(cardWidthMM * pxPerMM))
+	height := int(math.Round(cardHeightMM * pxPerMM))
+	return &pngCardCanvas{
+		renderer: renderer,
+		img:      image.NewRGBA(image.Rect(0, 0, width, height)),
+		pxPerMM:  pxPerMM,
+		dpi:      float64(dpi),
+		faces:    make(map[string]font.Face),
+	}
+}
+
+func (p *pngCardCanvas) px(v float64) int {
+	return int(math.Round(v * p.pxPerMM))
+}
+
+func (p *pngCardCanvas) face(size float64, bold bool) font.Face {
+	key := fmt.Sprintf("%.2f-%t", size, bold)
+	if face, ok := p.faces[key]; ok {
+		return face
+	}
+	f := p.renderer.regular
+	if bold {
+		f = p.renderer.bold
+	}
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: p.dpi, Hinting: font.HintingFull})
+	if err != nil {
+		face = nil
+	}
+	p.faces[key] = face
+	return face
+}
+
+func (p *pngCardCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	rect := image.Rect(p.px(x), p.px(y), p.px(x+w), p.px(y+h))
+	draw.Draw(p.img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func (p *pngCardCanvas) text(x, baseline float64, s string, size float64, bold bool, c color.RGBA) {
+	face := p.face(size, bold)
+	if face == nil {
+		return
+	}
+	drawer := &font.Drawer{
+		Dst:  p.img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(p.px(x), p.px(baseline)),
+	}
+	drawer.DrawString(s)
+}
+
+func (p *pngCardCanvas) textWidth(s string, size float64, bold bool) float64 {
+	face := p.face(size, bold)
+	if face == nil {
+		return 0
+	}
+	return float64(font.MeasureString(face, s).Round()) / p.pxPerMM
+}
+
+func (p *pngCardCanvas) drawImage(img image.Image, x, y, w, h float64) {
+	rect := image.Rect(p.px(x), p.px(y), p.px(x+w), p.px(y+h))
+	draw.CatmullRom.Scale(p.img, rect, img, img.Bounds(), draw.Over, nil)
+}
+
+// snap arrondit à un nombre entier de pixels (au moins un) pour des barres de largeur régulière
+func (p *pngCardCanvas) snap(v float64) float64 {
+	pixels := math.Floor(v * p.pxPerMM)
+	if pixels < 1 {
+		return 0
+	}
+	return pixels / p.pxPerMM
+}
+
+func (p *pngCardCanvas) finish() ([]byte, error) {
+	for _, face := range p.faces {
+		if face != nil {
+			face.Close()
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, p.img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG card: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ============================================================================
+// RENDU PDF
+// ============================================================================
+
+// pdfCardCanvas dessine la carte en PDF vectoriel au format ID-1
+type pdfCardCanvas struct {
+	pdf    *fpdf.Fpdf
+	images int
+}
+
+func newPDFCardCanvas(codePatient string) *pdfCardCanvas {
+	pdf := fpdf.NewCustom(&fpdf.InitType{
+		OrientationStr: "L",
+		UnitStr:        "mm",
+		Size:           fpdf.SizeType{Wd: cardHeightMM, Ht: cardWidthMM},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle("Carte patient "+codePatient, true)
+	pdf.AddUTF8FontFromBytes("go", "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes("go", "B", gobold.TTF)
+	pdf.AddPage()
+	return &pdfCardCanvas{pdf: pdf}
+}
+
+func (p *pdfCardCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	p.pdf.SetFillColor(int(c.R), int(c.G), int(c.B))
+	p.pdf.Rect(x, y, w, h, "F")
+}
+
+func (p *pdfCardCanvas) setFont(size float64, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	p.pdf.SetFont("go", style, size)
+}
+
+func (p *pdfCardCanvas) text(x, baseline float64, s string, size float64, bold bool, c color.RGBA) {
+	p.setFont(size, bold)
+	p.pdf.SetTextColor(int(c.R), int(c.G), int(c.B))
+	p.pdf.Text(x, baseline, s)
+}
+
+func (p *pdfCardCanvas) textWidth(s string, size float64, bold bool) float64 {
+	p.setFont(size, bold)
+	return p.pdf.GetStringWidth(s)
+}
+
+func (p *pdfCardCanvas) drawImage(img image.Image, x, y, w, h float64) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+	p.images++
+	name := fmt.Sprintf("image-%d", p.images)
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	p.pdf.RegisterImageOptionsReader(name, options, &buf)
+	p.pdf.ImageOptions(name, x, y, w, h, false, options, 0, "")
+}
+
+// snap est sans effet en PDF (rendu vectoriel)
+func (p *pdfCardCanvas) snap(v float64) float64 {
+	return v
+}
+
+func (p *pdfCardCanvas) finish() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF card: %w", err)
+	}
+	return buf.Bytes(), nil
+}
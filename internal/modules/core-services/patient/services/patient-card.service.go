@@ -0,0 +1,306 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Décodage des logos JPEG
+	_ "image/png"  // Décodage des logos PNG
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// Paramètres de téléchargement du logo des documents (enregistrement de la mise en page uniquement)
+const (
+	cardLogoTimeout  = 3 * time.Second
+	cardLogoMaxBytes = 2 << 20 // 2 Mo
+)
+
+// PatientCardService génère la carte patient imprimable (PDF/PNG) avec code-barres et QR code
+type PatientCardService struct {
+	db           *postgres.Client
+	cacheService *PatientCacheService
+	renderer     *patientCardRenderer
+	httpClient   *http.Client
+}
+
+// NewPatientCardService crée une nouvelle instance du service
+func NewPatientCardService(
+	db *postgres.Client,
+	cacheService *PatientCacheService,
+) (*PatientCardService, error) {
+	renderer, err := newPatientCardRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return &PatientCardService{
+		db:           db,
+		cacheService: cacheService,
+		renderer:     renderer,
+		httpClient:   &http.Client{Timeout: cardLogoTimeout},
+	}, nil
+}
+
+// RenderCard génère la carte d'un patient selon la mise en page de l'établissement
+func (s *PatientCardService) RenderCard(
+	ctx context.Context,
+	codePatient string,
+	etablissementID uuid.UUID,
+	format string,
+	acces *dto.PatientAccessContext,
+) (*dto.PatientCardDocument, error) {
+	if format == "" {
+		format = dto.PatientCardFormatPDF
+	}
+	if format != dto.PatientCardFormatPDF && format != dto.PatientCardFormatPNG {
+		return nil, fmt.Errorf("unsupported card format: %s", format)
+	}
+
+	// Lecture cache-first (redirection des codes fusionnés incluse), journalisée comme impression de carte
+	patient, err := s.cacheService.GetPatientByCode(ctx, &dto.GetPatientByCodeRequest{
+		CodePatient:     codePatient,
+		IncludeInactive: true,
+		Acces:           withAccessType(acces, dto.AccessTypeCarte),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := s.GetLayout(ctx, etablissementID)
+	if err != nil {
+		return nil, err
+	}
+
+	etablissement, err := s.getEstablishment(ctx, etablissementID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &patientCardData{
+		CodePatient:   patient.Patient.CodePatient,
+		Nom:           patient.Patient.Nom,
+		Prenoms:       patient.Patient.Prenoms,
+		DateNaissance: formatCardBirthDate(patient.Patient.DateNaissance, patient.Patient.EstDateSupposee),
+		Sexe:          patient.Patient.Sexe,
+		Telephone:     patient.Patient.TelephonePrincipal,
+		Etablissement: etablissement.Nom,
+	}
+	// Logo copié à l'enregistrement de la mise en page : aucun accès réseau ni fichier à l'impression
+	if layout.AfficherLogo && len(layout.Logo) > 0 {
+		logo, _, err := image.Decode(bytes.NewReader(layout.Logo))
+		if err != nil {
+			fmt.Printf("[CARD] Logo enregistré illisible pour l'établissement %s: %v\n", etablissementID, err)
+		} else {
+			data.Logo = logo
+		}
+	}
+
+	contenu, err := s.renderer.render(data, layout, format)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Carte patient %s générée (%s) pour l'établissement %s\n",
+		data.CodePatient, format, etablissementID)
+
+	document := &dto.PatientCardDocument{
+		Contenu:     contenu,
+		ContentType: "application/pdf",
+		NomFichier:  fmt.Sprintf("carte-%s.pdf", data.CodePatient),
+	}
+	if format == dto.PatientCardFormatPNG {
+		document.ContentType = "image/png"
+		document.NomFichier = fmt.Sprintf("carte-%s.png", data.CodePatient)
+	}
+	return document, nil
+}
+
+// GetLayout retourne la mise en page de la carte (défaut si l'établissement n'en a pas défini)
+func (s *PatientCardService) GetLayout(ctx context.Context, etablissementID uuid.UUID) (*dto.PatientCardLayout, error) {
+	layout := dto.DefaultPatientCardLayout(etablissementID)
+	var updatedAt time.Time
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientCardQueries.GetCardLayout,
+		etablissementID,
+	).Scan(
+		&layout.Titre,
+		&layout.CouleurPrincipale,
+		&layout.CouleurTexte,
+		&layout.AfficherLogo,
+		&layout.AfficherQRCode,
+		&layout.AfficherCodeBarre,
+		&layout.AfficherDateNaissance,
+		&layout.AfficherSexe,
+		&layout.AfficherTelephone,
+		&layout.MentionPied,
+		&layout.ResolutionDPI,
+		&layout.Logo,
+		&updatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.DefaultPatientCardLayout(etablissementID), nil
+		}
+		return nil, fmt.Errorf("failed to load card layout: %w", err)
+	}
+
+	layout.LogoEnregistre = len(layout.Logo) > 0
+	layout.UpdatedAt = &updatedAt
+	return layout, nil
+}
+
+// UpdateLayout remplace la mise en page de la carte d'un établissement
+func (s *PatientCardService) UpdateLayout(
+	ctx context.Context,
+	etablissementID uuid.UUID,
+	req *dto.UpdatePatientCardLayoutRequest,
+	userID string,
+) (*dto.PatientCardLayout, error) {
+	updatedBy, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+
+	couleurPrincipale := strings.ToUpper(req.CouleurPrincipale)
+	couleurTexte := strings.ToUpper(req.CouleurTexte)
+	mentionPied := req.MentionPied
+	if mentionPied != nil && strings.TrimSpace(*mentionPied) == "" {
+		mentionPied = nil
+	}
+
+	// Copie du logo des documents de l'établissement : la carte est ensuite rendue sans accès externe
+	// Un logo indisponible n'empêche pas l'enregistrement : la carte est imprimée sans logo
+	var logo []byte
+	var logoSource *string
+	if req.AfficherLogo {
+		etablissement, err := s.getEstablishment(ctx, etablissementID)
+		if err != nil {
+			return nil, err
+		}
+		if etablissement.LogoDocumentsURL != nil && strings.TrimSpace(*etablissement.LogoDocumentsURL) != "" {
+			source := strings.TrimSpace(*etablissement.LogoDocumentsURL)
+			logoSource = &source
+			logo, err = s.downloadLogo(ctx, source)
+			if err != nil {
+				fmt.Printf("[CARD] Logo indisponible pour l'établissement %s (%s): %v\n", etablissementID, source, err)
+				logo = nil
+			}
+		}
+	}
+
+	var updatedAt time.Time
+	err = s.db.QueryRow(ctx,
+		queries.PatientCardQueries.UpsertCardLayout,
+		etablissementID,
+		req.Titre,
+		couleurPrincipale,
+		couleurTexte,
+		req.AfficherLogo,
+		req.AfficherQRCode,
+		req.AfficherCodeBarre,
+		req.AfficherDateNaissance,
+		req.AfficherSexe,
+		req.AfficherTelephone,
+		mentionPied,
+		req.ResolutionDPI,
+		logo,
+		logoSource,
+		updatedBy,
+	).Scan(&updatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save card layout: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Mise en page carte patient modifiée pour l'établissement %s par %s\n",
+		etablissementID, userID)
+
+	return &dto.PatientCardLayout{
+		EtablissementID:       etablissementID,
+		Titre:                 req.Titre,
+		CouleurPrincipale:     couleurPrincipale,
+		CouleurTexte:          couleurTexte,
+		AfficherLogo:          req.AfficherLogo,
+		AfficherQRCode:        req.AfficherQRCode,
+		AfficherCodeBarre:     req.AfficherCodeBarre,
+		AfficherDateNaissance: req.AfficherDateNaissance,
+		AfficherSexe:          req.AfficherSexe,
+		AfficherTelephone:     req.AfficherTelephone,
+		MentionPied:           mentionPied,
+		ResolutionDPI:         req.ResolutionDPI,
+		LogoEnregistre:        len(logo) > 0,
+		Logo:                  logo,
+		UpdatedAt:             &updatedAt,
+	}, nil
+}
+
+// getEstablishment charge le nom et l'URL du logo des documents de l'établissement
+func (s *PatientCardService) getEstablishment(ctx context.Context, etablissementID uuid.UUID) (*dto.EstablishmentCardInfo, error) {
+	var etablissement dto.EstablishmentCardInfo
+	err := s.db.QueryRow(ctx,
+		queries.PatientCardQueries.GetEstablishmentCardInfo,
+		etablissementID,
+	).Scan(&etablissement.Nom, &etablissement.NomCourt, &etablissement.LogoDocumentsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load establishment %s: %w", etablissementID, err)
+	}
+	return &etablissement, nil
+}
+
+// downloadLogo télécharge le logo des documents (URL http(s) uniquement) et vérifie qu'il se décode (PNG ou JPEG)
+func (s *PatientCardService) downloadLogo(ctx context.Context, source string) ([]byte, error) {
+	parsed, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logo url: %w", err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("unsupported logo url scheme: %q", parsed.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	contenu, err := io.ReadAll(io.LimitReader(resp.Body, cardLogoMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	if len(contenu) > cardLogoMaxBytes {
+		return nil, fmt.Errorf("logo exceeds %d bytes", cardLogoMaxBytes)
+	}
+	if _, _, err := image.Decode(bytes.NewReader(contenu)); err != nil {
+		return nil, fmt.Errorf("failed to decode logo: %w", err)
+	}
+	return contenu, nil
+}
+
+// formatCardBirthDate formate la date de naissance (année seule si la date est supposée)
+func formatCardBirthDate(dateNaissance time.Time, estDateSupposee bool) string {
+	if dateNaissance.IsZero() {
+		return ""
+	}
+	if estDateSupposee {
+		return fmt.Sprintf("vers %d", dateNaissance.Year())
+	}
+	return dateNaissance.Format("02/01/2006")
+}
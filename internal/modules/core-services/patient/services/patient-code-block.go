@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// Paramètres de réservation des blocs de codes
+const (
+	codeBlockLockTTL      = 5 * time.Second
+	codeBlockLockAttempts = 5
+	codeBlockLockBackoff  = 20 * time.Millisecond
+)
+
+// codeBlock représente une plage de codes réservée par cette instance (allocation hi/lo)
+// Les positions [next, end] appartiennent exclusivement à l'instance : elles sont déjà
+// comptées dans la séquence PostgreSQL, un redémarrage ne fait que laisser un trou.
+type codeBlock struct {
+	mu       sync.Mutex
+	template string // Format ayant servi à la réservation (un changement de format invalide le bloc)
+	next     int64  // Prochaine position à distribuer
+	end      int64  // Dernière position réservée
+	suffixe  string // Suffixe stocké de la séquence (formats sans bloc alphabétique)
+}
+
+// generateFromBlock - Distribue le prochain code du bloc local, en réservant un nouveau bloc si épuisé
+func (s *PatientCodeGeneratorService) generateFromBlock(
+	ctx context.Context,
+	etablissementCode string,
+	year int,
+	config *cachedCodeConfiguration,
+) (*dto.CodeGenerationResponse, error) {
+	blockKey := fmt.Sprintf("%s-%d", etablissementCode, year)
+	blockValue, _ := s.blocks.LoadOrStore(blockKey, &codeBlock{})
+	block := blockValue.(*codeBlock)
+
+	block.mu.Lock()
+	if block.template != config.format.template || block.next == 0 || block.next > block.end {
+		if err := s.reserveBlock(ctx, etablissementCode, year, config, block); err != nil {
+			block.mu.Unlock()
+			return nil, err
+		}
+	}
+	position := block.next
+	block.next++
+	suffixeStocke := block.suffixe
+	block.mu.Unlock()
+
+	numero, suffixe := config.format.fromPosition(position, suffixeStocke)
+
+	// Formater le code final
+	codePatient, cleControle, err := s.formatCode(config, etablissementCode, year, numero, suffixe)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CodeGenerationResponse{
+		CodePatient:       codePatient,
+		EtablissementCode: etablissementCode,
+		Annee:             year,
+		Numero:            numero,
+		Suffixe:           suffixe,
+		CleControle:       cleControle,
+		GeneratedAt:       time.Now(),
+		Source:            "bloc",
+	}, nil
+}
+
+// reserveBlock - Réserve la plage suivante de la séquence (un verrou et une écriture par bloc)
+// La séquence PostgreSQL est avancée jusqu'à la fin du bloc avant toute distribution :
+// un crash de l'instance perd au plus les codes non distribués, jamais de doublon.
+func (s *PatientCodeGeneratorService) reserveBlock(
+	ctx context.Context,
+	etablissementCode string,
+	year int,
+	config *cachedCodeConfiguration,
+	block *codeBlock,
+) error {
+	format := config.format
+	redisKey := s.redisKeys.PatientSequenceKey(etablissementCode, year)
+	lockKey := s.redisKeys.PatientSequenceLockKey(etablissementCode, year)
+
+	// Verrou Redis partagé avec l'allocation code par code (Redis indisponible : PostgreSQL seul)
+	redisAvailable := true
+	locked := false
+	for attempt := 0; attempt < codeBlockLockAttempts; attempt++ {
+		ok, err := s.redis.SetNX(ctx, lockKey, "1", codeBlockLockTTL).Result()
+		if err != nil {
+			redisAvailable = false
+			break
+		}
+		if ok {
+			locked = true
+			break
+		}
+		time.Sleep(codeBlockLockBackoff)
+	}
+	if redisAvailable && !locked {
+		return fmt.Errorf("unable to acquire sequence lock for block reservation")
+	}
+	if locked {
+		defer s.redis.Del(ctx, lockKey)
+	}
+
+	var start, end int64
+	var suffixeStocke string
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		if err := tx.Exec(ctx,
+			queries.PatientCodeGenerationQueries.EnsureSequence,
+			etablissementCode,
+			year,
+			format.initialSuffix(),
+		); err != nil {
+			return fmt.Errorf("failed to initialize sequence: %w", err)
+		}
+
+		var numero int
+		if err := tx.QueryRow(ctx,
+			queries.PatientCodeGenerationQueries.LockSequence,
+			etablissementCode,
+			year,
+		).Scan(&numero, &suffixeStocke); err != nil {
+			return fmt.Errorf("failed to lock sequence: %w", err)
+		}
+
+		current, ok := format.position(numero, suffixeStocke)
+		if !ok {
+			return format.capacityError(etablissementCode, year)
+		}
+
+		// Redis peut être en avance sur PostgreSQL (mise à jour asynchrone du chemin code par code)
+		if redisAvailable {
+			if value, err := s.redis.Get(ctx, redisKey).Result(); err == nil {
+				var redisNumero int
+				var redisSuffixe string
+				if _, scanErr := fmt.Sscanf(value, "%d:%s", &redisNumero, &redisSuffixe); scanErr == nil {
+					if redisPosition, ok := format.position(redisNumero, redisSuffixe); ok && redisPosition > current {
+						current = redisPosition
+					}
+				}
+			} else if err != redis.Nil {
+				redisAvailable = false
+			}
+		}
+
+		start = current + 1
+		if start > format.capacity() {
+			return format.capacityError(etablissementCode, year)
+		}
+		end = current + int64(config.tailleBloc)
+		if end > format.capacity() {
+			end = format.capacity()
+		}
+
+		endNumero, endSuffixe := format.fromPosition(end, suffixeStocke)
+		return tx.Exec(ctx,
+			queries.PatientCodeGenerationQueries.ReserveBlock,
+			etablissementCode,
+			year,
+			endNumero,
+			endSuffixe,
+			end-start+1,
+		)
+	})
+	if err != nil {
+		if _, isCodeErr := err.(*dto.CodeGenerationError); isCodeErr {
+			return err
+		}
+		if strings.Contains(err.Error(), "Capacité maximale atteinte") {
+			return format.capacityError(etablissementCode, year)
+		}
+		return fmt.Errorf("failed to reserve code block: %w", err)
+	}
+
+	// Aligner Redis sur la fin du bloc pour les générations code par code des autres instances
+	if redisAvailable {
+		endNumero, endSuffixe := format.fromPosition(end, suffixeStocke)
+		s.redis.Set(ctx, redisKey, fmt.Sprintf("%d:%s", endNumero, endSuffixe), s.calculateSequenceTTL(year))
+	}
+
+	block.template = format.template
+	block.next = start
+	block.end = end
+	block.suffixe = suffixeStocke
+
+	fmt.Printf("[CODE] Block reserved - Etablissement: %s, Annee: %d, Positions: %d-%d\n",
+		etablissementCode, year, start, end)
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"soins-suite-core/internal/app/config"
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/infrastructure/database/redis"
+)
+
+// BenchmarkGeneratePatientCode compare le débit de génération des codes patient :
+// allocation code par code (verrou Redis + écriture par code) et allocation par blocs (hi/lo)
+//
+// Nécessite PostgreSQL (schéma à jour) et Redis, configurés comme l'API (variables d'environnement DB_*, REDIS_*).
+// Ignoré si DB_HOST n'est pas défini. Les séquences de test utilisent des codes établissement dédiés (BENCH...)
+// supprimés en fin d'exécution.
+//
+//	DB_HOST=localhost go test ./internal/modules/core-services/patient/services -run '^$' -bench GeneratePatientCode -cpu 1,4
+func BenchmarkGeneratePatientCode(b *testing.B) {
+	if os.Getenv("DB_HOST") == "" {
+		b.Skip("DB_HOST non défini : benchmark nécessitant PostgreSQL et Redis")
+	}
+
+	appConfig, err := config.NewConfig()
+	if err != nil {
+		b.Skipf("configuration: %v", err)
+	}
+	dbConfig := config.NewDatabaseConfigProvider(appConfig)
+
+	db, err := postgres.NewClient(config.NewPostgresConfig(dbConfig))
+	if err != nil {
+		b.Skipf("postgres indisponible: %v", err)
+	}
+	defer db.Close()
+
+	redisClient, err := redis.NewClient(config.NewRedisConfig(dbConfig), redis.NewRedisKeyGenerator())
+	if err != nil {
+		b.Skipf("redis indisponible: %v", err)
+	}
+	defer redisClient.Close()
+	rdb := redisClient.Client()
+
+	ctx := context.Background()
+
+	for _, tailleBloc := range []int{0, 10, 100, 1000} {
+		nom := "code_par_code"
+		if tailleBloc > 0 {
+			nom = fmt.Sprintf("bloc_%d", tailleBloc)
+		}
+		etablissementCode := fmt.Sprintf("BENCH%d", tailleBloc)
+
+		b.Run(nom, func(b *testing.B) {
+			if err := prepareCodeBenchmark(ctx, db, rdb, etablissementCode, tailleBloc); err != nil {
+				b.Fatalf("préparation: %v", err)
+			}
+			defer func() {
+				// Laisser terminer les mises à jour PostgreSQL asynchrones avant le nettoyage
+				time.Sleep(500 * time.Millisecond)
+				if err := cleanupCodeBenchmark(ctx, db, rdb, etablissementCode); err != nil {
+					b.Logf("nettoyage: %v", err)
+				}
+			}()
+
+			// Service neuf par scénario : aucun bloc ni paramétrage en cache
+			generator := NewPatientCodeGeneratorService(db, rdb)
+			var erreurs atomic.Int64
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := generator.GeneratePatientCode(ctx, etablissementCode); err != nil {
+						erreurs.Add(1)
+					}
+				}
+			})
+			b.StopTimer()
+
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "codes/s")
+			b.ReportMetric(float64(erreurs.Load()), "erreurs")
+		})
+	}
+}
+
+// prepareCodeBenchmark repart d'une séquence vide et fixe la taille de bloc de l'établissement de test
+func prepareCodeBenchmark(ctx context.Context, db *postgres.Client, rdb *goredis.Client, etablissementCode string, tailleBloc int) error {
+	if err := cleanupCodeBenchmark(ctx, db, rdb, etablissementCode); err != nil {
+		return err
+	}
+	return db.Exec(ctx, `
+		INSERT INTO patients_code_configuration (etablissement_code, taille_bloc)
+		VALUES ($1, $2)
+		ON CONFLICT (etablissement_code)
+		DO UPDATE SET taille_bloc = EXCLUDED.taille_bloc, updated_at = NOW()
+	`, etablissementCode, tailleBloc)
+}
+
+// cleanupCodeBenchmark supprime les séquences, le paramétrage et les clés Redis de l'établissement de test
+func cleanupCodeBenchmark(ctx context.Context, db *postgres.Client, rdb *goredis.Client, etablissementCode string) error {
+	keys := NewPatientRedisKeys()
+	year := time.Now().Year()
+	rdb.Del(ctx,
+		keys.PatientSequenceKey(etablissementCode, year),
+		keys.PatientSequenceLockKey(etablissementCode, year),
+	)
+
+	if err := db.Exec(ctx,
+		`DELETE FROM patients_code_sequences WHERE etablissement_code = $1`,
+		etablissementCode,
+	); err != nil {
+		return err
+	}
+	return db.Exec(ctx,
+		`DELETE FROM patients_code_configuration WHERE etablissement_code = $1`,
+		etablissementCode,
+	)
+}
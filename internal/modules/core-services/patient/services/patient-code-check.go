@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// Clé de contrôle ISO 7064 MOD 37-2 : détecte toute substitution d'un caractère
+// et toute transposition de deux caractères adjacents
+const (
+	patientCodeCheckAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ*"
+	patientCodeCheckModulus  = 37
+	maxCodeSuggestions       = 5
+)
+
+// Corps de code au format historique {ETABLISSEMENT}-{YYYY}-{NNN}-{LLL} (tout établissement)
+// Toujours vérifié : les codes à clé déjà attribués restent contrôlés quel que soit le paramétrage actuel
+var patientCodeBodyPattern = regexp.MustCompile(`^[0-9A-Z]+(-[0-9A-Z]+)*-[0-9]{4}-[0-9]{3}-[A-Z]{3}$`)
+
+// isCodeSeparator indique si le caractère est un séparateur de format (ignoré par la clé)
+func isCodeSeparator(r rune) bool {
+	return r == '-' || r == '_' || r == '.'
+}
+
+// computeCheckCharacter calcule la clé de contrôle d'un code (séparateurs ignorés)
+func computeCheckCharacter(body string) (byte, error) {
+	p := 0
+	for _, r := range strings.ToUpper(body) {
+		if isCodeSeparator(r) {
+			continue
+		}
+		value := strings.IndexRune(patientCodeCheckAlphabet[:36], r)
+		if value < 0 {
+			return 0, fmt.Errorf("caractère '%c' non autorisé dans un code patient", r)
+		}
+		p = ((p + value) * 2) % patientCodeCheckModulus
+	}
+	return patientCodeCheckAlphabet[(patientCodeCheckModulus+1-p)%patientCodeCheckModulus], nil
+}
+
+// appendCheckCharacter ajoute la clé de contrôle en dernier segment du code
+func appendCheckCharacter(body string) (string, string, error) {
+	check, err := computeCheckCharacter(body)
+	if err != nil {
+		return "", "", err
+	}
+	return body + "-" + string(check), string(check), nil
+}
+
+// splitCheckedCode sépare corps et clé d'un code dont le corps correspond à un format à clé connu
+func splitCheckedCode(code string, bodyPatterns []*regexp.Regexp) (string, byte, *regexp.Regexp, bool) {
+	code = strings.ToUpper(code)
+	if len(code) < 3 || code[len(code)-2] != '-' || !strings.ContainsRune(patientCodeCheckAlphabet, rune(code[len(code)-1])) {
+		return "", 0, nil, false
+	}
+	body := code[:len(code)-2]
+	for _, pattern := range bodyPatterns {
+		if pattern.MatchString(body) {
+			return body, code[len(code)-1], pattern, true
+		}
+	}
+	return "", 0, nil, false
+}
+
+// verifyCheckCharacter indique si le code porte une clé de contrôle et si elle est juste
+// Les codes sans clé (établissements sans clé de contrôle, codes antérieurs) ne sont pas vérifiés
+func verifyCheckCharacter(code string, bodyPatterns []*regexp.Regexp) (hasCheck bool, valid bool) {
+	body, check, _, ok := splitCheckedCode(code, bodyPatterns)
+	if !ok {
+		return false, false
+	}
+	expected, err := computeCheckCharacter(body)
+	if err != nil {
+		return true, false
+	}
+	return true, check == expected
+}
+
+// checkCodeCandidates liste les codes à clé valide à une faute de frappe du code saisi
+// (substitution d'un caractère, transposition de deux caractères adjacents, clé seule fausse)
+func checkCodeCandidates(code string, bodyPatterns []*regexp.Regexp) []string {
+	bodyString, check, bodyPattern, ok := splitCheckedCode(code, bodyPatterns)
+	if !ok {
+		return nil
+	}
+	body := []byte(bodyString)
+
+	seen := make(map[string]bool)
+	var candidates []string
+	add := func(candidateBody string, candidateCheck byte) {
+		if !bodyPattern.MatchString(candidateBody) {
+			return
+		}
+		expected, err := computeCheckCharacter(candidateBody)
+		if err != nil || expected != candidateCheck {
+			return
+		}
+		candidate := candidateBody + "-" + string(candidateCheck)
+		if !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	// Clé de contrôle mal saisie
+	if expected, err := computeCheckCharacter(string(body)); err == nil {
+		add(string(body), expected)
+	}
+
+	for i := range body {
+		if isCodeSeparator(rune(body[i])) {
+			continue
+		}
+
+		// Substitution d'un caractère
+		original := body[i]
+		for j := 0; j < 36; j++ {
+			if patientCodeCheckAlphabet[j] == original {
+				continue
+			}
+			body[i] = patientCodeCheckAlphabet[j]
+			add(string(body), check)
+		}
+		body[i] = original
+
+		// Transposition avec le caractère suivant
+		if i+1 < len(body) && !isCodeSeparator(rune(body[i+1])) && body[i+1] != body[i] {
+			body[i], body[i+1] = body[i+1], body[i]
+			add(string(body), check)
+			body[i], body[i+1] = body[i+1], body[i]
+		}
+	}
+
+	return candidates
+}
+
+// findCodeSuggestions retourne les dossiers existants correspondant aux corrections possibles d'un code
+func findCodeSuggestions(
+	ctx context.Context,
+	db *postgres.Client,
+	code string,
+	bodyPatterns []*regexp.Regexp,
+) ([]dto.PatientCodeSuggestion, error) {
+	candidates := checkCodeCandidates(code, bodyPatterns)
+	suggestions := []dto.PatientCodeSuggestion{}
+	if len(candidates) == 0 {
+		return suggestions, nil
+	}
+
+	rows, err := db.Query(ctx,
+		queries.PatientCodeGenerationQueries.FindPatientsByCodes,
+		candidates,
+		maxCodeSuggestions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find code suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var suggestion dto.PatientCodeSuggestion
+		if err := rows.Scan(
+			&suggestion.CodePatient,
+			&suggestion.Nom,
+			&suggestion.Prenoms,
+			&suggestion.DateNaissance,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan code suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, rows.Err()
+}
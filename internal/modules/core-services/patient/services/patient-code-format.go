@@ -0,0 +1,348 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+// Format historique des codes patient : {ETABLISSEMENT}-{YYYY}-{NNN}-{LLL}
+const defaultPatientCodeFormat = "{ETAB}-{YYYY}-{N:3}-{L:3}"
+
+// Limites des formats paramétrables
+const (
+	maxCodeCounterWidth = 9  // Compteur INTEGER
+	maxCodeAlphaWidth   = 4  // dernier_suffixe VARCHAR(4)
+	maxCodeBodyLength   = 38 // code_patient VARCHAR(40) moins la clé de contrôle "-C"
+	maxEtablissementLen = 20
+)
+
+// Jetons du format : {ETAB}, {YYYY}, {YY}, {N:largeur}, {L:largeur}
+var codeFormatTokenPattern = regexp.MustCompile(`\{([A-Z]+)(?::([0-9]+))?\}`)
+
+// Caractères autorisés hors jetons (le code apparaît dans les URL et les codes-barres)
+var codeFormatLiteralPattern = regexp.MustCompile(`^[A-Z0-9._-]*$`)
+
+// Types de segment d'un format de code
+const (
+	codeSegmentLiteral = iota
+	codeSegmentEtablissement
+	codeSegmentAnnee4
+	codeSegmentAnnee2
+	codeSegmentCompteur
+	codeSegmentAlpha
+)
+
+// codeFormatSegment représente un élément du format (texte fixe ou jeton)
+type codeFormatSegment struct {
+	kind    int
+	literal string
+	width   int
+}
+
+// patientCodeFormat représente un format de code patient analysé
+type patientCodeFormat struct {
+	template     string
+	segments     []codeFormatSegment
+	counterWidth int
+	alphaWidth   int // 0 = pas de bloc alphabétique (séquence numérique seule)
+	yearly       bool
+}
+
+// parsePatientCodeFormat analyse et valide un format de code patient
+// Règles : {ETAB} obligatoire (unicité entre établissements), un compteur {N:1-9},
+// au plus un bloc {L:1-4} et au plus une année ({YYYY} ou {YY})
+func parsePatientCodeFormat(template string) (*patientCodeFormat, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		template = defaultPatientCodeFormat
+	}
+
+	format := &patientCodeFormat{template: template}
+	var hasEtablissement bool
+	position := 0
+
+	for _, match := range codeFormatTokenPattern.FindAllStringSubmatchIndex(template, -1) {
+		if err := format.addLiteral(template[position:match[0]]); err != nil {
+			return nil, err
+		}
+		position = match[1]
+
+		token := template[match[2]:match[3]]
+		width := 0
+		if match[4] >= 0 {
+			width, _ = strconv.Atoi(template[match[4]:match[5]])
+		}
+
+		switch token {
+		case "ETAB":
+			if hasEtablissement || width != 0 {
+				return nil, fmt.Errorf("le jeton {ETAB} doit apparaître une seule fois, sans largeur")
+			}
+			hasEtablissement = true
+			format.segments = append(format.segments, codeFormatSegment{kind: codeSegmentEtablissement})
+		case "YYYY", "YY":
+			if format.yearly || width != 0 {
+				return nil, fmt.Errorf("l'année ({YYYY} ou {YY}) doit apparaître au plus une fois, sans largeur")
+			}
+			format.yearly = true
+			kind := codeSegmentAnnee4
+			if token == "YY" {
+				kind = codeSegmentAnnee2
+			}
+			format.segments = append(format.segments, codeFormatSegment{kind: kind})
+		case "N":
+			if format.counterWidth != 0 {
+				return nil, fmt.Errorf("le compteur {N:largeur} doit apparaître une seule fois")
+			}
+			if width < 1 || width > maxCodeCounterWidth {
+				return nil, fmt.Errorf("largeur du compteur invalide (1 à %d)", maxCodeCounterWidth)
+			}
+			format.counterWidth = width
+			format.segments = append(format.segments, codeFormatSegment{kind: codeSegmentCompteur, width: width})
+		case "L":
+			if format.alphaWidth != 0 {
+				return nil, fmt.Errorf("le bloc alphabétique {L:largeur} doit apparaître au plus une fois")
+			}
+			if width < 1 || width > maxCodeAlphaWidth {
+				return nil, fmt.Errorf("largeur du bloc alphabétique invalide (1 à %d)", maxCodeAlphaWidth)
+			}
+			format.alphaWidth = width
+			format.segments = append(format.segments, codeFormatSegment{kind: codeSegmentAlpha, width: width})
+		default:
+			return nil, fmt.Errorf("jeton {%s} inconnu (jetons autorisés : {ETAB}, {YYYY}, {YY}, {N:n}, {L:n})", token)
+		}
+	}
+	if err := format.addLiteral(template[position:]); err != nil {
+		return nil, err
+	}
+
+	if !hasEtablissement {
+		return nil, fmt.Errorf("le jeton {ETAB} est obligatoire (unicité des codes entre établissements)")
+	}
+	if format.counterWidth == 0 {
+		return nil, fmt.Errorf("le compteur {N:largeur} est obligatoire")
+	}
+	if format.bodyLength(maxEtablissementLen) > maxCodeBodyLength {
+		return nil, fmt.Errorf("format trop long : %d caractères maximum hors clé de contrôle", maxCodeBodyLength)
+	}
+
+	return format, nil
+}
+
+// addLiteral ajoute un texte fixe (les accolades isolées sont refusées)
+func (f *patientCodeFormat) addLiteral(literal string) error {
+	if literal == "" {
+		return nil
+	}
+	if !codeFormatLiteralPattern.MatchString(literal) {
+		return fmt.Errorf("texte fixe '%s' invalide (lettres majuscules, chiffres, '-', '_' et '.' uniquement)", literal)
+	}
+	f.segments = append(f.segments, codeFormatSegment{kind: codeSegmentLiteral, literal: literal})
+	return nil
+}
+
+// bodyLength calcule la longueur d'un code (hors clé de contrôle) pour un code établissement donné
+func (f *patientCodeFormat) bodyLength(etablissementLen int) int {
+	length := 0
+	for _, segment := range f.segments {
+		switch segment.kind {
+		case codeSegmentLiteral:
+			length += len(segment.literal)
+		case codeSegmentEtablissement:
+			length += etablissementLen
+		case codeSegmentAnnee4:
+			length += 4
+		case codeSegmentAnnee2:
+			length += 2
+		default:
+			length += segment.width
+		}
+	}
+	return length
+}
+
+// sequenceYear retourne l'année de la séquence (0 = séquence continue, jamais remise à zéro)
+func (f *patientCodeFormat) sequenceYear(now time.Time) int {
+	if f.yearly {
+		return now.Year()
+	}
+	return 0
+}
+
+// maxNumero retourne la valeur maximale du compteur
+func (f *patientCodeFormat) maxNumero() int {
+	max := 1
+	for i := 0; i < f.counterWidth; i++ {
+		max *= 10
+	}
+	return max - 1
+}
+
+// initialSuffix retourne le premier bloc alphabétique (AAA pour {L:3})
+func (f *patientCodeFormat) initialSuffix() string {
+	if f.alphaWidth == 0 {
+		return "A" // Non affiché, conservé pour un éventuel passage à un format avec bloc
+	}
+	return strings.Repeat("A", f.alphaWidth)
+}
+
+// capacity retourne le nombre de codes disponibles par séquence
+func (f *patientCodeFormat) capacity() int64 {
+	capacity := int64(f.maxNumero())
+	for i := 0; i < f.alphaWidth; i++ {
+		capacity *= 26
+	}
+	return capacity
+}
+
+// normalizeSuffix ajuste un bloc alphabétique à la largeur du format
+// (changement de format en cours de séquence : AB → AAB, AAB → AB)
+func (f *patientCodeFormat) normalizeSuffix(suffixe string) (string, bool) {
+	suffixe = strings.ToUpper(suffixe)
+	if f.alphaWidth == 0 {
+		return suffixe, true
+	}
+	if len(suffixe) < f.alphaWidth {
+		return strings.Repeat("A", f.alphaWidth-len(suffixe)) + suffixe, true
+	}
+	excess := len(suffixe) - f.alphaWidth
+	if strings.Trim(suffixe[:excess], "A") != "" {
+		return "", false
+	}
+	return suffixe[excess:], true
+}
+
+// next calcule la position suivante de la séquence (compteur puis bloc alphabétique)
+func (f *patientCodeFormat) next(numero int, suffixe string) (int, string, bool) {
+	numero++
+	if numero <= f.maxNumero() {
+		return numero, suffixe, true
+	}
+	if f.alphaWidth == 0 {
+		return 0, "", false
+	}
+
+	normalized, ok := f.normalizeSuffix(suffixe)
+	if !ok {
+		return 0, "", false
+	}
+	chars := []byte(normalized)
+	for i := len(chars) - 1; i >= 0; i-- {
+		if chars[i] < 'Z' {
+			chars[i]++
+			return 1, string(chars), true
+		}
+		chars[i] = 'A'
+	}
+	return 0, "", false // ZZZ : capacité épuisée
+}
+
+// position convertit un état de séquence (compteur, bloc) en rang linéaire (0 = aucun code généré)
+// Un compteur au-delà du maximum (largeur réduite en cours de séquence) compte comme fin de bloc, comme next
+func (f *patientCodeFormat) position(numero int, suffixe string) (int64, bool) {
+	if f.alphaWidth == 0 {
+		return int64(numero), numero <= f.maxNumero()
+	}
+	normalized, ok := f.normalizeSuffix(suffixe)
+	if !ok {
+		return 0, false
+	}
+	if numero > f.maxNumero() {
+		numero = f.maxNumero()
+	}
+	var index int64
+	for _, c := range []byte(normalized) {
+		index = index*26 + int64(c-'A')
+	}
+	return index*int64(f.maxNumero()) + int64(numero), true
+}
+
+// fromPosition convertit un rang linéaire en état de séquence (inverse de position)
+// Sans bloc alphabétique, le suffixe stocké est conservé tel quel
+func (f *patientCodeFormat) fromPosition(position int64, suffixe string) (int, string) {
+	if f.alphaWidth == 0 {
+		return int(position), suffixe
+	}
+	if position == 0 {
+		return 0, f.initialSuffix()
+	}
+
+	maxNumero := int64(f.maxNumero())
+	index := (position - 1) / maxNumero
+	numero := int((position-1)%maxNumero + 1)
+
+	chars := make([]byte, f.alphaWidth)
+	for i := f.alphaWidth - 1; i >= 0; i-- {
+		chars[i] = byte('A' + index%26)
+		index /= 26
+	}
+	return numero, string(chars)
+}
+
+// render produit le code (hors clé de contrôle) pour une position de séquence
+func (f *patientCodeFormat) render(etablissementCode string, year int, numero int, suffixe string) (string, error) {
+	if numero < 1 || numero > f.maxNumero() {
+		return "", fmt.Errorf("compteur %d hors du format %s", numero, f.template)
+	}
+	normalized, ok := f.normalizeSuffix(suffixe)
+	if !ok {
+		return "", fmt.Errorf("bloc alphabétique %s hors du format %s", suffixe, f.template)
+	}
+
+	var builder strings.Builder
+	for _, segment := range f.segments {
+		switch segment.kind {
+		case codeSegmentLiteral:
+			builder.WriteString(segment.literal)
+		case codeSegmentEtablissement:
+			builder.WriteString(etablissementCode)
+		case codeSegmentAnnee4:
+			fmt.Fprintf(&builder, "%04d", year)
+		case codeSegmentAnnee2:
+			fmt.Fprintf(&builder, "%02d", year%100)
+		case codeSegmentCompteur:
+			fmt.Fprintf(&builder, "%0*d", segment.width, numero)
+		case codeSegmentAlpha:
+			builder.WriteString(normalized)
+		}
+	}
+	return builder.String(), nil
+}
+
+// bodyPattern retourne l'expression régulière des codes (hors clé) de ce format pour un établissement
+func (f *patientCodeFormat) bodyPattern(etablissementCode string) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for _, segment := range f.segments {
+		switch segment.kind {
+		case codeSegmentLiteral:
+			builder.WriteString(regexp.QuoteMeta(segment.literal))
+		case codeSegmentEtablissement:
+			builder.WriteString(regexp.QuoteMeta(strings.ToUpper(etablissementCode)))
+		case codeSegmentAnnee4:
+			builder.WriteString("[0-9]{4}")
+		case codeSegmentAnnee2:
+			builder.WriteString("[0-9]{2}")
+		case codeSegmentCompteur:
+			fmt.Fprintf(&builder, "[0-9]{%d}", segment.width)
+		case codeSegmentAlpha:
+			fmt.Fprintf(&builder, "[A-Z]{%d}", segment.width)
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
+
+// capacityError construit l'erreur de capacité épuisée d'une séquence
+func (f *patientCodeFormat) capacityError(etablissementCode string, year int) error {
+	message := "Capacité maximale atteinte pour l'année"
+	if !f.yearly {
+		message = fmt.Sprintf("Capacité maximale atteinte pour le format %s", f.template)
+	}
+	return dto.NewCodeGenerationError(dto.ErrCodeCapaciteMaximale, message, etablissementCode, year)
+}
@@ -3,10 +3,12 @@ package services
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/redis/go-redis/v9"
 
@@ -22,6 +24,28 @@ type PatientCodeGeneratorService struct {
 	redis    *redis.Client
 	redisKeys *PatientRedisKeys
 	mu       sync.Map // Lock en mémoire par établissement pour éviter concurrence locale
+	configs  sync.Map // Paramétrage des codes par établissement (cache local court)
+	blocks   sync.Map // Blocs de codes réservés par cette instance (établissement-année -> *codeBlock)
+
+	checkedPatternsMu sync.Mutex
+	checkedPatterns   *cachedCheckedPatterns // Formats à clé de contrôle (vérification des codes saisis)
+}
+
+// Durée de vie du paramétrage en cache local (prise en compte d'une modification sur les autres instances)
+const codeConfigurationCacheTTL = time.Minute
+
+// cachedCodeConfiguration représente un paramétrage d'établissement en cache local
+type cachedCodeConfiguration struct {
+	format      *patientCodeFormat
+	cleControle bool
+	tailleBloc  int
+	chargeLe    time.Time
+}
+
+// cachedCheckedPatterns représente les formats à clé de contrôle en cache local
+type cachedCheckedPatterns struct {
+	patterns []*regexp.Regexp
+	chargeLe time.Time
 }
 
 // NewPatientCodeGeneratorService crée une nouvelle instance du service
@@ -36,28 +60,49 @@ func NewPatientCodeGeneratorService(db *postgres.Client, redis *redis.Client) *P
 }
 
 // GeneratePatientCode génère un code patient unique atomiquement
-// Format: {ETABLISSEMENT}-{YYYY}-{NNN}-{LLL}
-// Exemple: CENTREA-2025-001-AAA
+// Format paramétrable par établissement, par défaut {ETABLISSEMENT}-{YYYY}-{NNN}-{LLL}[-{C}]
+// (clé de contrôle si activée pour l'établissement)
+// Exemple: CENTREA-2025-001-AAA ou CENTREA-2025-001-AAA-K
 func (s *PatientCodeGeneratorService) GeneratePatientCode(
 	ctx context.Context,
 	etablissementCode string,
 ) (*dto.CodeGenerationResponse, error) {
 	startTime := time.Now()
-	year := time.Now().Year()
 
 	// Validation établissement
 	if err := s.validateEtablissementCode(etablissementCode); err != nil {
 		return nil, err
 	}
 
+	// Format de l'établissement : détermine la séquence (annuelle ou continue) et sa capacité
+	config := s.codeConfiguration(ctx, etablissementCode)
+	year := config.format.sequenceYear(startTime)
+
+	// 0. Allocation par blocs : aucun verrou ni écriture par code
+	if config.tailleBloc > 0 {
+		response, err := s.generateFromBlock(ctx, etablissementCode, year, config)
+		if err == nil {
+			response.GenerationTimeMs = int(time.Since(startTime).Milliseconds())
+			return response, nil
+		}
+		if _, isCodeErr := err.(*dto.CodeGenerationError); isCodeErr {
+			return nil, err
+		}
+		// Réservation impossible (verrou de séquence indisponible) : allocation code par code
+		fmt.Printf("[CODE] Block reservation failed, falling back to per-code allocation - Etablissement: %s, Error: %v\n",
+			etablissementCode, err)
+	}
+
 	// 1. Tentative rapide via Redis (99% des cas)
-	if response, err := s.generateFromRedis(ctx, etablissementCode, year); err == nil {
+	if response, err := s.generateFromRedis(ctx, etablissementCode, year, config); err == nil {
 		response.GenerationTimeMs = int(time.Since(startTime).Milliseconds())
 		return response, nil
+	} else if _, isCodeErr := err.(*dto.CodeGenerationError); isCodeErr {
+		return nil, err
 	}
 
 	// 2. Fallback PostgreSQL si Redis indisponible ou première génération
-	return s.generateFromPostgres(ctx, etablissementCode, year, startTime)
+	return s.generateFromPostgres(ctx, etablissementCode, year, config, startTime)
 }
 
 // generateFromRedis - Génération ultra-rapide via Redis avec locks distribués
@@ -65,6 +110,7 @@ func (s *PatientCodeGeneratorService) generateFromRedis(
 	ctx context.Context,
 	etablissementCode string,
 	year int,
+	config *cachedCodeConfiguration,
 ) (*dto.CodeGenerationResponse, error) {
 	redisKey := s.redisKeys.PatientSequenceKey(etablissementCode, year)
 	lockKey := s.redisKeys.PatientSequenceLockKey(etablissementCode, year)
@@ -79,8 +125,8 @@ func (s *PatientCodeGeneratorService) generateFromRedis(
 	// Récupérer la séquence actuelle
 	current, err := s.redis.Get(ctx, redisKey).Result()
 	if err == redis.Nil {
-		// Première génération de l'année - initialiser depuis PostgreSQL
-		return s.initializeRedisFromDB(ctx, etablissementCode, year)
+		// Première génération de la séquence - initialiser depuis PostgreSQL
+		return s.initializeRedisFromDB(ctx, etablissementCode, year, config)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("Redis get failed: %w", err)
@@ -94,14 +140,14 @@ func (s *PatientCodeGeneratorService) generateFromRedis(
 		return nil, fmt.Errorf("invalid Redis sequence format: %w", scanErr)
 	}
 
-	numero, suffixe, err = s.incrementSequence(numero, suffixe)
+	numero, suffixe, err = s.incrementSequence(config.format, etablissementCode, year, numero, suffixe)
 	if err != nil {
 		return nil, err
 	}
 
 	// Sauvegarder la nouvelle séquence
 	newValue := fmt.Sprintf("%d:%s", numero, suffixe)
-	ttl := s.calculateTTLUntilYearEnd()
+	ttl := s.calculateSequenceTTL(year)
 	if err := s.redis.Set(ctx, redisKey, newValue, ttl).Err(); err != nil {
 		return nil, fmt.Errorf("Redis set failed: %w", err)
 	}
@@ -110,7 +156,10 @@ func (s *PatientCodeGeneratorService) generateFromRedis(
 	go s.updatePostgresAsync(etablissementCode, year, numero, suffixe)
 
 	// Formater le code final
-	codePatient := fmt.Sprintf("%s-%d-%03d-%s", etablissementCode, year, numero, suffixe)
+	codePatient, cleControle, err := s.formatCode(config, etablissementCode, year, numero, suffixe)
+	if err != nil {
+		return nil, err
+	}
 
 	return &dto.CodeGenerationResponse{
 		CodePatient:       codePatient,
@@ -118,6 +167,7 @@ func (s *PatientCodeGeneratorService) generateFromRedis(
 		Annee:            year,
 		Numero:           numero,
 		Suffixe:          suffixe,
+		CleControle:      cleControle,
 		GeneratedAt:      time.Now(),
 		Source:           "redis",
 	}, nil
@@ -128,6 +178,7 @@ func (s *PatientCodeGeneratorService) generateFromPostgres(
 	ctx context.Context,
 	etablissementCode string,
 	year int,
+	config *cachedCodeConfiguration,
 	startTime time.Time,
 ) (*dto.CodeGenerationResponse, error) {
 	// Utiliser un lock en mémoire pour éviter la concurrence locale
@@ -148,10 +199,16 @@ func (s *PatientCodeGeneratorService) generateFromPostgres(
 			queries.PatientCodeGenerationQueries.GenerateNextCodeFromPostgres,
 			etablissementCode,
 			year,
+			config.format.maxNumero(),
+			config.format.alphaWidth,
+			config.format.initialSuffix(),
 		).Scan(&numero, &suffixe, &nombreGeneres)
 	})
 
 	if err != nil {
+		if strings.Contains(err.Error(), "Capacité maximale atteinte") {
+			return nil, config.format.capacityError(etablissementCode, year)
+		}
 		return nil, fmt.Errorf("failed to generate code: %w", err)
 	}
 
@@ -159,7 +216,10 @@ func (s *PatientCodeGeneratorService) generateFromPostgres(
 	s.syncRedisFromPostgres(ctx, etablissementCode, year, numero, suffixe)
 
 	// Formater le code final
-	codePatient := fmt.Sprintf("%s-%d-%03d-%s", etablissementCode, year, numero, suffixe)
+	codePatient, cleControle, err := s.formatCode(config, etablissementCode, year, numero, suffixe)
+	if err != nil {
+		return nil, err
+	}
 
 	return &dto.CodeGenerationResponse{
 		CodePatient:       codePatient,
@@ -167,6 +227,7 @@ func (s *PatientCodeGeneratorService) generateFromPostgres(
 		Annee:            year,
 		Numero:           numero,
 		Suffixe:          suffixe,
+		CleControle:      cleControle,
 		NombreGeneres:    nombreGeneres,
 		GeneratedAt:      time.Now(),
 		Source:           "postgres",
@@ -174,47 +235,27 @@ func (s *PatientCodeGeneratorService) generateFromPostgres(
 	}, nil
 }
 
-// incrementSequence - Logique d'incrémentation numéro/suffixe
-func (s *PatientCodeGeneratorService) incrementSequence(numero int, suffixe string) (int, string, error) {
-	numero++
-	if numero > 999 {
-		numero = 1
-		newSuffixe, err := s.nextSuffix(suffixe)
-		if err != nil {
-			return 0, "", err
-		}
-		suffixe = newSuffixe
+// incrementSequence - Logique d'incrémentation numéro/suffixe selon le format de l'établissement
+func (s *PatientCodeGeneratorService) incrementSequence(
+	format *patientCodeFormat,
+	etablissementCode string,
+	year int,
+	numero int,
+	suffixe string,
+) (int, string, error) {
+	numero, suffixe, ok := format.next(numero, suffixe)
+	if !ok {
+		return 0, "", format.capacityError(etablissementCode, year)
 	}
 	return numero, suffixe, nil
 }
 
-// nextSuffix - Calcul du suffixe suivant (AAA → AAB → ... → ZZZ)
-func (s *PatientCodeGeneratorService) nextSuffix(current string) (string, error) {
-	if current == "ZZZ" {
-		return "", dto.NewCodeGenerationError(
-			dto.ErrCodeCapaciteMaximale,
-			"Capacité maximale atteinte pour l'année",
-			"",
-			time.Now().Year(),
-		)
-	}
-
-	chars := []byte(strings.ToUpper(current))
-	for i := 2; i >= 0; i-- {
-		if chars[i] < 'Z' {
-			chars[i]++
-			break
-		}
-		chars[i] = 'A'
-	}
-	return string(chars), nil
-}
-
 // initializeRedisFromDB - Initialise Redis depuis PostgreSQL pour première génération
 func (s *PatientCodeGeneratorService) initializeRedisFromDB(
 	ctx context.Context,
 	etablissementCode string,
 	year int,
+	config *cachedCodeConfiguration,
 ) (*dto.CodeGenerationResponse, error) {
 	var numero int
 	var suffixe string
@@ -229,7 +270,7 @@ func (s *PatientCodeGeneratorService) initializeRedisFromDB(
 
 	if err == pgx.ErrNoRows {
 		// Première génération absolue - utiliser fallback PostgreSQL
-		return s.generateFromPostgres(ctx, etablissementCode, year, time.Now())
+		return s.generateFromPostgres(ctx, etablissementCode, year, config, time.Now())
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sequence state: %w", err)
@@ -238,14 +279,14 @@ func (s *PatientCodeGeneratorService) initializeRedisFromDB(
 	// Synchroniser Redis
 	redisKey := s.redisKeys.PatientSequenceKey(etablissementCode, year)
 	redisValue := fmt.Sprintf("%d:%s", numero, suffixe)
-	ttl := s.calculateTTLUntilYearEnd()
+	ttl := s.calculateSequenceTTL(year)
 
 	if err := s.redis.Set(ctx, redisKey, redisValue, ttl).Err(); err != nil {
 		return nil, fmt.Errorf("failed to initialize Redis: %w", err)
 	}
 
 	// Maintenant générer via Redis
-	return s.generateFromRedis(ctx, etablissementCode, year)
+	return s.generateFromRedis(ctx, etablissementCode, year, config)
 }
 
 // syncRedisFromPostgres - Synchronise Redis avec l'état PostgreSQL
@@ -258,7 +299,7 @@ func (s *PatientCodeGeneratorService) syncRedisFromPostgres(
 ) {
 	redisKey := s.redisKeys.PatientSequenceKey(etablissementCode, year)
 	redisValue := fmt.Sprintf("%d:%s", numero, suffixe)
-	ttl := s.calculateTTLUntilYearEnd()
+	ttl := s.calculateSequenceTTL(year)
 
 	// Best effort - on ignore les erreurs Redis en fallback
 	s.redis.Set(ctx, redisKey, redisValue, ttl)
@@ -284,6 +325,14 @@ func (s *PatientCodeGeneratorService) updatePostgresAsync(
 	)
 }
 
+// calculateSequenceTTL - TTL de la séquence Redis (sans expiration pour une séquence continue)
+func (s *PatientCodeGeneratorService) calculateSequenceTTL(year int) time.Duration {
+	if year == 0 {
+		return 0
+	}
+	return s.calculateTTLUntilYearEnd()
+}
+
 // calculateTTLUntilYearEnd - TTL jusqu'au 31 décembre 23:59:59
 func (s *PatientCodeGeneratorService) calculateTTLUntilYearEnd() time.Duration {
 	now := time.Now()
@@ -317,8 +366,9 @@ func (s *PatientCodeGeneratorService) GetSequenceStats(
 	ctx context.Context,
 	etablissementCode string,
 ) (*dto.CodeGenerationStats, error) {
-	year := time.Now().Year()
-	
+	config := s.codeConfiguration(ctx, etablissementCode)
+	year := config.format.sequenceYear(time.Now())
+
 	var numero int
 	var suffixe string
 	var nombreGeneres int64
@@ -333,32 +383,328 @@ func (s *PatientCodeGeneratorService) GetSequenceStats(
 		return &dto.CodeGenerationStats{
 			EtablissementCode: etablissementCode,
 			Annee:            year,
+			FormatCode:       config.format.template,
 			NombreGeneres:    0,
 			CapaciteUtilisee:  0.0,
 			DernierCode:      "Aucun",
-			ProchainCode:     fmt.Sprintf("%s-%d-001-AAA", etablissementCode, year),
+			ProchainCode:     s.formatCodeForDisplay(config, etablissementCode, year, 1, config.format.initialSuffix()),
 		}, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
 
-	// Calculs statistiques
-	capaciteMaximale := int64(17558424) // 999 * 17576 blocs
+	// Calculs statistiques (capacité du format : compteur x blocs alphabétiques)
+	capaciteMaximale := config.format.capacity()
 	capaciteUtilisee := (float64(nombreGeneres) / float64(capaciteMaximale)) * 100
 
-	dernierCode := fmt.Sprintf("%s-%d-%03d-%s", etablissementCode, year, numero, suffixe)
-	
+	dernierCode := s.formatCodeForDisplay(config, etablissementCode, year, numero, suffixe)
+
 	// Calculer le prochain code
-	prochainNumero, prochainSuffixe, _ := s.incrementSequence(numero, suffixe)
-	prochainCode := fmt.Sprintf("%s-%d-%03d-%s", etablissementCode, year, prochainNumero, prochainSuffixe)
+	prochainCode := "Capacité épuisée"
+	if prochainNumero, prochainSuffixe, err := s.incrementSequence(config.format, etablissementCode, year, numero, suffixe); err == nil {
+		prochainCode = s.formatCodeForDisplay(config, etablissementCode, year, prochainNumero, prochainSuffixe)
+	}
 
 	return &dto.CodeGenerationStats{
 		EtablissementCode: etablissementCode,
 		Annee:            year,
+		FormatCode:       config.format.template,
 		NombreGeneres:    nombreGeneres,
 		CapaciteUtilisee:  capaciteUtilisee,
 		DernierCode:      dernierCode,
 		ProchainCode:     prochainCode,
 	}, nil
-}
\ No newline at end of file
+}
+
+// formatCode formate le code final et ajoute la clé de contrôle si l'établissement l'a activée
+func (s *PatientCodeGeneratorService) formatCode(
+	config *cachedCodeConfiguration,
+	etablissementCode string,
+	year int,
+	numero int,
+	suffixe string,
+) (string, string, error) {
+	codePatient, err := config.format.render(etablissementCode, year, numero, suffixe)
+	if err != nil {
+		return "", "", dto.NewCodeGenerationError(
+			dto.ErrCodeFormatInvalide,
+			fmt.Sprintf("Code impossible à formater : %v", err),
+			etablissementCode,
+			year,
+		)
+	}
+	if !config.cleControle {
+		return codePatient, "", nil
+	}
+
+	codeAvecCle, cleControle, err := appendCheckCharacter(codePatient)
+	if err != nil {
+		return "", "", dto.NewCodeGenerationError(
+			dto.ErrCodeFormatInvalide,
+			fmt.Sprintf("Clé de contrôle impossible : %v", err),
+			etablissementCode,
+			year,
+		)
+	}
+	return codeAvecCle, cleControle, nil
+}
+
+// formatCodeForDisplay formate un code pour les statistiques (erreur de format ignorée)
+func (s *PatientCodeGeneratorService) formatCodeForDisplay(
+	config *cachedCodeConfiguration,
+	etablissementCode string,
+	year int,
+	numero int,
+	suffixe string,
+) string {
+	codePatient, _, err := s.formatCode(config, etablissementCode, year, numero, suffixe)
+	if err != nil {
+		return fmt.Sprintf("%s (%d:%s)", config.format.template, numero, suffixe)
+	}
+	return codePatient
+}
+
+// codeConfiguration lit le paramétrage de l'établissement (cache local, PostgreSQL sinon)
+// En cas d'erreur PostgreSQL, la génération continue au format par défaut sans clé plutôt que d'échouer
+func (s *PatientCodeGeneratorService) codeConfiguration(ctx context.Context, etablissementCode string) *cachedCodeConfiguration {
+	if cached, ok := s.configs.Load(etablissementCode); ok {
+		config := cached.(*cachedCodeConfiguration)
+		if time.Since(config.chargeLe) < codeConfigurationCacheTTL {
+			return config
+		}
+	}
+
+	if _, err := s.GetCodeConfiguration(ctx, etablissementCode); err != nil {
+		fmt.Printf("[CODE] Code configuration unavailable - Etablissement: %s, Error: %v\n", etablissementCode, err)
+		format, _ := parsePatientCodeFormat(defaultPatientCodeFormat)
+		return &cachedCodeConfiguration{format: format, chargeLe: time.Now()}
+	}
+
+	cached, _ := s.configs.Load(etablissementCode)
+	return cached.(*cachedCodeConfiguration)
+}
+
+// GetCodeConfiguration retourne le paramétrage des codes patient d'un établissement
+func (s *PatientCodeGeneratorService) GetCodeConfiguration(
+	ctx context.Context,
+	etablissementCode string,
+) (*dto.PatientCodeConfiguration, error) {
+	configuration := &dto.PatientCodeConfiguration{
+		EtablissementCode: etablissementCode,
+		FormatCode:        defaultPatientCodeFormat,
+	}
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientCodeGenerationQueries.GetCodeConfiguration,
+		etablissementCode,
+	).Scan(&configuration.FormatCode, &configuration.CleControle, &configuration.TailleBloc, &configuration.UpdatedAt)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get code configuration: %w", err)
+	}
+
+	format, err := parsePatientCodeFormat(configuration.FormatCode)
+	if err != nil {
+		// Format stocké invalide (modification manuelle) : format par défaut plutôt qu'un blocage des créations
+		fmt.Printf("[CODE] Invalid stored code format - Etablissement: %s, Format: %s, Error: %v\n",
+			etablissementCode, configuration.FormatCode, err)
+		format, _ = parsePatientCodeFormat(defaultPatientCodeFormat)
+	}
+
+	config := &cachedCodeConfiguration{
+		format:      format,
+		cleControle: configuration.CleControle,
+		tailleBloc:  configuration.TailleBloc,
+		chargeLe:    time.Now(),
+	}
+	s.configs.Store(etablissementCode, config)
+	s.describeConfiguration(configuration, config)
+
+	return configuration, nil
+}
+
+// UpdateCodeConfiguration modifie le paramétrage des codes patient d'un établissement
+// Seuls les codes générés ensuite sont concernés : les codes existants restent valides tels quels
+func (s *PatientCodeGeneratorService) UpdateCodeConfiguration(
+	ctx context.Context,
+	etablissementCode string,
+	req *dto.UpdatePatientCodeConfigurationRequest,
+	userID uuid.UUID,
+) (*dto.PatientCodeConfiguration, error) {
+	if err := s.validateEtablissementCode(etablissementCode); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetCodeConfiguration(ctx, etablissementCode)
+	if err != nil {
+		return nil, err
+	}
+
+	formatCode := current.FormatCode
+	if req.FormatCode != nil {
+		formatCode = strings.ToUpper(strings.TrimSpace(*req.FormatCode))
+	}
+
+	format, err := s.validateCodeFormat(ctx, etablissementCode, formatCode)
+	if err != nil {
+		return nil, err
+	}
+
+	tailleBloc := current.TailleBloc
+	if req.TailleBloc != nil {
+		tailleBloc = *req.TailleBloc
+	}
+
+	configuration := &dto.PatientCodeConfiguration{
+		EtablissementCode: etablissementCode,
+	}
+
+	err = s.db.QueryRow(ctx,
+		queries.PatientCodeGenerationQueries.UpsertCodeConfiguration,
+		etablissementCode,
+		format.template,
+		*req.CleControle,
+		tailleBloc,
+		userID,
+	).Scan(&configuration.FormatCode, &configuration.CleControle, &configuration.TailleBloc, &configuration.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update code configuration: %w", err)
+	}
+
+	config := &cachedCodeConfiguration{
+		format:      format,
+		cleControle: configuration.CleControle,
+		tailleBloc:  configuration.TailleBloc,
+		chargeLe:    time.Now(),
+	}
+	s.configs.Store(etablissementCode, config)
+	s.describeConfiguration(configuration, config)
+	s.invalidateCheckedPatterns()
+
+	fmt.Printf("[AUDIT] Patient code configuration updated - Etablissement: %s, Format: %s, Cle controle: %t, Taille bloc: %d, By: %s\n",
+		etablissementCode, configuration.FormatCode, configuration.CleControle, configuration.TailleBloc, userID)
+
+	return configuration, nil
+}
+
+// validateCodeFormat valide un format pour l'établissement et sa compatibilité avec la séquence en cours
+// La séquence se poursuit après un changement de format : son état doit rester représentable
+func (s *PatientCodeGeneratorService) validateCodeFormat(
+	ctx context.Context,
+	etablissementCode string,
+	formatCode string,
+) (*patientCodeFormat, error) {
+	format, err := parsePatientCodeFormat(formatCode)
+	if err != nil {
+		return nil, dto.NewCodeGenerationError(dto.ErrCodeFormatInvalide, err.Error(), etablissementCode, 0)
+	}
+
+	if format.bodyLength(len(etablissementCode)) > maxCodeBodyLength {
+		return nil, dto.NewCodeGenerationError(
+			dto.ErrCodeFormatInvalide,
+			fmt.Sprintf("Codes trop longs pour cet établissement (%d caractères maximum hors clé de contrôle)", maxCodeBodyLength),
+			etablissementCode,
+			0,
+		)
+	}
+
+	year := format.sequenceYear(time.Now())
+	var numero int
+	var suffixe string
+	var nombreGeneres int64
+	err = s.db.QueryRow(ctx,
+		queries.PatientCodeGenerationQueries.GetSequenceState,
+		etablissementCode,
+		year,
+	).Scan(&numero, &suffixe, &nombreGeneres)
+	if err == pgx.ErrNoRows {
+		return format, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sequence state: %w", err)
+	}
+
+	if _, ok := format.normalizeSuffix(suffixe); !ok {
+		return nil, dto.NewCodeGenerationError(
+			dto.ErrCodeFormatInvalide,
+			fmt.Sprintf("Bloc alphabétique trop court : la séquence en cours est au bloc %s", suffixe),
+			etablissementCode,
+			year,
+		)
+	}
+	if _, _, ok := format.next(numero, suffixe); !ok {
+		return nil, dto.NewCodeGenerationError(
+			dto.ErrCodeFormatInvalide,
+			fmt.Sprintf("Format sans capacité restante : la séquence en cours est au compteur %d", numero),
+			etablissementCode,
+			year,
+		)
+	}
+
+	return format, nil
+}
+
+// describeConfiguration complète la réponse avec les caractéristiques du format
+func (s *PatientCodeGeneratorService) describeConfiguration(
+	configuration *dto.PatientCodeConfiguration,
+	config *cachedCodeConfiguration,
+) {
+	year := config.format.sequenceYear(time.Now())
+	configuration.FormatCode = config.format.template
+	configuration.SequenceAnnuelle = config.format.yearly
+	configuration.Capacite = config.format.capacity()
+	configuration.ExempleCode = s.formatCodeForDisplay(config, configuration.EtablissementCode, year, 1, config.format.initialSuffix())
+}
+
+// VerifyCheckCharacter indique si un code saisi porte une clé de contrôle et si elle est juste
+// Formats vérifiés : format par défaut (tout établissement) et formats des établissements à clé
+func (s *PatientCodeGeneratorService) VerifyCheckCharacter(ctx context.Context, codePatient string) (hasCheck bool, valid bool) {
+	return verifyCheckCharacter(codePatient, s.checkedCodePatterns(ctx))
+}
+
+// FindCodeSuggestions retourne les dossiers existants à une faute de frappe d'un code à clé fausse
+func (s *PatientCodeGeneratorService) FindCodeSuggestions(
+	ctx context.Context,
+	codePatient string,
+) ([]dto.PatientCodeSuggestion, error) {
+	return findCodeSuggestions(ctx, s.db, codePatient, s.checkedCodePatterns(ctx))
+}
+
+// checkedCodePatterns retourne les formats (corps de code) porteurs d'une clé de contrôle
+func (s *PatientCodeGeneratorService) checkedCodePatterns(ctx context.Context) []*regexp.Regexp {
+	s.checkedPatternsMu.Lock()
+	defer s.checkedPatternsMu.Unlock()
+
+	if s.checkedPatterns != nil && time.Since(s.checkedPatterns.chargeLe) < codeConfigurationCacheTTL {
+		return s.checkedPatterns.patterns
+	}
+
+	patterns := []*regexp.Regexp{patientCodeBodyPattern}
+	rows, err := s.db.Query(ctx, queries.PatientCodeGenerationQueries.ListCheckedCodeFormats)
+	if err != nil {
+		// Vérification limitée au format par défaut, sans mise en cache pour réessayer
+		fmt.Printf("[CODE] Checked code formats unavailable - Error: %v\n", err)
+		return patterns
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var etablissementCode, formatCode string
+		if err := rows.Scan(&etablissementCode, &formatCode); err != nil {
+			continue
+		}
+		if format, err := parsePatientCodeFormat(formatCode); err == nil && format.template != defaultPatientCodeFormat {
+			patterns = append(patterns, format.bodyPattern(etablissementCode))
+		}
+	}
+
+	s.checkedPatterns = &cachedCheckedPatterns{patterns: patterns, chargeLe: time.Now()}
+	return patterns
+}
+
+// invalidateCheckedPatterns force le rechargement des formats à clé après une modification locale
+func (s *PatientCodeGeneratorService) invalidateCheckedPatterns() {
+	s.checkedPatternsMu.Lock()
+	s.checkedPatterns = nil
+	s.checkedPatternsMu.Unlock()
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientCoverageService gère les couvertures d'assurance d'un patient après sa création
+// (ajout principal/ayant droit, inactivation motivée) et maintient patients_patient.est_assure
+type PatientCoverageService struct {
+	db             *postgres.Client
+	txManager      *postgres.TransactionManager
+	cacheService   *PatientCacheService
+	historyService *PatientHistoryService
+}
+
+// NewPatientCoverageService crée une nouvelle instance du service
+func NewPatientCoverageService(
+	db *postgres.Client,
+	cacheService *PatientCacheService,
+	historyService *PatientHistoryService,
+) *PatientCoverageService {
+	return &PatientCoverageService{
+		db:             db,
+		txManager:      postgres.NewTransactionManager(db),
+		cacheService:   cacheService,
+		historyService: historyService,
+	}
+}
+
+// ListCoverages retourne les couvertures d'un patient (inactives incluses sur demande)
+func (s *PatientCoverageService) ListCoverages(
+	ctx context.Context,
+	codePatient string,
+	req *dto.ListCoveragesRequest,
+) (*dto.PatientCoveragesResponse, error) {
+	var patientID uuid.UUID
+	var estAssure bool
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientCoverageQueries.GetPatientCoverageStatus,
+		codePatient,
+	).Scan(&patientID, &estAssure)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return nil, fmt.Errorf("failed to load patient %s: %w", codePatient, err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientCoverageQueries.ListCoverages,
+		patientID,
+		req.IncludeInactive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list patient coverages: %w", err)
+	}
+	defer rows.Close()
+
+	couvertures := []dto.PatientCoverage{}
+	for rows.Next() {
+		couverture, err := scanCoverage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan patient coverage: %w", err)
+		}
+		couvertures = append(couvertures, *couverture)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate patient coverages: %w", err)
+	}
+
+	return &dto.PatientCoveragesResponse{
+		CodePatient: codePatient,
+		EstAssure:   estAssure,
+		Couvertures: couvertures,
+	}, nil
+}
+
+// AddCoverage ajoute une couverture active à un patient existant
+// Un ayant droit doit être rattaché à un assuré principal actif de la même assurance
+func (s *PatientCoverageService) AddCoverage(
+	ctx context.Context,
+	codePatient string,
+	req *dto.AddCoverageRequest,
+	etablissementID uuid.UUID,
+	userID uuid.UUID,
+) (*dto.PatientCoverageResult, error) {
+	numeroAssure := strings.TrimSpace(req.NumeroAssure)
+
+	var assurance dto.ReferenceInfo
+	err := s.db.QueryRow(ctx,
+		queries.PatientCoverageQueries.ValidateAssurance,
+		req.AssuranceID,
+		etablissementID,
+	).Scan(&assurance.ID, &assurance.Code, &assurance.Nom)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientCoverageError(dto.ErrCouvertureAssuranceInvalide,
+				"Assurance inconnue ou inactive pour cet établissement")
+		}
+		return nil, fmt.Errorf("failed to validate assurance: %w", err)
+	}
+
+	var result *dto.PatientCoverageResult
+
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, err := s.lockPatient(ctx, tx, codePatient, true)
+		if err != nil {
+			return err
+		}
+
+		var dejaActive bool
+		if err := tx.QueryRow(ctx,
+			queries.PatientCoverageQueries.ExistsActiveCoverage,
+			patientID,
+			assurance.ID,
+		).Scan(&dejaActive); err != nil {
+			return fmt.Errorf("failed to check existing coverage: %w", err)
+		}
+		if dejaActive {
+			return dto.NewPatientCoverageError(dto.ErrCouvertureDejaActive,
+				fmt.Sprintf("Le patient a déjà une couverture active auprès de %s", assurance.Nom))
+		}
+
+		var numeroPrincipal, lien *string
+		if req.TypeBeneficiaire == dto.BeneficiaireAyantDroit {
+			principal := strings.TrimSpace(*req.NumeroAssurePrincipal)
+			if err := s.checkPrincipal(ctx, tx, patientID, assurance, principal); err != nil {
+				return err
+			}
+			numeroPrincipal = &principal
+			lien = req.LienAvecPrincipal
+		} else {
+			var dejaAttribue bool
+			if err := tx.QueryRow(ctx,
+				queries.PatientCoverageQueries.ExistsPrincipalNumber,
+				assurance.ID,
+				numeroAssure,
+				patientID,
+			).Scan(&dejaAttribue); err != nil {
+				return fmt.Errorf("failed to check principal number: %w", err)
+			}
+			if dejaAttribue {
+				return dto.NewPatientCoverageError(dto.ErrCouvertureDejaActive,
+					fmt.Sprintf("Le numéro d'assuré %s est déjà attribué à un autre assuré principal", numeroAssure))
+			}
+		}
+
+		avant, err := s.historyService.Snapshot(ctx, tx, patientID)
+		if err != nil {
+			return err
+		}
+
+		couverture := dto.PatientCoverage{
+			Assurance:             assurance,
+			NumeroAssure:          numeroAssure,
+			TypeBeneficiaire:      req.TypeBeneficiaire,
+			NumeroAssurePrincipal: numeroPrincipal,
+			LienAvecPrincipal:     lien,
+			EstActif:              true,
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.PatientCoverageQueries.InsertCoverage,
+			patientID,
+			assurance.ID,
+			numeroAssure,
+			req.TypeBeneficiaire,
+			numeroPrincipal,
+			lien,
+			userID,
+		).Scan(&couverture.ID, &couverture.CreatedAt); err != nil {
+			return fmt.Errorf("failed to insert patient coverage: %w", err)
+		}
+
+		estAssure, err := s.syncEstAssure(ctx, tx, patientID, codePatient, avant,
+			fmt.Sprintf("Ajout couverture %s n° %s", assurance.Nom, numeroAssure), userID)
+		if err != nil {
+			return err
+		}
+
+		result = &dto.PatientCoverageResult{
+			CodePatient: codePatient,
+			EstAssure:   estAssure,
+			Couverture:  couverture,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCaches(ctx, codePatient)
+
+	fmt.Printf("[AUDIT] Patient coverage added - Code: %s, Assurance: %s, Type: %s, By: %s\n",
+		codePatient, assurance.Code, req.TypeBeneficiaire, userID)
+
+	return result, nil
+}
+
+// DeactivateCoverage inactive une couverture avec un motif (la ligne reste consultable)
+func (s *PatientCoverageService) DeactivateCoverage(
+	ctx context.Context,
+	codePatient string,
+	couvertureID uuid.UUID,
+	req *dto.DeactivateCoverageRequest,
+	userID uuid.UUID,
+) (*dto.PatientCoverageResult, error) {
+	var result *dto.PatientCoverageResult
+	var ayantsDroitActifs int
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, err := s.lockPatient(ctx, tx, codePatient, false)
+		if err != nil {
+			return err
+		}
+
+		couverture, err := scanCoverage(tx.QueryRow(ctx,
+			queries.PatientCoverageQueries.LockCoverage,
+			couvertureID,
+			patientID,
+		))
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewPatientCoverageError(dto.ErrCouvertureIntrouvable,
+					"Couverture introuvable pour ce patient")
+			}
+			return fmt.Errorf("failed to lock patient coverage: %w", err)
+		}
+		if !couverture.EstActif {
+			return dto.NewPatientCoverageError(dto.ErrCouvertureDejaInactive,
+				"Cette couverture est déjà inactive")
+		}
+
+		avant, err := s.historyService.Snapshot(ctx, tx, patientID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(ctx,
+			queries.PatientCoverageQueries.DeactivateCoverage,
+			couvertureID,
+			req.MotifInactivation,
+			userID,
+		).Scan(&couverture.InactiveLe); err != nil {
+			return fmt.Errorf("failed to deactivate patient coverage: %w", err)
+		}
+		couverture.EstActif = false
+		couverture.MotifInactivation = &req.MotifInactivation
+
+		// Les ayants droit ne sont pas inactivés en cascade : signalés pour régularisation
+		if couverture.TypeBeneficiaire == dto.BeneficiairePrincipal {
+			if err := tx.QueryRow(ctx,
+				queries.PatientCoverageQueries.CountActiveDependents,
+				couverture.Assurance.ID,
+				couverture.NumeroAssure,
+			).Scan(&ayantsDroitActifs); err != nil {
+				return fmt.Errorf("failed to count active dependents: %w", err)
+			}
+		}
+
+		estAssure, err := s.syncEstAssure(ctx, tx, patientID, codePatient, avant, req.MotifInactivation, userID)
+		if err != nil {
+			return err
+		}
+
+		result = &dto.PatientCoverageResult{
+			CodePatient: codePatient,
+			EstAssure:   estAssure,
+			Couverture:  *couverture,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCaches(ctx, codePatient)
+
+	fmt.Printf("[AUDIT] Patient coverage deactivated - Code: %s, Couverture: %s, Ayants droit actifs: %d, By: %s\n",
+		codePatient, couvertureID, ayantsDroitActifs, userID)
+
+	return result, nil
+}
+
+// lockPatient verrouille le dossier ; un dossier archivé (ou décédé ou anonymisé pour un ajout) n'est pas modifiable
+func (s *PatientCoverageService) lockPatient(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	codePatient string,
+	ajout bool,
+) (uuid.UUID, error) {
+	var patientID uuid.UUID
+	var statut string
+	var estDecede, estAnonymise bool
+
+	err := tx.QueryRow(ctx,
+		queries.PatientCoverageQueries.LockPatientForCoverage,
+		codePatient,
+	).Scan(&patientID, &statut, &estDecede, &estAnonymise)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return uuid.Nil, fmt.Errorf("failed to lock patient %s: %w", codePatient, err)
+	}
+
+	if statut == dto.PatientStatutArchive {
+		return uuid.Nil, dto.NewPatientArchivedError(codePatient)
+	}
+	if ajout && estAnonymise {
+		return uuid.Nil, dto.NewPatientAnonymizedError(codePatient)
+	}
+	if ajout && estDecede {
+		return uuid.Nil, dto.NewPatientCoverageError(dto.ErrCouverturePatientNonModifiable,
+			"Impossible d'ajouter une couverture à un patient décédé")
+	}
+
+	return patientID, nil
+}
+
+// checkPrincipal vérifie que l'assuré principal existe (couverture principale active de la même assurance)
+func (s *PatientCoverageService) checkPrincipal(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+	assurance dto.ReferenceInfo,
+	numeroPrincipal string,
+) error {
+	var principalID uuid.UUID
+
+	err := tx.QueryRow(ctx,
+		queries.PatientCoverageQueries.FindPrincipalCoverage,
+		assurance.ID,
+		numeroPrincipal,
+	).Scan(&principalID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewPatientCoverageError(dto.ErrCouverturePrincipalIntrouvable,
+				fmt.Sprintf("Aucun assuré principal actif n° %s chez %s", numeroPrincipal, assurance.Nom))
+		}
+		return fmt.Errorf("failed to find principal coverage: %w", err)
+	}
+
+	if principalID == patientID {
+		return dto.NewPatientCoverageError(dto.ErrCouverturePrincipalIntrouvable,
+			"Un patient ne peut pas être ayant droit de sa propre couverture")
+	}
+
+	return nil
+}
+
+// syncEstAssure resynchronise est_assure et historise le changement éventuel
+func (s *PatientCoverageService) syncEstAssure(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+	codePatient string,
+	avant map[string]interface{},
+	motif string,
+	userID uuid.UUID,
+) (bool, error) {
+	var estAssure bool
+	if err := tx.QueryRow(ctx,
+		queries.PatientCoverageQueries.SyncEstAssure,
+		patientID,
+		userID,
+	).Scan(&estAssure); err != nil {
+		return false, fmt.Errorf("failed to sync est_assure: %w", err)
+	}
+
+	apres, err := s.historyService.Snapshot(ctx, tx, patientID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.historyService.RecordChanges(ctx, tx,
+		patientID, codePatient, dto.HistoryTypeCouverture,
+		avant, apres, motif, userID); err != nil {
+		return false, err
+	}
+
+	return estAssure, nil
+}
+
+// invalidateCaches invalide le cache détail et les recherches en cache du patient (best effort)
+func (s *PatientCoverageService) invalidateCaches(ctx context.Context, codePatient string) {
+	if err := s.cacheService.InvalidatePatientCache(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+	if err := s.cacheService.InvalidateSearchCaches(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient search cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+}
+
+// scanCoverage lit une ligne de couverture (ListCoverages / LockCoverage)
+func scanCoverage(row pgx.Row) (*dto.PatientCoverage, error) {
+	var couverture dto.PatientCoverage
+
+	err := row.Scan(
+		&couverture.ID,
+		&couverture.Assurance.ID,
+		&couverture.Assurance.Code,
+		&couverture.Assurance.Nom,
+		&couverture.NumeroAssure,
+		&couverture.TypeBeneficiaire,
+		&couverture.NumeroAssurePrincipal,
+		&couverture.LienAvecPrincipal,
+		&couverture.EstActif,
+		&couverture.MotifInactivation,
+		&couverture.InactiveLe,
+		&couverture.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &couverture, nil
+}
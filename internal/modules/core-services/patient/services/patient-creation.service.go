@@ -60,7 +60,7 @@ func (s *PatientCreationService) CreatePatient(
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	if !validationResult.IsValid {
-		return nil, fmt.Errorf("invalid patient data: %d errors", len(validationResult.Errors))
+		return nil, dto.NewInvalidPatientDataError(validationResult.Errors)
 	}
 
 	// 2. Vérification anti-doublon
@@ -81,7 +81,7 @@ func (s *PatientCreationService) CreatePatient(
 
 	// Bloquer si duplicata détecté avec score élevé
 	if duplicateResult.ShouldBlock() {
-		return nil, fmt.Errorf("patient duplicate detected with score %d - creation blocked", duplicateResult.HighestScore)
+		return nil, dto.NewPatientDuplicateError(duplicateResult)
 	}
 
 	// 3. Génération du code patient unique
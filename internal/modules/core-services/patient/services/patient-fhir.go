@@ -0,0 +1,535 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+// PatientFhirConverter convertit un dossier patient vers et depuis une ressource FHIR R4 Patient
+// Conversion pure : la résolution des codes référentiels est faite par PatientFhirService
+type PatientFhirConverter struct{}
+
+// NewPatientFhirConverter crée un nouveau convertisseur
+func NewPatientFhirConverter() *PatientFhirConverter {
+	return &PatientFhirConverter{}
+}
+
+// FhirPatientDraft représente une demande de création issue d'une ressource FHIR,
+// avec les codes référentiels restant à traduire en identifiants
+type FhirPatientDraft struct {
+	Request             *dto.CreatePatientRequest
+	NationaliteCode     string
+	SituationCode       string
+	TypePieceCode       *string
+	ContactAffiliations []string // Même ordre que Request.PersonnesAContacter
+}
+
+// Correspondance situation matrimoniale Soins Suite <-> HL7 v3 MaritalStatus
+var fhirMaritalStatusV3 = map[string]string{
+	"CELIBATAIRE": "S",
+	"MARIE":       "M",
+	"DIVORCE":     "D",
+	"VEUF":        "W",
+}
+
+const fhirDateLayout = "2006-01-02"
+
+// Pays de résidence par défaut (cf. CreatePatientRequest.PaysResidence)
+const defaultPaysResidence = "Côte d'Ivoire"
+
+// ToFhir convertit un dossier détaillé en ressource FHIR R4 Patient
+// affiliations : référentiel des liens de parenté indexé par ID (codes des personnes à contacter)
+func (c *PatientFhirConverter) ToFhir(
+	detail *dto.PatientDetailResponse,
+	affiliations map[uuid.UUID]dto.ReferenceInfo,
+) *dto.FhirPatient {
+	patient := detail.Patient
+	active := patient.Statut == dto.PatientStatutActif
+
+	resource := &dto.FhirPatient{
+		ResourceType: "Patient",
+		ID:           patient.CodePatient,
+		Meta:         &dto.FhirMeta{LastUpdated: detail.LastUpdated.Format(time.RFC3339)},
+		Active:       &active,
+		Gender:       c.genderToFhir(patient.Sexe),
+		BirthDate:    patient.DateNaissance.Format(fhirDateLayout),
+	}
+
+	// Identifiants : code patient, CNI/NNI, pièce d'identité
+	resource.Identifier = append(resource.Identifier, dto.FhirIdentifier{
+		Use:    "official",
+		System: dto.FhirSystemCodePatient,
+		Value:  patient.CodePatient,
+	})
+	if detail.CniNni != nil && *detail.CniNni != "" {
+		resource.Identifier = append(resource.Identifier, dto.FhirIdentifier{
+			Use:    "official",
+			System: dto.FhirSystemCniNni,
+			Value:  *detail.CniNni,
+		})
+	}
+	if detail.NumeroPieceIdentite != nil && *detail.NumeroPieceIdentite != "" {
+		identifier := dto.FhirIdentifier{
+			Use:    "secondary",
+			System: dto.FhirSystemPieceIdentite,
+			Value:  *detail.NumeroPieceIdentite,
+		}
+		if detail.TypePieceIdentite != nil {
+			identifier.Type = &dto.FhirCodeableConcept{
+				Coding: []dto.FhirCoding{{
+					System:  dto.FhirSystemTypePieceIdentite,
+					Code:    detail.TypePieceIdentite.Code,
+					Display: detail.TypePieceIdentite.Nom,
+				}},
+				Text: detail.TypePieceIdentite.Nom,
+			}
+		}
+		resource.Identifier = append(resource.Identifier, identifier)
+	}
+
+	// Noms : officiel + nom de jeune fille
+	resource.Name = append(resource.Name, dto.FhirHumanName{
+		Use:    "official",
+		Text:   strings.TrimSpace(patient.Prenoms + " " + patient.Nom),
+		Family: patient.Nom,
+		Given:  strings.Fields(patient.Prenoms),
+	})
+	if detail.NomJeuneFille != nil && *detail.NomJeuneFille != "" {
+		resource.Name = append(resource.Name, dto.FhirHumanName{
+			Use:    "maiden",
+			Family: *detail.NomJeuneFille,
+		})
+	}
+
+	// Télécom
+	resource.Telecom = c.phonesToFhir(patient.TelephonePrincipal, patient.TelephoneSecondaire)
+	if patient.Email != nil && *patient.Email != "" {
+		resource.Telecom = append(resource.Telecom, dto.FhirContactPoint{
+			System: "email",
+			Value:  *patient.Email,
+			Use:    "home",
+		})
+	}
+
+	// Décès
+	if detail.EstDecede && detail.DateDeces != nil {
+		dateDeces := detail.DateDeces.Format(fhirDateLayout)
+		resource.DeceasedDateTime = &dateDeces
+	} else {
+		estDecede := detail.EstDecede
+		resource.DeceasedBoolean = &estDecede
+	}
+
+	// Adresse (commune et quartier en extensions)
+	resource.Address = []dto.FhirAddress{c.addressToFhir(detail)}
+
+	// Situation matrimoniale
+	resource.MaritalStatus = c.maritalStatusToFhir(detail.SituationMatrimoniale)
+
+	// Extensions : nationalité, lieu de naissance, date supposée
+	resource.Extension = append(resource.Extension, dto.FhirExtension{
+		URL: dto.FhirExtensionNationalite,
+		Extension: []dto.FhirExtension{{
+			URL: "code",
+			ValueCodeableConcept: &dto.FhirCodeableConcept{
+				Coding: []dto.FhirCoding{{
+					System:  dto.FhirSystemIso3166,
+					Code:    detail.Nationalite.Code,
+					Display: detail.Nationalite.Nom,
+				}},
+			},
+		}},
+	})
+	if detail.LieuNaissance != nil && *detail.LieuNaissance != "" {
+		resource.Extension = append(resource.Extension, dto.FhirExtension{
+			URL:          dto.FhirExtensionLieuNaissance,
+			ValueAddress: &dto.FhirAddress{Text: *detail.LieuNaissance},
+		})
+	}
+	if patient.EstDateSupposee {
+		supposee := true
+		resource.Extension = append(resource.Extension, dto.FhirExtension{
+			URL:          dto.FhirExtensionDateSupposee,
+			ValueBoolean: &supposee,
+		})
+	}
+
+	// Personnes à contacter
+	for _, personne := range detail.PersonnesAContacter {
+		resource.Contact = append(resource.Contact, c.contactToFhir(personne, affiliations))
+	}
+
+	return resource
+}
+
+// FromFhir convertit une ressource FHIR R4 Patient en demande de création
+// Retourne la liste des problèmes bloquants (vide si la ressource est exploitable)
+func (c *PatientFhirConverter) FromFhir(resource *dto.FhirPatient) (*FhirPatientDraft, []string) {
+	var issues []string
+	req := &dto.CreatePatientRequest{
+		PaysResidence: defaultPaysResidence,
+	}
+	draft := &FhirPatientDraft{Request: req}
+
+	if resource.ResourceType != "Patient" {
+		return nil, []string{fmt.Sprintf("resourceType '%s' non supporté (attendu: Patient)", resource.ResourceType)}
+	}
+
+	// Décès : un dossier est toujours créé actif, le décès passe par la déclaration dédiée
+	if (resource.DeceasedBoolean != nil && *resource.DeceasedBoolean) || resource.DeceasedDateTime != nil {
+		issues = append(issues, "deceased: un patient décédé ne peut pas être importé (utiliser la déclaration de décès)")
+	}
+
+	// Noms
+	for _, name := range resource.Name {
+		switch name.Use {
+		case "maiden":
+			if name.Family != "" {
+				nomJeuneFille := name.Family
+				req.NomJeuneFille = &nomJeuneFille
+			}
+		default:
+			if req.Nom == "" {
+				req.Nom = strings.TrimSpace(name.Family)
+				req.Prenoms = strings.TrimSpace(strings.Join(name.Given, " "))
+			}
+		}
+	}
+	if req.Nom == "" || req.Prenoms == "" {
+		issues = append(issues, "name: nom (family) et prénoms (given) requis")
+	}
+
+	// Sexe
+	switch resource.Gender {
+	case "male":
+		req.Sexe = "M"
+	case "female":
+		req.Sexe = "F"
+	default:
+		issues = append(issues, fmt.Sprintf("gender: valeur '%s' non supportée (male ou female)", resource.Gender))
+	}
+
+	// Date de naissance (dates partielles FHIR acceptées comme date supposée)
+	if dateNaissance, partielle, err := c.parseFhirDate(resource.BirthDate); err != nil {
+		issues = append(issues, fmt.Sprintf("birthDate: %v", err))
+	} else {
+		req.DateNaissance = dateNaissance
+		req.EstDateSupposee = partielle
+	}
+
+	// Identifiants
+	for _, identifier := range resource.Identifier {
+		value := strings.TrimSpace(identifier.Value)
+		if value == "" {
+			continue
+		}
+		switch identifier.System {
+		case dto.FhirSystemCniNni:
+			req.CniNni = &value
+		case dto.FhirSystemPieceIdentite:
+			req.NumeroPieceIdentite = &value
+			if code := identifier.Type.CodeFor(dto.FhirSystemTypePieceIdentite); code != "" {
+				draft.TypePieceCode = &code
+			}
+		}
+	}
+
+	// Télécom : téléphones par rang, premier email
+	phones := c.phonesFromFhir(resource.Telecom)
+	if len(phones) > 0 {
+		req.TelephonePrincipal = phones[0]
+	}
+	if len(phones) > 1 {
+		req.TelephoneSecondaire = &phones[1]
+	}
+	for _, telecom := range resource.Telecom {
+		if telecom.System == "email" && telecom.Value != "" {
+			email := telecom.Value
+			req.Email = &email
+			break
+		}
+	}
+
+	// Adresse
+	if len(resource.Address) > 0 {
+		c.addressFromFhir(c.selectAddress(resource.Address), req)
+	}
+
+	// Situation matrimoniale
+	draft.SituationCode = c.maritalStatusFromFhir(resource.MaritalStatus)
+	if draft.SituationCode == "" {
+		issues = append(issues, "maritalStatus: situation matrimoniale requise")
+	}
+
+	// Extensions
+	for _, extension := range resource.Extension {
+		switch extension.URL {
+		case dto.FhirExtensionNationalite:
+			for _, sub := range extension.Extension {
+				if sub.URL == "code" && sub.ValueCodeableConcept != nil && len(sub.ValueCodeableConcept.Coding) > 0 {
+					draft.NationaliteCode = sub.ValueCodeableConcept.Coding[0].Code
+				}
+			}
+		case dto.FhirExtensionLieuNaissance:
+			if extension.ValueAddress != nil && extension.ValueAddress.Text != "" {
+				lieu := extension.ValueAddress.Text
+				req.LieuNaissance = &lieu
+			}
+		case dto.FhirExtensionDateSupposee:
+			if extension.ValueBoolean != nil && *extension.ValueBoolean {
+				req.EstDateSupposee = true
+			}
+		}
+	}
+	if draft.NationaliteCode == "" {
+		issues = append(issues, "extension patient-nationality: nationalité requise")
+	}
+
+	// Personnes à contacter
+	for i, contact := range resource.Contact {
+		personne, affiliation, contactIssues := c.contactFromFhir(i, contact)
+		issues = append(issues, contactIssues...)
+		if len(contactIssues) == 0 {
+			req.PersonnesAContacter = append(req.PersonnesAContacter, personne)
+			draft.ContactAffiliations = append(draft.ContactAffiliations, affiliation)
+		}
+	}
+
+	return draft, issues
+}
+
+func (c *PatientFhirConverter) genderToFhir(sexe string) string {
+	switch sexe {
+	case "M":
+		return "male"
+	case "F":
+		return "female"
+	default:
+		return "unknown"
+	}
+}
+
+func (c *PatientFhirConverter) phonesToFhir(principal string, secondaire *string) []dto.FhirContactPoint {
+	var telecom []dto.FhirContactPoint
+	if principal != "" {
+		telecom = append(telecom, dto.FhirContactPoint{System: "phone", Value: principal, Use: "mobile", Rank: 1})
+	}
+	if secondaire != nil && *secondaire != "" {
+		telecom = append(telecom, dto.FhirContactPoint{System: "phone", Value: *secondaire, Use: "mobile", Rank: 2})
+	}
+	return telecom
+}
+
+// phonesFromFhir retourne les téléphones triés par rang (rang absent = en dernier)
+func (c *PatientFhirConverter) phonesFromFhir(telecom []dto.FhirContactPoint) []string {
+	var ranked, unranked []dto.FhirContactPoint
+	for _, point := range telecom {
+		if point.System != "phone" || strings.TrimSpace(point.Value) == "" {
+			continue
+		}
+		if point.Rank > 0 {
+			ranked = append(ranked, point)
+		} else {
+			unranked = append(unranked, point)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Rank < ranked[j].Rank
+	})
+
+	var phones []string
+	for _, point := range append(ranked, unranked...) {
+		phones = append(phones, strings.TrimSpace(point.Value))
+	}
+	return phones
+}
+
+func (c *PatientFhirConverter) addressToFhir(detail *dto.PatientDetailResponse) dto.FhirAddress {
+	address := dto.FhirAddress{
+		Use:     "home",
+		Type:    "physical",
+		Text:    detail.Patient.AdresseComplete,
+		Country: detail.PaysResidence,
+	}
+	if detail.Ville != nil {
+		address.City = *detail.Ville
+	}
+	if detail.Commune != nil && *detail.Commune != "" {
+		commune := *detail.Commune
+		address.Extension = append(address.Extension, dto.FhirExtension{
+			URL:         dto.FhirExtensionCommune,
+			ValueString: &commune,
+		})
+	}
+	if detail.Quartier != nil && *detail.Quartier != "" {
+		quartier := *detail.Quartier
+		address.Extension = append(address.Extension, dto.FhirExtension{
+			URL:         dto.FhirExtensionQuartier,
+			ValueString: &quartier,
+		})
+	}
+	return address
+}
+
+// selectAddress privilégie l'adresse de domicile
+func (c *PatientFhirConverter) selectAddress(addresses []dto.FhirAddress) dto.FhirAddress {
+	for _, address := range addresses {
+		if address.Use == "home" {
+			return address
+		}
+	}
+	return addresses[0]
+}
+
+func (c *PatientFhirConverter) addressFromFhir(address dto.FhirAddress, req *dto.CreatePatientRequest) {
+	req.AdresseComplete = strings.TrimSpace(address.Text)
+	if req.AdresseComplete == "" {
+		req.AdresseComplete = strings.TrimSpace(strings.Join(address.Line, ", "))
+	}
+	if address.City != "" {
+		ville := address.City
+		req.Ville = &ville
+	}
+	if address.Country != "" {
+		req.PaysResidence = address.Country
+	}
+	for _, extension := range address.Extension {
+		if extension.ValueString == nil || *extension.ValueString == "" {
+			continue
+		}
+		value := *extension.ValueString
+		switch extension.URL {
+		case dto.FhirExtensionCommune:
+			req.Commune = &value
+		case dto.FhirExtensionQuartier:
+			req.Quartier = &value
+		}
+	}
+}
+
+func (c *PatientFhirConverter) maritalStatusToFhir(situation dto.ReferenceInfo) *dto.FhirCodeableConcept {
+	concept := &dto.FhirCodeableConcept{
+		Coding: []dto.FhirCoding{{
+			System:  dto.FhirSystemSituationMatrimoniale,
+			Code:    situation.Code,
+			Display: situation.Nom,
+		}},
+		Text: situation.Nom,
+	}
+	if code, ok := fhirMaritalStatusV3[situation.Code]; ok {
+		concept.Coding = append(concept.Coding, dto.FhirCoding{
+			System: dto.FhirSystemV3MaritalStatus,
+			Code:   code,
+		})
+	}
+	return concept
+}
+
+// maritalStatusFromFhir retourne le code Soins Suite (codification locale prioritaire, sinon HL7 v3)
+func (c *PatientFhirConverter) maritalStatusFromFhir(concept *dto.FhirCodeableConcept) string {
+	if code := concept.CodeFor(dto.FhirSystemSituationMatrimoniale); code != "" {
+		return code
+	}
+	if v3 := concept.CodeFor(dto.FhirSystemV3MaritalStatus); v3 != "" {
+		for code, v3Code := range fhirMaritalStatusV3 {
+			if v3Code == v3 {
+				return code
+			}
+		}
+	}
+	return ""
+}
+
+func (c *PatientFhirConverter) contactToFhir(
+	personne dto.PersonneContactDetail,
+	affiliations map[uuid.UUID]dto.ReferenceInfo,
+) dto.FhirPatientContact {
+	relationship := dto.FhirCodeableConcept{
+		Coding: []dto.FhirCoding{{
+			System:  dto.FhirSystemV2ContactRole,
+			Code:    "C",
+			Display: "Emergency Contact",
+		}},
+	}
+	if affiliation, ok := affiliations[personne.Affiliation.ID]; ok {
+		relationship.Coding = append(relationship.Coding, dto.FhirCoding{
+			System:  dto.FhirSystemAffiliation,
+			Code:    affiliation.Code,
+			Display: affiliation.Nom,
+		})
+		relationship.Text = affiliation.Nom
+	}
+
+	return dto.FhirPatientContact{
+		Relationship: []dto.FhirCodeableConcept{relationship},
+		Name:         &dto.FhirHumanName{Text: personne.NomPrenoms},
+		Telecom:      c.phonesToFhir(personne.Telephone, personne.TelephoneSecondaire),
+	}
+}
+
+func (c *PatientFhirConverter) contactFromFhir(
+	index int,
+	contact dto.FhirPatientContact,
+) (dto.PersonneContact, string, []string) {
+	var issues []string
+	var personne dto.PersonneContact
+
+	if contact.Name != nil {
+		personne.NomPrenoms = contact.Name.FullText()
+	}
+	if personne.NomPrenoms == "" {
+		issues = append(issues, fmt.Sprintf("contact[%d].name: nom requis", index))
+	}
+
+	phones := c.phonesFromFhir(contact.Telecom)
+	if len(phones) == 0 {
+		issues = append(issues, fmt.Sprintf("contact[%d].telecom: téléphone requis", index))
+	} else {
+		personne.Telephone = phones[0]
+		if len(phones) > 1 {
+			personne.TelephoneSecondaire = &phones[1]
+		}
+	}
+
+	affiliation := ""
+	for i := range contact.Relationship {
+		if code := contact.Relationship[i].CodeFor(dto.FhirSystemAffiliation); code != "" {
+			affiliation = code
+			break
+		}
+	}
+	if affiliation == "" {
+		issues = append(issues, fmt.Sprintf("contact[%d].relationship: lien de parenté (%s) requis", index, dto.FhirSystemAffiliation))
+	}
+
+	return personne, affiliation, issues
+}
+
+// parseFhirDate accepte les dates FHIR complètes (YYYY-MM-DD) ou partielles (YYYY-MM, YYYY)
+func (c *PatientFhirConverter) parseFhirDate(value string) (time.Time, bool, error) {
+	layouts := []struct {
+		layout    string
+		partielle bool
+	}{
+		{fhirDateLayout, false},
+		{"2006-01", true},
+		{"2006", true},
+	}
+
+	for _, l := range layouts {
+		if len(value) == len(l.layout) {
+			if date, err := time.Parse(l.layout, value); err == nil {
+				return date, l.partielle, nil
+			}
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("date '%s' invalide (formats: YYYY-MM-DD, YYYY-MM, YYYY)", value)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientFhirService expose les dossiers patients au format FHIR R4 Patient (laboratoires, hôpitaux partenaires)
+type PatientFhirService struct {
+	db              *postgres.Client
+	cacheService    *PatientCacheService
+	creationService *PatientCreationService
+	converter       *PatientFhirConverter
+}
+
+// NewPatientFhirService crée une nouvelle instance du service
+func NewPatientFhirService(
+	db *postgres.Client,
+	cacheService *PatientCacheService,
+	creationService *PatientCreationService,
+) *PatientFhirService {
+	return &PatientFhirService{
+		db:              db,
+		cacheService:    cacheService,
+		creationService: creationService,
+		converter:       NewPatientFhirConverter(),
+	}
+}
+
+// ExportPatient retourne le dossier patient sous forme de ressource FHIR R4 Patient
+// Les dossiers archivés restent exportables (statut reflété par active=false)
+func (s *PatientFhirService) ExportPatient(
+	ctx context.Context,
+	codePatient string,
+	acces *dto.PatientAccessContext,
+) (*dto.FhirPatient, error) {
+	detail, err := s.cacheService.GetPatientByCode(ctx, &dto.GetPatientByCodeRequest{
+		CodePatient:             codePatient,
+		IncludeInactive:         true,
+		IncludePersonnesContact: true,
+		Acces:                   withAccessType(acces, dto.AccessTypeExportFhir),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	affiliations, err := s.loadAffiliations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]dto.ReferenceInfo, len(affiliations))
+	for _, affiliation := range affiliations {
+		byID[affiliation.ID] = affiliation
+	}
+
+	fmt.Printf("[AUDIT] Patient exported to FHIR - Code: %s\n", detail.Patient.CodePatient)
+
+	return s.converter.ToFhir(detail, byID), nil
+}
+
+// ImportPatient crée un patient à partir d'une ressource FHIR R4 Patient
+// La création passe par le circuit standard (PatientValidationService : validation + anti-doublon, puis génération du code)
+func (s *PatientFhirService) ImportPatient(
+	ctx context.Context,
+	etablissementCode string,
+	etablissementID uuid.UUID,
+	resource *dto.FhirPatient,
+	userID uuid.UUID,
+) (*dto.PatientCreationResult, error) {
+	draft, issues := s.converter.FromFhir(resource)
+	if draft == nil {
+		return nil, dto.NewFhirImportError(issues)
+	}
+
+	// Traduction des codes référentiels en identifiants
+	referenceIssues, err := s.resolveReferences(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, referenceIssues...)
+
+	if len(issues) > 0 {
+		return nil, dto.NewFhirImportError(issues)
+	}
+
+	result, err := s.creationService.CreatePatient(ctx, etablissementCode, etablissementID, draft.Request, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Patient imported from FHIR - Code: %s, By: %s\n", result.Patient.CodePatient, userID)
+
+	return result, nil
+}
+
+// resolveReferences renseigne les identifiants nationalité, situation matrimoniale, type de pièce et affiliations
+func (s *PatientFhirService) resolveReferences(ctx context.Context, draft *FhirPatientDraft) ([]string, error) {
+	var issues []string
+	var nationaliteID, situationID, typePieceID *uuid.UUID
+
+	if err := s.db.QueryRow(ctx,
+		queries.PatientFhirQueries.ResolveReferenceCodes,
+		draft.NationaliteCode,
+		draft.SituationCode,
+		draft.TypePieceCode,
+	).Scan(&nationaliteID, &situationID, &typePieceID); err != nil {
+		return nil, fmt.Errorf("failed to resolve FHIR reference codes: %w", err)
+	}
+
+	if nationaliteID != nil {
+		draft.Request.NationaliteID = *nationaliteID
+	} else if draft.NationaliteCode != "" {
+		issues = append(issues, fmt.Sprintf("extension patient-nationality: code '%s' inconnu", draft.NationaliteCode))
+	}
+
+	if situationID != nil {
+		draft.Request.SituationMatrimonialeID = *situationID
+	} else if draft.SituationCode != "" {
+		issues = append(issues, fmt.Sprintf("maritalStatus: code '%s' inconnu", draft.SituationCode))
+	}
+
+	if draft.TypePieceCode != nil {
+		if typePieceID == nil {
+			issues = append(issues, fmt.Sprintf("identifier piece-identite: type '%s' inconnu", *draft.TypePieceCode))
+		}
+		draft.Request.TypePieceIdentiteID = typePieceID
+	}
+
+	if len(draft.ContactAffiliations) == 0 {
+		return issues, nil
+	}
+
+	affiliations, err := s.loadAffiliations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]uuid.UUID, len(affiliations))
+	for _, affiliation := range affiliations {
+		byCode[affiliation.Code] = affiliation.ID
+	}
+
+	for i, code := range draft.ContactAffiliations {
+		id, ok := byCode[code]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("contact[%d].relationship: affiliation '%s' inconnue", i, code))
+			continue
+		}
+		draft.Request.PersonnesAContacter[i].AffiliationID = id
+	}
+
+	return issues, nil
+}
+
+// loadAffiliations charge le référentiel des liens de parenté
+func (s *PatientFhirService) loadAffiliations(ctx context.Context) ([]dto.ReferenceInfo, error) {
+	rows, err := s.db.Query(ctx, queries.PatientFhirQueries.ListAffiliations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load affiliations: %w", err)
+	}
+	defer rows.Close()
+
+	var affiliations []dto.ReferenceInfo
+	for rows.Next() {
+		var affiliation dto.ReferenceInfo
+		if err := rows.Scan(&affiliation.ID, &affiliation.Code, &affiliation.Nom); err != nil {
+			return nil, fmt.Errorf("failed to scan affiliation: %w", err)
+		}
+		affiliations = append(affiliations, affiliation)
+	}
+
+	return affiliations, rows.Err()
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientHistoryService gère l'historique append-only des modifications patient
+type PatientHistoryService struct {
+	db *postgres.Client
+}
+
+// NewPatientHistoryService crée une nouvelle instance du service
+func NewPatientHistoryService(db *postgres.Client) *PatientHistoryService {
+	return &PatientHistoryService{
+		db: db,
+	}
+}
+
+// Colonnes techniques exclues du diff (gérées automatiquement)
+var historyIgnoredFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"created_by": true,
+	"updated_at": true,
+	"updated_by": true,
+}
+
+// LockSnapshot verrouille un dossier modifiable et retourne son état avant modification
+// Retourne pgx.ErrNoRows si le dossier est inexistant ou archivé
+func (s *PatientHistoryService) LockSnapshot(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	codePatient string,
+) (uuid.UUID, map[string]interface{}, error) {
+	var patientID uuid.UUID
+	var snapshot map[string]interface{}
+
+	err := tx.QueryRow(ctx,
+		queries.PatientHistoryQueries.LockPatientSnapshotByCode,
+		codePatient,
+	).Scan(&patientID, &snapshot)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return patientID, snapshot, nil
+}
+
+// Snapshot retourne l'état courant d'un dossier dans la transaction
+func (s *PatientHistoryService) Snapshot(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+) (map[string]interface{}, error) {
+	var snapshot map[string]interface{}
+
+	if err := tx.QueryRow(ctx,
+		queries.PatientHistoryQueries.GetPatientSnapshotByID,
+		patientID,
+	).Scan(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to load patient snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// RecordChanges calcule le diff avant/après et l'historise (aucune entrée si rien n'a changé)
+func (s *PatientHistoryService) RecordChanges(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+	codePatient string,
+	typeModification string,
+	avant map[string]interface{},
+	apres map[string]interface{},
+	motif string,
+	userID uuid.UUID,
+) error {
+	modifications := s.diffSnapshots(avant, apres)
+	if len(modifications) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(modifications)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patient history: %w", err)
+	}
+
+	if err := tx.Exec(ctx,
+		queries.PatientHistoryQueries.InsertHistoryEntry,
+		patientID,
+		codePatient,
+		typeModification,
+		string(data),
+		motif,
+		userID,
+	); err != nil {
+		return fmt.Errorf("failed to insert patient history: %w", err)
+	}
+
+	return nil
+}
+
+// GetPatientHistory retourne la chronologie paginée des modifications d'un patient
+func (s *PatientHistoryService) GetPatientHistory(
+	ctx context.Context,
+	codePatient string,
+	filter *dto.PatientHistoryFilter,
+) (*dto.PatientHistoryResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	patientID, _, _, err := s.loadCurrentSnapshot(ctx, codePatient)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PatientHistoryQueries.CountHistory,
+		patientID,
+		filter.Champ,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count patient history: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientHistoryQueries.ListHistory,
+		patientID,
+		filter.Champ,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list patient history: %w", err)
+	}
+	defer rows.Close()
+
+	historique := []dto.PatientHistoryEntry{}
+	for rows.Next() {
+		var entry dto.PatientHistoryEntry
+		var modificationsJSON string
+		var userID *uuid.UUID
+		var userNom, userPrenoms *string
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.TypeModification,
+			&modificationsJSON,
+			&entry.Motif,
+			&entry.ModifieLe,
+			&userID, &userNom, &userPrenoms,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan patient history: %w", err)
+		}
+
+		if err := json.Unmarshal([]byte(modificationsJSON), &entry.Modifications); err != nil {
+			return nil, fmt.Errorf("failed to parse patient history: %w", err)
+		}
+		entry.ModifiePar = buildUserInfo(userID, userNom, userPrenoms)
+		historique = append(historique, entry)
+	}
+
+	return &dto.PatientHistoryResponse{
+		CodePatient: codePatient,
+		Historique:  historique,
+		Pagination:  dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// GetPatientStateAt reconstitue le dossier tel qu'il était à une date donnée
+// Part de l'état courant et annule les modifications postérieures, de la plus récente à la plus ancienne
+func (s *PatientHistoryService) GetPatientStateAt(
+	ctx context.Context,
+	codePatient string,
+	date time.Time,
+) (*dto.PatientStateAtDateResponse, error) {
+	patientID, createdAt, etat, err := s.loadCurrentSnapshot(ctx, codePatient)
+	if err != nil {
+		return nil, err
+	}
+
+	if date.Before(createdAt) {
+		return nil, dto.NewPatientNotExistingAtDateError(codePatient, createdAt)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientHistoryQueries.ListHistorySince,
+		patientID,
+		date,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load patient history: %w", err)
+	}
+	defer rows.Close()
+
+	annulees := 0
+	for rows.Next() {
+		var modificationsJSON string
+		if err := rows.Scan(&modificationsJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan patient history: %w", err)
+		}
+
+		var modifications map[string]dto.FieldChange
+		if err := json.Unmarshal([]byte(modificationsJSON), &modifications); err != nil {
+			return nil, fmt.Errorf("failed to parse patient history: %w", err)
+		}
+
+		for champ, change := range modifications {
+			etat[champ] = change.Avant
+		}
+		annulees++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read patient history: %w", err)
+	}
+
+	// updated_at/updated_by reflètent l'état courant et ne sont pas historisés
+	delete(etat, "updated_at")
+	delete(etat, "updated_by")
+
+	return &dto.PatientStateAtDateResponse{
+		CodePatient:           codePatient,
+		Date:                  date,
+		Etat:                  etat,
+		ModificationsAnnulees: annulees,
+	}, nil
+}
+
+// loadCurrentSnapshot charge l'état courant d'un dossier (archivés inclus, pour consultation)
+func (s *PatientHistoryService) loadCurrentSnapshot(
+	ctx context.Context,
+	codePatient string,
+) (uuid.UUID, time.Time, map[string]interface{}, error) {
+	var patientID uuid.UUID
+	var createdAt time.Time
+	var snapshot map[string]interface{}
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientHistoryQueries.GetPatientSnapshotByCode,
+		codePatient,
+	).Scan(&patientID, &createdAt, &snapshot)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, time.Time{}, nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return uuid.Nil, time.Time{}, nil, fmt.Errorf("failed to load patient: %w", err)
+	}
+
+	return patientID, createdAt, snapshot, nil
+}
+
+// diffSnapshots retourne les champs dont la valeur a changé entre deux états
+func (s *PatientHistoryService) diffSnapshots(avant, apres map[string]interface{}) map[string]dto.FieldChange {
+	modifications := make(map[string]dto.FieldChange)
+
+	for champ, valeurApres := range apres {
+		if historyIgnoredFields[champ] {
+			continue
+		}
+		valeurAvant := avant[champ]
+		if !reflect.DeepEqual(valeurAvant, valeurApres) {
+			modifications[champ] = dto.FieldChange{Avant: valeurAvant, Apres: valeurApres}
+		}
+	}
+
+	return modifications
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientLifecycleService gère les transitions de statut d'un dossier patient
+// (déclaration de décès, désactivation, archivage, réactivation)
+type PatientLifecycleService struct {
+	db             *postgres.Client
+	txManager      *postgres.TransactionManager
+	cacheService   *PatientCacheService
+	historyService *PatientHistoryService
+}
+
+// NewPatientLifecycleService crée une nouvelle instance du service
+func NewPatientLifecycleService(
+	db *postgres.Client,
+	cacheService *PatientCacheService,
+	historyService *PatientHistoryService,
+) *PatientLifecycleService {
+	return &PatientLifecycleService{
+		db:             db,
+		txManager:      postgres.NewTransactionManager(db),
+		cacheService:   cacheService,
+		historyService: historyService,
+	}
+}
+
+// lifecycleTransitions liste les transitions autorisées : statut actuel -> statuts cibles
+// Un décès est irréversible : un dossier décédé ne peut qu'être archivé
+var lifecycleTransitions = map[string]map[string]bool{
+	dto.PatientStatutActif: {
+		dto.PatientStatutInactif: true,
+		dto.PatientStatutDecede:  true,
+		dto.PatientStatutArchive: true,
+	},
+	dto.PatientStatutInactif: {
+		dto.PatientStatutActif:   true,
+		dto.PatientStatutDecede:  true,
+		dto.PatientStatutArchive: true,
+	},
+	dto.PatientStatutDecede: {
+		dto.PatientStatutArchive: true,
+	},
+	dto.PatientStatutArchive: {
+		dto.PatientStatutActif: true,
+	},
+}
+
+// lifecycleState représente l'état d'un dossier verrouillé pendant une transition
+type lifecycleState struct {
+	ID            uuid.UUID
+	Statut        string
+	EstDecede     bool
+	DateNaissance time.Time
+	FusionneVers  *uuid.UUID
+	EstAnonymise  bool
+}
+
+// DeclareDeath enregistre le décès d'un patient certifié par un médecin de l'établissement
+func (s *PatientLifecycleService) DeclareDeath(
+	ctx context.Context,
+	codePatient string,
+	req *dto.DeclareDeathRequest,
+	etablissementID uuid.UUID,
+	userID uuid.UUID,
+) (*dto.PatientLifecycleResult, error) {
+	if req.DateDeces.After(time.Now()) {
+		return nil, dto.NewPatientLifecycleError(dto.ErrLifecycleDateDecesInvalide,
+			"La date de décès ne peut pas être dans le futur", "", dto.PatientStatutDecede)
+	}
+
+	var medecinValide bool
+	if err := s.db.QueryRow(ctx,
+		queries.PatientLifecycleQueries.ValidateMedecinDeclarant,
+		req.MedecinDeclarantID,
+		etablissementID,
+	).Scan(&medecinValide); err != nil {
+		return nil, fmt.Errorf("failed to validate declaring physician: %w", err)
+	}
+	if !medecinValide {
+		return nil, dto.NewPatientLifecycleError(dto.ErrLifecycleMedecinInvalide,
+			"Le déclarant doit être un médecin actif de l'établissement", "", dto.PatientStatutDecede)
+	}
+
+	motif := req.Motif
+	if motif == "" {
+		motif = "Déclaration de décès"
+	}
+
+	return s.transition(ctx, codePatient, dto.HistoryTypeDeclarationDeces, dto.PatientStatutDecede, motif, userID,
+		func(tx *postgres.Transaction, state *lifecycleState) (time.Time, error) {
+			if req.DateDeces.Before(state.DateNaissance) {
+				return time.Time{}, dto.NewPatientLifecycleError(dto.ErrLifecycleDateDecesInvalide,
+					"La date de décès est antérieure à la date de naissance", state.Statut, dto.PatientStatutDecede)
+			}
+
+			var effectueLe time.Time
+			err := tx.QueryRow(ctx,
+				queries.PatientLifecycleQueries.DeclareDeath,
+				state.ID,
+				req.DateDeces,
+				req.CauseDeces,
+				req.MedecinDeclarantID,
+				userID,
+			).Scan(&effectueLe)
+			return effectueLe, err
+		})
+}
+
+// Deactivate passe un dossier actif au statut inactif
+func (s *PatientLifecycleService) Deactivate(
+	ctx context.Context,
+	codePatient string,
+	req *dto.LifecycleTransitionRequest,
+	userID uuid.UUID,
+) (*dto.PatientLifecycleResult, error) {
+	return s.transition(ctx, codePatient, dto.HistoryTypeDesactivation, dto.PatientStatutInactif, req.Motif, userID,
+		s.updateStatus(ctx, dto.PatientStatutInactif, userID))
+}
+
+// Archive archive un dossier (actif, inactif ou décédé)
+func (s *PatientLifecycleService) Archive(
+	ctx context.Context,
+	codePatient string,
+	req *dto.LifecycleTransitionRequest,
+	userID uuid.UUID,
+) (*dto.PatientLifecycleResult, error) {
+	return s.transition(ctx, codePatient, dto.HistoryTypeArchivage, dto.PatientStatutArchive, req.Motif, userID,
+		s.updateStatus(ctx, dto.PatientStatutArchive, userID))
+}
+
+// Reactivate remet un dossier inactif ou archivé au statut actif
+// Refusé pour un patient décédé et pour un dossier fusionné (passer par l'annulation de fusion)
+func (s *PatientLifecycleService) Reactivate(
+	ctx context.Context,
+	codePatient string,
+	req *dto.LifecycleTransitionRequest,
+	userID uuid.UUID,
+) (*dto.PatientLifecycleResult, error) {
+	return s.transition(ctx, codePatient, dto.HistoryTypeReactivation, dto.PatientStatutActif, req.Motif, userID,
+		s.updateStatus(ctx, dto.PatientStatutActif, userID))
+}
+
+// transition verrouille le dossier, contrôle la légalité du changement, l'applique et l'historise
+func (s *PatientLifecycleService) transition(
+	ctx context.Context,
+	codePatient string,
+	typeTransition string,
+	statutCible string,
+	motif string,
+	userID uuid.UUID,
+	apply func(tx *postgres.Transaction, state *lifecycleState) (time.Time, error),
+) (*dto.PatientLifecycleResult, error) {
+	var result *dto.PatientLifecycleResult
+
+	err := s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		state, err := s.lockState(ctx, tx, codePatient)
+		if err != nil {
+			return err
+		}
+
+		// Un dossier anonymisé ne reçoit plus de nouvelles données : ni réactivation ni déclaration de décès
+		if state.EstAnonymise && statutCible != dto.PatientStatutInactif && statutCible != dto.PatientStatutArchive {
+			return dto.NewPatientAnonymizedError(codePatient)
+		}
+
+		if err := s.checkTransition(state, statutCible); err != nil {
+			return err
+		}
+
+		avant, err := s.historyService.Snapshot(ctx, tx, state.ID)
+		if err != nil {
+			return err
+		}
+
+		effectueLe, err := apply(tx, state)
+		if err != nil {
+			return err
+		}
+
+		apres, err := s.historyService.Snapshot(ctx, tx, state.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.historyService.RecordChanges(ctx, tx,
+			state.ID, codePatient, typeTransition,
+			avant, apres, motif, userID); err != nil {
+			return err
+		}
+
+		result = &dto.PatientLifecycleResult{
+			CodePatient:     codePatient,
+			Transition:      typeTransition,
+			StatutPrecedent: state.Statut,
+			Statut:          statutCible,
+			EffectueLe:      effectueLe,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCaches(ctx, codePatient)
+
+	fmt.Printf("[AUDIT] Patient status changed - Code: %s, Transition: %s, %s -> %s, By: %s\n",
+		codePatient, typeTransition, result.StatutPrecedent, result.Statut, userID)
+
+	return result, nil
+}
+
+// checkTransition refuse les transitions absentes de la table et les réactivations impossibles
+func (s *PatientLifecycleService) checkTransition(state *lifecycleState, statutCible string) error {
+	if statutCible == dto.PatientStatutActif {
+		if state.EstDecede {
+			return dto.NewPatientLifecycleError(dto.ErrLifecyclePatientDecede,
+				"Un patient décédé ne peut pas être réactivé", state.Statut, statutCible)
+		}
+		if state.FusionneVers != nil {
+			return dto.NewPatientLifecycleError(dto.ErrLifecyclePatientFusionne,
+				"Ce dossier a été fusionné : la réactivation passe par l'annulation de la fusion", state.Statut, statutCible)
+		}
+	}
+
+	if !lifecycleTransitions[state.Statut][statutCible] {
+		return dto.NewPatientLifecycleError(dto.ErrLifecycleTransitionInterdite,
+			fmt.Sprintf("Transition de statut interdite : %s -> %s", state.Statut, statutCible),
+			state.Statut, statutCible)
+	}
+
+	return nil
+}
+
+// lockState verrouille le dossier et charge son état de cycle de vie
+func (s *PatientLifecycleService) lockState(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	codePatient string,
+) (*lifecycleState, error) {
+	var state lifecycleState
+
+	err := tx.QueryRow(ctx,
+		queries.PatientLifecycleQueries.LockPatientStatus,
+		codePatient,
+	).Scan(
+		&state.ID,
+		&state.Statut,
+		&state.EstDecede,
+		&state.DateNaissance,
+		&state.FusionneVers,
+		&state.EstAnonymise,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return nil, fmt.Errorf("failed to lock patient %s: %w", codePatient, err)
+	}
+
+	return &state, nil
+}
+
+// updateStatus construit l'application d'un simple changement de statut
+func (s *PatientLifecycleService) updateStatus(
+	ctx context.Context,
+	statut string,
+	userID uuid.UUID,
+) func(tx *postgres.Transaction, state *lifecycleState) (time.Time, error) {
+	return func(tx *postgres.Transaction, state *lifecycleState) (time.Time, error) {
+		var effectueLe time.Time
+		err := tx.QueryRow(ctx,
+			queries.PatientLifecycleQueries.UpdateStatus,
+			state.ID,
+			statut,
+			userID,
+		).Scan(&effectueLe)
+		return effectueLe, err
+	}
+}
+
+// invalidateCaches invalide le cache détail et les recherches en cache du patient (best effort)
+func (s *PatientLifecycleService) invalidateCaches(ctx context.Context, codePatient string) {
+	if err := s.cacheService.InvalidatePatientCache(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+	if err := s.cacheService.InvalidateSearchCaches(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient search cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+}
@@ -0,0 +1,502 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+// PatientMatchingEngine calcule côté Go la similarité entre une identité saisie et un dossier existant
+// Couvre les cas réels du guichet : nom/prénoms inversés, graphies phonétiques
+// (Kouadio/Kwadio, N'Guessan/Nguessan), inversion jour/mois et chiffres transposés
+type PatientMatchingEngine struct {
+	accentReplacer *strings.Replacer
+	phoneticRules  []phoneticRule
+}
+
+// phoneticRule représente une substitution de la clé phonétique
+type phoneticRule struct {
+	from string
+	to   string
+}
+
+// Pondérations du score global (identiques à l'ancien scoring SQL)
+const (
+	matchWeightNoms      = 0.4
+	matchWeightDate      = 0.4
+	matchWeightTelephone = 0.2
+)
+
+// Année minimale d'une variante de date de naissance
+const minBirthYearVariant = 1800
+
+// NewPatientMatchingEngine crée une nouvelle instance du moteur de rapprochement
+func NewPatientMatchingEngine() *PatientMatchingEngine {
+	return &PatientMatchingEngine{
+		accentReplacer: strings.NewReplacer(
+			"À", "A", "Â", "A", "Ä", "A", "Á", "A", "Ã", "A",
+			"Ç", "C",
+			"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+			"Î", "I", "Ï", "I", "Í", "I",
+			"Ô", "O", "Ö", "O", "Ó", "O",
+			"Ù", "U", "Û", "U", "Ü", "U", "Ú", "U",
+			"Ÿ", "Y", "Ñ", "N", "Æ", "AE", "Œ", "OE",
+			"'", "", "’", "", "`", "", "´", "",
+			"-", " ", ".", " ", ",", " ",
+		),
+		// Ordre significatif : les digrammes sont traités avant les lettres isolées
+		phoneticRules: []phoneticRule{
+			{"TCH", "X"}, {"CH", "X"}, {"SH", "X"},
+			{"DJ", "J"}, {"DZ", "J"},
+			{"PH", "F"}, {"GH", "G"}, {"TH", "T"},
+			{"QU", "K"}, {"CK", "K"}, {"Q", "K"},
+			{"GUE", "GE"}, {"GUI", "GI"},
+			{"CE", "SE"}, {"CI", "SI"}, {"CY", "SI"}, {"C", "K"},
+			{"EAU", "O"}, {"AU", "O"},
+			{"OU", "U"}, {"W", "U"},
+			{"AI", "E"}, {"EI", "E"},
+			{"Y", "I"}, {"Z", "S"},
+			{"H", ""},
+		},
+	}
+}
+
+// ScoreCandidate compare l'identité saisie à un dossier candidat et explique chaque signal
+func (e *PatientMatchingEngine) ScoreCandidate(
+	req *dto.DuplicateCheckRequest,
+	candidate *dto.PatientSearchResult,
+) dto.MatchDetail {
+	var detail dto.MatchDetail
+
+	// 1. Noms : comparaison directe puis croisée (nom ↔ prénoms)
+	nomDirect, nomSignal := e.compareNames(req.Nom, candidate.Nom)
+	prenomsDirect, prenomsSignal := e.compareNames(req.Prenoms, candidate.Prenoms)
+	nomCroise, nomCroiseSignal := e.compareNames(req.Nom, candidate.Prenoms)
+	prenomsCroise, prenomsCroiseSignal := e.compareNames(req.Prenoms, candidate.Nom)
+
+	scoreNoms := (nomDirect + prenomsDirect) / 2
+	if scoreCroise := (nomCroise + prenomsCroise) / 2; scoreCroise > scoreNoms+5 {
+		scoreNoms = scoreCroise
+		detail.NomMatch = nomCroise
+		detail.PrenomsMatch = prenomsCroise
+		detail.InversionNomPrenoms = true
+		detail.Signaux = append(detail.Signaux,
+			dto.NewMatchSignal(dto.MatchSignalInversionNomPrenoms, scoreCroise,
+				"Nom et prénoms saisis en ordre inverse"),
+			dto.NewMatchSignal(dto.MatchSignalNom, nomCroise, nomCroiseSignal),
+			dto.NewMatchSignal(dto.MatchSignalPrenoms, prenomsCroise, prenomsCroiseSignal),
+		)
+	} else {
+		detail.NomMatch = nomDirect
+		detail.PrenomsMatch = prenomsDirect
+		detail.Signaux = append(detail.Signaux,
+			dto.NewMatchSignal(dto.MatchSignalNom, nomDirect, nomSignal),
+			dto.NewMatchSignal(dto.MatchSignalPrenoms, prenomsDirect, prenomsSignal),
+		)
+	}
+
+	// 2. Date de naissance (inversion jour/mois, année transposée, écart)
+	scoreDate, dateSignal, inversionJourMois := e.compareDates(req.DateNaissance, candidate.DateNaissance)
+	detail.DateNaissanceMatch = scoreDate
+	detail.InversionJourMois = inversionJourMois
+	detail.Signaux = append(detail.Signaux,
+		dto.NewMatchSignal(dto.MatchSignalDateNaissance, scoreDate, dateSignal))
+
+	// 3. Téléphone (optionnel) - poids redistribué s'il n'est pas fourni
+	if req.TelephonePrincipal != nil && strings.TrimSpace(*req.TelephonePrincipal) != "" {
+		scoreTelephone, telephoneSignal := e.comparePhones(*req.TelephonePrincipal, candidate.TelephonePrincipal)
+		detail.TelephoneMatch = scoreTelephone
+		detail.Signaux = append(detail.Signaux,
+			dto.NewMatchSignal(dto.MatchSignalTelephone, scoreTelephone, telephoneSignal))
+
+		detail.ScoreGlobal = int(float64(scoreNoms)*matchWeightNoms +
+			float64(scoreDate)*matchWeightDate +
+			float64(scoreTelephone)*matchWeightTelephone)
+	} else {
+		poids := matchWeightNoms + matchWeightDate
+		detail.ScoreGlobal = int(float64(scoreNoms)*matchWeightNoms/poids +
+			float64(scoreDate)*matchWeightDate/poids)
+	}
+
+	return detail
+}
+
+// SwappedBirthDate retourne la date avec jour et mois inversés (nil si l'inversion est impossible ou neutre)
+func (e *PatientMatchingEngine) SwappedBirthDate(date time.Time) *time.Time {
+	jour, mois := date.Day(), int(date.Month())
+	if jour > 12 || jour == mois {
+		return nil
+	}
+	swapped := time.Date(date.Year(), time.Month(jour), mois, 0, 0, 0, 0, time.UTC)
+	return &swapped
+}
+
+// BirthDateVariants retourne les dates voisines d'une saisie erronée : jour/mois inversés et chiffres de l'année transposés
+func (e *PatientMatchingEngine) BirthDateVariants(date time.Time) []time.Time {
+	variants := []time.Time{}
+	if swapped := e.SwappedBirthDate(date); swapped != nil {
+		variants = append(variants, *swapped)
+	}
+
+	annee := []byte(padYear(date.Year()))
+	for i := 0; i+1 < len(annee); i++ {
+		if annee[i] == annee[i+1] {
+			continue
+		}
+		annee[i], annee[i+1] = annee[i+1], annee[i]
+		variant := time.Date(atoiYear(annee), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		// Années implausibles écartées ; 29 février d'une année non bissextile : pas de variante
+		if variant.Year() >= minBirthYearVariant && !variant.After(time.Now()) && variant.Day() == date.Day() {
+			variants = append(variants, variant)
+		}
+		annee[i], annee[i+1] = annee[i+1], annee[i]
+	}
+
+	return variants
+}
+
+// PhoneSuffix retourne les 8 derniers chiffres d'un numéro (nil si trop court)
+func (e *PatientMatchingEngine) PhoneSuffix(telephone *string) *string {
+	if telephone == nil {
+		return nil
+	}
+	digits := e.NormalizePhone(*telephone)
+	if len(digits) < 8 {
+		return nil
+	}
+	suffix := digits[len(digits)-8:]
+	return &suffix
+}
+
+// NormalizeIdentity normalise un nom : majuscules, sans accents, apostrophes ni tirets
+func (e *PatientMatchingEngine) NormalizeIdentity(value string) string {
+	normalized := e.accentReplacer.Replace(strings.ToUpper(strings.TrimSpace(value)))
+
+	var builder strings.Builder
+	for _, r := range normalized {
+		if unicode.IsLetter(r) || unicode.IsSpace(r) {
+			builder.WriteRune(r)
+		}
+	}
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// PhoneticKey calcule la clé phonétique française/ouest-africaine d'un nom (mot par mot)
+func (e *PatientMatchingEngine) PhoneticKey(value string) string {
+	tokens := strings.Fields(e.NormalizeIdentity(value))
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if key := e.phoneticToken(token); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return strings.Join(keys, " ")
+}
+
+// NormalizePhone conserve uniquement les chiffres et retire l'indicatif ivoirien
+func (e *PatientMatchingEngine) NormalizePhone(telephone string) string {
+	var builder strings.Builder
+	for _, r := range telephone {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+	digits := builder.String()
+
+	digits = strings.TrimPrefix(digits, "00225")
+	if len(digits) > 10 {
+		digits = strings.TrimPrefix(digits, "225")
+	}
+	return digits
+}
+
+// phoneticToken applique les règles phonétiques à un mot normalisé
+func (e *PatientMatchingEngine) phoneticToken(token string) string {
+	key := token
+	for _, rule := range e.phoneticRules {
+		key = strings.ReplaceAll(key, rule.from, rule.to)
+	}
+
+	// Nasales : EN devant consonne ou en fin de mot se prononce AN (Assémien/Assamian)
+	var builder strings.Builder
+	runes := []rune(key)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == 'E' && i+1 < len(runes) && runes[i+1] == 'N' &&
+			(i+2 == len(runes) || !isVowel(runes[i+2])) {
+			builder.WriteRune('A')
+			continue
+		}
+		builder.WriteRune(runes[i])
+	}
+	key = builder.String()
+
+	// Lettres doublées (Koffi/Kofi, Kouassi/Kouasi)
+	builder.Reset()
+	var previous rune
+	for _, r := range key {
+		if r != previous {
+			builder.WriteRune(r)
+		}
+		previous = r
+	}
+	key = builder.String()
+
+	// E final muet
+	if len(key) > 3 && strings.HasSuffix(key, "E") {
+		key = strings.TrimSuffix(key, "E")
+	}
+
+	return key
+}
+
+// compareNames compare deux noms et retourne un score 0-100 avec son explication
+func (e *PatientMatchingEngine) compareNames(saisi, existant string) (int, string) {
+	a, b := e.NormalizeIdentity(saisi), e.NormalizeIdentity(existant)
+	if a == "" || b == "" {
+		return 0, "Nom absent"
+	}
+	if a == b {
+		return 100, "Identique"
+	}
+	if strings.ReplaceAll(a, " ", "") == strings.ReplaceAll(b, " ", "") {
+		return 98, "Identique hors espaces, accents et apostrophes"
+	}
+
+	tokensA, tokensB := strings.Fields(a), strings.Fields(b)
+	if sortedTokens(tokensA) == sortedTokens(tokensB) {
+		return 95, "Mêmes mots dans un ordre différent"
+	}
+
+	phonetiqueA, phonetiqueB := e.PhoneticKey(a), e.PhoneticKey(b)
+	if phonetiqueA == phonetiqueB {
+		return 90, "Même prononciation (" + phonetiqueA + ")"
+	}
+	if sortedTokens(strings.Fields(phonetiqueA)) == sortedTokens(strings.Fields(phonetiqueB)) {
+		return 88, "Même prononciation, mots dans un ordre différent"
+	}
+
+	if tokensIncluded(strings.Fields(phonetiqueA), strings.Fields(phonetiqueB)) {
+		return 80, "Prénoms partiellement renseignés"
+	}
+
+	similarite := jaroWinkler(a, b)
+	if phonetique := jaroWinkler(phonetiqueA, phonetiqueB); phonetique > similarite {
+		similarite = phonetique
+	}
+	score := int(similarite * 100)
+
+	switch {
+	case score >= 85:
+		return score, "Graphie très proche (faute de frappe probable)"
+	case score >= 70:
+		return score, "Graphie proche"
+	default:
+		return score, "Différent"
+	}
+}
+
+// compareDates compare deux dates de naissance et détecte l'inversion jour/mois
+func (e *PatientMatchingEngine) compareDates(saisie, existante time.Time) (int, string, bool) {
+	ys, ms, ds := saisie.Date()
+	ye, me, de := existante.Date()
+
+	if ys == ye && ms == me && ds == de {
+		return 100, "Identique", false
+	}
+
+	if swapped := e.SwappedBirthDate(saisie); swapped != nil {
+		yw, mw, dw := swapped.Date()
+		if yw == ye && mw == me && dw == de {
+			return 90, "Jour et mois inversés", true
+		}
+	}
+
+	if ms == me && ds == de && yearTransposed(ys, ye) {
+		return 75, "Chiffres de l'année inversés", false
+	}
+
+	joursEcart := int(saisie.Sub(existante).Hours() / 24)
+	if joursEcart < 0 {
+		joursEcart = -joursEcart
+	}
+
+	switch {
+	case joursEcart <= 7:
+		return 80, "Écart de quelques jours", false
+	case ms == me && ds == de:
+		return 70, "Même jour et mois, année différente", false
+	case joursEcart <= 31:
+		return 60, "Écart de moins d'un mois", false
+	case joursEcart <= 365:
+		return 30, "Écart de moins d'un an", false
+	default:
+		return 0, "Différente", false
+	}
+}
+
+// comparePhones compare deux numéros et détecte les chiffres transposés
+func (e *PatientMatchingEngine) comparePhones(saisi, existant string) (int, string) {
+	a, b := e.NormalizePhone(saisi), e.NormalizePhone(existant)
+	if a == "" || b == "" {
+		return 0, "Téléphone absent"
+	}
+	if a == b {
+		return 100, "Identique"
+	}
+	if len(a) >= 8 && len(b) >= 8 && a[len(a)-8:] == b[len(b)-8:] {
+		return 90, "Mêmes 8 derniers chiffres (ancienne numérotation)"
+	}
+	if len(a) != len(b) {
+		return 0, "Différent"
+	}
+
+	differences := []int{}
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			differences = append(differences, i)
+		}
+	}
+
+	switch {
+	case len(differences) == 2 && differences[1] == differences[0]+1 &&
+		a[differences[0]] == b[differences[1]] && a[differences[1]] == b[differences[0]]:
+		return 80, "Deux chiffres consécutifs inversés"
+	case len(differences) == 1:
+		return 60, "Un chiffre différent"
+	default:
+		return 0, "Différent"
+	}
+}
+
+// sortByScore trie les doublons potentiels par score décroissant
+func sortByScore(matches []dto.PotentialDuplicate) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("AEIOU", r)
+}
+
+func sortedTokens(tokens []string) string {
+	sorted := append([]string(nil), tokens...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
+
+// tokensIncluded indique si tous les mots de la liste la plus courte figurent dans l'autre
+func tokensIncluded(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 || len(a) == len(b) {
+		return false
+	}
+	courte, longue := a, b
+	if len(a) > len(b) {
+		courte, longue = b, a
+	}
+	present := make(map[string]bool, len(longue))
+	for _, token := range longue {
+		present[token] = true
+	}
+	for _, token := range courte {
+		if !present[token] {
+			return false
+		}
+	}
+	return true
+}
+
+// yearTransposed détecte deux chiffres consécutifs inversés dans l'année (1985/1958, 1985/1895)
+func yearTransposed(a, b int) bool {
+	sa, sb := []byte(padYear(a)), []byte(padYear(b))
+	for i := 0; i+1 < len(sa); i++ {
+		if sa[i] == sa[i+1] {
+			continue
+		}
+		sa[i], sa[i+1] = sa[i+1], sa[i]
+		match := string(sa) == string(sb)
+		sa[i], sa[i+1] = sa[i+1], sa[i]
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func padYear(year int) string {
+	s := []byte("0000")
+	for i := 3; i >= 0 && year > 0; i-- {
+		s[i] = byte('0' + year%10)
+		year /= 10
+	}
+	return string(s)
+}
+
+func atoiYear(digits []byte) int {
+	year := 0
+	for _, d := range digits {
+		year = year*10 + int(d-'0')
+	}
+	return year
+}
+
+// jaroWinkler calcule la similarité Jaro-Winkler (0-1) entre deux chaînes
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	fenetre := max(len(ra), len(rb))/2 - 1
+	if fenetre < 0 {
+		fenetre = 0
+	}
+
+	matchA := make([]bool, len(ra))
+	matchB := make([]bool, len(rb))
+	correspondances := 0
+	for i := range ra {
+		debut := max(0, i-fenetre)
+		fin := min(len(rb), i+fenetre+1)
+		for j := debut; j < fin; j++ {
+			if matchB[j] || ra[i] != rb[j] {
+				continue
+			}
+			matchA[i], matchB[j] = true, true
+			correspondances++
+			break
+		}
+	}
+	if correspondances == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	k := 0
+	for i := range ra {
+		if !matchA[i] {
+			continue
+		}
+		for !matchB[k] {
+			k++
+		}
+		if ra[i] != rb[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(correspondances)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefixe := 0
+	for i := 0; i < min(4, len(ra), len(rb)) && ra[i] == rb[i]; i++ {
+		prefixe++
+	}
+
+	return jaro + float64(prefixe)*0.1*(1-jaro)
+}
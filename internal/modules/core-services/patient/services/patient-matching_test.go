@@ -0,0 +1,319 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNormalizeIdentity(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"accent aigu", "Kouamé", "KOUAME"},
+		{"accents multiples", "Éléonore Adjé", "ELEONORE ADJE"},
+		{"cédille et tréma", "François Loïc", "FRANCOIS LOIC"},
+		{"apostrophe", "N'Guessan", "NGUESSAN"},
+		{"apostrophe typographique", "N’Guessan", "NGUESSAN"},
+		{"tiret et espaces", "  Aka-Brou   Yao ", "AKA BROU YAO"},
+		{"chiffres ignorés", "Kone2", "KONE"},
+		{"vide", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := engine.NormalizeIdentity(tt.value); got != tt.want {
+				t.Errorf("NormalizeIdentity(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneticKey(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"accent", "Kouamé", "Kouame", true},
+		{"OU et W", "Kouadio", "Kwadio", true},
+		{"lettres doublées", "Koffi", "Kofi", true},
+		{"S doublé", "Kouassi", "Kouasi", true},
+		{"apostrophe", "N'Guessan", "Nguessan", true},
+		{"CH et SH", "Chérif", "Sherif", true},
+		{"DJ et J", "Djédjé", "Jéjé", true},
+		{"Y et I", "Yao", "Iao", true},
+		{"noms différents", "Kouadio", "Traoré", false},
+		{"prénoms différents", "Aya", "Affoué", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyA, keyB := engine.PhoneticKey(tt.a), engine.PhoneticKey(tt.b)
+			if (keyA == keyB) != tt.same {
+				t.Errorf("PhoneticKey(%q) = %q, PhoneticKey(%q) = %q, same = %v, want %v",
+					tt.a, keyA, tt.b, keyB, keyA == keyB, tt.same)
+			}
+		})
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name     string
+		saisi    string
+		existant string
+		minScore int
+		maxScore int
+	}{
+		{"identique", "Kouadio", "Kouadio", 100, 100},
+		{"accents ignorés", "Kouamé", "KOUAME", 100, 100},
+		{"espaces ignorés", "Nguessan", "N Guessan", 98, 98},
+		{"ordre des mots", "Aya Marie", "Marie Aya", 95, 95},
+		{"même prononciation", "Kouadio", "Kwadio", 90, 90},
+		{"prénoms partiels", "Aya", "Aya Marie", 80, 80},
+		{"faute de frappe", "Kouadio", "Kouadoi", 85, 99},
+		{"différent", "Kouadio", "Traoré", 0, 69},
+		{"absent", "", "Kouadio", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _ := engine.compareNames(tt.saisi, tt.existant)
+			if score < tt.minScore || score > tt.maxScore {
+				t.Errorf("compareNames(%q, %q) = %d, want [%d, %d]",
+					tt.saisi, tt.existant, score, tt.minScore, tt.maxScore)
+			}
+		})
+	}
+}
+
+func TestCompareDates(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name          string
+		saisie        time.Time
+		existante     time.Time
+		wantScore     int
+		wantInversion bool
+	}{
+		{"identique", date(1985, 3, 12), date(1985, 3, 12), 100, false},
+		{"jour et mois inversés", date(1985, 3, 12), date(1985, 12, 3), 90, true},
+		{"jour et mois inversés (sens inverse)", date(1990, 11, 7), date(1990, 7, 11), 90, true},
+		{"chiffres de l'année transposés", date(1985, 3, 12), date(1958, 3, 12), 75, false},
+		{"siècle transposé", date(1985, 3, 12), date(1895, 3, 12), 75, false},
+		{"écart de quelques jours", date(1985, 3, 12), date(1985, 3, 15), 80, false},
+		{"même jour et mois", date(1985, 3, 12), date(1987, 3, 12), 70, false},
+		{"écart de moins d'un mois", date(1985, 3, 12), date(1985, 4, 2), 60, false},
+		{"écart de moins d'un an", date(1985, 3, 12), date(1985, 9, 1), 30, false},
+		{"différente", date(1985, 3, 12), date(1970, 6, 20), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _, inversion := engine.compareDates(tt.saisie, tt.existante)
+			if score != tt.wantScore || inversion != tt.wantInversion {
+				t.Errorf("compareDates(%s, %s) = (%d, %v), want (%d, %v)",
+					tt.saisie.Format("2006-01-02"), tt.existante.Format("2006-01-02"),
+					score, inversion, tt.wantScore, tt.wantInversion)
+			}
+		})
+	}
+}
+
+func TestSwappedBirthDate(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name  string
+		value time.Time
+		want  *time.Time
+	}{
+		{"inversion possible", date(1985, 3, 12), ptrTime(date(1985, 12, 3))},
+		{"jour supérieur à 12", date(1985, 3, 25), nil},
+		{"jour égal au mois", date(1985, 4, 4), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.SwappedBirthDate(tt.value)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil || !got.Equal(*tt.want):
+				t.Errorf("SwappedBirthDate(%s) = %v, want %v", tt.value.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBirthDateVariants(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name  string
+		value time.Time
+		want  []time.Time
+	}{
+		{"inversion et transpositions", date(1985, 3, 12), []time.Time{
+			date(1985, 12, 3), date(1895, 3, 12), date(1958, 3, 12),
+		}},
+		{"chiffres identiques ignorés", date(1988, 5, 20), []time.Time{
+			date(1898, 5, 20),
+		}},
+		{"29 février hors année bissextile", date(1996, 2, 29), []time.Time{}},
+		{"années futures ou implausibles écartées", date(2019, 4, 25), []time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.BirthDateVariants(tt.value)
+			if len(got) != len(tt.want) {
+				t.Fatalf("BirthDateVariants(%s) = %v, want %v", tt.value.Format("2006-01-02"), got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("BirthDateVariants(%s)[%d] = %s, want %s", tt.value.Format("2006-01-02"), i,
+						got[i].Format("2006-01-02"), tt.want[i].Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
+
+func TestComparePhones(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+
+	tests := []struct {
+		name     string
+		saisi    string
+		existant string
+		want     int
+	}{
+		{"identique avec indicatif", "+2250701020304", "0701020304", 100},
+		{"ancienne numérotation", "0701020304", "01020304", 90},
+		{"chiffres consécutifs inversés", "0701020304", "0701020340", 80},
+		{"un chiffre différent", "0701020304", "0701020305", 60},
+		{"différent", "0701020304", "0509080706", 0},
+		{"absent", "", "0701020304", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := engine.comparePhones(tt.saisi, tt.existant); got != tt.want {
+				t.Errorf("comparePhones(%q, %q) = %d, want %d", tt.saisi, tt.existant, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"MARTHA", "MARHTA", 0.961},
+		{"DWAYNE", "DUANE", 0.840},
+		{"DIXON", "DICKSONX", 0.813},
+		{"KOUADIO", "KOUADIO", 1},
+		{"ABC", "XYZ", 0},
+		{"", "KOUADIO", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := jaroWinkler(tt.a, tt.b); math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("jaroWinkler(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreCandidateThresholds(t *testing.T) {
+	engine := NewPatientMatchingEngine()
+	validation := &PatientValidationService{}
+	telephone := "0701020304"
+
+	tests := []struct {
+		name               string
+		req                dto.DuplicateCheckRequest
+		candidate          dto.PatientSearchResult
+		wantRecommendation string
+		wantInversionNoms  bool
+		wantInversionDate  bool
+	}{
+		{
+			name:               "identité identique hors accents",
+			req:                dto.DuplicateCheckRequest{Nom: "Kouamé", Prenoms: "Aya", DateNaissance: date(1985, 3, 12)},
+			candidate:          dto.PatientSearchResult{Nom: "KOUAME", Prenoms: "AYA", DateNaissance: date(1985, 3, 12)},
+			wantRecommendation: dto.RecommendationBlock,
+		},
+		{
+			name:               "nom et prénoms inversés",
+			req:                dto.DuplicateCheckRequest{Nom: "Aya", Prenoms: "Kouadio", DateNaissance: date(1985, 3, 12)},
+			candidate:          dto.PatientSearchResult{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1985, 3, 12)},
+			wantRecommendation: dto.RecommendationBlock,
+			wantInversionNoms:  true,
+		},
+		{
+			name:               "jour et mois inversés",
+			req:                dto.DuplicateCheckRequest{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1985, 3, 12)},
+			candidate:          dto.PatientSearchResult{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1985, 12, 3)},
+			wantRecommendation: dto.RecommendationBlock,
+			wantInversionDate:  true,
+		},
+		{
+			name:               "graphie phonétique et année transposée",
+			req:                dto.DuplicateCheckRequest{Nom: "Kwadio", Prenoms: "Afoué", DateNaissance: date(1985, 3, 12)},
+			candidate:          dto.PatientSearchResult{Nom: "Kouadio", Prenoms: "Affoué", DateNaissance: date(1958, 3, 12)},
+			wantRecommendation: dto.RecommendationWarn,
+		},
+		{
+			name: "homonyme sans date ni téléphone concordants",
+			req: dto.DuplicateCheckRequest{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1985, 3, 12),
+				TelephonePrincipal: &telephone},
+			candidate: dto.PatientSearchResult{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1960, 8, 25),
+				TelephonePrincipal: "0509080706"},
+			wantRecommendation: dto.RecommendationAllow,
+		},
+		{
+			name:               "personne différente",
+			req:                dto.DuplicateCheckRequest{Nom: "Traoré", Prenoms: "Moussa", DateNaissance: date(1970, 6, 20)},
+			candidate:          dto.PatientSearchResult{Nom: "Kouadio", Prenoms: "Aya", DateNaissance: date(1985, 3, 12)},
+			wantRecommendation: dto.RecommendationAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := engine.ScoreCandidate(&tt.req, &tt.candidate)
+			if got := validation.getRecommendationFromScore(detail.ScoreGlobal); got != tt.wantRecommendation {
+				t.Errorf("score %d: recommendation = %q, want %q", detail.ScoreGlobal, got, tt.wantRecommendation)
+			}
+			if detail.InversionNomPrenoms != tt.wantInversionNoms {
+				t.Errorf("InversionNomPrenoms = %v, want %v", detail.InversionNomPrenoms, tt.wantInversionNoms)
+			}
+			if detail.InversionJourMois != tt.wantInversionDate {
+				t.Errorf("InversionJourMois = %v, want %v", detail.InversionJourMois, tt.wantInversionDate)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,627 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientMergeService gère la fusion des dossiers patients confirmés comme doublons
+type PatientMergeService struct {
+	db             *postgres.Client
+	txManager      *postgres.TransactionManager
+	redis          *redis.Client
+	redisKeys      *PatientRedisKeys
+	cacheService   *PatientCacheService
+	historyService *PatientHistoryService
+}
+
+// NewPatientMergeService crée une nouvelle instance du service
+func NewPatientMergeService(
+	db *postgres.Client,
+	redis *redis.Client,
+	cacheService *PatientCacheService,
+	historyService *PatientHistoryService,
+) *PatientMergeService {
+	return &PatientMergeService{
+		db:             db,
+		txManager:      postgres.NewTransactionManager(db),
+		redis:          redis,
+		redisKeys:      NewPatientRedisKeys(),
+		cacheService:   cacheService,
+		historyService: historyService,
+	}
+}
+
+// lockedPatient représente l'état d'un dossier verrouillé pendant la fusion
+type lockedPatient struct {
+	ID                  uuid.UUID
+	CodePatient         string
+	Statut              string
+	EstAssure           bool
+	EstAnonymise        bool
+	PersonnesAContacter string
+}
+
+// Nombre maximum de personnes à contacter (règle métier création)
+const maxPersonnesAContacter = 5
+
+var nonDigitRegex = regexp.MustCompile(`[^0-9]`)
+
+// MergePatients fusionne le dossier "fusionné" dans le dossier "survivant"
+// Couvertures et personnes à contacter sont déplacées, le dossier fusionné est archivé avec pointeur
+func (s *PatientMergeService) MergePatients(
+	ctx context.Context,
+	req *dto.MergePatientsRequest,
+	etablissementID uuid.UUID,
+	userID uuid.UUID,
+) (*dto.MergePatientsResult, error) {
+	if req.CodePatientSurvivant == req.CodePatientFusionne {
+		return nil, dto.NewPatientMergeError(dto.ErrMergeSamePatient,
+			"Le dossier survivant et le dossier fusionné doivent être différents")
+	}
+
+	var result *dto.MergePatientsResult
+
+	err := s.txManager.WithTransactionIsolation(ctx, pgx.Serializable, func(tx *postgres.Transaction) error {
+		// 1. Verrouillage des deux dossiers dans un ordre déterministe (anti deadlock)
+		codes := []string{req.CodePatientSurvivant, req.CodePatientFusionne}
+		sort.Strings(codes)
+
+		locked := make(map[string]*lockedPatient, 2)
+		for _, code := range codes {
+			patient, err := s.lockPatient(ctx, tx, code)
+			if err != nil {
+				return err
+			}
+			locked[code] = patient
+		}
+
+		survivant := locked[req.CodePatientSurvivant]
+		fusionne := locked[req.CodePatientFusionne]
+
+		// Un survivant anonymisé ne peut recevoir ni contacts ni couvertures
+		if survivant.EstAnonymise {
+			return dto.NewPatientAnonymizedError(survivant.CodePatient)
+		}
+
+		avantSurvivant, err := s.historyService.Snapshot(ctx, tx, survivant.ID)
+		if err != nil {
+			return err
+		}
+		avantFusionne, err := s.historyService.Snapshot(ctx, tx, fusionne.ID)
+		if err != nil {
+			return err
+		}
+
+		// 2. Transfert des couvertures d'assurance
+		assurancesTransferees, err := s.transferAssurances(ctx, tx, fusionne.ID, survivant.ID)
+		if err != nil {
+			return err
+		}
+
+		var assurancesRestantes int
+		if err := tx.QueryRow(ctx,
+			queries.PatientMergeQueries.CountAssurancesRestantes,
+			fusionne.ID,
+		).Scan(&assurancesRestantes); err != nil {
+			return fmt.Errorf("failed to count remaining assurances: %w", err)
+		}
+
+		// 3. Fusion des personnes à contacter (survivant prioritaire, dédoublonnage par téléphone)
+		personnesFusionnees, personnesAjoutees, ajoutees, err := s.mergePersonnesAContacter(
+			survivant.PersonnesAContacter,
+			fusionne.PersonnesAContacter,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec(ctx,
+			queries.PatientMergeQueries.UpdateSurvivorAfterMerge,
+			survivant.ID,
+			personnesFusionnees,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to update survivor: %w", err)
+		}
+
+		// 4. Archivage du dossier fusionné avec pointeur vers le survivant
+		if err := tx.Exec(ctx,
+			queries.PatientMergeQueries.ArchiveMergedPatient,
+			fusionne.ID,
+			survivant.ID,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to archive merged patient: %w", err)
+		}
+
+		// 5. Journalisation de la fusion (état nécessaire à l'annulation)
+		var fusionID uuid.UUID
+		var fusionneLe time.Time
+		if err := tx.QueryRow(ctx,
+			queries.PatientMergeQueries.InsertMergeHistory,
+			survivant.ID,                  // $1
+			fusionne.ID,                   // $2
+			survivant.CodePatient,         // $3
+			fusionne.CodePatient,          // $4
+			etablissementID,               // $5
+			req.Motif,                     // $6
+			fusionne.Statut,               // $7
+			survivant.EstAssure,           // $8
+			survivant.PersonnesAContacter, // $9
+			personnesAjoutees,             // $10
+			assurancesTransferees,         // $11
+			userID,                        // $12
+		).Scan(&fusionID, &fusionneLe); err != nil {
+			return fmt.Errorf("failed to insert merge history: %w", err)
+		}
+
+		// 6. Historisation des deux dossiers
+		motifHistorique := fmt.Sprintf("Fusion %s → %s : %s", fusionne.CodePatient, survivant.CodePatient, req.Motif)
+		if err := s.recordHistory(ctx, tx, survivant.ID, survivant.CodePatient, dto.HistoryTypeFusion,
+			avantSurvivant, motifHistorique, userID); err != nil {
+			return err
+		}
+		if err := s.recordHistory(ctx, tx, fusionne.ID, fusionne.CodePatient, dto.HistoryTypeFusion,
+			avantFusionne, motifHistorique, userID); err != nil {
+			return err
+		}
+
+		result = &dto.MergePatientsResult{
+			FusionID:                    fusionID,
+			CodePatientSurvivant:        survivant.CodePatient,
+			CodePatientFusionne:         fusionne.CodePatient,
+			AssurancesTransferees:       len(assurancesTransferees),
+			AssurancesNonTransferees:    assurancesRestantes,
+			PersonnesAContacterAjoutees: ajoutees,
+			FusionneLe:                  fusionneLe,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Cache : invalidation des deux dossiers + redirection de l'ancien code
+	s.invalidateCaches(ctx, result.CodePatientSurvivant, result.CodePatientFusionne)
+	s.redis.Set(ctx, s.redisKeys.PatientRedirectKey(result.CodePatientFusionne), result.CodePatientSurvivant, mergeRedirectTTL)
+
+	fmt.Printf("[AUDIT] Patients merged - Fusion: %s, Survivant: %s, Fusionne: %s, By: %s\n",
+		result.FusionID, result.CodePatientSurvivant, result.CodePatientFusionne, userID)
+
+	return result, nil
+}
+
+// UndoMerge annule une fusion : le dossier fusionné retrouve son statut et ses couvertures
+// Le survivant ne perd que les contacts ajoutés par la fusion, ses modifications ultérieures sont conservées
+func (s *PatientMergeService) UndoMerge(
+	ctx context.Context,
+	fusionID uuid.UUID,
+	req *dto.UndoMergeRequest,
+	userID uuid.UUID,
+) (*dto.MergeHistoryEntry, error) {
+	var codeSurvivant, codeFusionne string
+
+	err := s.txManager.WithTransactionIsolation(ctx, pgx.Serializable, func(tx *postgres.Transaction) error {
+		var survivantID, fusionneID uuid.UUID
+		var statutFusionneAvant, personnesAjoutees, statut string
+		var estAssureAvant bool
+		var assurancesTransferees []uuid.UUID
+
+		err := tx.QueryRow(ctx,
+			queries.PatientMergeQueries.LockMergeHistory,
+			fusionID,
+		).Scan(
+			&fusionID,
+			&survivantID,
+			&fusionneID,
+			&codeSurvivant,
+			&codeFusionne,
+			&statutFusionneAvant,
+			&estAssureAvant,
+			&personnesAjoutees,
+			&assurancesTransferees,
+			&statut,
+		)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewPatientMergeError(dto.ErrMergeNotFound,
+					fmt.Sprintf("Fusion '%s' introuvable", fusionID))
+			}
+			return fmt.Errorf("failed to load merge history: %w", err)
+		}
+
+		if statut == dto.MergeStatutAnnulee {
+			return dto.NewPatientMergeError(dto.ErrMergeAlreadyUndone,
+				"Cette fusion a déjà été annulée")
+		}
+
+		avantSurvivant, err := s.historyService.Snapshot(ctx, tx, survivantID)
+		if err != nil {
+			return err
+		}
+		avantFusionne, err := s.historyService.Snapshot(ctx, tx, fusionneID)
+		if err != nil {
+			return err
+		}
+
+		// 1. Verrouillage du survivant (refusé s'il a lui-même été fusionné depuis)
+		var statutSurvivant, personnesCourantes string
+		err = tx.QueryRow(ctx,
+			queries.PatientMergeQueries.LockSurvivorForUndo,
+			survivantID,
+		).Scan(&statutSurvivant, &personnesCourantes)
+		if err != nil {
+			return fmt.Errorf("failed to lock survivor: %w", err)
+		}
+		if statutSurvivant == "archive" {
+			return dto.NewPatientMergeError(dto.ErrMergeSurvivorNotActive,
+				fmt.Sprintf("Le dossier survivant '%s' est archivé : annuler d'abord sa propre fusion", codeSurvivant))
+		}
+
+		// Seuls les contacts ajoutés par la fusion sont retirés, les modifications ultérieures sont conservées
+		personnesRestaurees, err := s.removePersonnesAjoutees(personnesCourantes, personnesAjoutees, codeSurvivant)
+		if err != nil {
+			return err
+		}
+
+		// 2. Réactivation du dossier fusionné
+		var restoredID uuid.UUID
+		err = tx.QueryRow(ctx,
+			queries.PatientMergeQueries.RestoreMergedPatient,
+			fusionneID,
+			statutFusionneAvant,
+			userID,
+			survivantID,
+		).Scan(&restoredID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return dto.NewPatientMergeError(dto.ErrMergeStateInconsistent,
+					fmt.Sprintf("Le dossier '%s' ne pointe plus vers '%s'", codeFusionne, codeSurvivant))
+			}
+			return fmt.Errorf("failed to restore merged patient: %w", err)
+		}
+
+		// 3. Restitution des couvertures transférées
+		if len(assurancesTransferees) > 0 {
+			if err := tx.Exec(ctx,
+				queries.PatientMergeQueries.RestoreAssurances,
+				fusionneID,
+				assurancesTransferees,
+				survivantID,
+			); err != nil {
+				return fmt.Errorf("failed to restore assurances: %w", err)
+			}
+		}
+
+		// 4. Restauration du survivant (après restitution, pour réaligner le flag assuré)
+		if err := tx.Exec(ctx,
+			queries.PatientMergeQueries.RestoreSurvivor,
+			survivantID,
+			personnesRestaurees,
+			estAssureAvant,
+			userID,
+		); err != nil {
+			return fmt.Errorf("failed to restore survivor: %w", err)
+		}
+
+		// 5. Journalisation de l'annulation
+		if err := tx.Exec(ctx,
+			queries.PatientMergeQueries.MarkMergeUndone,
+			fusionID,
+			userID,
+			req.Motif,
+		); err != nil {
+			return fmt.Errorf("failed to mark merge as undone: %w", err)
+		}
+
+		// 6. Historisation des deux dossiers
+		motifHistorique := fmt.Sprintf("Annulation fusion %s → %s : %s", codeFusionne, codeSurvivant, req.Motif)
+		if err := s.recordHistory(ctx, tx, survivantID, codeSurvivant, dto.HistoryTypeAnnulationFusion,
+			avantSurvivant, motifHistorique, userID); err != nil {
+			return err
+		}
+		return s.recordHistory(ctx, tx, fusionneID, codeFusionne, dto.HistoryTypeAnnulationFusion,
+			avantFusionne, motifHistorique, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache : suppression de la redirection + invalidation des deux dossiers
+	s.redis.Del(ctx, s.redisKeys.PatientRedirectKey(codeFusionne))
+	s.invalidateCaches(ctx, codeSurvivant, codeFusionne)
+
+	fmt.Printf("[AUDIT] Patient merge undone - Fusion: %s, Survivant: %s, Fusionne: %s, By: %s\n",
+		fusionID, codeSurvivant, codeFusionne, userID)
+
+	now := time.Now()
+	return &dto.MergeHistoryEntry{
+		ID:                   fusionID,
+		CodePatientSurvivant: codeSurvivant,
+		CodePatientFusionne:  codeFusionne,
+		Statut:               dto.MergeStatutAnnulee,
+		AnnuleLe:             &now,
+		MotifAnnulation:      &req.Motif,
+	}, nil
+}
+
+// ListMergeHistory retourne l'historique paginé des fusions
+func (s *PatientMergeService) ListMergeHistory(
+	ctx context.Context,
+	filter *dto.MergeHistoryFilter,
+) (*dto.MergeHistoryResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PatientMergeQueries.CountMergeHistory,
+		filter.CodePatient,
+		filter.Statut,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count merge history: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientMergeQueries.ListMergeHistory,
+		filter.CodePatient,
+		filter.Statut,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge history: %w", err)
+	}
+	defer rows.Close()
+
+	fusions := []dto.MergeHistoryEntry{}
+	for rows.Next() {
+		var entry dto.MergeHistoryEntry
+		var fusionneParID, annuleParID *uuid.UUID
+		var fusionneParNom, fusionneParPrenoms, annuleParNom, annuleParPrenoms *string
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.CodePatientSurvivant,
+			&entry.CodePatientFusionne,
+			&entry.EtablissementID,
+			&entry.Motif,
+			&entry.AssurancesTransferees,
+			&entry.Statut,
+			&entry.FusionneLe,
+			&entry.AnnuleLe,
+			&entry.MotifAnnulation,
+			&fusionneParID, &fusionneParNom, &fusionneParPrenoms,
+			&annuleParID, &annuleParNom, &annuleParPrenoms,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan merge history: %w", err)
+		}
+
+		entry.FusionnePar = buildUserInfo(fusionneParID, fusionneParNom, fusionneParPrenoms)
+		entry.AnnulePar = buildUserInfo(annuleParID, annuleParNom, annuleParPrenoms)
+		fusions = append(fusions, entry)
+	}
+
+	return &dto.MergeHistoryResponse{
+		Fusions:    fusions,
+		Pagination: dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// lockPatient verrouille un dossier et vérifie qu'il est fusionnable
+func (s *PatientMergeService) lockPatient(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	codePatient string,
+) (*lockedPatient, error) {
+	var patient lockedPatient
+
+	err := tx.QueryRow(ctx,
+		queries.PatientMergeQueries.LockPatientByCode,
+		codePatient,
+	).Scan(
+		&patient.ID,
+		&patient.CodePatient,
+		&patient.Statut,
+		&patient.EstAssure,
+		&patient.EstAnonymise,
+		&patient.PersonnesAContacter,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return nil, fmt.Errorf("failed to lock patient %s: %w", codePatient, err)
+	}
+
+	if patient.Statut == "archive" {
+		return nil, dto.NewPatientMergeError(dto.ErrMergePatientArchived,
+			fmt.Sprintf("Le dossier '%s' est archivé et ne peut être fusionné", codePatient))
+	}
+
+	return &patient, nil
+}
+
+// transferAssurances déplace les couvertures et retourne les IDs transférés
+func (s *PatientMergeService) transferAssurances(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	fromPatientID uuid.UUID,
+	toPatientID uuid.UUID,
+) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx,
+		queries.PatientMergeQueries.TransferAssurances,
+		fromPatientID,
+		toPatientID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer assurances: %w", err)
+	}
+	defer rows.Close()
+
+	transferees := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan transferred assurance: %w", err)
+		}
+		transferees = append(transferees, id)
+	}
+
+	return transferees, rows.Err()
+}
+
+// mergePersonnesAContacter fusionne deux listes JSON (survivant prioritaire, max 5)
+// Retourne la liste fusionnée et les contacts ajoutés (conservés pour l'annulation)
+func (s *PatientMergeService) mergePersonnesAContacter(survivantJSON, fusionneJSON string) (string, string, int, error) {
+	var survivant, fusionne []dto.PersonneContact
+
+	if err := json.Unmarshal([]byte(survivantJSON), &survivant); err != nil {
+		return "", "", 0, fmt.Errorf("failed to parse survivor personnes_a_contacter: %w", err)
+	}
+	if err := json.Unmarshal([]byte(fusionneJSON), &fusionne); err != nil {
+		return "", "", 0, fmt.Errorf("failed to parse merged personnes_a_contacter: %w", err)
+	}
+
+	telephones := make(map[string]bool, len(survivant))
+	for _, personne := range survivant {
+		telephones[nonDigitRegex.ReplaceAllString(personne.Telephone, "")] = true
+	}
+
+	merged := survivant
+	ajoutees := []dto.PersonneContact{}
+	for _, personne := range fusionne {
+		if len(merged) >= maxPersonnesAContacter {
+			break
+		}
+		telephone := nonDigitRegex.ReplaceAllString(personne.Telephone, "")
+		if telephones[telephone] {
+			continue
+		}
+		telephones[telephone] = true
+		merged = append(merged, personne)
+		ajoutees = append(ajoutees, personne)
+	}
+
+	if merged == nil {
+		merged = []dto.PersonneContact{}
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to marshal personnes_a_contacter: %w", err)
+	}
+	dataAjoutees, err := json.Marshal(ajoutees)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("failed to marshal added personnes_a_contacter: %w", err)
+	}
+
+	return string(data), string(dataAjoutees), len(ajoutees), nil
+}
+
+// removePersonnesAjoutees retire des contacts courants ceux ajoutés par la fusion
+// Un contact ajouté supprimé depuis est ignoré ; un contact ajouté modifié depuis bloque l'annulation
+func (s *PatientMergeService) removePersonnesAjoutees(courantJSON, ajouteesJSON, codeSurvivant string) (string, error) {
+	var courant, ajoutees []dto.PersonneContact
+
+	if err := json.Unmarshal([]byte(courantJSON), &courant); err != nil {
+		return "", fmt.Errorf("failed to parse survivor personnes_a_contacter: %w", err)
+	}
+	if err := json.Unmarshal([]byte(ajouteesJSON), &ajoutees); err != nil {
+		return "", fmt.Errorf("failed to parse added personnes_a_contacter: %w", err)
+	}
+
+	ajouteesParTelephone := make(map[string]dto.PersonneContact, len(ajoutees))
+	for _, personne := range ajoutees {
+		ajouteesParTelephone[nonDigitRegex.ReplaceAllString(personne.Telephone, "")] = personne
+	}
+
+	restantes := []dto.PersonneContact{}
+	for _, personne := range courant {
+		ajoutee, ok := ajouteesParTelephone[nonDigitRegex.ReplaceAllString(personne.Telephone, "")]
+		if !ok {
+			restantes = append(restantes, personne)
+			continue
+		}
+		if !samePersonneContact(personne, ajoutee) {
+			return "", dto.NewPatientMergeError(dto.ErrMergeSurvivorModified,
+				fmt.Sprintf("La personne à contacter '%s' ajoutée par la fusion a été modifiée depuis sur le dossier '%s' : corriger le dossier avant d'annuler",
+					personne.NomPrenoms, codeSurvivant))
+		}
+	}
+
+	data, err := json.Marshal(restantes)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal personnes_a_contacter: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// samePersonneContact compare deux personnes à contacter champ par champ
+func samePersonneContact(a, b dto.PersonneContact) bool {
+	if a.NomPrenoms != b.NomPrenoms || a.Telephone != b.Telephone || a.AffiliationID != b.AffiliationID {
+		return false
+	}
+	if a.TelephoneSecondaire == nil || b.TelephoneSecondaire == nil {
+		return a.TelephoneSecondaire == b.TelephoneSecondaire
+	}
+	return *a.TelephoneSecondaire == *b.TelephoneSecondaire
+}
+
+// recordHistory historise l'écart entre l'état avant et l'état courant d'un dossier
+func (s *PatientMergeService) recordHistory(
+	ctx context.Context,
+	tx *postgres.Transaction,
+	patientID uuid.UUID,
+	codePatient string,
+	typeModification string,
+	avant map[string]interface{},
+	motif string,
+	userID uuid.UUID,
+) error {
+	apres, err := s.historyService.Snapshot(ctx, tx, patientID)
+	if err != nil {
+		return err
+	}
+	return s.historyService.RecordChanges(ctx, tx, patientID, codePatient, typeModification, avant, apres, motif, userID)
+}
+
+// invalidateCaches invalide le cache des dossiers concernés (best effort)
+func (s *PatientMergeService) invalidateCaches(ctx context.Context, codes ...string) {
+	for _, code := range codes {
+		if err := s.cacheService.InvalidatePatientCache(ctx, code); err != nil {
+			fmt.Printf("[CACHE] Patient cache invalidation failed - Code: %s, Error: %v\n", code, err)
+		}
+	}
+}
+
+// buildUserInfo construit un UserInfo à partir de colonnes nullable
+func buildUserInfo(id *uuid.UUID, nom, prenoms *string) *dto.UserInfo {
+	if id == nil || nom == nil || prenoms == nil {
+		return nil
+	}
+	return &dto.UserInfo{
+		ID:      *id,
+		Nom:     *nom,
+		Prenoms: *prenoms,
+	}
+}
@@ -0,0 +1,442 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/infrastructure/database/postgres"
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// PatientProvisionalService gère les patients inconnus admis sans identité (urgences)
+// Le dossier reçoit immédiatement un vrai code, puis est réconcilié : identité complétée
+// ou fusion vers le dossier existant du patient une fois identifié
+type PatientProvisionalService struct {
+	db                *postgres.Client
+	txManager         *postgres.TransactionManager
+	codeGenerator     *PatientCodeGeneratorService
+	validationService *PatientValidationService
+	mergeService      *PatientMergeService
+	cacheService      *PatientCacheService
+	historyService    *PatientHistoryService
+}
+
+// NewPatientProvisionalService crée une nouvelle instance du service
+func NewPatientProvisionalService(
+	db *postgres.Client,
+	codeGenerator *PatientCodeGeneratorService,
+	validationService *PatientValidationService,
+	mergeService *PatientMergeService,
+	cacheService *PatientCacheService,
+	historyService *PatientHistoryService,
+) *PatientProvisionalService {
+	return &PatientProvisionalService{
+		db:                db,
+		txManager:         postgres.NewTransactionManager(db),
+		codeGenerator:     codeGenerator,
+		validationService: validationService,
+		mergeService:      mergeService,
+		cacheService:      cacheService,
+		historyService:    historyService,
+	}
+}
+
+// RegisterUnknownPatient admet un patient non identifié avec une identité factice marquée provisoire
+// Aucune vérification anti-doublon : l'identité réelle est rapprochée lors de la réconciliation
+func (s *PatientProvisionalService) RegisterUnknownPatient(
+	ctx context.Context,
+	etablissementCode string,
+	etablissementID uuid.UUID,
+	req *dto.RegisterUnknownPatientRequest,
+	userID uuid.UUID,
+) (*dto.PatientCreationResult, error) {
+	startTime := time.Now()
+	stepsExecuted := []string{"reference_resolution"}
+
+	nationaliteID, situationID, err := s.resolveReferences(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Date de naissance supposée au 1er janvier de l'année correspondant à l'âge apparent
+	dateNaissance := time.Date(startTime.Year()-*req.AgeEstime, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	stepsExecuted = append(stepsExecuted, "code_generation")
+	codeGeneration, err := s.codeGenerator.GeneratePatientCode(ctx, etablissementCode)
+	if err != nil {
+		return nil, fmt.Errorf("code generation failed: %w", err)
+	}
+
+	stepsExecuted = append(stepsExecuted, "patient_creation")
+	var patient dto.PatientResponse
+	err = s.db.QueryRow(ctx,
+		queries.PatientProvisionalQueries.InsertProvisionalPatient,
+		codeGeneration.CodePatient, // $1
+		etablissementID,            // $2
+		dto.ProvisionalNom,         // $3
+		dto.ProvisionalPrenoms,     // $4
+		dateNaissance,              // $5
+		req.Sexe,                   // $6
+		nationaliteID,              // $7
+		situationID,                // $8
+		dto.ProvisionalTelephone,   // $9
+		dto.ProvisionalAdresse,     // $10
+		req.Signalement,            // $11
+		userID,                     // $12
+	).Scan(
+		&patient.ID,
+		&patient.CodePatient,
+		&patient.Nom,
+		&patient.Prenoms,
+		&patient.DateNaissance,
+		&patient.EstDateSupposee,
+		&patient.Sexe,
+		&patient.TelephonePrincipal,
+		&patient.AdresseComplete,
+		&patient.EstAssure,
+		&patient.EtablissementCreateurID,
+		&patient.EstProvisoire,
+		&patient.Statut,
+		&patient.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("patient creation failed: %w", err)
+	}
+
+	result := &dto.PatientCreationResult{
+		Patient:        &patient,
+		CodeGeneration: codeGeneration,
+		CreationTimeMs: int(time.Since(startTime).Milliseconds()),
+		StepsExecuted:  stepsExecuted,
+	}
+
+	fmt.Printf("[AUDIT] Unknown patient registered - ID: %s, Code: %s, Etablissement: %s, By: %s\n",
+		patient.ID, patient.CodePatient, etablissementCode, userID)
+
+	return result, nil
+}
+
+// resolveReferences retourne les référentiels fournis ou, à défaut, les codes d'identité inconnue
+func (s *PatientProvisionalService) resolveReferences(
+	ctx context.Context,
+	req *dto.RegisterUnknownPatientRequest,
+) (uuid.UUID, uuid.UUID, error) {
+	if req.NationaliteID != nil && req.SituationMatrimonialeID != nil {
+		return *req.NationaliteID, *req.SituationMatrimonialeID, nil
+	}
+
+	var nationaliteID, situationID *uuid.UUID
+	if err := s.db.QueryRow(ctx,
+		queries.PatientProvisionalQueries.ResolveProvisionalReferences,
+		dto.ProvisionalNationaliteCode,
+		dto.ProvisionalSituationMatrimoniale,
+	).Scan(&nationaliteID, &situationID); err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("failed to resolve provisional references: %w", err)
+	}
+
+	if req.NationaliteID != nil {
+		nationaliteID = req.NationaliteID
+	}
+	if req.SituationMatrimonialeID != nil {
+		situationID = req.SituationMatrimonialeID
+	}
+
+	if nationaliteID == nil {
+		return uuid.Nil, uuid.Nil, dto.NewProvisionalPatientError(dto.ErrProvisoireReferenceManquante,
+			fmt.Sprintf("Nationalité '%s' absente du référentiel : renseigner nationalite_id", dto.ProvisionalNationaliteCode))
+	}
+	if situationID == nil {
+		return uuid.Nil, uuid.Nil, dto.NewProvisionalPatientError(dto.ErrProvisoireReferenceManquante,
+			fmt.Sprintf("Situation matrimoniale '%s' absente du référentiel : renseigner situation_matrimoniale_id", dto.ProvisionalSituationMatrimoniale))
+	}
+
+	return *nationaliteID, *situationID, nil
+}
+
+// ListProvisionalPatients liste les patients inconnus non réconciliés (plus anciens en premier)
+func (s *PatientProvisionalService) ListProvisionalPatients(
+	ctx context.Context,
+	filter *dto.ProvisionalPatientFilter,
+) (*dto.ProvisionalPatientListResponse, error) {
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	var total int
+	if err := s.db.QueryRow(ctx,
+		queries.PatientProvisionalQueries.CountProvisionalPatients,
+		filter.EtablissementID,
+	).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count provisional patients: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientProvisionalQueries.ListProvisionalPatients,
+		filter.EtablissementID,
+		filter.Limit,
+		(filter.Page-1)*filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provisional patients: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	patients := []dto.ProvisionalPatientEntry{}
+	for rows.Next() {
+		var entry dto.ProvisionalPatientEntry
+		var dateNaissance time.Time
+		var createdByID *uuid.UUID
+		var createdByNom, createdByPrenoms *string
+
+		if err := rows.Scan(
+			&entry.CodePatient,
+			&entry.Sexe,
+			&dateNaissance,
+			&entry.Signalement,
+			&entry.Statut,
+			&entry.EtablissementID,
+			&entry.CreatedAt,
+			&createdByID, &createdByNom, &createdByPrenoms,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan provisional patient: %w", err)
+		}
+
+		entry.AgeEstime = now.Year() - dateNaissance.Year()
+		entry.AttenteHeures = int(now.Sub(entry.CreatedAt).Hours())
+		entry.CreatedBy = buildUserInfo(createdByID, createdByNom, createdByPrenoms)
+		patients = append(patients, entry)
+	}
+
+	return &dto.ProvisionalPatientListResponse{
+		Patients:   patients,
+		Pagination: dto.NewPaginationInfo(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+// CompleteIdentity réconcilie un patient inconnu en remplaçant son identité factice
+// Mêmes validations qu'une création ; un doublon probable bloque (la fusion est alors proposée)
+// sauf confirmation explicite
+func (s *PatientProvisionalService) CompleteIdentity(
+	ctx context.Context,
+	codePatient string,
+	etablissementID uuid.UUID,
+	req *dto.CompleteProvisionalIdentityRequest,
+	userID uuid.UUID,
+) (*dto.ReconciliationResult, error) {
+	createReq := req.ToCreatePatientRequest()
+
+	// 1. Validations identiques à la création
+	validationResult, err := s.validationService.ValidatePatientData(ctx, createReq, etablissementID)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if !validationResult.IsValid {
+		return nil, dto.NewInvalidPatientDataError(validationResult.Errors)
+	}
+
+	// 2. Anti-doublon : le patient identifié a peut-être déjà un dossier
+	if !req.IgnorerDoublons {
+		duplicateResult, err := s.validationService.CheckPatientDuplicate(ctx, &dto.DuplicateCheckRequest{
+			Nom:                req.Nom,
+			Prenoms:            req.Prenoms,
+			DateNaissance:      req.DateNaissance,
+			TelephonePrincipal: &req.TelephonePrincipal,
+			ScoreMinimum:       70,
+			LimiteResultats:    5,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("duplicate check failed: %w", err)
+		}
+		if duplicateResult.ShouldBlock() {
+			return nil, dto.NewPatientDuplicateError(duplicateResult)
+		}
+	}
+
+	personnesJSON, err := json.Marshal(req.PersonnesAContacter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal personnes_a_contacter: %w", err)
+	}
+	if req.PersonnesAContacter == nil {
+		personnesJSON = []byte("[]")
+	}
+	paysResidence := req.PaysResidence
+	if paysResidence == "" {
+		paysResidence = "Côte d'Ivoire"
+	}
+
+	// 3. Remplacement de l'identité + historisation dans la même transaction
+	var patient dto.PatientResponse
+	var reconcilieLe time.Time
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, avant, err := s.historyService.LockSnapshot(ctx, tx, codePatient)
+		if err != nil {
+			return err
+		}
+		if estAnonymise, _ := avant["est_anonymise"].(bool); estAnonymise {
+			return dto.NewPatientAnonymizedError(codePatient)
+		}
+		if estProvisoire, _ := avant["est_provisoire"].(bool); !estProvisoire {
+			return dto.NewProvisionalPatientError(dto.ErrProvisoireNonProvisoire,
+				fmt.Sprintf("Le dossier '%s' n'est pas un dossier provisoire", codePatient))
+		}
+
+		err = tx.QueryRow(ctx,
+			queries.PatientProvisionalQueries.CompleteProvisionalIdentity,
+			patientID,                   // $1
+			req.Nom,                     // $2
+			req.Prenoms,                 // $3
+			req.DateNaissance,           // $4
+			req.EstDateSupposee,         // $5
+			req.Sexe,                    // $6
+			req.NationaliteID,           // $7
+			req.SituationMatrimonialeID, // $8
+			req.TypePieceIdentiteID,     // $9
+			req.CniNni,                  // $10
+			req.NumeroPieceIdentite,     // $11
+			req.LieuNaissance,           // $12
+			req.NomJeuneFille,           // $13
+			req.TelephonePrincipal,      // $14
+			req.TelephoneSecondaire,     // $15
+			req.Email,                   // $16
+			req.AdresseComplete,         // $17
+			req.Quartier,                // $18
+			req.Ville,                   // $19
+			req.Commune,                 // $20
+			paysResidence,               // $21
+			req.ProfessionID,            // $22
+			string(personnesJSON),       // $23
+			userID,                      // $24
+		).Scan(
+			&patient.ID,
+			&patient.CodePatient,
+			&patient.Nom,
+			&patient.Prenoms,
+			&patient.DateNaissance,
+			&patient.EstDateSupposee,
+			&patient.Sexe,
+			&patient.TelephonePrincipal,
+			&patient.TelephoneSecondaire,
+			&patient.Email,
+			&patient.AdresseComplete,
+			&patient.EstAssure,
+			&patient.EtablissementCreateurID,
+			&patient.EstProvisoire,
+			&patient.Statut,
+			&patient.CreatedAt,
+			&reconcilieLe,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to complete provisional identity: %w", err)
+		}
+
+		apres, err := s.historyService.Snapshot(ctx, tx, patientID)
+		if err != nil {
+			return err
+		}
+
+		return s.historyService.RecordChanges(ctx, tx,
+			patientID, codePatient, dto.HistoryTypeReconciliation,
+			avant, apres, req.Motif, userID)
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, dto.NewPatientNotFoundError(codePatient)
+		}
+		return nil, err
+	}
+
+	// Invalidation cache (best effort - le prochain accès rechargera depuis PostgreSQL)
+	if err := s.cacheService.InvalidatePatientCache(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+
+	fmt.Printf("[AUDIT] Unknown patient identified - Code: %s, By: %s\n", codePatient, userID)
+
+	return &dto.ReconciliationResult{
+		CodePatientProvisoire: codePatient,
+		Mode:                  dto.ReconciliationModeIdentite,
+		CodePatient:           patient.CodePatient,
+		Patient:               &patient,
+		ReconcilieLe:          reconcilieLe,
+	}, nil
+}
+
+// MergeIntoExisting réconcilie un patient inconnu en le fusionnant dans le dossier existant du patient
+// Le dossier provisoire est archivé avec redirection de son code (fusion annulable)
+func (s *PatientProvisionalService) MergeIntoExisting(
+	ctx context.Context,
+	codePatient string,
+	etablissementID uuid.UUID,
+	req *dto.MergeProvisionalPatientRequest,
+	userID uuid.UUID,
+) (*dto.ReconciliationResult, error) {
+	estProvisoire, err := s.provisionalState(ctx, codePatient)
+	if err != nil {
+		return nil, err
+	}
+	if !estProvisoire {
+		return nil, dto.NewProvisionalPatientError(dto.ErrProvisoireNonProvisoire,
+			fmt.Sprintf("Le dossier '%s' n'est pas un dossier provisoire", codePatient))
+	}
+
+	cibleProvisoire, err := s.provisionalState(ctx, req.CodePatientExistant)
+	if err != nil {
+		return nil, err
+	}
+	if cibleProvisoire {
+		return nil, dto.NewProvisionalPatientError(dto.ErrProvisoireCibleProvisoire,
+			fmt.Sprintf("Le dossier '%s' est lui-même provisoire : compléter son identité d'abord", req.CodePatientExistant))
+	}
+
+	fusion, err := s.mergeService.MergePatients(ctx, &dto.MergePatientsRequest{
+		CodePatientSurvivant: req.CodePatientExistant,
+		CodePatientFusionne:  codePatient,
+		Motif:                fmt.Sprintf("Réconciliation patient inconnu : %s", req.Motif),
+	}, etablissementID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[AUDIT] Unknown patient reconciled by merge - Provisoire: %s, Existant: %s, By: %s\n",
+		codePatient, req.CodePatientExistant, userID)
+
+	return &dto.ReconciliationResult{
+		CodePatientProvisoire: codePatient,
+		Mode:                  dto.ReconciliationModeFusion,
+		CodePatient:           fusion.CodePatientSurvivant,
+		Fusion:                fusion,
+		ReconcilieLe:          fusion.FusionneLe,
+	}, nil
+}
+
+// provisionalState indique si un dossier est provisoire (dossier introuvable = erreur métier)
+func (s *PatientProvisionalService) provisionalState(ctx context.Context, codePatient string) (bool, error) {
+	var estProvisoire bool
+	var statut string
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientProvisionalQueries.GetProvisionalState,
+		codePatient,
+	).Scan(&estProvisoire, &statut)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, dto.NewPatientNotFoundError(codePatient)
+		}
+		return false, fmt.Errorf("failed to load patient %s: %w", codePatient, err)
+	}
+	if statut == dto.PatientStatutArchive {
+		return false, dto.NewPatientArchivedError(codePatient)
+	}
+
+	return estProvisoire, nil
+}
@@ -11,7 +11,7 @@ func NewPatientRedisKeys() *PatientRedisKeys {
 }
 
 // PatientSequenceKey génère la clé Redis pour les séquences de génération de codes patient
-// Format: soins_suite_{etablissement}_patient_sequence:{year}
+// Format: soins_suite_{etablissement}_patient_sequence:{year} (year = 0 pour une séquence continue)
 func (k *PatientRedisKeys) PatientSequenceKey(etablissementCode string, year int) string {
 	return fmt.Sprintf("soins_suite_%s_patient_sequence:%d", etablissementCode, year)
 }
@@ -38,4 +38,22 @@ func (k *PatientRedisKeys) PatientSearchCacheKey(etablissementCode, hashCriteres
 // Format: soins_suite_patient_cache:{code_patient} (selon spécifications Redis)
 func (k *PatientRedisKeys) PatientDetailCacheKey(codePatient string) string {
 	return fmt.Sprintf("soins_suite_patient_cache:%s", codePatient)
-}
\ No newline at end of file
+}
+
+// PatientRedirectKey génère la clé Redis de redirection d'un code fusionné vers le survivant
+// Format: soins_suite_patient_redirect:{code_patient_fusionne}
+func (k *PatientRedisKeys) PatientRedirectKey(codePatient string) string {
+	return fmt.Sprintf("soins_suite_patient_redirect:%s", codePatient)
+}
+
+// PatientSearchCachePattern génère le motif SCAN couvrant les recherches en cache de tous les établissements
+// Format: soins_suite_*_patient_search:*
+func (k *PatientRedisKeys) PatientSearchCachePattern() string {
+	return "soins_suite_*_patient_search:*"
+}
+
+// PatientCachePattern génère le motif SCAN couvrant le cache établissement d'un patient
+// Format: soins_suite_*_patient_cache:{code_patient}
+func (k *PatientRedisKeys) PatientCachePattern(codePatient string) string {
+	return fmt.Sprintf("soins_suite_*_patient_cache:%s", codePatient)
+}
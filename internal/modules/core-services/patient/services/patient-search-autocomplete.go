@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// likeEscaper neutralise les jokers LIKE présents dans la saisie
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Autocomplete retourne quelques suggestions allégées pendant la saisie (zone de recherche de l'accueil)
+// Préfixe du code patient, chiffres du téléphone, ou mots du nom et des prénoms (index trigramme)
+func (s *PatientSearchService) Autocomplete(
+	ctx context.Context,
+	req *dto.PatientAutocompleteRequest,
+) (*dto.PatientAutocompleteResponse, error) {
+	startTime := time.Now()
+
+	saisie := strings.TrimSpace(req.Q)
+	if len([]rune(saisie)) < 2 {
+		return nil, dto.NewPatientSearchError(dto.ErrSearchInvalidCriteria,
+			"autocomplete requires at least 2 characters")
+	}
+
+	limit := req.Limit
+	if limit <= 0 || limit > 20 {
+		limit = dto.AutocompleteDefaultLimit
+	}
+
+	// Saisie numérique (espaces, tirets et indicatif tolérés) : recherche par téléphone
+	var chiffres *string
+	if digits := phoneDigits(saisie); len(digits) >= dto.AutocompletePhoneMinDigits {
+		chiffres = &digits
+	}
+
+	// Deux premiers mots : "KOUASSI Ama" trouve nom puis prénom dans n'importe quel ordre
+	mots := strings.Fields(saisie)
+	premierMot := likeEscaper.Replace(mots[0])
+	var secondMot *string
+	if len(mots) > 1 {
+		mot := likeEscaper.Replace(mots[1])
+		secondMot = &mot
+	}
+
+	rows, err := s.db.Query(ctx,
+		queries.PatientSearchQueries.AutocompletePatients,
+		likeEscaper.Replace(saisie), // $1
+		chiffres,                    // $2
+		premierMot,                  // $3
+		secondMot,                   // $4
+		saisie,                      // $5
+		limit,                       // $6
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete patients: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []dto.PatientAutocompleteResult{}
+	for rows.Next() {
+		var suggestion dto.PatientAutocompleteResult
+		if err := rows.Scan(
+			&suggestion.ID,
+			&suggestion.CodePatient,
+			&suggestion.Nom,
+			&suggestion.Prenoms,
+			&suggestion.DateNaissance,
+			&suggestion.Sexe,
+			&suggestion.TelephonePrincipal,
+			&suggestion.EstProvisoire,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan autocomplete suggestion: %w", err)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to autocomplete patients: %w", err)
+	}
+
+	return &dto.PatientAutocompleteResponse{
+		Suggestions:     suggestions,
+		ExecutionTimeMs: int(time.Since(startTime).Milliseconds()),
+	}, nil
+}
+
+// phoneDigits retourne les chiffres d'une saisie téléphonique, ou "" si elle contient autre chose
+func phoneDigits(saisie string) string {
+	var digits strings.Builder
+	for _, r := range saisie {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '+' || r == '(' || r == ')':
+		default:
+			return ""
+		}
+	}
+	return digits.String()
+}
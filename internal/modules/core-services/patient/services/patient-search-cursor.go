@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"soins-suite-core/internal/modules/core-services/patient/dto"
+	"soins-suite-core/internal/modules/core-services/patient/queries"
+)
+
+// searchCursor position de la dernière ligne servie (opaque côté client)
+type searchCursor struct {
+	SearchType string    `json:"k"` // full_text ou criteria : un curseur ne change pas de stratégie
+	SortBy     string    `json:"t"`
+	Score      float64   `json:"s"`
+	CreatedAt  time.Time `json:"c"`
+	ID         uuid.UUID `json:"i"`
+}
+
+// encodeSearchCursor sérialise le curseur en base64 URL-safe
+func encodeSearchCursor(cursor *searchCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode search cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeSearchCursor relit un curseur et vérifie qu'il correspond à la recherche en cours
+func decodeSearchCursor(value string, searchType string, sortBy string) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor searchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if cursor.SearchType != searchType || cursor.SortBy != sortBy {
+		return nil, fmt.Errorf("cursor does not match search criteria")
+	}
+
+	return &cursor, nil
+}
+
+// searchKeyset exécute une page de recherche par curseur (score, created_at, id)
+// Une ligne de plus que la limite est lue pour savoir s'il existe une page suivante
+func (s *PatientSearchService) searchKeyset(
+	ctx context.Context,
+	req *dto.SearchPatientRequest,
+	searchType string,
+) ([]dto.PatientSearchResult, string, error) {
+	var cursor *searchCursor
+	if req.Cursor != "" {
+		decoded, err := decodeSearchCursor(req.Cursor, searchType, req.SortBy)
+		if err != nil {
+			return nil, "", dto.NewPatientSearchError(dto.ErrSearchInvalidCriteria, err.Error())
+		}
+		cursor = decoded
+	}
+
+	var cursorScore *float64
+	var cursorCreatedAt *time.Time
+	var cursorID *uuid.UUID
+	if cursor != nil {
+		cursorScore = &cursor.Score
+		cursorCreatedAt = &cursor.CreatedAt
+		cursorID = &cursor.ID
+	}
+
+	var rows pgx.Rows
+	var err error
+	if searchType == dto.SearchTypeFullText {
+		rows, err = s.db.Query(ctx,
+			queries.PatientSearchQueries.SearchPatientsFullTextKeyset,
+			req.SearchTerm,            // $1
+			req.Statut,                // $2
+			req.Nom,                   // $3
+			req.Prenoms,               // $4
+			req.TelephonePrincipal,    // $5
+			req.DateNaissanceDebut,    // $6
+			req.DateNaissanceFin,      // $7
+			req.Sexe,                  // $8
+			req.CniNni,                // $9
+			req.EstAssure,             // $10
+			req.EtablissementCreateur, // $11
+			req.SortBy,                // $12
+			cursorScore,               // $13
+			cursorCreatedAt,           // $14
+			cursorID,                  // $15
+			req.Limit+1,               // $16
+		)
+	} else {
+		rows, err = s.db.Query(ctx,
+			queries.PatientSearchQueries.SearchPatientsByCriteriaKeyset,
+			req.Statut,                // $1
+			req.Nom,                   // $2
+			req.Prenoms,               // $3
+			req.TelephonePrincipal,    // $4
+			req.DateNaissanceDebut,    // $5
+			req.DateNaissanceFin,      // $6
+			req.DateNaissance,         // $7
+			req.Sexe,                  // $8
+			req.CniNni,                // $9
+			req.EstAssure,             // $10
+			req.EtablissementCreateur, // $11
+			cursorCreatedAt,           // $12
+			cursorID,                  // $13
+			req.Limit+1,               // $14
+		)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	patients := []dto.PatientSearchResult{}
+	cleScores := []float64{}
+	for rows.Next() {
+		var patient dto.PatientSearchResult
+		var score *float64
+		var cleScore float64
+
+		if err := rows.Scan(
+			&patient.ID,
+			&patient.CodePatient,
+			&patient.Nom,
+			&patient.Prenoms,
+			&patient.DateNaissance,
+			&patient.Sexe,
+			&patient.TelephonePrincipal,
+			&patient.AdresseComplete,
+			&patient.EstAssure,
+			&patient.Statut,
+			&patient.CreatedAt,
+			&score,
+			&cleScore,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan search result: %w", err)
+		}
+
+		patient.Score = score
+		patients = append(patients, patient)
+		cleScores = append(cleScores, cleScore)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(patients) > req.Limit {
+		patients = patients[:req.Limit]
+		last := patients[req.Limit-1]
+		nextCursor, err = encodeSearchCursor(&searchCursor{
+			SearchType: searchType,
+			SortBy:     req.SortBy,
+			Score:      cleScores[req.Limit-1],
+			CreatedAt:  last.CreatedAt,
+			ID:         last.ID,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if req.IncludeAssurances && len(patients) > 0 {
+		if err := s.attachAssurances(ctx, patients); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return patients, nextCursor, nil
+}
+
+// attachAssurances charge les assurances actives de la page en une seule requête
+func (s *PatientSearchService) attachAssurances(ctx context.Context, patients []dto.PatientSearchResult) error {
+	ids := make([]uuid.UUID, len(patients))
+	index := make(map[uuid.UUID]int, len(patients))
+	for i, patient := range patients {
+		ids[i] = patient.ID
+		index[patient.ID] = i
+	}
+
+	rows, err := s.db.Query(ctx, queries.PatientSearchQueries.GetActiveAssurancesByPatients, ids)
+	if err != nil {
+		return fmt.Errorf("failed to load search result assurances: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var patientID uuid.UUID
+		var assurancesJSON []byte
+		if err := rows.Scan(&patientID, &assurancesJSON); err != nil {
+			return fmt.Errorf("failed to scan search result assurances: %w", err)
+		}
+
+		var assurances []dto.AssuranceResponse
+		if err := json.Unmarshal(assurancesJSON, &assurances); err == nil {
+			patients[index[patientID]].Assurances = assurances
+		}
+	}
+
+	return rows.Err()
+}
@@ -15,20 +15,23 @@ import (
 
 // PatientSearchService gère les recherches multi-critères de patients
 type PatientSearchService struct {
-	db        *postgres.Client
-	redis     *redis.Client
-	redisKeys *PatientRedisKeys
+	db            *postgres.Client
+	redis         *redis.Client
+	redisKeys     *PatientRedisKeys
+	codeGenerator *PatientCodeGeneratorService // Vérification des clés de contrôle selon les formats
 }
 
 // NewPatientSearchService crée une nouvelle instance du service
 func NewPatientSearchService(
 	db *postgres.Client,
 	redis *redis.Client,
+	codeGenerator *PatientCodeGeneratorService,
 ) *PatientSearchService {
 	return &PatientSearchService{
-		db:        db,
-		redis:     redis,
-		redisKeys: NewPatientRedisKeys(),
+		db:            db,
+		redis:         redis,
+		redisKeys:     NewPatientRedisKeys(),
+		codeGenerator: codeGenerator,
 	}
 }
 
@@ -41,7 +44,7 @@ func (s *PatientSearchService) SearchPatients(
 
 	// Validation et normalisation de la requête
 	if err := s.validateAndNormalizeRequest(req); err != nil {
-		return nil, fmt.Errorf("invalid search request: %w", err)
+		return nil, dto.NewPatientSearchError(dto.ErrSearchInvalidCriteria, err.Error())
 	}
 
 	// Stratégie de recherche basée sur le type
@@ -65,6 +68,25 @@ func (s *PatientSearchService) searchByCodeWithCache(
 	startTime time.Time,
 	appliedFilters []string,
 ) (*dto.SearchPatientResponse, error) {
+	// 0. Clé de contrôle fausse : aucun résultat, suggestions des dossiers proches
+	if hasCheck, valid := s.codeGenerator.VerifyCheckCharacter(ctx, req.CodePatient); hasCheck && !valid {
+		suggestions, err := s.codeGenerator.FindCodeSuggestions(ctx, req.CodePatient)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.SearchPatientResponse{
+			Patients:   []dto.PatientSearchResult{},
+			Pagination: dto.NewPaginationInfo(req.Page, req.Limit, 0),
+			SearchInfo: dto.NewSearchMetadata(
+				dto.SearchTypeDirectCode,
+				int(time.Since(startTime).Milliseconds()),
+				0,
+				appliedFilters,
+			),
+			Suggestions: suggestions,
+		}, nil
+	}
+
 	// 1. Tentative cache Redis (< 5ms selon spécifications)
 	cacheKey := fmt.Sprintf("soins_suite_patient_cache:%s", req.CodePatient)
 	
@@ -184,10 +206,13 @@ func (s *PatientSearchService) searchByFullText(
 		return nil, fmt.Errorf("failed to count search results: %w", err)
 	}
 
-	// 2. Récupérer les résultats paginés
+	// 2. Récupérer les résultats paginés (curseur pour les tris décroissants, OFFSET sinon)
 	var patients []dto.PatientSearchResult
+	var nextCursor string
 
-	if req.IncludeAssurances {
+	if req.UsesKeyset() {
+		patients, nextCursor, err = s.searchKeyset(ctx, req, dto.SearchTypeFullText)
+	} else if req.IncludeAssurances {
 		patients, err = s.searchWithAssurances(ctx, req)
 	} else {
 		patients, err = s.searchWithoutAssurances(ctx, req, queries.PatientSearchQueries.SearchPatientsFullText)
@@ -199,7 +224,7 @@ func (s *PatientSearchService) searchByFullText(
 
 	return &dto.SearchPatientResponse{
 		Patients:   patients,
-		Pagination: dto.NewPaginationInfo(req.Page, req.Limit, total),
+		Pagination: newSearchPagination(req, total, nextCursor),
 		SearchInfo: dto.NewSearchMetadata(
 			dto.SearchTypeFullText,
 			int(time.Since(startTime).Milliseconds()),
@@ -236,15 +261,22 @@ func (s *PatientSearchService) searchByCriteria(
 		return nil, fmt.Errorf("failed to count search results: %w", err)
 	}
 
-	// 2. Récupérer les résultats paginés
-	patients, err := s.searchWithoutAssurances(ctx, req, queries.PatientSearchQueries.SearchPatientsByCriteria)
+	// 2. Récupérer les résultats paginés (curseur pour les tris décroissants, OFFSET sinon)
+	var patients []dto.PatientSearchResult
+	var nextCursor string
+
+	if req.UsesKeyset() {
+		patients, nextCursor, err = s.searchKeyset(ctx, req, dto.SearchTypeCriteria)
+	} else {
+		patients, err = s.searchWithoutAssurances(ctx, req, queries.PatientSearchQueries.SearchPatientsByCriteria)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute criteria search: %w", err)
 	}
 
 	return &dto.SearchPatientResponse{
 		Patients:   patients,
-		Pagination: dto.NewPaginationInfo(req.Page, req.Limit, total),
+		Pagination: newSearchPagination(req, total, nextCursor),
 		SearchInfo: dto.NewSearchMetadata(
 			dto.SearchTypeCriteria,
 			int(time.Since(startTime).Milliseconds()),
@@ -412,6 +444,16 @@ func (s *PatientSearchService) searchWithAssurances(
 	return patients, nil
 }
 
+// newSearchPagination construit la pagination ; en mode curseur, has_next suit l'existence d'une page suivante
+func newSearchPagination(req *dto.SearchPatientRequest, total int, nextCursor string) dto.PaginationInfo {
+	pagination := dto.NewPaginationInfo(req.Page, req.Limit, total)
+	if req.UsesKeyset() {
+		pagination.HasNext = nextCursor != ""
+		pagination.NextCursor = nextCursor
+	}
+	return pagination
+}
+
 // validateAndNormalizeRequest valide et normalise la requête de recherche
 func (s *PatientSearchService) validateAndNormalizeRequest(req *dto.SearchPatientRequest) error {
 	// Définir les valeurs par défaut
@@ -422,6 +464,11 @@ func (s *PatientSearchService) validateAndNormalizeRequest(req *dto.SearchPatien
 		return fmt.Errorf("limit cannot exceed 50")
 	}
 
+	// Pagination par curseur : uniquement pour les tris décroissants par pertinence ou date
+	if req.Cursor != "" && !req.UsesKeyset() {
+		return fmt.Errorf("cursor pagination requires sort_order desc and sort_by score or created_at")
+	}
+
 	// Validation des dates
 	if req.DateNaissanceDebut != nil && req.DateNaissanceFin != nil {
 		if req.DateNaissanceDebut.After(*req.DateNaissanceFin) {
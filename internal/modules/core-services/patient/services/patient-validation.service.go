@@ -2,12 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"soins-suite-core/internal/infrastructure/database/postgres"
 	"soins-suite-core/internal/modules/core-services/patient/dto"
@@ -16,19 +18,31 @@ import (
 
 // PatientValidationService gère les validations métier et détection de doublons
 type PatientValidationService struct {
-	db *postgres.Client
+	db             *postgres.Client
+	txManager      *postgres.TransactionManager
+	historyService *PatientHistoryService
+	matcher        *PatientMatchingEngine
 
 	// Regex pour validations Côte d'Ivoire
 	phoneRegex *regexp.Regexp
 	emailRegex *regexp.Regexp
 }
 
+// Nombre maximum de candidats présélectionnés en base avant scoring Go
+const maxDuplicateCandidates = 500
+
 // NewPatientValidationService crée une nouvelle instance du service
-func NewPatientValidationService(db *postgres.Client) *PatientValidationService {
+func NewPatientValidationService(
+	db *postgres.Client,
+	historyService *PatientHistoryService,
+) *PatientValidationService {
 	return &PatientValidationService{
-		db:         db,
-		phoneRegex: regexp.MustCompile(`^(\+225|00225)?[0-9]{10}$`),
-		emailRegex: regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
+		db:             db,
+		txManager:      postgres.NewTransactionManager(db),
+		historyService: historyService,
+		matcher:        NewPatientMatchingEngine(),
+		phoneRegex:     regexp.MustCompile(`^(\+225|00225)?[0-9]{10}$`),
+		emailRegex:     regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`),
 	}
 }
 
@@ -44,28 +58,27 @@ func (s *PatientValidationService) CheckPatientDuplicate(
 		return nil, err
 	}
 
-	// Exécution de la requête de détection avec scoring
+	// 1. Présélection large des candidats en base (trigrammes sans accents, variantes de date, suffixe téléphone)
 	rows, err := s.db.Query(ctx,
-		queries.PatientCreationQueries.CheckDuplicateWithScoring,
+		queries.PatientCreationQueries.SelectDuplicateCandidates,
 		req.Nom,
 		req.Prenoms,
 		req.DateNaissance,
-		req.TelephonePrincipal,
-		req.ScoreMinimum,
-		req.LimiteResultats,
+		s.matcher.BirthDateVariants(req.DateNaissance),
+		s.matcher.PhoneSuffix(req.TelephonePrincipal),
+		maxDuplicateCandidates,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check duplicates: %w", err)
 	}
 	defer rows.Close()
 
-	var potentialMatches []dto.PotentialDuplicate
+	potentialMatches := []dto.PotentialDuplicate{}
 	var highestScore int
 
-	// Parser les résultats
+	// 2. Scoring côté Go (phonétique, inversions, transpositions)
 	for rows.Next() {
 		var patient dto.PatientSearchResult
-		var scoreGlobal, nomMatch, prenomsMatch, dateMatch, telephoneMatch int
 
 		err := rows.Scan(
 			&patient.ID,
@@ -79,37 +92,35 @@ func (s *PatientValidationService) CheckPatientDuplicate(
 			&patient.EstAssure,
 			&patient.Statut,
 			&patient.CreatedAt,
-			&scoreGlobal,
-			&nomMatch,
-			&prenomsMatch,
-			&dateMatch,
-			&telephoneMatch,
 		)
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("failed to scan duplicate candidate: %w", err)
 		}
 
-		// Mettre à jour le score le plus élevé
-		if scoreGlobal > highestScore {
-			highestScore = scoreGlobal
+		matchDetail := s.matcher.ScoreCandidate(req, &patient)
+		if matchDetail.ScoreGlobal < req.ScoreMinimum {
+			continue
 		}
 
-		// Créer le détail du match
-		matchDetail := dto.MatchDetail{
-			NomMatch:           nomMatch,
-			PrenomsMatch:       prenomsMatch,
-			DateNaissanceMatch: dateMatch,
-			TelephoneMatch:     telephoneMatch,
-			ScoreGlobal:        scoreGlobal,
+		// Mettre à jour le score le plus élevé
+		if matchDetail.ScoreGlobal > highestScore {
+			highestScore = matchDetail.ScoreGlobal
 		}
 
-		potentialMatch := dto.PotentialDuplicate{
+		potentialMatches = append(potentialMatches, dto.PotentialDuplicate{
 			Patient:      patient,
-			Score:        scoreGlobal,
+			Score:        matchDetail.ScoreGlobal,
 			MatchDetails: matchDetail,
-		}
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate duplicate candidates: %w", err)
+	}
 
-		potentialMatches = append(potentialMatches, potentialMatch)
+	// 3. Tri par score et limitation du nombre de résultats
+	sortByScore(potentialMatches)
+	if len(potentialMatches) > req.LimiteResultats {
+		potentialMatches = potentialMatches[:req.LimiteResultats]
 	}
 
 	// Construire la réponse
@@ -121,12 +132,6 @@ func (s *PatientValidationService) CheckPatientDuplicate(
 		CheckExecutedAt:  time.Now(),
 	}
 
-	// Log pour debug si duplicatas détectés
-	if response.HasDuplicates {
-		fmt.Printf("[DEBUG] Duplicate check - Highest score: %d, Matches: %d, Recommendation: %s\n",
-			highestScore, len(potentialMatches), response.Recommendation)
-	}
-
 	return response, nil
 }
 
@@ -349,19 +354,19 @@ func (s *PatientValidationService) validateBusinessRules(req *dto.CreatePatientR
 // validateDuplicateCheckRequest valide la requête de vérification de doublon
 func (s *PatientValidationService) validateDuplicateCheckRequest(req *dto.DuplicateCheckRequest) error {
 	if strings.TrimSpace(req.Nom) == "" {
-		return fmt.Errorf("nom requis pour la vérification de doublon")
+		return dto.NewPatientSearchError(dto.ErrDuplicateCheckInvalid, "nom requis pour la vérification de doublon")
 	}
 	if strings.TrimSpace(req.Prenoms) == "" {
-		return fmt.Errorf("prénoms requis pour la vérification de doublon")
+		return dto.NewPatientSearchError(dto.ErrDuplicateCheckInvalid, "prénoms requis pour la vérification de doublon")
 	}
 	if req.DateNaissance.IsZero() {
-		return fmt.Errorf("date de naissance requise pour la vérification de doublon")
+		return dto.NewPatientSearchError(dto.ErrDuplicateCheckInvalid, "date de naissance requise pour la vérification de doublon")
 	}
 	if req.ScoreMinimum < 0 || req.ScoreMinimum > 100 {
-		return fmt.Errorf("score minimum doit être entre 0 et 100")
+		return dto.NewPatientSearchError(dto.ErrDuplicateCheckInvalid, "score minimum doit être entre 0 et 100")
 	}
 	if req.LimiteResultats < 1 || req.LimiteResultats > 50 {
-		return fmt.Errorf("limite résultats doit être entre 1 et 50")
+		return dto.NewPatientSearchError(dto.ErrDuplicateCheckInvalid, "limite résultats doit être entre 1 et 50")
 	}
 	return nil
 }
@@ -377,13 +382,252 @@ func (s *PatientValidationService) getRecommendationFromScore(score int) string
 	return dto.RecommendationAllow
 }
 
-// UpdatePatient effectue la mise à jour partielle d'un patient avec validations
+// UpdatePatient effectue la mise à jour partielle d'un patient avec validations (CS-P-004)
 func (s *PatientValidationService) UpdatePatient(
 	ctx context.Context,
 	codePatient string,
 	req *dto.UpdatePatientRequest,
+	updatedBy uuid.UUID,
 ) (*dto.PatientResponse, error) {
-	// TODO: Implémentation de la mise à jour partielle
-	// Pour l'instant, on retourne une erreur indiquant que cette fonctionnalité n'est pas encore implémentée
-	return nil, fmt.Errorf("update patient not implemented yet - CS-P-004")
+	// 1. Validations format et cohérence des champs fournis
+	errors := s.validateUpdateData(req)
+
+	// 2. Validations références modifiées
+	refErrors, err := s.validateUpdateReferenceData(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate references: %w", err)
+	}
+	errors = append(errors, refErrors...)
+
+	if len(errors) > 0 {
+		return nil, dto.NewInvalidPatientDataError(errors)
+	}
+
+	// 3. Sérialisation des personnes à contacter (remplacement complet si fourni)
+	var personnesJSON *string
+	if req.PersonnesAContacter != nil {
+		data, err := json.Marshal(*req.PersonnesAContacter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal personnes_a_contacter: %w", err)
+		}
+		value := string(data)
+		personnesJSON = &value
+	}
+
+	// 4. Mise à jour partielle + historisation du diff dans la même transaction
+	var patient dto.PatientResponse
+	err = s.txManager.WithTransaction(ctx, func(tx *postgres.Transaction) error {
+		patientID, avant, err := s.historyService.LockSnapshot(ctx, tx, codePatient)
+		if err != nil {
+			return err
+		}
+		if estAnonymise, _ := avant["est_anonymise"].(bool); estAnonymise {
+			return dto.NewPatientAnonymizedError(codePatient)
+		}
+
+		err = tx.QueryRow(ctx,
+			queries.PatientUpdateQueries.UpdatePatientPartial,
+			codePatient,                 // $1
+			req.Nom,                     // $2
+			req.Prenoms,                 // $3
+			req.DateNaissance,           // $4
+			req.EstDateSupposee,         // $5
+			req.Sexe,                    // $6
+			req.NationaliteID,           // $7
+			req.SituationMatrimonialeID, // $8
+			req.TelephonePrincipal,      // $9
+			req.TelephoneSecondaire,     // $10
+			req.Email,                   // $11
+			req.AdresseComplete,         // $12
+			req.Quartier,                // $13
+			req.Ville,                   // $14
+			req.Commune,                 // $15
+			personnesJSON,               // $16
+			updatedBy,                   // $17
+		).Scan(
+			&patient.ID,
+			&patient.CodePatient,
+			&patient.Nom,
+			&patient.Prenoms,
+			&patient.DateNaissance,
+			&patient.EstDateSupposee,
+			&patient.Sexe,
+			&patient.TelephonePrincipal,
+			&patient.TelephoneSecondaire,
+			&patient.Email,
+			&patient.AdresseComplete,
+			&patient.EstAssure,
+			&patient.EtablissementCreateurID,
+			&patient.Statut,
+			&patient.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		apres, err := s.historyService.Snapshot(ctx, tx, patientID)
+		if err != nil {
+			return err
+		}
+
+		return s.historyService.RecordChanges(ctx, tx,
+			patientID, codePatient, dto.HistoryTypeModification,
+			avant, apres, req.Motif, updatedBy)
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, s.resolveUpdateNotFound(ctx, codePatient)
+		}
+		return nil, fmt.Errorf("failed to update patient: %w", err)
+	}
+
+	fmt.Printf("[AUDIT] Patient updated - Code: %s, UpdatedBy: %s\n", patient.CodePatient, updatedBy)
+
+	return &patient, nil
+}
+
+// validateUpdateData valide uniquement les champs fournis dans la mise à jour
+func (s *PatientValidationService) validateUpdateData(req *dto.UpdatePatientRequest) []dto.ValidationError {
+	var errors []dto.ValidationError
+
+	if req.Nom != nil && strings.TrimSpace(*req.Nom) == "" {
+		errors = append(errors, dto.NewValidationError(
+			"nom",
+			dto.ValidationErrorRequiredField,
+			"Le nom ne peut être vide",
+			*req.Nom,
+		))
+	}
+
+	if req.Prenoms != nil && strings.TrimSpace(*req.Prenoms) == "" {
+		errors = append(errors, dto.NewValidationError(
+			"prenoms",
+			dto.ValidationErrorRequiredField,
+			"Les prénoms ne peuvent être vides",
+			*req.Prenoms,
+		))
+	}
+
+	if req.TelephonePrincipal != nil && !s.phoneRegex.MatchString(*req.TelephonePrincipal) {
+		errors = append(errors, dto.NewValidationError(
+			"telephone_principal",
+			dto.ValidationErrorInvalidPhone,
+			"Format téléphone invalide pour la Côte d'Ivoire",
+			*req.TelephonePrincipal,
+		))
+	}
+
+	if req.Email != nil && *req.Email != "" && !s.emailRegex.MatchString(*req.Email) {
+		errors = append(errors, dto.NewValidationError(
+			"email",
+			dto.ValidationErrorInvalidEmail,
+			"Format email invalide",
+			*req.Email,
+		))
+	}
+
+	if req.DateNaissance != nil {
+		if req.DateNaissance.After(time.Now()) {
+			errors = append(errors, dto.NewValidationError(
+				"date_naissance",
+				dto.ValidationErrorInvalidDate,
+				"La date de naissance ne peut pas être dans le futur",
+				*req.DateNaissance,
+			))
+		} else if req.DateNaissance.Before(time.Now().AddDate(-150, 0, 0)) {
+			errors = append(errors, dto.NewValidationError(
+				"date_naissance",
+				dto.ValidationErrorInvalidDate,
+				"Âge non réaliste (plus de 150 ans)",
+				*req.DateNaissance,
+			))
+		}
+	}
+
+	if req.AdresseComplete != nil && strings.TrimSpace(*req.AdresseComplete) == "" {
+		errors = append(errors, dto.NewValidationError(
+			"adresse_complete",
+			dto.ValidationErrorRequiredField,
+			"L'adresse ne peut être vide",
+			*req.AdresseComplete,
+		))
+	}
+
+	if req.PersonnesAContacter != nil && len(*req.PersonnesAContacter) > 5 {
+		errors = append(errors, dto.NewValidationError(
+			"personnes_a_contacter",
+			dto.ValidationErrorInvalidFormat,
+			"Maximum 5 personnes à contacter autorisées",
+			len(*req.PersonnesAContacter),
+		))
+	}
+
+	return errors
+}
+
+// validateUpdateReferenceData valide les références modifiées
+func (s *PatientValidationService) validateUpdateReferenceData(
+	ctx context.Context,
+	req *dto.UpdatePatientRequest,
+) ([]dto.ValidationError, error) {
+	if req.NationaliteID == nil && req.SituationMatrimonialeID == nil {
+		return nil, nil
+	}
+
+	var errors []dto.ValidationError
+	var nationaliteExists, situationExists bool
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientUpdateQueries.ValidateUpdateReferenceData,
+		req.NationaliteID,
+		req.SituationMatrimonialeID,
+	).Scan(&nationaliteExists, &situationExists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate reference data: %w", err)
+	}
+
+	if !nationaliteExists {
+		errors = append(errors, dto.NewValidationError(
+			"nationalite_id",
+			dto.ValidationErrorReferenceNotFound,
+			"Nationalité introuvable ou inactive",
+			req.NationaliteID,
+		))
+	}
+
+	if !situationExists {
+		errors = append(errors, dto.NewValidationError(
+			"situation_matrimoniale_id",
+			dto.ValidationErrorReferenceNotFound,
+			"Situation matrimoniale introuvable ou inactive",
+			req.SituationMatrimonialeID,
+		))
+	}
+
+	return errors, nil
+}
+
+// resolveUpdateNotFound distingue patient inexistant et patient archivé après un UPDATE sans effet
+func (s *PatientValidationService) resolveUpdateNotFound(ctx context.Context, codePatient string) error {
+	var patientID uuid.UUID
+	var statut string
+	var estDecede bool
+	var updatedAt time.Time
+
+	err := s.db.QueryRow(ctx,
+		queries.PatientDetailQueries.CheckPatientExists,
+		codePatient,
+	).Scan(&patientID, &statut, &estDecede, &updatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return dto.NewPatientNotFoundError(codePatient)
+		}
+		return fmt.Errorf("failed to check patient existence: %w", err)
+	}
+
+	if statut == "archive" {
+		return dto.NewPatientArchivedError(codePatient)
+	}
+
+	return dto.NewPatientNotFoundError(codePatient)
 }
@@ -0,0 +1,105 @@
+package accueil
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+
+	patientsControllers "soins-suite-core/internal/modules/front-office/accueil/controllers/patients"
+	patientsServices "soins-suite-core/internal/modules/front-office/accueil/services/patients"
+	authMiddleware "soins-suite-core/internal/shared/middleware/auth"
+	"soins-suite-core/internal/shared/middleware/tenant"
+)
+
+// Module regroupe tous les providers du module front-office ACCUEIL
+var Module = fx.Options(
+	// Services (utilisent core-services directement)
+	fx.Provide(patientsServices.NewPatientsService),
+
+	// Controllers
+	fx.Provide(patientsControllers.NewPatientsController),
+
+	// Configuration des routes
+	fx.Invoke(RegisterPatientsRoutes),
+)
+
+// RegisterPatientsRoutes configure les routes de la rubrique ACCUEIL › PATIENTS
+func RegisterPatientsRoutes(
+	r *gin.Engine,
+	ctrl *patientsControllers.PatientsController,
+	authStack *authMiddleware.AuthMiddlewareStack,
+	licenseMW *tenant.LicenseMiddleware,
+) {
+	api := r.Group("/api/v1/front-office/patients")
+	api.Use(authMiddleware.RequireRubrique(authStack, "ACCUEIL", "PATIENTS")...)
+	api.Use(licenseMW.Handler())
+	{
+		// POST /api/v1/front-office/patients - Créer un patient
+		api.POST("", ctrl.CreatePatient)
+
+		// POST /api/v1/front-office/patients/search - Recherche multi-critères
+		api.POST("/search", ctrl.SearchPatients)
+
+		// GET /api/v1/front-office/patients/autocompletion - Suggestions pendant la saisie (nom, prénoms, code, téléphone)
+		api.GET("/autocompletion", ctrl.AutocompletePatients)
+
+		// POST /api/v1/front-office/patients/duplicates/check - Vérification anti-doublon
+		api.POST("/duplicates/check", ctrl.CheckDuplicate)
+
+		// POST /api/v1/front-office/patients/merge - Fusion de doublons confirmés
+		api.POST("/merge", ctrl.MergePatients)
+
+		// POST /api/v1/front-office/patients/$fhir - Import d'une ressource FHIR R4 Patient
+		api.POST("/$fhir", ctrl.ImportFhirPatient)
+
+		// POST /api/v1/front-office/patients/inconnus - Admission d'un patient non identifié (urgences)
+		api.POST("/inconnus", ctrl.RegisterUnknownPatient)
+
+		// GET /api/v1/front-office/patients/inconnus - Patients inconnus restant à réconcilier
+		api.GET("/inconnus", ctrl.ListUnknownPatients)
+
+		// GET /api/v1/front-office/patients/:code - Détail patient (cache-first)
+		api.GET("/:code", ctrl.GetPatientByCode)
+
+		// PATCH /api/v1/front-office/patients/:code - Modification partielle
+		api.PATCH("/:code", ctrl.UpdatePatient)
+
+		// GET /api/v1/front-office/patients/:code/$fhir - Export FHIR R4 Patient
+		api.GET("/:code/$fhir", ctrl.ExportFhirPatient)
+
+		// POST /api/v1/front-office/patients/:code/deces - Déclaration de décès
+		api.POST("/:code/deces", ctrl.DeclareDeath)
+
+		// POST /api/v1/front-office/patients/:code/desactivation - Passage au statut inactif
+		api.POST("/:code/desactivation", ctrl.DeactivatePatient)
+
+		// POST /api/v1/front-office/patients/:code/archivage - Archivage du dossier
+		api.POST("/:code/archivage", ctrl.ArchivePatient)
+
+		// POST /api/v1/front-office/patients/:code/reactivation - Réactivation (hors décédés)
+		api.POST("/:code/reactivation", ctrl.ReactivatePatient)
+
+		// GET /api/v1/front-office/patients/:code/historique - Chronologie des modifications
+		api.GET("/:code/historique", ctrl.GetPatientHistory)
+
+		// GET /api/v1/front-office/patients/:code/historique/etat - Dossier à une date donnée
+		api.GET("/:code/historique/etat", ctrl.GetPatientStateAt)
+
+		// GET /api/v1/front-office/patients/:code/assurances - Couvertures d'assurance
+		api.GET("/:code/assurances", ctrl.ListPatientCoverages)
+
+		// POST /api/v1/front-office/patients/:code/assurances - Ajout d'une couverture (principal ou ayant droit)
+		api.POST("/:code/assurances", ctrl.AddPatientCoverage)
+
+		// POST /api/v1/front-office/patients/:code/assurances/:id/desactivation - Inactivation motivée
+		api.POST("/:code/assurances/:id/desactivation", ctrl.DeactivatePatientCoverage)
+
+		// GET /api/v1/front-office/patients/:code/carte - Carte patient imprimable (PDF ou PNG)
+		api.GET("/:code/carte", ctrl.GetPatientCard)
+
+		// POST /api/v1/front-office/patients/:code/reconciliation/identite - Patient inconnu identifié : identité complétée
+		api.POST("/:code/reconciliation/identite", ctrl.CompleteUnknownPatientIdentity)
+
+		// POST /api/v1/front-office/patients/:code/reconciliation/fusion - Patient inconnu identifié : fusion vers son dossier
+		api.POST("/:code/reconciliation/fusion", ctrl.MergeUnknownPatient)
+	}
+}
@@ -0,0 +1,1102 @@
+package patients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	services "soins-suite-core/internal/modules/front-office/accueil/services/patients"
+)
+
+type PatientsController struct {
+	service   *services.PatientsService
+	validator *validator.Validate
+}
+
+func NewPatientsController(service *services.PatientsService) *PatientsController {
+	return &PatientsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// CreatePatient POST /api/v1/front-office/patients
+func (c *PatientsController) CreatePatient(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.CreatePatientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.CreatePatient(ctx.Request.Context(), establishmentCode, establishmentID, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la création du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// SearchPatients POST /api/v1/front-office/patients/search
+func (c *PatientsController) SearchPatients(ctx *gin.Context) {
+	var req corePatientDTO.SearchPatientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	result, err := c.service.SearchPatients(ctx.Request.Context(), &req)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la recherche de patients")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// AutocompletePatients GET /api/v1/front-office/patients/autocompletion
+func (c *PatientsController) AutocompletePatients(ctx *gin.Context) {
+	var req corePatientDTO.PatientAutocompleteRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.AutocompletePatients(ctx.Request.Context(), &req)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la recherche de patients")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetPatientByCode GET /api/v1/front-office/patients/:code
+func (c *PatientsController) GetPatientByCode(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	includeInactive := ctx.Query("include_inactive") == "true"
+	forceRefresh := ctx.Query("force_refresh") == "true"
+
+	result, err := c.service.GetPatientByCode(ctx.Request.Context(), ctx.GetString("establishment_id"), codePatient,
+		includeInactive, forceRefresh, ctx.GetString("user_id"))
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la récupération du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CheckDuplicate POST /api/v1/front-office/patients/duplicates/check
+func (c *PatientsController) CheckDuplicate(ctx *gin.Context) {
+	var req corePatientDTO.DuplicateCheckRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.CheckDuplicate(ctx.Request.Context(), &req)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la vérification des doublons")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// UpdatePatient PATCH /api/v1/front-office/patients/:code
+func (c *PatientsController) UpdatePatient(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.UpdatePatientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.UpdatePatient(ctx.Request.Context(), codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la modification du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// MergePatients POST /api/v1/front-office/patients/merge
+func (c *PatientsController) MergePatients(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.MergePatientsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.MergePatients(ctx.Request.Context(), establishmentID, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la fusion des dossiers patients")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetPatientHistory GET /api/v1/front-office/patients/:code/historique
+func (c *PatientsController) GetPatientHistory(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	var filter corePatientDTO.PatientHistoryFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	result, err := c.service.GetPatientHistory(ctx.Request.Context(), codePatient, &filter)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la récupération de l'historique du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetPatientStateAt GET /api/v1/front-office/patients/:code/historique/etat?date=YYYY-MM-DD
+func (c *PatientsController) GetPatientStateAt(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	date, err := c.parseHistoryDate(ctx.Query("date"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code": "VALIDATION_ERROR",
+				"champs": map[string]string{
+					"date": "Date requise au format YYYY-MM-DD ou RFC3339",
+				},
+			},
+		})
+		return
+	}
+
+	result, err := c.service.GetPatientStateAt(ctx.Request.Context(), codePatient, date)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la reconstitution du dossier patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// parseHistoryDate accepte une date simple (fin de journée incluse) ou un horodatage RFC3339
+func (c *PatientsController) parseHistoryDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("date requise")
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// DeclareDeath POST /api/v1/front-office/patients/:code/deces
+func (c *PatientsController) DeclareDeath(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.DeclareDeathRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.DeclareDeath(ctx.Request.Context(), establishmentID, codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la déclaration de décès")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// DeactivatePatient POST /api/v1/front-office/patients/:code/desactivation
+func (c *PatientsController) DeactivatePatient(ctx *gin.Context) {
+	c.handleLifecycleTransition(ctx, c.service.DeactivatePatient, "Erreur lors de la désactivation du patient")
+}
+
+// ArchivePatient POST /api/v1/front-office/patients/:code/archivage
+func (c *PatientsController) ArchivePatient(ctx *gin.Context) {
+	c.handleLifecycleTransition(ctx, c.service.ArchivePatient, "Erreur lors de l'archivage du patient")
+}
+
+// ReactivatePatient POST /api/v1/front-office/patients/:code/reactivation
+func (c *PatientsController) ReactivatePatient(ctx *gin.Context) {
+	c.handleLifecycleTransition(ctx, c.service.ReactivatePatient, "Erreur lors de la réactivation du patient")
+}
+
+// handleLifecycleTransition factorise les transitions de statut ne nécessitant qu'un motif
+func (c *PatientsController) handleLifecycleTransition(
+	ctx *gin.Context,
+	action func(context.Context, string, *corePatientDTO.LifecycleTransitionRequest, string) (*corePatientDTO.PatientLifecycleResult, error),
+	defaultMessage string,
+) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.LifecycleTransitionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := action(ctx.Request.Context(), codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, defaultMessage)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ExportFhirPatient GET /api/v1/front-office/patients/:code/$fhir
+func (c *PatientsController) ExportFhirPatient(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	resource, err := c.service.ExportFhirPatient(ctx.Request.Context(), ctx.GetString("establishment_id"), codePatient,
+		ctx.GetString("user_id"))
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de l'export FHIR du patient")
+		return
+	}
+
+	// Ressource FHIR brute (sans enveloppe) pour consommation directe par les partenaires
+	ctx.Header("Content-Type", "application/fhir+json; charset=utf-8")
+	ctx.JSON(http.StatusOK, resource)
+}
+
+// ImportFhirPatient POST /api/v1/front-office/patients/$fhir
+func (c *PatientsController) ImportFhirPatient(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var resource corePatientDTO.FhirPatient
+	if err := ctx.ShouldBindJSON(&resource); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	result, err := c.service.ImportFhirPatient(ctx.Request.Context(), establishmentCode, establishmentID, &resource, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de l'import FHIR du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListPatientCoverages GET /api/v1/front-office/patients/:code/assurances?include_inactive=true
+func (c *PatientsController) ListPatientCoverages(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	var req corePatientDTO.ListCoveragesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	result, err := c.service.ListPatientCoverages(ctx.Request.Context(), codePatient, &req)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la récupération des couvertures du patient")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// AddPatientCoverage POST /api/v1/front-office/patients/:code/assurances
+func (c *PatientsController) AddPatientCoverage(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.AddCoverageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.AddPatientCoverage(ctx.Request.Context(), establishmentID, codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de l'ajout de la couverture")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// DeactivatePatientCoverage POST /api/v1/front-office/patients/:code/assurances/:id/desactivation
+func (c *PatientsController) DeactivatePatientCoverage(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	couvertureID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Identifiant de couverture invalide",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.DeactivateCoverageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.DeactivatePatientCoverage(ctx.Request.Context(), codePatient, couvertureID, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de l'inactivation de la couverture")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetPatientCard GET /api/v1/front-office/patients/:code/carte?format=pdf|png
+func (c *PatientsController) GetPatientCard(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.RenderPatientCardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+	if champs := c.validateStruct(&req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	document, err := c.service.RenderPatientCard(ctx.Request.Context(), establishmentID, codePatient, req.Format,
+		ctx.GetString("user_id"))
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la génération de la carte patient")
+		return
+	}
+
+	// Document binaire (sans enveloppe) : impression directe ou téléchargement
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", document.NomFichier))
+	ctx.Data(http.StatusOK, document.ContentType, document.Contenu)
+}
+
+// RegisterUnknownPatient POST /api/v1/front-office/patients/inconnus
+func (c *PatientsController) RegisterUnknownPatient(ctx *gin.Context) {
+	establishmentCode := ctx.GetHeader("X-Establishment-Code")
+	if establishmentCode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Header X-Establishment-Code requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.RegisterUnknownPatientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.RegisterUnknownPatient(ctx.Request.Context(), establishmentCode, establishmentID, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de l'admission du patient inconnu")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListUnknownPatients GET /api/v1/front-office/patients/inconnus?page=1&limit=20
+func (c *PatientsController) ListUnknownPatients(ctx *gin.Context) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	var filter corePatientDTO.ProvisionalPatientFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	result, err := c.service.ListUnknownPatients(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la récupération des patients inconnus")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// CompleteUnknownPatientIdentity POST /api/v1/front-office/patients/:code/reconciliation/identite
+func (c *PatientsController) CompleteUnknownPatientIdentity(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.CompleteProvisionalIdentityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.CompleteUnknownPatientIdentity(ctx.Request.Context(), establishmentID, codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la réconciliation du patient inconnu")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// MergeUnknownPatient POST /api/v1/front-office/patients/:code/reconciliation/fusion
+func (c *PatientsController) MergeUnknownPatient(ctx *gin.Context) {
+	codePatient := ctx.Param("code")
+	if codePatient == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Code patient requis",
+		})
+		return
+	}
+
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return
+	}
+
+	var req corePatientDTO.MergeProvisionalPatientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.respondBindingError(ctx, err)
+		return
+	}
+
+	if champs := c.validateStruct(req); champs != nil {
+		c.respondValidationError(ctx, champs)
+		return
+	}
+
+	result, err := c.service.MergeUnknownPatient(ctx.Request.Context(), establishmentID, codePatient, &req, userID)
+	if err != nil {
+		c.respondServiceError(ctx, err, "Erreur lors de la réconciliation du patient inconnu")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// respondServiceError traduit les erreurs des core-services patient en réponses HTTP
+func (c *PatientsController) respondServiceError(ctx *gin.Context, err error, defaultMessage string) {
+	var notFoundErr *corePatientDTO.PatientNotFoundError
+	var archivedErr *corePatientDTO.PatientArchivedError
+	var invalidErr *corePatientDTO.InvalidPatientDataError
+	var duplicateErr *corePatientDTO.PatientDuplicateError
+	var mergeErr *corePatientDTO.PatientMergeError
+	var notExistingErr *corePatientDTO.PatientNotExistingAtDateError
+	var lifecycleErr *corePatientDTO.PatientLifecycleError
+	var fhirErr *corePatientDTO.FhirImportError
+	var coverageErr *corePatientDTO.PatientCoverageError
+	var mistypedErr *corePatientDTO.PatientCodeMistypedError
+	var provisionalErr *corePatientDTO.ProvisionalPatientError
+	var searchErr *corePatientDTO.PatientSearchError
+	var anonymizedErr *corePatientDTO.PatientAnonymizedError
+
+	switch {
+	case errors.As(err, &searchErr):
+		message := "Critères de recherche invalides"
+		if searchErr.Code == corePatientDTO.ErrDuplicateCheckInvalid {
+			message = "Paramètres de vérification invalides"
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+			"details": map[string]interface{}{
+				"code":    searchErr.Code,
+				"message": searchErr.Message,
+			},
+		})
+	case errors.As(err, &mistypedErr):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error": "Code patient mal saisi",
+			"details": map[string]interface{}{
+				"code":         "PATIENT_CODE_MISTYPED",
+				"code_patient": mistypedErr.CodePatient,
+				"message":      mistypedErr.Message,
+				"suggestions":  mistypedErr.Suggestions,
+			},
+		})
+	case errors.As(err, &notFoundErr):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error": "Patient introuvable",
+			"details": map[string]interface{}{
+				"code":         "PATIENT_NOT_FOUND",
+				"code_patient": notFoundErr.CodePatient,
+				"message":      notFoundErr.Message,
+			},
+		})
+	case errors.As(err, &archivedErr):
+		ctx.JSON(http.StatusGone, gin.H{
+			"error": "Patient archivé",
+			"details": map[string]interface{}{
+				"code":         "PATIENT_ARCHIVED",
+				"code_patient": archivedErr.CodePatient,
+				"message":      archivedErr.Message,
+			},
+		})
+	case errors.As(err, &anonymizedErr):
+		ctx.JSON(http.StatusGone, gin.H{
+			"error": "Patient anonymisé",
+			"details": map[string]interface{}{
+				"code":         "PATIENT_ANONYMIZED",
+				"code_patient": anonymizedErr.CodePatient,
+				"message":      anonymizedErr.Message,
+			},
+		})
+	case errors.As(err, &invalidErr):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": "Données patient invalides",
+			"details": map[string]interface{}{
+				"code":   "INVALID_PATIENT_DATA",
+				"errors": invalidErr.Errors,
+			},
+		})
+	case errors.As(err, &duplicateErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error": "Doublon patient probable détecté",
+			"details": map[string]interface{}{
+				"code":            "PATIENT_DUPLICATE_DETECTED",
+				"message":         duplicateErr.Error(),
+				"duplicate_check": duplicateErr.Result,
+			},
+		})
+	case errors.As(err, &mergeErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error": "Fusion impossible",
+			"details": map[string]interface{}{
+				"code":    mergeErr.Code,
+				"message": mergeErr.Message,
+			},
+		})
+	case errors.As(err, &notExistingErr):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"error": "Patient inexistant à cette date",
+			"details": map[string]interface{}{
+				"code":         "PATIENT_NOT_EXISTING_AT_DATE",
+				"code_patient": notExistingErr.CodePatient,
+				"created_at":   notExistingErr.CreatedAt,
+				"message":      notExistingErr.Message,
+			},
+		})
+	case errors.As(err, &lifecycleErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"error": "Changement de statut impossible",
+			"details": map[string]interface{}{
+				"code":          lifecycleErr.Code,
+				"message":       lifecycleErr.Message,
+				"statut_actuel": lifecycleErr.StatutActuel,
+				"statut_cible":  lifecycleErr.StatutCible,
+			},
+		})
+	case errors.As(err, &fhirErr):
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": "Ressource FHIR invalide",
+			"details": map[string]interface{}{
+				"code":    "FHIR_PATIENT_INVALID",
+				"message": fhirErr.Message,
+				"issues":  fhirErr.Issues,
+			},
+		})
+	case errors.As(err, &coverageErr):
+		status := http.StatusConflict
+		switch coverageErr.Code {
+		case corePatientDTO.ErrCouvertureIntrouvable:
+			status = http.StatusNotFound
+		case corePatientDTO.ErrCouvertureAssuranceInvalide, corePatientDTO.ErrCouverturePrincipalIntrouvable:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": "Opération sur la couverture impossible",
+			"details": map[string]interface{}{
+				"code":    coverageErr.Code,
+				"message": coverageErr.Message,
+			},
+		})
+	case errors.As(err, &provisionalErr):
+		status := http.StatusConflict
+		if provisionalErr.Code == corePatientDTO.ErrProvisoireReferenceManquante {
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": "Opération sur le patient inconnu impossible",
+			"details": map[string]interface{}{
+				"code":    provisionalErr.Code,
+				"message": provisionalErr.Message,
+			},
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"error": defaultMessage,
+			"details": map[string]interface{}{
+				"code":    "INTERNAL_ERROR",
+				"message": err.Error(),
+			},
+		})
+	}
+}
+
+func (c *PatientsController) respondBindingError(ctx *gin.Context, err error) {
+	ctx.JSON(http.StatusBadRequest, gin.H{
+		"error": "Données invalides",
+		"details": map[string]interface{}{
+			"code":    "VALIDATION_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
+
+func (c *PatientsController) respondValidationError(ctx *gin.Context, champs map[string]string) {
+	ctx.JSON(http.StatusBadRequest, gin.H{
+		"error": "Erreur de validation",
+		"details": map[string]interface{}{
+			"code":   "VALIDATION_ERROR",
+			"champs": champs,
+		},
+	})
+}
+
+func (c *PatientsController) validateStruct(req interface{}) map[string]string {
+	err := c.validator.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return map[string]string{"requete": err.Error()}
+	}
+
+	champs := make(map[string]string)
+	for _, fieldErr := range validationErrors {
+		champs[c.getJSONFieldName(fieldErr.Field())] = c.getValidationMessage(fieldErr)
+	}
+	return champs
+}
+
+func (c *PatientsController) getJSONFieldName(fieldName string) string {
+	mapping := map[string]string{
+		"Nom":                     "nom",
+		"Prenoms":                 "prenoms",
+		"DateNaissance":           "date_naissance",
+		"Sexe":                    "sexe",
+		"NationaliteID":           "nationalite_id",
+		"SituationMatrimonialeID": "situation_matrimoniale_id",
+		"TelephonePrincipal":      "telephone_principal",
+		"Email":                   "email",
+		"AdresseComplete":         "adresse_complete",
+		"Assurances":              "assurances",
+		"Statut":                  "statut",
+		"CodePatientSurvivant":    "code_patient_survivant",
+		"CodePatientFusionne":     "code_patient_fusionne",
+		"Motif":                   "motif",
+		"DateDeces":               "date_deces",
+		"CauseDeces":              "cause_deces",
+		"MedecinDeclarantID":      "medecin_declarant_id",
+		"AssuranceID":             "assurance_id",
+		"NumeroAssure":            "numero_assure",
+		"TypeBeneficiaire":        "type_beneficiaire",
+		"NumeroAssurePrincipal":   "numero_assure_principal",
+		"LienAvecPrincipal":       "lien_avec_principal",
+		"MotifInactivation":       "motif_inactivation",
+		"AgeEstime":               "age_estime",
+		"Signalement":             "signalement",
+		"CodePatientExistant":     "code_patient_existant",
+	}
+
+	if jsonName, exists := mapping[fieldName]; exists {
+		return jsonName
+	}
+	return strings.ToLower(fieldName)
+}
+
+func (c *PatientsController) getValidationMessage(err validator.FieldError) string {
+	switch err.Tag() {
+	case "required", "required_if":
+		return "Ce champ est requis"
+	case "min":
+		return fmt.Sprintf("Doit contenir au moins %s caractères", err.Param())
+	case "max":
+		return fmt.Sprintf("Doit contenir au maximum %s caractères", err.Param())
+	case "email":
+		return "Format d'email invalide"
+	case "e164":
+		return "Format téléphone invalide (format international attendu)"
+	case "oneof":
+		return fmt.Sprintf("Valeur invalide. Valeurs autorisées: %s", err.Param())
+	default:
+		return "Valeur invalide"
+	}
+}
@@ -0,0 +1,454 @@
+package patients
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	corePatientDTO "soins-suite-core/internal/modules/core-services/patient/dto"
+	corePatientServices "soins-suite-core/internal/modules/core-services/patient/services"
+)
+
+// Rubrique journalisée pour les accès aux dossiers depuis l'accueil
+const (
+	accessModuleCode   = "ACCUEIL"
+	accessRubriqueCode = "PATIENTS"
+)
+
+// PatientsService expose les core-services patient à la rubrique ACCUEIL › PATIENTS
+// Utilise les core-services patient (pattern réutilisation)
+type PatientsService struct {
+	creationService    *corePatientServices.PatientCreationService
+	searchService      *corePatientServices.PatientSearchService
+	cacheService       *corePatientServices.PatientCacheService
+	validationService  *corePatientServices.PatientValidationService
+	mergeService       *corePatientServices.PatientMergeService
+	historyService     *corePatientServices.PatientHistoryService
+	lifecycleService   *corePatientServices.PatientLifecycleService
+	fhirService        *corePatientServices.PatientFhirService
+	coverageService    *corePatientServices.PatientCoverageService
+	cardService        *corePatientServices.PatientCardService
+	provisionalService *corePatientServices.PatientProvisionalService
+}
+
+// NewPatientsService constructeur Fx compatible
+func NewPatientsService(
+	creationService *corePatientServices.PatientCreationService,
+	searchService *corePatientServices.PatientSearchService,
+	cacheService *corePatientServices.PatientCacheService,
+	validationService *corePatientServices.PatientValidationService,
+	mergeService *corePatientServices.PatientMergeService,
+	historyService *corePatientServices.PatientHistoryService,
+	lifecycleService *corePatientServices.PatientLifecycleService,
+	fhirService *corePatientServices.PatientFhirService,
+	coverageService *corePatientServices.PatientCoverageService,
+	cardService *corePatientServices.PatientCardService,
+	provisionalService *corePatientServices.PatientProvisionalService,
+) *PatientsService {
+	return &PatientsService{
+		creationService:    creationService,
+		searchService:      searchService,
+		cacheService:       cacheService,
+		validationService:  validationService,
+		mergeService:       mergeService,
+		historyService:     historyService,
+		lifecycleService:   lifecycleService,
+		fhirService:        fhirService,
+		coverageService:    coverageService,
+		cardService:        cardService,
+		provisionalService: provisionalService,
+	}
+}
+
+// CreatePatient crée un patient via core-service (anti-doublon + génération code)
+func (s *PatientsService) CreatePatient(
+	ctx context.Context,
+	establishmentCode string,
+	establishmentID string,
+	req *corePatientDTO.CreatePatientRequest,
+	userID string,
+) (*corePatientDTO.PatientCreationResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	if req.PaysResidence == "" {
+		req.PaysResidence = "Côte d'Ivoire"
+	}
+
+	return s.creationService.CreatePatient(ctx, establishmentCode, etablissementUUID, req, userUUID)
+}
+
+// SearchPatients effectue une recherche multi-critères via core-service
+func (s *PatientsService) SearchPatients(
+	ctx context.Context,
+	req *corePatientDTO.SearchPatientRequest,
+) (*corePatientDTO.SearchPatientResponse, error) {
+	return s.searchService.SearchPatients(ctx, req)
+}
+
+// AutocompletePatients retourne les suggestions de la zone de recherche via core-service
+func (s *PatientsService) AutocompletePatients(
+	ctx context.Context,
+	req *corePatientDTO.PatientAutocompleteRequest,
+) (*corePatientDTO.PatientAutocompleteResponse, error) {
+	return s.searchService.Autocomplete(ctx, req)
+}
+
+// GetPatientByCode récupère un patient complet (cache-first) via core-service
+func (s *PatientsService) GetPatientByCode(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	includeInactive bool,
+	forceRefresh bool,
+	userID string,
+) (*corePatientDTO.PatientDetailResponse, error) {
+	return s.cacheService.GetPatientByCode(ctx, &corePatientDTO.GetPatientByCodeRequest{
+		CodePatient:             codePatient,
+		IncludeInactive:         includeInactive,
+		IncludeAssurances:       true,
+		IncludePersonnesContact: true,
+		ForceRefreshCache:       forceRefresh,
+		Acces:                   accessContext(establishmentID, userID),
+	})
+}
+
+// CheckDuplicate vérifie les doublons potentiels avant création
+func (s *PatientsService) CheckDuplicate(
+	ctx context.Context,
+	req *corePatientDTO.DuplicateCheckRequest,
+) (*corePatientDTO.DuplicateCheckResponse, error) {
+	// Valeurs par défaut identiques à la création
+	if req.ScoreMinimum == 0 {
+		req.ScoreMinimum = 70
+	}
+	if req.LimiteResultats == 0 {
+		req.LimiteResultats = 5
+	}
+
+	return s.validationService.CheckPatientDuplicate(ctx, req)
+}
+
+// UpdatePatient modifie partiellement un patient puis invalide son cache
+func (s *PatientsService) UpdatePatient(
+	ctx context.Context,
+	codePatient string,
+	req *corePatientDTO.UpdatePatientRequest,
+	userID string,
+) (*corePatientDTO.PatientResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	patient, err := s.validationService.UpdatePatient(ctx, codePatient, req, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invalidation cache (best effort - le prochain accès rechargera depuis PostgreSQL)
+	if err := s.cacheService.InvalidatePatientCache(ctx, codePatient); err != nil {
+		fmt.Printf("[CACHE] Patient cache invalidation failed - Code: %s, Error: %v\n", codePatient, err)
+	}
+
+	return patient, nil
+}
+
+// MergePatients fusionne deux dossiers confirmés comme doublons via core-service
+func (s *PatientsService) MergePatients(
+	ctx context.Context,
+	establishmentID string,
+	req *corePatientDTO.MergePatientsRequest,
+	userID string,
+) (*corePatientDTO.MergePatientsResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.mergeService.MergePatients(ctx, req, etablissementUUID, userUUID)
+}
+
+// GetPatientHistory retourne la chronologie des modifications d'un patient
+func (s *PatientsService) GetPatientHistory(
+	ctx context.Context,
+	codePatient string,
+	filter *corePatientDTO.PatientHistoryFilter,
+) (*corePatientDTO.PatientHistoryResponse, error) {
+	return s.historyService.GetPatientHistory(ctx, codePatient, filter)
+}
+
+// GetPatientStateAt retourne le dossier patient tel qu'il était à une date donnée
+func (s *PatientsService) GetPatientStateAt(
+	ctx context.Context,
+	codePatient string,
+	date time.Time,
+) (*corePatientDTO.PatientStateAtDateResponse, error) {
+	return s.historyService.GetPatientStateAt(ctx, codePatient, date)
+}
+
+// DeclareDeath enregistre le décès d'un patient via core-service (caches invalidés par le core-service)
+func (s *PatientsService) DeclareDeath(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	req *corePatientDTO.DeclareDeathRequest,
+	userID string,
+) (*corePatientDTO.PatientLifecycleResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.lifecycleService.DeclareDeath(ctx, codePatient, req, etablissementUUID, userUUID)
+}
+
+// DeactivatePatient passe un dossier au statut inactif
+func (s *PatientsService) DeactivatePatient(
+	ctx context.Context,
+	codePatient string,
+	req *corePatientDTO.LifecycleTransitionRequest,
+	userID string,
+) (*corePatientDTO.PatientLifecycleResult, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.lifecycleService.Deactivate(ctx, codePatient, req, userUUID)
+}
+
+// ArchivePatient archive un dossier
+func (s *PatientsService) ArchivePatient(
+	ctx context.Context,
+	codePatient string,
+	req *corePatientDTO.LifecycleTransitionRequest,
+	userID string,
+) (*corePatientDTO.PatientLifecycleResult, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.lifecycleService.Archive(ctx, codePatient, req, userUUID)
+}
+
+// ReactivatePatient remet un dossier inactif ou archivé au statut actif
+func (s *PatientsService) ReactivatePatient(
+	ctx context.Context,
+	codePatient string,
+	req *corePatientDTO.LifecycleTransitionRequest,
+	userID string,
+) (*corePatientDTO.PatientLifecycleResult, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.lifecycleService.Reactivate(ctx, codePatient, req, userUUID)
+}
+
+// ExportFhirPatient retourne le dossier patient au format FHIR R4 Patient
+func (s *PatientsService) ExportFhirPatient(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	userID string,
+) (*corePatientDTO.FhirPatient, error) {
+	return s.fhirService.ExportPatient(ctx, codePatient, accessContext(establishmentID, userID))
+}
+
+// ImportFhirPatient crée un patient à partir d'une ressource FHIR R4 Patient (validation + anti-doublon)
+func (s *PatientsService) ImportFhirPatient(
+	ctx context.Context,
+	establishmentCode string,
+	establishmentID string,
+	resource *corePatientDTO.FhirPatient,
+	userID string,
+) (*corePatientDTO.PatientCreationResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.fhirService.ImportPatient(ctx, establishmentCode, etablissementUUID, resource, userUUID)
+}
+
+// ListPatientCoverages retourne les couvertures d'assurance d'un patient
+func (s *PatientsService) ListPatientCoverages(
+	ctx context.Context,
+	codePatient string,
+	req *corePatientDTO.ListCoveragesRequest,
+) (*corePatientDTO.PatientCoveragesResponse, error) {
+	return s.coverageService.ListCoverages(ctx, codePatient, req)
+}
+
+// AddPatientCoverage ajoute une couverture d'assurance (est_assure resynchronisé par le core-service)
+func (s *PatientsService) AddPatientCoverage(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	req *corePatientDTO.AddCoverageRequest,
+	userID string,
+) (*corePatientDTO.PatientCoverageResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.coverageService.AddCoverage(ctx, codePatient, req, etablissementUUID, userUUID)
+}
+
+// DeactivatePatientCoverage inactive une couverture d'assurance avec un motif
+func (s *PatientsService) DeactivatePatientCoverage(
+	ctx context.Context,
+	codePatient string,
+	couvertureID uuid.UUID,
+	req *corePatientDTO.DeactivateCoverageRequest,
+	userID string,
+) (*corePatientDTO.PatientCoverageResult, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.coverageService.DeactivateCoverage(ctx, codePatient, couvertureID, req, userUUID)
+}
+
+// RenderPatientCard génère la carte patient imprimable selon la mise en page de l'établissement
+func (s *PatientsService) RenderPatientCard(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	format string,
+	userID string,
+) (*corePatientDTO.PatientCardDocument, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.cardService.RenderCard(ctx, codePatient, etablissementUUID, format, accessContext(establishmentID, userID))
+}
+
+// RegisterUnknownPatient admet un patient non identifié (urgences) avec un code définitif et une identité provisoire
+func (s *PatientsService) RegisterUnknownPatient(
+	ctx context.Context,
+	establishmentCode string,
+	establishmentID string,
+	req *corePatientDTO.RegisterUnknownPatientRequest,
+	userID string,
+) (*corePatientDTO.PatientCreationResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.provisionalService.RegisterUnknownPatient(ctx, establishmentCode, etablissementUUID, req, userUUID)
+}
+
+// ListUnknownPatients liste les patients inconnus de l'établissement restant à réconcilier
+func (s *PatientsService) ListUnknownPatients(
+	ctx context.Context,
+	establishmentID string,
+	filter *corePatientDTO.ProvisionalPatientFilter,
+) (*corePatientDTO.ProvisionalPatientListResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	filter.EtablissementID = &etablissementUUID
+	return s.provisionalService.ListProvisionalPatients(ctx, filter)
+}
+
+// CompleteUnknownPatientIdentity réconcilie un patient inconnu en complétant son identité
+func (s *PatientsService) CompleteUnknownPatientIdentity(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	req *corePatientDTO.CompleteProvisionalIdentityRequest,
+	userID string,
+) (*corePatientDTO.ReconciliationResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.provisionalService.CompleteIdentity(ctx, codePatient, etablissementUUID, req, userUUID)
+}
+
+// MergeUnknownPatient réconcilie un patient inconnu en le fusionnant dans son dossier existant
+func (s *PatientsService) MergeUnknownPatient(
+	ctx context.Context,
+	establishmentID string,
+	codePatient string,
+	req *corePatientDTO.MergeProvisionalPatientRequest,
+	userID string,
+) (*corePatientDTO.ReconciliationResult, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return s.provisionalService.MergeIntoExisting(ctx, codePatient, etablissementUUID, req, userUUID)
+}
+
+// accessContext construit le contexte journalisé des lectures de dossier (identifiants invalides ignorés)
+func accessContext(establishmentID, userID string) *corePatientDTO.PatientAccessContext {
+	acces := &corePatientDTO.PatientAccessContext{
+		ModuleCode:   accessModuleCode,
+		RubriqueCode: accessRubriqueCode,
+	}
+	if etablissementUUID, err := uuid.Parse(establishmentID); err == nil {
+		acces.EtablissementID = &etablissementUUID
+	}
+	if userUUID, err := uuid.Parse(userID); err == nil {
+		acces.UtilisateurID = &userUUID
+	}
+	return acces
+}
@@ -0,0 +1,267 @@
+package lits
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	coreInfraDTO "soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	services "soins-suite-core/internal/modules/front-office/hospitalisation/services/lits"
+)
+
+type LitsController struct {
+	service   *services.LitsService
+	validator *validator.Validate
+}
+
+func NewLitsController(service *services.LitsService) *LitsController {
+	return &LitsController{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+// GetOccupancyBoard GET /api/v1/front-office/hospitalisation/occupation
+func (c *LitsController) GetOccupancyBoard(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreInfraDTO.OccupancyBoardFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "batiment_id UUID",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.GetOccupancyBoard(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors du calcul du tableau d'occupation")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ListAvailableBeds GET /api/v1/front-office/hospitalisation/lits/disponibles
+func (c *LitsController) ListAvailableBeds(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var filter coreInfraDTO.AvailableBedFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil || c.validator.Struct(&filter) != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Paramètres invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": "batiment_id et categorie_chambre_id UUID ; type_lit: standard, medicalise ou reanimation",
+			},
+		})
+		return
+	}
+
+	result, err := c.service.ListAvailableBeds(ctx.Request.Context(), establishmentID, &filter)
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération des lits disponibles")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// GetBedHistory GET /api/v1/front-office/hospitalisation/lits/:id/historique
+func (c *LitsController) GetBedHistory(ctx *gin.Context) {
+	establishmentID, _, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.service.GetBedHistory(ctx.Request.Context(), establishmentID, ctx.Param("id"))
+	if err != nil {
+		c.respondError(ctx, err, "Consultation impossible", "Erreur lors de la récupération de l'historique du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// AssignBed POST /api/v1/front-office/hospitalisation/lits/:id/attribution
+func (c *LitsController) AssignBed(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.AssignBedRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"code_patient":     "Code patient requis",
+		"reference_sejour": "50 caractères maximum",
+		"commentaire":      "1000 caractères maximum",
+	}) {
+		return
+	}
+
+	result, err := c.service.AssignBed(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Attribution du lit impossible", "Erreur lors de l'attribution du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// ReleaseBed POST /api/v1/front-office/hospitalisation/lits/:id/liberation
+func (c *LitsController) ReleaseBed(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.ReleaseBedRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"motif":       "sortie, deces ou annulation",
+		"commentaire": "1000 caractères maximum",
+	}) {
+		return
+	}
+
+	result, err := c.service.ReleaseBed(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Libération du lit impossible", "Erreur lors de la libération du lit")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// TransferBed POST /api/v1/front-office/hospitalisation/lits/:id/transfert
+func (c *LitsController) TransferBed(ctx *gin.Context) {
+	establishmentID, userID, ok := c.session(ctx)
+	if !ok {
+		return
+	}
+
+	var req coreInfraDTO.TransferBedRequest
+	if !c.bind(ctx, &req, map[string]string{
+		"lit_destination_id": "Lit de destination requis",
+		"commentaire":        "1000 caractères maximum",
+	}) {
+		return
+	}
+
+	result, err := c.service.TransferBed(ctx.Request.Context(), establishmentID, ctx.Param("id"), &req, userID)
+	if err != nil {
+		c.respondError(ctx, err, "Transfert impossible", "Erreur lors du transfert de lit")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// session retourne l'établissement et l'utilisateur de la session (réponse d'erreur envoyée sinon)
+func (c *LitsController) session(ctx *gin.Context) (string, string, bool) {
+	establishmentID := ctx.GetString("establishment_id")
+	if establishmentID == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Etablissement non identifié",
+		})
+		return "", "", false
+	}
+
+	userID := ctx.GetString("user_id")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Utilisateur non identifié",
+		})
+		return "", "", false
+	}
+
+	return establishmentID, userID, true
+}
+
+// bind décode et valide le corps JSON (réponse d'erreur envoyée sinon)
+func (c *LitsController) bind(ctx *gin.Context, req interface{}, champs map[string]string) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Données invalides",
+			"details": map[string]interface{}{
+				"code":    "VALIDATION_ERROR",
+				"message": err.Error(),
+			},
+		})
+		return false
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error": "Erreur de validation",
+			"details": map[string]interface{}{
+				"code":   "VALIDATION_ERROR",
+				"champs": champs,
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// respondError traduit les refus métier (404 lit ou patient introuvable, 422 patient non admissible, 409 sinon)
+func (c *LitsController) respondError(ctx *gin.Context, err error, refus, interne string) {
+	var infraErr *coreInfraDTO.InfrastructureError
+	if errors.As(err, &infraErr) {
+		status := http.StatusConflict
+		switch infraErr.Code {
+		case coreInfraDTO.ErrInfraLitIntrouvable,
+			coreInfraDTO.ErrInfraChambreIntrouvable,
+			coreInfraDTO.ErrInfraPatientIntrouvable:
+			status = http.StatusNotFound
+		case coreInfraDTO.ErrInfraPatientNonAdmissible:
+			status = http.StatusUnprocessableEntity
+		}
+		ctx.JSON(status, gin.H{
+			"error": refus,
+			"details": map[string]interface{}{
+				"code":    infraErr.Code,
+				"message": infraErr.Message,
+			},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusInternalServerError, gin.H{
+		"error": interne,
+		"details": map[string]interface{}{
+			"code":    "INTERNAL_ERROR",
+			"message": err.Error(),
+		},
+	})
+}
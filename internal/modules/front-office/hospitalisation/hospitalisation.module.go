@@ -0,0 +1,55 @@
+package hospitalisation
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+
+	litsControllers "soins-suite-core/internal/modules/front-office/hospitalisation/controllers/lits"
+	litsServices "soins-suite-core/internal/modules/front-office/hospitalisation/services/lits"
+	authMiddleware "soins-suite-core/internal/shared/middleware/auth"
+	"soins-suite-core/internal/shared/middleware/tenant"
+)
+
+// Module regroupe tous les providers du module front-office HOSPITALISATION
+var Module = fx.Options(
+	// Services (utilisent core-services directement)
+	fx.Provide(litsServices.NewLitsService),
+
+	// Controllers
+	fx.Provide(litsControllers.NewLitsController),
+
+	// Configuration des routes
+	fx.Invoke(RegisterLitsRoutes),
+)
+
+// RegisterLitsRoutes configure les routes d'occupation des lits du module HOSPITALISATION
+// Le module ne déclare pas de rubrique : l'accès est contrôlé au niveau du module
+func RegisterLitsRoutes(
+	r *gin.Engine,
+	ctrl *litsControllers.LitsController,
+	authStack *authMiddleware.AuthMiddlewareStack,
+	licenseMW *tenant.LicenseMiddleware,
+) {
+	api := r.Group("/api/v1/front-office/hospitalisation")
+	api.Use(authMiddleware.RequireModule(authStack, "HOSPITALISATION")...)
+	api.Use(licenseMW.Handler())
+	{
+		// GET /api/v1/front-office/hospitalisation/occupation - Tableau d'occupation en temps réel (?batiment_id=)
+		api.GET("/occupation", ctrl.GetOccupancyBoard)
+
+		// GET /api/v1/front-office/hospitalisation/lits/disponibles - Lits libres attribuables (?batiment_id=&categorie_chambre_id=&type_lit=)
+		api.GET("/lits/disponibles", ctrl.ListAvailableBeds)
+
+		// GET /api/v1/front-office/hospitalisation/lits/:id/historique - Occupations successives du lit
+		api.GET("/lits/:id/historique", ctrl.GetBedHistory)
+
+		// POST /api/v1/front-office/hospitalisation/lits/:id/attribution - Installation d'un patient (lit libre, un lit par patient)
+		api.POST("/lits/:id/attribution", ctrl.AssignBed)
+
+		// POST /api/v1/front-office/hospitalisation/lits/:id/liberation - Sortie, décès ou annulation
+		api.POST("/lits/:id/liberation", ctrl.ReleaseBed)
+
+		// POST /api/v1/front-office/hospitalisation/lits/:id/transfert - Déplacement de l'occupant vers un lit libre
+		api.POST("/lits/:id/transfert", ctrl.TransferBed)
+	}
+}
@@ -0,0 +1,160 @@
+package lits
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	coreInfraDTO "soins-suite-core/internal/modules/core-services/infrastructure/dto"
+	coreInfraServices "soins-suite-core/internal/modules/core-services/infrastructure/services"
+)
+
+// LitsService expose l'attribution des lits et le tableau d'occupation au module HOSPITALISATION
+// Utilise les core-services infrastructure (pattern réutilisation)
+type LitsService struct {
+	allocationService *coreInfraServices.BedAllocationService
+	boardService      *coreInfraServices.OccupancyBoardService
+}
+
+// NewLitsService constructeur Fx compatible
+func NewLitsService(
+	allocationService *coreInfraServices.BedAllocationService,
+	boardService *coreInfraServices.OccupancyBoardService,
+) *LitsService {
+	return &LitsService{
+		allocationService: allocationService,
+		boardService:      boardService,
+	}
+}
+
+// GetOccupancyBoard retourne le tableau d'occupation des lits
+func (s *LitsService) GetOccupancyBoard(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreInfraDTO.OccupancyBoardFilter,
+) (*coreInfraDTO.OccupancyBoard, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.boardService.GetBoard(ctx, etablissementUUID, filter)
+}
+
+// ListAvailableBeds retourne les lits libres attribuables
+func (s *LitsService) ListAvailableBeds(
+	ctx context.Context,
+	establishmentID string,
+	filter *coreInfraDTO.AvailableBedFilter,
+) ([]coreInfraDTO.AvailableBed, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	return s.allocationService.ListAvailableBeds(ctx, etablissementUUID, filter)
+}
+
+// GetBedHistory retourne l'historique d'occupation d'un lit
+func (s *LitsService) GetBedHistory(ctx context.Context, establishmentID, litID string) ([]coreInfraDTO.BedOccupancyResponse, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	litUUID, err := parseLitID(litID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.allocationService.GetBedHistory(ctx, etablissementUUID, litUUID)
+}
+
+// AssignBed attribue un lit à un patient
+func (s *LitsService) AssignBed(
+	ctx context.Context,
+	establishmentID string,
+	litID string,
+	req *coreInfraDTO.AssignBedRequest,
+	userID string,
+) (*coreInfraDTO.BedOccupancyResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	litUUID, err := parseLitID(litID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.allocationService.AssignBed(ctx, etablissementUUID, litUUID, req, userUUID)
+}
+
+// ReleaseBed libère un lit
+func (s *LitsService) ReleaseBed(
+	ctx context.Context,
+	establishmentID string,
+	litID string,
+	req *coreInfraDTO.ReleaseBedRequest,
+	userID string,
+) (*coreInfraDTO.BedOccupancyResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	litUUID, err := parseLitID(litID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.allocationService.ReleaseBed(ctx, etablissementUUID, litUUID, req, userUUID)
+}
+
+// TransferBed déplace l'occupant d'un lit vers un autre lit
+func (s *LitsService) TransferBed(
+	ctx context.Context,
+	establishmentID string,
+	litID string,
+	req *coreInfraDTO.TransferBedRequest,
+	userID string,
+) (*coreInfraDTO.BedOccupancyResponse, error) {
+	etablissementUUID, userUUID, err := parseSession(establishmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	litUUID, err := parseLitID(litID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.allocationService.TransferBed(ctx, etablissementUUID, litUUID, req, userUUID)
+}
+
+// parseSession convertit les identifiants établissement et utilisateur de la session
+func parseSession(establishmentID, userID string) (uuid.UUID, uuid.UUID, error) {
+	etablissementUUID, err := uuid.Parse(establishmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant établissement invalide: %w", err)
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("identifiant utilisateur invalide: %w", err)
+	}
+
+	return etablissementUUID, userUUID, nil
+}
+
+// parseLitID convertit l'identifiant d'un lit (introuvable s'il est mal formé)
+func parseLitID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, coreInfraDTO.NewInfrastructureError(coreInfraDTO.ErrInfraLitIntrouvable,
+			fmt.Sprintf("Identifiant invalide: %s", id))
+	}
+	return parsed, nil
+}